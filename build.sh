@@ -2,9 +2,11 @@
 # shellcheck disable=SC2164
 go build -o ./bin/flydb-server cmd/server/cli/flydb-server.go
 go build -o ./bin/flydb-client cmd/client/cli/flydb-client.go
+go build -o ./bin/flydb-admin cmd/admin/cli/flydb-admin.go
 echo "build success"
 
 echo "Now you can run the follow command:
       start server: ./bin/flydb-server
-      start client: ./bin/flydb-client 127.0.0.1:8999"
+      start client: ./bin/flydb-client 127.0.0.1:8999
+      inspect data: ./bin/flydb-admin --dir /path/to/data files"
 
@@ -0,0 +1,399 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/desertbit/grumble"
+
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/db/engine"
+)
+
+var (
+	DirPath      string
+	DataFileSize int64
+)
+
+var (
+	ErrDirPathIsEmpty    = errors.New("data directory is empty, use --dir to set it")
+	ErrNotAnExportFile   = errors.New("the file is not a FlyDB export file")
+	ErrExportFileExists  = errors.New("the export file already exists")
+	ErrImportPathIsEmpty = errors.New("no file to import from was given")
+)
+
+// options returns the engine options used to open the data directory
+func options() (config.Options, error) {
+	opts := config.DefaultOptions
+	if DirPath == "" {
+		return opts, ErrDirPathIsEmpty
+	}
+	// The engine creates a missing directory, which is never what an admin wants
+	if _, err := os.Stat(DirPath); err != nil {
+		return opts, err
+	}
+	opts.DirPath = DirPath
+	opts.DataFileSize = DataFileSize
+	// Standard file IO leaves the files as they are, while mmap resizes them
+	opts.FIOType = config.FileIOType
+	return opts, nil
+}
+
+func openDB() (*engine.DB, error) {
+	opts, err := options()
+	if err != nil {
+		return nil, err
+	}
+	return engine.NewDB(opts)
+}
+
+// openReadOnlyDB opens the data directory for commands that only read it
+func openReadOnlyDB() (*engine.DB, error) {
+	opts, err := options()
+	if err != nil {
+		return nil, err
+	}
+	return engine.OpenReadOnly(opts)
+}
+
+func listFiles(c *grumble.Context) error {
+	db, err := openReadOnlyDB()
+	if err != nil {
+		fmt.Println("open db error: ", err)
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	stats, err := db.FileStats()
+	if err != nil {
+		fmt.Println("scan data files error: ", err)
+		return err
+	}
+
+	fmt.Printf("%-10s %-8s %-12s %-10s %-10s %-8s\n", "FILE", "VERSION", "SIZE", "RECORDS", "LIVE", "GARBAGE")
+	for _, stat := range stats {
+		fmt.Printf("%09d  %-8d %-12d %-10d %-10d %.2f%%\n",
+			stat.FileID, stat.Version, stat.Size, stat.Records, stat.LiveRecords, stat.GarbageRatio()*100)
+	}
+	// The inspecting commands leave a torn write in place, the next open truncates it
+	for _, stat := range stats {
+		if stat.TornBytes > 0 {
+			fmt.Printf("%09d: torn write of %d bytes, truncated at offset %d on the next open\n",
+				stat.FileID, stat.TornBytes, stat.End)
+		}
+	}
+	return nil
+}
+
+func dumpRecords(c *grumble.Context) error {
+	if DirPath == "" {
+		fmt.Println(ErrDirPathIsEmpty)
+		return ErrDirPathIsEmpty
+	}
+	fileID := uint32(c.Args.Uint(FileIDArg))
+	fileName := data.GetDataFileName(DirPath, fileID)
+	if _, err := os.Stat(fileName); err != nil {
+		fmt.Println("open data file error: ", err)
+		return err
+	}
+	dataFile, err := data.OpenReadOnlyFile(fileName, fileID)
+	if err != nil {
+		fmt.Println("open data file error: ", err)
+		return err
+	}
+	defer func() {
+		_ = dataFile.Close()
+	}()
+
+	offset := c.Flags.Int64(OffsetFlag)
+	if offset < 0 {
+		offset = dataFile.HeaderSize
+	}
+	limit := c.Flags.Int(LimitFlag)
+
+	fmt.Printf("file %s, format version %d\n", filepath.Base(fileName), dataFile.Version())
+	fmt.Printf("%-12s %-10s %-10s %-10s %-6s %s\n", "OFFSET", "SIZE", "TYPE", "SEQ", "CRC", "KEY")
+	count := 0
+	err = scanFile(dataFile, offset, func(offset int64, logRecord *data.LogRecord, size int64, crcOK bool) bool {
+		key, seq := engine.ParseLogRecordKey(logRecord.Key)
+		crc := "ok"
+		if !crcOK {
+			crc = "BAD"
+		}
+		fmt.Printf("%-12d %-10d %-10s %-10d %-6s %q\n", offset, size, recordTypeName(logRecord.Type), seq, crc, key)
+		count++
+		return limit <= 0 || count < limit
+	})
+	if err != nil {
+		fmt.Println("dump data file error: ", err)
+		return err
+	}
+	return nil
+}
+
+func verifyFiles(c *grumble.Context) error {
+	if DirPath == "" {
+		fmt.Println(ErrDirPathIsEmpty)
+		return ErrDirPathIsEmpty
+	}
+	fileNames, err := listDataFileNames(DirPath)
+	if err != nil {
+		fmt.Println("list data files error: ", err)
+		return err
+	}
+	hintFileName := filepath.Join(DirPath, data.HintFileSuffix)
+	if _, err := os.Stat(hintFileName); err == nil {
+		fileNames = append(fileNames, hintFileName)
+	}
+
+	var corrupted int
+	for _, fileName := range fileNames {
+		fileID, _ := strconv.Atoi(strings.TrimSuffix(filepath.Base(fileName), data.DataFileSuffix))
+		dataFile, err := data.OpenReadOnlyFile(fileName, uint32(fileID))
+		if err != nil {
+			fmt.Printf("%s: open error: %v\n", filepath.Base(fileName), err)
+			corrupted++
+			continue
+		}
+
+		var records, bad int
+		err = scanFile(dataFile, dataFile.HeaderSize, func(offset int64, logRecord *data.LogRecord, size int64, crcOK bool) bool {
+			records++
+			if !crcOK {
+				bad++
+				fmt.Printf("%s: invalid crc at offset %d\n", filepath.Base(fileName), offset)
+			}
+			return true
+		})
+		_ = dataFile.Close()
+		if err != nil {
+			fmt.Printf("%s: read error after %d records: %v\n", filepath.Base(fileName), records, err)
+			corrupted++
+			continue
+		}
+		fmt.Printf("%s: %d records, %d invalid\n", filepath.Base(fileName), records, bad)
+		corrupted += bad
+	}
+
+	if corrupted > 0 {
+		return fmt.Errorf("found %d corrupted records or files", corrupted)
+	}
+	fmt.Println("verify success")
+	return nil
+}
+
+func rebuildHint(c *grumble.Context) error {
+	opts, err := options()
+	if err != nil {
+		fmt.Println("rebuild hint file error: ", err)
+		return err
+	}
+	if err := engine.RebuildHintFile(opts); err != nil {
+		fmt.Println("rebuild hint file error: ", err)
+		return err
+	}
+	fmt.Println("rebuild hint file success")
+	return nil
+}
+
+func mergeFiles(c *grumble.Context) error {
+	opts, err := options()
+	if err != nil {
+		fmt.Println("merge error: ", err)
+		return err
+	}
+	if err := engine.MergeOffline(opts); err != nil {
+		fmt.Println("merge error: ", err)
+		return err
+	}
+	fmt.Println("merge success")
+	return nil
+}
+
+func exportKeyspace(c *grumble.Context) error {
+	path := c.Args.String(PathArg)
+	if _, err := os.Stat(path); err == nil {
+		fmt.Println("export error: ", ErrExportFileExists)
+		return ErrExportFileExists
+	}
+
+	db, err := openReadOnlyDB()
+	if err != nil {
+		fmt.Println("open db error: ", err)
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	exportFile, err := data.OpenExportFile(path, config.FileIOType)
+	if err != nil {
+		fmt.Println("export error: ", err)
+		return err
+	}
+	defer func() {
+		_ = exportFile.Close()
+	}()
+
+	var count int
+	var writeErr error
+	err = db.Fold(func(key []byte, value []byte) bool {
+		encRecord, _ := data.EncodeLogRecord(&data.LogRecord{Key: key, Value: value})
+		if writeErr = exportFile.Write(encRecord); writeErr != nil {
+			return false
+		}
+		count++
+		return true
+	})
+	if err == nil {
+		err = writeErr
+	}
+	if err == nil {
+		err = exportFile.Sync()
+	}
+	if err != nil {
+		fmt.Println("export error: ", err)
+		return err
+	}
+	fmt.Printf("exported %d keys\n", count)
+	return nil
+}
+
+func importKeyspace(c *grumble.Context) error {
+	path := c.Args.String(PathArg)
+	if path == "" {
+		fmt.Println("import error: ", ErrImportPathIsEmpty)
+		return ErrImportPathIsEmpty
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		fmt.Println("import error: ", err)
+		return err
+	}
+	// Opening an empty file for writing would give it a header, it is not an export
+	if info.Size() == 0 {
+		fmt.Println("import error: ", ErrNotAnExportFile)
+		return ErrNotAnExportFile
+	}
+	importFile, err := data.OpenReadOnlyFile(path, 0)
+	if err != nil {
+		fmt.Println("import error: ", err)
+		return err
+	}
+	defer func() {
+		_ = importFile.Close()
+	}()
+	if importFile.Header == nil || importFile.Header.Flags&data.FileFlagExport == 0 {
+		fmt.Println("import error: ", ErrNotAnExportFile)
+		return ErrNotAnExportFile
+	}
+
+	db, err := openDB()
+	if err != nil {
+		fmt.Println("open db error: ", err)
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	// Commit the keys in batches so that each batch is written atomically
+	batchOptions := config.DefaultWriteBatchOptions
+	batch := db.NewWriteBatch(batchOptions)
+	var count, pending uint
+	var putErr error
+	err = scanFile(importFile, importFile.HeaderSize, func(offset int64, logRecord *data.LogRecord, size int64, crcOK bool) bool {
+		if !crcOK {
+			putErr = data.ErrInvalidCRC
+			return false
+		}
+		if putErr = batch.Put(logRecord.Key, logRecord.Value); putErr != nil {
+			return false
+		}
+		count++
+		pending++
+		if pending == batchOptions.MaxBatchNum {
+			putErr = batch.Commit()
+			pending = 0
+		}
+		return putErr == nil
+	})
+	if err == nil {
+		err = putErr
+	}
+	if err == nil {
+		err = batch.Commit()
+	}
+	if err != nil {
+		fmt.Println("import error: ", err)
+		return err
+	}
+	fmt.Printf("imported %d keys\n", count)
+	return nil
+}
+
+// scanFile reads the records of a file from offset until the end of the file
+// or until fn returns false. Records with an invalid crc are passed to fn as well.
+func scanFile(dataFile *data.DataFile, offset int64,
+	fn func(offset int64, logRecord *data.LogRecord, size int64, crcOK bool) bool) error {
+	for {
+		logRecord, size, err := dataFile.ReadLogRecord(offset)
+		if err != nil && !(err == data.ErrInvalidCRC && size > 0) {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("offset %d: %w", offset, err)
+		}
+		if !fn(offset, logRecord, size, err == nil) {
+			return nil
+		}
+		offset += size
+	}
+}
+
+// listDataFileNames returns the data files of a directory ordered by file id
+func listDataFileNames(dirPath string) ([]string, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	var fileIds []int
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), data.DataFileSuffix) {
+			continue
+		}
+		fileID, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), data.DataFileSuffix))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected data file %s", entry.Name())
+		}
+		fileIds = append(fileIds, fileID)
+	}
+	sort.Ints(fileIds)
+
+	fileNames := make([]string, len(fileIds))
+	for i, fid := range fileIds {
+		fileNames[i] = data.GetDataFileName(dirPath, uint32(fid))
+	}
+	return fileNames, nil
+}
+
+func recordTypeName(typ data.LogRecrdType) string {
+	switch typ {
+	case data.LogRecordNormal:
+		return "normal"
+	case data.LogRecordDeleted:
+		return "deleted"
+	case data.LogRecordTransFinished:
+		return "txn-fina"
+	}
+	return "unknown"
+}
@@ -0,0 +1,161 @@
+package admin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	"github.com/ByteStorage/FlyDB/lib/randkv"
+)
+
+// newFixture writes keys to a new data directory, closes it and points the commands at it
+func newFixture(t *testing.T, keys int) string {
+	dir, err := os.MkdirTemp("", "flydb-admin")
+	assert.Nil(t, err)
+	opts := config.DefaultOptions
+	opts.DirPath = dir
+	opts.DataFileSize = 4 * 1024
+	opts.FIOType = config.FileIOType
+	db, err := engine.NewDB(opts)
+	assert.Nil(t, err)
+	for i := 0; i < keys; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	assert.Nil(t, db.Close())
+
+	DirPath, DataFileSize = dir, opts.DataFileSize
+	return dir
+}
+
+// run runs an admin command and returns what it printed
+func run(t *testing.T, args ...string) (string, error) {
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	assert.Nil(t, err)
+	os.Stdout = w
+	output := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		output <- string(b)
+	}()
+	err = App.RunCommand(args)
+	os.Stdout = stdout
+	_ = w.Close()
+	return <-output, err
+}
+
+func TestVerifyFiles(t *testing.T) {
+	dir := newFixture(t, 200)
+	defer os.RemoveAll(dir)
+
+	out, err := run(t, "verify")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "verify success")
+
+	// Flip a byte of the first record of the first file
+	fileName := data.GetDataFileName(dir, 0)
+	dataFile, err := data.OpenReadOnlyFile(fileName, 0)
+	assert.Nil(t, err)
+	offset := dataFile.HeaderSize + 10
+	assert.Nil(t, dataFile.Close())
+	f, err := os.OpenFile(fileName, os.O_RDWR, 0)
+	assert.Nil(t, err)
+	b := make([]byte, 1)
+	_, err = f.ReadAt(b, offset)
+	assert.Nil(t, err)
+	b[0] ^= 0xff
+	_, err = f.WriteAt(b, offset)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	out, err = run(t, "verify")
+	assert.NotNil(t, err)
+	assert.Contains(t, out, fmt.Sprintf("%s: invalid crc at offset %d", filepath.Base(fileName), dataFile.HeaderSize))
+}
+
+func TestDumpRecords(t *testing.T) {
+	dir := newFixture(t, 200)
+	defer os.RemoveAll(dir)
+
+	out, err := run(t, "dump", "-n", "2", "0")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "file 000000000.data, format version")
+	assert.Contains(t, out, fmt.Sprintf("%q", randkv.GetTestKey(0)))
+	assert.Contains(t, out, fmt.Sprintf("%q", randkv.GetTestKey(1)))
+	assert.NotContains(t, out, fmt.Sprintf("%q", randkv.GetTestKey(2)))
+
+	_, err = run(t, "dump", "9999")
+	assert.NotNil(t, err)
+}
+
+func TestListFilesTornWrite(t *testing.T) {
+	dir := newFixture(t, 20)
+	defer os.RemoveAll(dir)
+
+	fileName := data.GetDataFileName(dir, 0)
+	info, err := os.Stat(fileName)
+	assert.Nil(t, err)
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_APPEND, 0)
+	assert.Nil(t, err)
+	_, err = f.Write([]byte{1, 2, 3})
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	out, err := run(t, "files")
+	assert.Nil(t, err)
+	assert.Contains(t, out, fmt.Sprintf("000000000: torn write of 3 bytes, truncated at offset %d", info.Size()))
+}
+
+func TestImportKeyspace(t *testing.T) {
+	dir := newFixture(t, 200)
+	defer os.RemoveAll(dir)
+	exportDir, err := os.MkdirTemp("", "flydb-admin-export")
+	assert.Nil(t, err)
+	defer os.RemoveAll(exportDir)
+
+	exportFile := filepath.Join(exportDir, "keys.export")
+	out, err := run(t, "export", exportFile)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "exported 200 keys")
+
+	// Into a new directory
+	importDir, err := os.MkdirTemp("", "flydb-admin-import")
+	assert.Nil(t, err)
+	defer os.RemoveAll(importDir)
+	DirPath = importDir
+	out, err = run(t, "import", exportFile)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "imported 200 keys")
+	db, err := openReadOnlyDB()
+	assert.Nil(t, err)
+	for _, i := range []int{0, 199} {
+		value, err := db.Get(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(i), value)
+	}
+	assert.Nil(t, db.Close())
+
+	// An empty file is rejected and left empty
+	emptyFile := filepath.Join(exportDir, "empty.export")
+	assert.Nil(t, os.WriteFile(emptyFile, nil, 0644))
+	_, err = run(t, "import", emptyFile)
+	assert.Equal(t, ErrNotAnExportFile, err)
+	info, err := os.Stat(emptyFile)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), info.Size())
+
+	// So are a missing file and a data file
+	_, err = run(t, "import", filepath.Join(exportDir, "missing.export"))
+	assert.NotNil(t, err)
+	_, err = os.Stat(filepath.Join(exportDir, "missing.export"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = run(t, "import", data.GetDataFileName(dir, 0))
+	assert.Equal(t, ErrNotAnExportFile, err)
+}
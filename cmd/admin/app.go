@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"os"
+	"path"
+
+	"github.com/desertbit/grumble"
+	"github.com/fatih/color"
+)
+
+// App FlyDB offline administration app
+var App = grumble.New(&grumble.Config{
+	Name:                  "FlyDB Admin",
+	Description:           "Inspect and repair a FlyDB data directory offline",
+	HistoryFile:           path.Join(os.TempDir(), ".FlyDB_Admin.history"),
+	HistoryLimit:          10000,
+	ErrorColor:            color.New(color.FgRed, color.Bold, color.Faint),
+	HelpHeadlineColor:     color.New(color.FgGreen),
+	HelpHeadlineUnderline: false,
+	HelpSubCommands:       true,
+	Prompt:                "flydb-admin $> ",
+	PromptColor:           color.New(color.FgBlue, color.Bold),
+	Flags: func(f *grumble.Flags) {
+		f.String("d", "dir", "", "the data directory of the database")
+		f.Int64("s", "file-size", DefaultDataFileSize, "the max size of each data file")
+	},
+})
+
+func init() {
+	App.OnInit(func(a *grumble.App, fm grumble.FlagMap) error {
+		DirPath = fm.String("dir")
+		DataFileSize = fm.Int64("file-size")
+		return nil
+	})
+	register(App)
+}
+
+func register(app *grumble.App) {
+	app.AddCommand(&grumble.Command{
+		Name: "files",
+		Help: "list data files with record counts and garbage ratios",
+		Run:  listFiles,
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "dump",
+		Help: "dump the records of a data file starting at an offset",
+		Run:  dumpRecords,
+		Args: func(a *grumble.Args) {
+			a.Uint(FileIDArg, "the id of the data file")
+		},
+		Flags: func(f *grumble.Flags) {
+			f.Int64("o", OffsetFlag, -1, "the offset to start from, defaults to the first record")
+			f.Int("n", LimitFlag, 0, "the max number of records to dump, 0 means all")
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "verify",
+		Help: "verify the crc of every record in the data and hint files",
+		Run:  verifyFiles,
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "rebuild-hint",
+		Help: "rebuild the hint file from the data files",
+		Run:  rebuildHint,
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "merge",
+		Help: "merge the data files offline",
+		Run:  mergeFiles,
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "export",
+		Help: "export every key and value to a file",
+		Run:  exportKeyspace,
+		Args: func(a *grumble.Args) {
+			a.String(PathArg, "the file to export to")
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "import",
+		Help: "import the keys and values of an exported file",
+		Run:  importKeyspace,
+		Args: func(a *grumble.Args) {
+			a.String(PathArg, "the file to import from")
+		},
+	})
+}
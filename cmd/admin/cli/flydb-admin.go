@@ -0,0 +1,11 @@
+package main
+
+import (
+	"github.com/ByteStorage/FlyDB/cmd/admin"
+	"github.com/desertbit/grumble"
+)
+
+func main() {
+	// start admin CLI, commands given on the command line run once and exit
+	grumble.Main(admin.App)
+}
@@ -0,0 +1,11 @@
+package admin
+
+const (
+	FileIDArg = "fid"
+	PathArg   = "path"
+
+	OffsetFlag = "offset"
+	LimitFlag  = "limit"
+
+	DefaultDataFileSize = 256 * 1024 * 1024
+)
@@ -16,13 +16,26 @@ const (
 	ZSetIncrByArg = "incrBy"
 	ZSetMinArg    = "min"
 	ZSetMaxArg    = "max"
+	ZSetOffsetArg = "offset"
+	ZSetCountArg  = "count"
+	ZSetStopArg   = "stop"
+	ZSetDestArg   = "destination"
+	ZSetKeysArg   = "keys"
 
-	ZSetDefaultScore      = 0
+	ZSetWeightsFlag   = "weights"
+	ZSetAggregateFlag = "aggregate"
+
+	ZSetDefaultScore      = 0.0
 	ZSetDefaultRangeStart = 0
 	ZSetDefaultRangeEnd   = math.MaxInt
-	ZSetDefaultIncrBy     = 0
-	ZSetDefaultMin        = 0
-	ZSetDefaultMax        = math.MaxInt
+	ZSetDefaultIncrBy     = 0.0
+	ZSetDefaultMin        = -math.MaxFloat64
+	ZSetDefaultMax        = math.MaxFloat64
+	ZSetDefaultMinBound   = "-inf"
+	ZSetDefaultMaxBound   = "+inf"
+	ZSetDefaultOffset     = 0
+	ZSetDefaultCount      = -1
+	ZSetDefaultPopCount   = 1
 
 	ZSetDefaultKeyHelp     = "the key of the zset"
 	ZSetDefaultMemberHelp  = "the member of the zset"
@@ -34,4 +47,16 @@ const (
 	ZSetDefaultIncrByHelp  = "the increment value of the zset"
 	ZSetDefaultMinHelp     = "the min score of the zset"
 	ZSetDefaultMaxHelp     = "the max score of the zset"
+	ZSetMinBoundHelp       = "the min score, e.g. 1.5, (1.5 to exclude it, or -inf"
+	ZSetMaxBoundHelp       = "the max score, e.g. 1.5, (1.5 to exclude it, or +inf"
+	ZSetMinLexHelp         = "the min member, [a to include it, (a to exclude it, or -"
+	ZSetMaxLexHelp         = "the max member, [a to include it, (a to exclude it, or +"
+	ZSetDefaultOffsetHelp  = "how many members to skip"
+	ZSetDefaultCountHelp   = "how many members to return, -1 for all of them"
+	ZSetPopCountHelp       = "how many members to pop"
+	ZSetStopHelp           = "the stop rank, inclusive, negative ranks count from the end"
+	ZSetDestHelp           = "the key to write the resulting zset to"
+	ZSetKeysHelp           = "the keys of the zsets, separated by space, e.g. key1 key2 key3"
+	ZSetWeightsHelp        = "the weights of the zsets, separated by comma, e.g. 1,2.5"
+	ZSetAggregateHelp      = "how to combine the scores of a member: sum, min or max"
 )
@@ -227,3 +227,22 @@ func hashHType(c *grumble.Context) error {
 	fmt.Println("Type of field", field, "in hash", key, "is", hashType)
 	return nil
 }
+
+func hashHScan(c *grumble.Context) error {
+	key := c.Args.String("key")
+	cursor := c.Args.String("cursor")
+	match := c.Args.String("match")
+	count := c.Args.Int("count")
+
+	if key == "" {
+		return errors.New("key is empty")
+	}
+	next, fields, err := newClient().HScan(key, cursor, match, int64(count))
+	if err != nil {
+		return errors.Wrap(err, "HScan error")
+	}
+
+	fmt.Println("Cursor:", next)
+	fmt.Println("Fields:", fields)
+	return nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/desertbit/grumble"
+)
+
+func keysType(c *grumble.Context) error {
+	key := c.Args.String("key")
+	if key == "" {
+		fmt.Println("key is empty")
+		return nil
+	}
+	valueType, err := newClient().KeyType(key)
+	if err != nil {
+		fmt.Println("get type error: ", err)
+		return err
+	}
+	fmt.Println("Type:", valueType)
+	return nil
+}
+
+func keysExists(c *grumble.Context) error {
+	keys := c.Args.StringList("key")
+	if len(keys) == 0 {
+		fmt.Println("key is empty")
+		return nil
+	}
+	count, err := newClient().ExistsKeys(keys...)
+	if err != nil {
+		fmt.Println("exists error: ", err)
+		return err
+	}
+	fmt.Println(count)
+	return nil
+}
+
+func keysDel(c *grumble.Context) error {
+	keys := c.Args.StringList("key")
+	if len(keys) == 0 {
+		fmt.Println("key is empty")
+		return nil
+	}
+	count, err := newClient().DelKeys(keys...)
+	if err != nil {
+		fmt.Println("del error: ", err)
+		return err
+	}
+	fmt.Println(count)
+	return nil
+}
+
+func keysRename(c *grumble.Context) error {
+	key := c.Args.String("key")
+	newKey := c.Args.String("newkey")
+	if key == "" || newKey == "" {
+		fmt.Println("key is empty")
+		return nil
+	}
+	if err := newClient().RenameKey(key, newKey); err != nil {
+		fmt.Println("rename error: ", err)
+		return err
+	}
+	fmt.Println("OK")
+	return nil
+}
+
+func keysExpire(c *grumble.Context) error {
+	key := c.Args.String("key")
+	ttl := c.Args.Int64("ttl")
+	if err := newClient().ExpireKey(key, ttl); err != nil {
+		fmt.Println("expire error: ", err)
+		return err
+	}
+	fmt.Println("OK")
+	return nil
+}
+
+func keysPersist(c *grumble.Context) error {
+	key := c.Args.String("key")
+	if err := newClient().PersistKey(key); err != nil {
+		fmt.Println("key is not Persist")
+		return err
+	}
+	fmt.Println("key is Persist")
+	return nil
+}
+
+func keysTTL(c *grumble.Context) error {
+	key := c.Args.String("key")
+	ttl, err := newClient().KeyTTL(key)
+	if err != nil {
+		fmt.Println("get ttl error: ", err)
+		return err
+	}
+	fmt.Println(ttl)
+	return nil
+}
+
+func keysScan(c *grumble.Context) error {
+	cursor := c.Args.String("cursor")
+	match := c.Args.String("match")
+	count := c.Args.Int("count")
+	typ := c.Args.String("type")
+	next, keys, err := newClient().Scan(cursor, match, int64(count), typ)
+	if err != nil {
+		fmt.Println("scan error: ", err)
+		return err
+	}
+	fmt.Println("Cursor:", next)
+	fmt.Println("Keys:", keys)
+	return nil
+}
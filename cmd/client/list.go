@@ -1,7 +1,10 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/desertbit/grumble"
 )
@@ -234,3 +237,119 @@ func stringLTrimData(c *grumble.Context) error {
 	fmt.Println("LTrim data success")
 	return nil
 }
+
+// CLI for LInsert command
+func stringLInsertData(c *grumble.Context) error {
+	key := c.Args.String("key")
+	where := strings.ToUpper(c.Args.String("where"))
+	pivot := c.Args.String("pivot")
+	value := c.Args.String("value")
+	if key == "" || pivot == "" || value == "" {
+		fmt.Println("key, pivot or value is empty")
+		return nil
+	}
+	if where != "BEFORE" && where != "AFTER" {
+		fmt.Println("where must be BEFORE or AFTER")
+		return nil
+	}
+
+	length, err := newClient().LInsert(key, where == "BEFORE", pivot, value)
+	if err != nil {
+		fmt.Println("LInsert data error:", err)
+		return err
+	}
+	fmt.Println("LInsert data success:", length)
+	return nil
+}
+
+// CLI for LPos command
+func stringLPosData(c *grumble.Context) error {
+	key := c.Args.String("key")
+	value := c.Args.String("value")
+	if key == "" || value == "" {
+		fmt.Println("key or value is empty")
+		return nil
+	}
+
+	positions, err := newClient().LPos(key, value, c.Args.Int("rank"), c.Args.Int("count"), c.Args.Int("maxlen"))
+	if err != nil {
+		fmt.Println("LPos data error:", err)
+		return err
+	}
+	fmt.Println("LPos data success:", positions)
+	return nil
+}
+
+// CLI for LMove command
+func stringLMoveData(c *grumble.Context) error {
+	source := c.Args.String("source")
+	destination := c.Args.String("destination")
+	if source == "" || destination == "" {
+		fmt.Println("source or destination is empty")
+		return nil
+	}
+
+	value, err := newClient().LMove(source, destination, c.Args.String("from"), c.Args.String("to"))
+	if err != nil {
+		fmt.Println("LMove data error:", err)
+		return err
+	}
+	fmt.Println("LMove data success:", value)
+	return nil
+}
+
+// CLI for BLPop command
+func stringBLPopData(c *grumble.Context) error {
+	keys := c.Args.StringList("keys")
+	if len(keys) == 0 {
+		fmt.Println("keys is empty")
+		return nil
+	}
+
+	timeout := time.Duration(c.Flags.Float64("timeout") * float64(time.Second))
+	key, value, err := newClient().BLPop(context.Background(), timeout, keys...)
+	if err != nil {
+		fmt.Println("BLPop data error:", err)
+		return err
+	}
+	fmt.Println("BLPop data success:", key, value)
+	return nil
+}
+
+// CLI for BRPop command
+func stringBRPopData(c *grumble.Context) error {
+	keys := c.Args.StringList("keys")
+	if len(keys) == 0 {
+		fmt.Println("keys is empty")
+		return nil
+	}
+
+	timeout := time.Duration(c.Flags.Float64("timeout") * float64(time.Second))
+	key, value, err := newClient().BRPop(context.Background(), timeout, keys...)
+	if err != nil {
+		fmt.Println("BRPop data error:", err)
+		return err
+	}
+	fmt.Println("BRPop data success:", key, value)
+	return nil
+}
+
+// CLI for BLMove command
+func stringBLMoveData(c *grumble.Context) error {
+	source := c.Args.String("source")
+	destination := c.Args.String("destination")
+	if source == "" || destination == "" {
+		fmt.Println("source or destination is empty")
+		return nil
+	}
+
+	timeout := time.Duration(c.Flags.Float64("timeout") * float64(time.Second))
+	value, err := newClient().BLMove(context.Background(), source, destination,
+		c.Args.String("from"), c.Args.String("to"), timeout)
+	if err != nil {
+		fmt.Println("BLMove data error:", err)
+		return err
+	}
+	fmt.Println("BLMove data success:", value)
+	return nil
+}
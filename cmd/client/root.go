@@ -44,8 +44,8 @@ func register(app *grumble.App) {
 
 	app.AddCommand(&grumble.Command{
 		Name: "type",
-		Help: "get the type of the value stored in a key in string-structure",
-		Run:  stringGetType,
+		Help: "get the type of the value stored in a key of any structure",
+		Run:  keysType,
 		Args: func(a *grumble.Args) {
 			a.String("key", "The key whose value type to retrieve", grumble.Default(""))
 		},
@@ -121,17 +121,17 @@ func register(app *grumble.App) {
 
 	app.AddCommand(&grumble.Command{
 		Name: "exists",
-		Help: "check if a key exists in string-structure",
-		Run:  stringExists,
+		Help: "count the keys of any structure that exist",
+		Run:  keysExists,
 		Args: func(a *grumble.Args) {
-			a.String("key", "The key to check for existence", grumble.Default(""))
+			a.StringList("key", "The keys to check for existence", grumble.Default([]string{}))
 		},
 	})
 
 	app.AddCommand(&grumble.Command{
 		Name: "expire",
-		Help: "set a timeout on a key in string-structure",
-		Run:  stringExpire,
+		Help: "set a timeout on a key of any structure",
+		Run:  keysExpire,
 		Args: func(a *grumble.Args) {
 			a.String("key", "The key to set a timeout on", grumble.Default(""))
 			a.Int64("ttl", "The time-to-live (TTL) in seconds", grumble.Default(0))
@@ -140,13 +140,53 @@ func register(app *grumble.App) {
 
 	app.AddCommand(&grumble.Command{
 		Name: "persist",
-		Help: "remove the timeout on a key, making it persist in string-structure",
-		Run:  stringPersist,
+		Help: "remove the timeout on a key of any structure, making it persist",
+		Run:  keysPersist,
 		Args: func(a *grumble.Args) {
 			a.String("key", "The key to make persistent", grumble.Default(""))
 		},
 	})
 
+	app.AddCommand(&grumble.Command{
+		Name: "ttl",
+		Help: "get the time to live of a key of any structure in seconds",
+		Run:  keysTTL,
+		Args: func(a *grumble.Args) {
+			a.String("key", "The key whose time to live to retrieve", grumble.Default(""))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "del",
+		Help: "delete keys of any structure",
+		Run:  keysDel,
+		Args: func(a *grumble.Args) {
+			a.StringList("key", "The keys to delete", grumble.Default([]string{}))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "rename",
+		Help: "rename a key of any structure, replacing the value of the new key",
+		Run:  keysRename,
+		Args: func(a *grumble.Args) {
+			a.String("key", "The key to rename", grumble.Default(""))
+			a.String("newkey", "The new name of the key", grumble.Default(""))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "scan",
+		Help: "iterate over the keys of any structure, one page per call",
+		Run:  keysScan,
+		Args: func(a *grumble.Args) {
+			a.String("cursor", "The cursor returned by the previous page, 0 to start", grumble.Default("0"))
+			a.String("match", "Only return the keys matching this pattern", grumble.Default("*"))
+			a.Int("count", "How many keys to look at", grumble.Default(10))
+			a.String("type", "Only return the keys of this type", grumble.Default(""))
+		},
+	})
+
 	app.AddCommand(&grumble.Command{
 		Name: "mget",
 		Help: "get the values of multiple keys in string-structure",
@@ -311,6 +351,18 @@ func register(app *grumble.App) {
 		},
 	})
 
+	app.AddCommand(&grumble.Command{
+		Name: "HScan",
+		Help: "Iterate over the fields of a hash in hash-structure, one page per call",
+		Run:  hashHScan,
+		Args: func(a *grumble.Args) {
+			a.String("key", "key", grumble.Default(""))
+			a.String("cursor", "cursor", grumble.Default("0"))
+			a.String("match", "match", grumble.Default("*"))
+			a.Int("count", "count", grumble.Default(10))
+		},
+	})
+
 	app.AddCommand(&grumble.Command{
 		Name: "LPush",
 		Help: "Inserts a value at the head of a list in list-structure",
@@ -432,6 +484,82 @@ func register(app *grumble.App) {
 		},
 	})
 
+	app.AddCommand(&grumble.Command{
+		Name: "LInsert",
+		Help: "Insert an element before or after another one in a list in list-structure",
+		Run:  stringLInsertData,
+		Args: func(a *grumble.Args) {
+			a.String("key", "key", grumble.Default(""))
+			a.String("where", "BEFORE or AFTER", grumble.Default("BEFORE"))
+			a.String("pivot", "pivot", grumble.Default(""))
+			a.String("value", "value", grumble.Default(""))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "LPos",
+		Help: "Get the indexes of an element in a list in list-structure",
+		Run:  stringLPosData,
+		Args: func(a *grumble.Args) {
+			a.String("key", "key", grumble.Default(""))
+			a.String("value", "value", grumble.Default(""))
+			a.Int("rank", "rank, negative to search from the tail", grumble.Default(1))
+			a.Int("count", "count, 0 for all", grumble.Default(1))
+			a.Int("maxlen", "maximum number of elements compared, 0 for all", grumble.Default(0))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "LMove",
+		Help: "Move an element from an end of a list to an end of another list in list-structure",
+		Run:  stringLMoveData,
+		Args: func(a *grumble.Args) {
+			a.String("source", "source", grumble.Default(""))
+			a.String("destination", "destination", grumble.Default(""))
+			a.String("from", "LEFT or RIGHT", grumble.Default("LEFT"))
+			a.String("to", "LEFT or RIGHT", grumble.Default("RIGHT"))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "BLPop",
+		Help: "Removes and returns the first element of the first non-empty list, waiting for one in list-structure",
+		Run:  stringBLPopData,
+		Args: func(a *grumble.Args) {
+			a.StringList("keys", "keys", grumble.Default(""))
+		},
+		Flags: func(f *grumble.Flags) {
+			f.Float64("t", "timeout", 0, "seconds to wait, 0 waits forever")
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "BRPop",
+		Help: "Removes and returns the last element of the first non-empty list, waiting for one in list-structure",
+		Run:  stringBRPopData,
+		Args: func(a *grumble.Args) {
+			a.StringList("keys", "keys", grumble.Default(""))
+		},
+		Flags: func(f *grumble.Flags) {
+			f.Float64("t", "timeout", 0, "seconds to wait, 0 waits forever")
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "BLMove",
+		Help: "Move an element from an end of a list to an end of another list, waiting for one in list-structure",
+		Run:  stringBLMoveData,
+		Args: func(a *grumble.Args) {
+			a.String("source", "source", grumble.Default(""))
+			a.String("destination", "destination", grumble.Default(""))
+			a.String("from", "LEFT or RIGHT", grumble.Default("LEFT"))
+			a.String("to", "LEFT or RIGHT", grumble.Default("RIGHT"))
+		},
+		Flags: func(f *grumble.Flags) {
+			f.Float64("t", "timeout", 0, "seconds to wait, 0 waits forever")
+		},
+	})
+
 	app.AddCommand(&grumble.Command{
 		Name: "Sadd",
 		Help: "Set the value in a set in set-structure",
@@ -547,6 +675,18 @@ func register(app *grumble.App) {
 		},
 	})
 
+	app.AddCommand(&grumble.Command{
+		Name: "Sscan",
+		Help: "Iterate over the members of a set in set-structure, one page per call",
+		Run:  SetScan,
+		Args: func(a *grumble.Args) {
+			a.String("key", "key", grumble.Default(""))
+			a.String("cursor", "cursor", grumble.Default("0"))
+			a.String("match", "match", grumble.Default("*"))
+			a.Int("count", "count", grumble.Default(10))
+		},
+	})
+
 	app.AddCommand(&grumble.Command{
 		Name: "ZAdd",
 		Help: "Add a member to a sorted set, or update its score if it already exists",
@@ -554,9 +694,17 @@ func register(app *grumble.App) {
 		Args: func(a *grumble.Args) {
 			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
 			a.String(CommonMemberArg, ZSetDefaultMemberHelp, grumble.Default(CommonDefaultEmptyString))
-			a.Int(ZSetScoreArg, ZSetDefaultScoreHelp, grumble.Default(ZSetDefaultScore))
+			a.Float64(ZSetScoreArg, ZSetDefaultScoreHelp, grumble.Default(ZSetDefaultScore))
 			a.String(CommonValueArg, ZSetDefaultValueHelp, grumble.Default(CommonDefaultEmptyString))
 		},
+		Flags: func(f *grumble.Flags) {
+			f.Bool("", "nx", false, "only add new members")
+			f.Bool("", "xx", false, "only update existing members")
+			f.Bool("", "gt", false, "only update a member if its new score is greater")
+			f.Bool("", "lt", false, "only update a member if its new score is lower")
+			f.Bool("", "ch", false, "count the changed members as well as the added ones")
+			f.Bool("", "incr", false, "add the score to the score of the member")
+		},
 	})
 
 	app.AddCommand(&grumble.Command{
@@ -626,8 +774,8 @@ func register(app *grumble.App) {
 		Run:  ZSetCount,
 		Args: func(a *grumble.Args) {
 			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
-			a.Int(ZSetMinArg, ZSetDefaultMinHelp, grumble.Default(ZSetDefaultMin))
-			a.Int(ZSetMaxArg, ZSetDefaultMaxHelp, grumble.Default(ZSetDefaultMax))
+			a.Float64(ZSetMinArg, ZSetDefaultMinHelp, grumble.Default(ZSetDefaultMin))
+			a.Float64(ZSetMaxArg, ZSetDefaultMaxHelp, grumble.Default(ZSetDefaultMax))
 		},
 	})
 
@@ -658,7 +806,183 @@ func register(app *grumble.App) {
 		Args: func(a *grumble.Args) {
 			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
 			a.String(CommonMemberArg, ZSetDefaultMemberHelp, grumble.Default(CommonDefaultEmptyString))
-			a.Int(ZSetIncrByArg, ZSetDefaultIncrByHelp, grumble.Default(ZSetDefaultIncrBy))
+			a.Float64(ZSetIncrByArg, ZSetDefaultIncrByHelp, grumble.Default(ZSetDefaultIncrBy))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZScan",
+		Help: "Iterate over the members of a sorted set, one page per call",
+		Run:  ZSetScan,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.String("cursor", "The cursor returned by the previous page, 0 to start", grumble.Default("0"))
+			a.String("match", "Only return the members matching this pattern", grumble.Default("*"))
+			a.Int("count", "How many members to look at", grumble.Default(10))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZRangeByScore",
+		Help: "Get the members of a sorted set with scores within the given range",
+		Run:  ZSetRangeByScore,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.String(ZSetMinArg, ZSetMinBoundHelp, grumble.Default(ZSetDefaultMinBound))
+			a.String(ZSetMaxArg, ZSetMaxBoundHelp, grumble.Default(ZSetDefaultMaxBound))
+			a.Int(ZSetOffsetArg, ZSetDefaultOffsetHelp, grumble.Default(ZSetDefaultOffset))
+			a.Int(ZSetCountArg, ZSetDefaultCountHelp, grumble.Default(ZSetDefaultCount))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZRevRangeByScore",
+		Help: "Get the members of a sorted set with scores within the given range, from the highest score",
+		Run:  ZSetRevRangeByScore,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.String(ZSetMaxArg, ZSetMaxBoundHelp, grumble.Default(ZSetDefaultMaxBound))
+			a.String(ZSetMinArg, ZSetMinBoundHelp, grumble.Default(ZSetDefaultMinBound))
+			a.Int(ZSetOffsetArg, ZSetDefaultOffsetHelp, grumble.Default(ZSetDefaultOffset))
+			a.Int(ZSetCountArg, ZSetDefaultCountHelp, grumble.Default(ZSetDefaultCount))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZRangeByLex",
+		Help: "Get the members of a sorted set within the given range of members",
+		Run:  ZSetRangeByLex,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.String(ZSetMinArg, ZSetMinLexHelp, grumble.Default("-"))
+			a.String(ZSetMaxArg, ZSetMaxLexHelp, grumble.Default("+"))
+			a.Int(ZSetOffsetArg, ZSetDefaultOffsetHelp, grumble.Default(ZSetDefaultOffset))
+			a.Int(ZSetCountArg, ZSetDefaultCountHelp, grumble.Default(ZSetDefaultCount))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZPopMin",
+		Help: "Remove and get the members of a sorted set with the lowest scores",
+		Run:  ZSetPopMin,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.Int(ZSetCountArg, ZSetPopCountHelp, grumble.Default(ZSetDefaultPopCount))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZPopMax",
+		Help: "Remove and get the members of a sorted set with the highest scores",
+		Run:  ZSetPopMax,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.Int(ZSetCountArg, ZSetPopCountHelp, grumble.Default(ZSetDefaultPopCount))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZRemRangeByScore",
+		Help: "Remove the members of a sorted set with scores within the given range",
+		Run:  ZSetRemRangeByScore,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.String(ZSetMinArg, ZSetMinBoundHelp, grumble.Default(ZSetDefaultMinBound))
+			a.String(ZSetMaxArg, ZSetMaxBoundHelp, grumble.Default(ZSetDefaultMaxBound))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZRemRangeByRank",
+		Help: "Remove the members of a sorted set within the given range of ranks",
+		Run:  ZSetRemRangeByRank,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.Int(ZSetStartArg, ZSetDefaultStartHelp, grumble.Default(ZSetDefaultRangeStart))
+			a.Int(ZSetStopArg, ZSetStopHelp, grumble.Default(-1))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZMScore",
+		Help: "Get the scores of multiple members in a sorted set",
+		Run:  ZSetMScore,
+		Args: func(a *grumble.Args) {
+			a.String(CommonKeyArg, ZSetDefaultKeyHelp, grumble.Default(CommonDefaultEmptyString))
+			a.StringList(CommonMembersArg, ZSetDefaultMembersHelp, grumble.Default(CommonDefaultEmptyString))
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZUnion",
+		Help: "Get the union of sorted sets",
+		Run:  ZSetUnion,
+		Args: func(a *grumble.Args) {
+			a.StringList(ZSetKeysArg, ZSetKeysHelp)
+		},
+		Flags: func(f *grumble.Flags) {
+			f.String("w", ZSetWeightsFlag, "", ZSetWeightsHelp)
+			f.String("a", ZSetAggregateFlag, "sum", ZSetAggregateHelp)
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZInter",
+		Help: "Get the intersection of sorted sets",
+		Run:  ZSetInter,
+		Args: func(a *grumble.Args) {
+			a.StringList(ZSetKeysArg, ZSetKeysHelp)
+		},
+		Flags: func(f *grumble.Flags) {
+			f.String("w", ZSetWeightsFlag, "", ZSetWeightsHelp)
+			f.String("a", ZSetAggregateFlag, "sum", ZSetAggregateHelp)
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZDiff",
+		Help: "Get the members of the first sorted set that are in none of the others",
+		Run:  ZSetDiff,
+		Args: func(a *grumble.Args) {
+			a.StringList(ZSetKeysArg, ZSetKeysHelp)
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZUnionStore",
+		Help: "Write the union of sorted sets to a key",
+		Run:  ZSetUnionStore,
+		Args: func(a *grumble.Args) {
+			a.String(ZSetDestArg, ZSetDestHelp, grumble.Default(CommonDefaultEmptyString))
+			a.StringList(ZSetKeysArg, ZSetKeysHelp)
+		},
+		Flags: func(f *grumble.Flags) {
+			f.String("w", ZSetWeightsFlag, "", ZSetWeightsHelp)
+			f.String("a", ZSetAggregateFlag, "sum", ZSetAggregateHelp)
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZInterStore",
+		Help: "Write the intersection of sorted sets to a key",
+		Run:  ZSetInterStore,
+		Args: func(a *grumble.Args) {
+			a.String(ZSetDestArg, ZSetDestHelp, grumble.Default(CommonDefaultEmptyString))
+			a.StringList(ZSetKeysArg, ZSetKeysHelp)
+		},
+		Flags: func(f *grumble.Flags) {
+			f.String("w", ZSetWeightsFlag, "", ZSetWeightsHelp)
+			f.String("a", ZSetAggregateFlag, "sum", ZSetAggregateHelp)
+		},
+	})
+
+	app.AddCommand(&grumble.Command{
+		Name: "ZDiffStore",
+		Help: "Write the members of the first sorted set that are in none of the others to a key",
+		Run:  ZSetDiffStore,
+		Args: func(a *grumble.Args) {
+			a.String(ZSetDestArg, ZSetDestHelp, grumble.Default(CommonDefaultEmptyString))
+			a.StringList(ZSetKeysArg, ZSetKeysHelp)
 		},
 	})
 }
@@ -204,3 +204,23 @@ func SetInterStore(c *grumble.Context) error {
 	fmt.Println("SInterStore success")
 	return nil
 }
+
+func SetScan(c *grumble.Context) error {
+	key := c.Args.String("key")
+	cursor := c.Args.String("cursor")
+	match := c.Args.String("match")
+	count := c.Args.Int("count")
+	if key == "" {
+		fmt.Println("key is empty")
+		return nil
+	}
+
+	next, members, err := newClient().SScan(key, cursor, match, int64(count))
+	if err != nil {
+		fmt.Println("SScan data error: ", err)
+		return err
+	}
+	fmt.Println("SScan cursor:", next)
+	fmt.Println("SScan data:", members)
+	return nil
+}
@@ -53,21 +53,6 @@ func stringDeleteKey(c *grumble.Context) error {
 	return nil
 }
 
-func stringGetType(c *grumble.Context) error {
-	key := c.Args.String("key")
-	if key == "" {
-		fmt.Println("key is empty")
-		return nil
-	}
-	valueType, err := newClient().Type(key)
-	if err != nil {
-		fmt.Println("get type error: ", err)
-		return err
-	}
-	fmt.Println("Type:", valueType)
-	return nil
-}
-
 func stringStrLen(c *grumble.Context) error {
 	key := c.Args.String("key")
 	strLen, err := newClient().StrLen(key)
@@ -184,39 +169,6 @@ func stringDecrBy(c *grumble.Context) error {
 	return nil
 }
 
-func stringExists(c *grumble.Context) error {
-	key := c.Args.String("key")
-	exists, err := newClient().Exists(key)
-	if err != nil {
-		return err
-	}
-	if exists {
-		fmt.Println("key is exist", key)
-	}
-	return nil
-}
-
-func stringExpire(c *grumble.Context) error {
-	key := c.Args.String("key")
-	ttl := c.Args.Int64("ttl")
-	err := newClient().Expire(key, ttl)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func stringPersist(c *grumble.Context) error {
-	key := c.Args.String("key")
-	err := newClient().Persist(key)
-	if err != nil {
-		fmt.Println("key is not Persist")
-		return err
-	}
-	fmt.Println("key is Persist")
-	return nil
-}
-
 func stringMGet(c *grumble.Context) error {
 	keys := c.Args.StringList("key")
 	values, err := newClient().MGet(keys)
@@ -3,6 +3,8 @@ package client
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
 	"github.com/desertbit/grumble"
 	pbany "github.com/golang/protobuf/ptypes/any"
@@ -48,27 +50,45 @@ func ZSetAdd(ctx *grumble.Context) error {
 	var (
 		key       = ctx.Args.String(CommonKeyArg)
 		member    = ctx.Args.String(CommonMemberArg)
-		score     = ctx.Args.Int(ZSetScoreArg)
+		score     = ctx.Args.Float64(ZSetScoreArg)
 		value     = ctx.Args.String(CommonValueArg)
 		zSetValue = &gzset.ZSetValue{}
+		response  *gzset.ZAddResponse
 		err       error
 	)
 
-	if checkIsEmpty(CommonKeyArg, key) || checkIsEmpty(CommonMemberArg, member) || checkIsEmpty(CommonValueArg, value) || checkIsInt32(ZSetScoreArg, score) {
+	if checkIsEmpty(CommonKeyArg, key) || checkIsEmpty(CommonMemberArg, member) || checkIsEmpty(CommonValueArg, value) {
 		return nil
 	}
 
 	zSetValue.Member = member
-	zSetValue.Score = int32(score)
+	zSetValue.Score = score
 	zSetValue.Value = string2Any(value)
+	flags := &gzset.ZAddFlags{
+		Nx:   ctx.Flags.Bool("nx"),
+		Xx:   ctx.Flags.Bool("xx"),
+		Gt:   ctx.Flags.Bool("gt"),
+		Lt:   ctx.Flags.Bool("lt"),
+		Ch:   ctx.Flags.Bool("ch"),
+		Incr: ctx.Flags.Bool("incr"),
+	}
 
-	if _, err = newClient().ZAdd(key, zSetValue); err != nil {
+	if response, err = newClient().ZAddWithFlags(key, zSetValue, flags); err != nil {
 		fmt.Println("ZAdd data error: ", err)
 
 		return err
 	}
 
-	fmt.Println("ZAdd data success")
+	if flags.Incr {
+		if !response.Exists {
+			fmt.Println("ZAdd data skipped")
+			return nil
+		}
+		fmt.Printf("ZAdd data success, new score: %g\n", response.Score)
+		return nil
+	}
+
+	fmt.Printf("ZAdd data success, count: %d\n", response.Count)
 
 	return nil
 }
@@ -135,7 +155,7 @@ func ZSetScore(ctx *grumble.Context) error {
 		return err
 	}
 
-	fmt.Printf("ZScore data success, score: %d\n", response.Score)
+	fmt.Printf("ZScore data success, score: %g\n", response.Score)
 
 	return nil
 }
@@ -188,7 +208,7 @@ func ZSetRevRank(ctx *grumble.Context) error {
 
 func printZSetResponse(memberList []*gzset.ZSetValue) {
 	for _, member := range memberList {
-		fmt.Printf("Member: %s, Score: %d, Value: %s\n", member.Member, member.Score, member.Value.Value)
+		fmt.Printf("Member: %s, Score: %g, Value: %s\n", member.Member, member.Score, member.Value.GetValue())
 	}
 }
 
@@ -224,19 +244,23 @@ func ZSetRange(ctx *grumble.Context) error {
 func ZSetCount(ctx *grumble.Context) error {
 	var (
 		key          = ctx.Args.String(CommonKeyArg)
-		minArg       = ctx.Args.Int(ZSetMinArg)
-		maxArg       = ctx.Args.Int(ZSetMaxArg)
-		requestRange = &client.Range{}
+		minArg       = ctx.Args.Float64(ZSetMinArg)
+		maxArg       = ctx.Args.Float64(ZSetMaxArg)
+		requestRange = &client.ScoreRange{}
 		response     *gzset.ZCountResponse
 		err          error
 	)
 
-	if checkIsEmpty(CommonKeyArg, key) || checkIsInt32(ZSetStartArg, minArg) || checkIsInt32(ZSetEndArg, maxArg) || checkRange(minArg, maxArg) {
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+	if minArg > maxArg {
+		fmt.Println("Min is greater than max")
 		return nil
 	}
 
-	requestRange.Start = int32(minArg)
-	requestRange.End = int32(maxArg)
+	requestRange.Min = minArg
+	requestRange.Max = maxArg
 
 	if response, err = newClient().ZCount(key, requestRange); err != nil {
 		fmt.Println("ZCount data error: ", err)
@@ -305,18 +329,18 @@ func ZSetIncrBy(ctx *grumble.Context) error {
 	var (
 		key      = ctx.Args.String(CommonKeyArg)
 		member   = ctx.Args.String(CommonMemberArg)
-		incrBy   = ctx.Args.Int(ZSetIncrByArg)
+		incrBy   = ctx.Args.Float64(ZSetIncrByArg)
 		incr     = &client.Incr{}
 		response *gzset.ZIncrByResponse
 		err      error
 	)
 
-	if checkIsEmpty(CommonKeyArg, key) || checkIsEmpty(CommonMemberArg, member) || checkIsInt32(ZSetIncrByArg, incrBy) {
+	if checkIsEmpty(CommonKeyArg, key) || checkIsEmpty(CommonMemberArg, member) {
 		return nil
 	}
 
 	incr.Member = member
-	incr.Inc = int32(incrBy)
+	incr.Inc = incrBy
 
 	if response, err = newClient().ZIncrBy(key, incr); err != nil {
 		fmt.Println("ZIncrBy data error: ", err)
@@ -324,7 +348,349 @@ func ZSetIncrBy(ctx *grumble.Context) error {
 		return err
 	}
 
-	fmt.Printf("ZIncrBy data success, new score: %d\n", response.NewScore)
+	fmt.Printf("ZIncrBy data success, new score: %g\n", response.NewScore)
+
+	return nil
+}
+
+func ZSetScan(ctx *grumble.Context) error {
+	var (
+		key      = ctx.Args.String(CommonKeyArg)
+		cursor   = ctx.Args.String("cursor")
+		match    = ctx.Args.String("match")
+		count    = ctx.Args.Int("count")
+		response *gzset.ZScanResponse
+		err      error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZScan(key, cursor, match, int64(count)); err != nil {
+		fmt.Println("ZScan data error: ", err)
+
+		return err
+	}
+
+	fmt.Printf("ZScan data success, cursor: %s\n", response.Cursor)
+	printZSetResponse(response.Members)
+
+	return nil
+}
+
+func zSetLimit(ctx *grumble.Context) *gzset.Limit {
+	return &gzset.Limit{Offset: int64(ctx.Args.Int(ZSetOffsetArg)), Count: int64(ctx.Args.Int(ZSetCountArg))}
+}
+
+func ZSetRangeByScore(ctx *grumble.Context) error {
+	var (
+		key      = ctx.Args.String(CommonKeyArg)
+		response *gzset.ZRangeByScoreResponse
+		err      error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZRangeByScore(key, ctx.Args.String(ZSetMinArg), ctx.Args.String(ZSetMaxArg),
+		zSetLimit(ctx)); err != nil {
+		fmt.Println("ZRangeByScore data error: ", err)
+
+		return err
+	}
+
+	fmt.Println("ZRangeByScore data success")
+	printZSetResponse(response.Members)
+
+	return nil
+}
+
+func ZSetRevRangeByScore(ctx *grumble.Context) error {
+	var (
+		key      = ctx.Args.String(CommonKeyArg)
+		response *gzset.ZRangeByScoreResponse
+		err      error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZRevRangeByScore(key, ctx.Args.String(ZSetMaxArg), ctx.Args.String(ZSetMinArg),
+		zSetLimit(ctx)); err != nil {
+		fmt.Println("ZRevRangeByScore data error: ", err)
+
+		return err
+	}
+
+	fmt.Println("ZRevRangeByScore data success")
+	printZSetResponse(response.Members)
+
+	return nil
+}
+
+func ZSetRangeByLex(ctx *grumble.Context) error {
+	var (
+		key      = ctx.Args.String(CommonKeyArg)
+		response *gzset.ZRangeByLexResponse
+		err      error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZRangeByLex(key, ctx.Args.String(ZSetMinArg), ctx.Args.String(ZSetMaxArg),
+		zSetLimit(ctx)); err != nil {
+		fmt.Println("ZRangeByLex data error: ", err)
+
+		return err
+	}
+
+	fmt.Println("ZRangeByLex data success")
+	printZSetResponse(response.Members)
+
+	return nil
+}
+
+func ZSetPopMin(ctx *grumble.Context) error {
+	var (
+		key      = ctx.Args.String(CommonKeyArg)
+		count    = ctx.Args.Int(ZSetCountArg)
+		response *gzset.ZPopResponse
+		err      error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZPopMin(key, int64(count)); err != nil {
+		fmt.Println("ZPopMin data error: ", err)
+
+		return err
+	}
+
+	fmt.Println("ZPopMin data success")
+	printZSetResponse(response.Members)
+
+	return nil
+}
+
+func ZSetPopMax(ctx *grumble.Context) error {
+	var (
+		key      = ctx.Args.String(CommonKeyArg)
+		count    = ctx.Args.Int(ZSetCountArg)
+		response *gzset.ZPopResponse
+		err      error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZPopMax(key, int64(count)); err != nil {
+		fmt.Println("ZPopMax data error: ", err)
+
+		return err
+	}
+
+	fmt.Println("ZPopMax data success")
+	printZSetResponse(response.Members)
+
+	return nil
+}
+
+func ZSetRemRangeByScore(ctx *grumble.Context) error {
+	var (
+		key      = ctx.Args.String(CommonKeyArg)
+		response *gzset.ZRemRangeResponse
+		err      error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZRemRangeByScore(key, ctx.Args.String(ZSetMinArg),
+		ctx.Args.String(ZSetMaxArg)); err != nil {
+		fmt.Println("ZRemRangeByScore data error: ", err)
+
+		return err
+	}
+
+	fmt.Printf("ZRemRangeByScore data success, removed: %d\n", response.Removed)
 
 	return nil
 }
+
+func ZSetRemRangeByRank(ctx *grumble.Context) error {
+	var (
+		key      = ctx.Args.String(CommonKeyArg)
+		start    = ctx.Args.Int(ZSetStartArg)
+		stop     = ctx.Args.Int(ZSetStopArg)
+		response *gzset.ZRemRangeResponse
+		err      error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZRemRangeByRank(key, int64(start), int64(stop)); err != nil {
+		fmt.Println("ZRemRangeByRank data error: ", err)
+
+		return err
+	}
+
+	fmt.Printf("ZRemRangeByRank data success, removed: %d\n", response.Removed)
+
+	return nil
+}
+
+func ZSetMScore(ctx *grumble.Context) error {
+	var (
+		key        = ctx.Args.String(CommonKeyArg)
+		memberList = ctx.Args.StringList(CommonMembersArg)
+		response   *gzset.ZMScoreResponse
+		err        error
+	)
+
+	if checkIsEmpty(CommonKeyArg, key) {
+		return nil
+	}
+
+	if response, err = newClient().ZMScore(key, memberList); err != nil {
+		fmt.Println("ZMScore data error: ", err)
+
+		return err
+	}
+
+	fmt.Println("ZMScore data success")
+	for i, member := range memberList {
+		if response.Exists[i] {
+			fmt.Printf("Member: %s, Score: %g\n", member, response.Scores[i])
+		} else {
+			fmt.Printf("Member: %s, Score: (nil)\n", member)
+		}
+	}
+
+	return nil
+}
+
+func zSetWeights(ctx *grumble.Context) ([]float64, error) {
+	var weights []float64
+	for _, w := range strings.Split(ctx.Flags.String(ZSetWeightsFlag), ",") {
+		if w = strings.TrimSpace(w); w == "" {
+			continue
+		}
+		weight, err := strconv.ParseFloat(w, 64)
+		if err != nil {
+			return nil, err
+		}
+		weights = append(weights, weight)
+	}
+	return weights, nil
+}
+
+func zSetCombine(ctx *grumble.Context, name string) error {
+	var (
+		keys     = ctx.Args.StringList(ZSetKeysArg)
+		c        = newClient()
+		weights  []float64
+		response *gzset.ZCombineResponse
+		err      error
+	)
+
+	// ZDiff and ZDiffStore take neither weights nor an aggregate
+	if !strings.HasPrefix(name, "ZDiff") {
+		if weights, err = zSetWeights(ctx); err != nil {
+			fmt.Printf("%s data error: %v\n", name, err)
+			return err
+		}
+	}
+
+	switch name {
+	case "ZUnion":
+		response, err = c.ZUnion(keys, weights, ctx.Flags.String(ZSetAggregateFlag))
+	case "ZInter":
+		response, err = c.ZInter(keys, weights, ctx.Flags.String(ZSetAggregateFlag))
+	default:
+		response, err = c.ZDiff(keys)
+	}
+	if err != nil {
+		fmt.Printf("%s data error: %v\n", name, err)
+		return err
+	}
+
+	fmt.Printf("%s data success\n", name)
+	printZSetResponse(response.Members)
+
+	return nil
+}
+
+func zSetCombineStore(ctx *grumble.Context, name string) error {
+	var (
+		destination = ctx.Args.String(ZSetDestArg)
+		keys        = ctx.Args.StringList(ZSetKeysArg)
+		c           = newClient()
+		weights     []float64
+		response    *gzset.ZCombineStoreResponse
+		err         error
+	)
+
+	if checkIsEmpty(ZSetDestArg, destination) {
+		return nil
+	}
+
+	// ZDiff and ZDiffStore take neither weights nor an aggregate
+	if !strings.HasPrefix(name, "ZDiff") {
+		if weights, err = zSetWeights(ctx); err != nil {
+			fmt.Printf("%s data error: %v\n", name, err)
+			return err
+		}
+	}
+
+	switch name {
+	case "ZUnionStore":
+		response, err = c.ZUnionStore(destination, keys, weights, ctx.Flags.String(ZSetAggregateFlag))
+	case "ZInterStore":
+		response, err = c.ZInterStore(destination, keys, weights, ctx.Flags.String(ZSetAggregateFlag))
+	default:
+		response, err = c.ZDiffStore(destination, keys)
+	}
+	if err != nil {
+		fmt.Printf("%s data error: %v\n", name, err)
+		return err
+	}
+
+	fmt.Printf("%s data success, count: %d\n", name, response.Count)
+
+	return nil
+}
+
+func ZSetUnion(ctx *grumble.Context) error {
+	return zSetCombine(ctx, "ZUnion")
+}
+
+func ZSetInter(ctx *grumble.Context) error {
+	return zSetCombine(ctx, "ZInter")
+}
+
+func ZSetDiff(ctx *grumble.Context) error {
+	return zSetCombine(ctx, "ZDiff")
+}
+
+func ZSetUnionStore(ctx *grumble.Context) error {
+	return zSetCombineStore(ctx, "ZUnionStore")
+}
+
+func ZSetInterStore(ctx *grumble.Context) error {
+	return zSetCombineStore(ctx, "ZInterStore")
+}
+
+func ZSetDiffStore(ctx *grumble.Context) error {
+	return zSetCombineStore(ctx, "ZDiffStore")
+}
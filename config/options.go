@@ -1,8 +1,10 @@
 package config
 
 import (
+	"github.com/ByteStorage/FlyDB/db/fileio"
 	"github.com/ByteStorage/FlyDB/lib/wal"
 	"os"
+	"time"
 )
 
 // Options is a comprehensive configuration struct that
@@ -23,6 +25,10 @@ type Options struct {
 
 	// FIOType indicates the type of file I/O optimization to be applied by the database.
 	FIOType FIOType
+
+	// FaultInjector programs the faults of the files opened with FaultIOType, for testing.
+	// Without one, such files never fail.
+	FaultInjector *fileio.FaultInjector
 }
 
 // ColumnOptions are configurations for database column families
@@ -35,6 +41,36 @@ type ColumnOptions struct {
 	WalOptions wal.Options
 }
 
+// ColumnFamilyOptions are the settings of a single column family.
+// Zero fields fall back to the DbMemoryOptions of the column.
+type ColumnFamilyOptions struct {
+	// IndexType selects the index of the family's data files.
+	IndexType IndexerType `json:"index_type,omitempty"`
+
+	// DataFileSize is the maximum size of the family's data files.
+	DataFileSize int64 `json:"data_file_size,omitempty"`
+
+	// MemSize is the size of the family's memory tables.
+	MemSize int64 `json:"mem_size,omitempty"`
+
+	// Durability decides when writes to the family return.
+	Durability DurabilityMode `json:"durability,omitempty"`
+
+	// DefaultTTL is how long the values written to the family live, 0 keeps them forever.
+	DefaultTTL time.Duration `json:"default_ttl,omitempty"`
+}
+
+// ExpireSweepOptions configure how the keys of the data structures whose time to live
+// ran out are deleted in the background, rather than only once they are read
+type ExpireSweepOptions struct {
+	// Interval is the time between two sweeps, 0 turns the sweeper off.
+	Interval time.Duration
+
+	// MaxKeysPerSweep is the most keys that are due a sweep looks at,
+	// together with Interval it bounds the rate at which keys are deleted.
+	MaxKeysPerSweep int
+}
+
 // DbMemoryOptions is related to configuration of database memory tables
 type DbMemoryOptions struct {
 	// Option contains a set of database configuration options
@@ -63,8 +99,50 @@ type DbMemoryOptions struct {
 
 	// Wal is a reference to the Write-Ahead Logging (WAL) mechanism that ensures data durability.
 	Wal *wal.Wal
+
+	// WalID tells the records of this column family apart from those of the others
+	// in a shared Wal. If it is empty, ColumnName is used.
+	WalID string
+
+	// OnFlush is called after a memory table has been written to disk, so that the owner
+	// of a shared Wal can truncate it. It is called by the flusher and must not block.
+	OnFlush func()
+
+	// MaxImmutableMemTables is the number of full memory tables that may wait to be flushed.
+	// Once it is reached, writes stall until a flush finishes.
+	MaxImmutableMemTables int
+
+	// SlowdownImmutableMemTables is the number of waiting memory tables from which on
+	// every write is delayed a little, to let flushing catch up before writes stall.
+	SlowdownImmutableMemTables int
+
+	// FlushWorkers is the number of goroutines writing a memory table to disk.
+	FlushWorkers int
+
+	// Durability decides when Put and Delete return, see DurabilityMode.
+	// If it is not set, Option.SyncWrite selects DurabilitySync or DurabilityAsync.
+	Durability DurabilityMode
 }
 
+// DurabilityMode decides how far a write to a memory table has to reach before it is acknowledged
+type DurabilityMode = int8
+
+const (
+	// DurabilityNone keeps writes in memory only, without a WAL.
+	// Writes that have not been flushed to disk are lost on a crash.
+	DurabilityNone DurabilityMode = iota + 1
+
+	// DurabilityAsync writes to the WAL before returning, the WAL is synced every SaveTime.
+	// Writes survive a process crash, but the last ones may be lost when the machine fails.
+	DurabilityAsync
+
+	// DurabilityGroupCommit returns once the WAL is synced, concurrent writes share one sync
+	DurabilityGroupCommit
+
+	// DurabilitySync syncs the WAL on every write before returning
+	DurabilitySync
+)
+
 // IteratorOptions is the configuration for index iteration.
 type IteratorOptions struct {
 	// Prefix specifies the prefix value for keys to iterate over. Default is empty.
@@ -73,6 +151,17 @@ type IteratorOptions struct {
 	// Reverse indicates whether to iterate in reverse order.
 	// Default is false for forward iteration.
 	Reverse bool
+
+	// Start is the smallest key of a range scan, inclusive. Default is nil, no lower bound.
+	Start []byte
+
+	// End is the key a range scan stops before, exclusive. Default is nil, no upper bound.
+	End []byte
+
+	// Snapshot makes the iterator copy the entries of the index when it is created, so that
+	// it does not see later writes. Default is false: the index is read in batches as the
+	// iterator moves, and writes made meanwhile may or may not be seen.
+	Snapshot bool
 }
 
 // WriteBatchOptions is the configuration for batch writing.
@@ -87,9 +176,12 @@ type WriteBatchOptions struct {
 type FIOType = int8
 
 const (
-	FileIOType = iota + 1 // Standard File IO
-	BufIOType             // File IO with buffer
-	MmapIOType            // Memory Mapping IO
+	FileIOType   = iota + 1 // Standard File IO
+	BufIOType               // File IO with buffer
+	MmapIOType              // Memory Mapping IO
+	MemIOType               // In-memory IO, for databases that need no persistence
+	FaultIOType             // Standard File IO with faults injected through Options.FaultInjector
+	DirectIOType            // File IO with O_DIRECT, bypassing the page cache, linux only
 )
 
 type IndexerType = int8
@@ -106,6 +198,12 @@ const (
 
 	// ARTWithBloom index With Bloom Filter
 	ARTWithBloom
+
+	// BPlusTree persistent index stored on disk, for keyspaces larger than memory
+	BPlusTree
+
+	// HashMap sharded hash index, for workloads dominated by point lookups
+	HashMap
 )
 
 const (
@@ -120,6 +218,12 @@ var DefaultOptions = Options{
 	FIOType:      MmapIOType,
 }
 
+// DefaultExpireSweepOptions leave the sweeper off, setting Interval, e.g. to 100ms, turns it on
+var DefaultExpireSweepOptions = ExpireSweepOptions{
+	Interval:        0,
+	MaxKeysPerSweep: 200,
+}
+
 var DefaultIteratorOptions = IteratorOptions{
 	Prefix:  nil,
 	Reverse: false,
@@ -139,4 +243,9 @@ var DefaultDbMemoryOptions = DbMemoryOptions{
 	TotalMemSize: 1 * 1024 * 1024 * 1024, // 2GB
 	ColumnName:   "default",
 	Wal:          nil,
+	Durability:   DurabilityAsync,
+
+	MaxImmutableMemTables:      4,
+	SlowdownImmutableMemTables: 3,
+	FlushWorkers:               2,
 }
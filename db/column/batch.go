@@ -0,0 +1,110 @@
+package column
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/memory"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"sync"
+)
+
+// WriteBatch collects puts and deletes for several column families and commits them atomically.
+// The batch is written to the shared wal as one record ending with a commit marker,
+// so after a crash it is replayed completely or not at all.
+type WriteBatch struct {
+	lock    *sync.Mutex
+	column  *column
+	entries []memory.BatchEntry
+}
+
+// NewWriteBatch creates an empty batch
+func (c *column) NewWriteBatch() *WriteBatch {
+	return &WriteBatch{
+		lock:   new(sync.Mutex),
+		column: c,
+	}
+}
+
+// Put adds a put of a key/value pair to the column family
+func (wb *WriteBatch) Put(cf string, key []byte, value []byte) error {
+	if len(key) == 0 {
+		return _const.ErrKeyIsEmpty
+	}
+	wb.lock.Lock()
+	defer wb.lock.Unlock()
+	wb.entries = append(wb.entries, memory.BatchEntry{ColumnFamily: cf, Key: key, Value: value})
+	return nil
+}
+
+// Delete adds a delete of a key from the column family
+func (wb *WriteBatch) Delete(cf string, key []byte) error {
+	if len(key) == 0 {
+		return _const.ErrKeyIsEmpty
+	}
+	wb.lock.Lock()
+	defer wb.lock.Unlock()
+	wb.entries = append(wb.entries, memory.BatchEntry{ColumnFamily: cf, Delete: true, Key: key})
+	return nil
+}
+
+// Commit writes the batch to the wal and applies it to its column families.
+// Readers of the column see either none or all of its writes.
+// The batch is empty again afterwards and can be reused.
+func (wb *WriteBatch) Commit() error {
+	wb.lock.Lock()
+	defer wb.lock.Unlock()
+	if len(wb.entries) == 0 {
+		return nil
+	}
+
+	c := wb.column
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	// Every column family must exist before anything is written
+	families := make(map[string]*memory.Db)
+	for _, entry := range wb.entries {
+		db, ok := c.columnFamily[entry.ColumnFamily]
+		if !ok {
+			return ErrColumnFamilyNotExists
+		}
+		families[entry.ColumnFamily] = db
+	}
+
+	// The batch is as durable as its most durable column family demands,
+	// and the values of families with a default ttl carry their expiry time
+	var durability config.DurabilityMode
+	entries := make([]memory.BatchEntry, len(wb.entries))
+	for i, entry := range wb.entries {
+		opts := c.manifest.Families[entry.ColumnFamily]
+		if opts.Durability > durability {
+			durability = opts.Durability
+		}
+		if !entry.Delete {
+			entry.Value = encodeValue(entry.Value, opts.DefaultTTL)
+		}
+		// in the wal the column families go by their ids
+		entry.ColumnFamily = c.manifest.walID(entry.ColumnFamily)
+		entries[i] = entry
+	}
+
+	pos := c.wal.Position()
+	if durability != config.DurabilityNone {
+		if err := c.wal.Write(memory.NewBatchRecord(entries)); err != nil {
+			return err
+		}
+		if durability == config.DurabilitySync || durability == config.DurabilityGroupCommit {
+			if err := c.wal.Save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Every column family picks its own entries from the batch
+	for _, db := range families {
+		if err := db.ApplyBatch(entries, pos); err != nil {
+			return err
+		}
+	}
+	wb.entries = nil
+	return nil
+}
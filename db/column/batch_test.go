@@ -0,0 +1,180 @@
+package column
+
+import (
+	"fmt"
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/memory"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func batchTestOptions(name string) config.ColumnOptions {
+	option := DefaultColumnOptions
+	option.DbMemoryOptions.Option.DirPath = filepath.Join(os.TempDir(), name)
+	option.DbMemoryOptions.Option.FIOType = config.FileIOType
+	option.WalOptions.DirPath = filepath.Join(option.DbMemoryOptions.Option.DirPath, "wal")
+	option.WalOptions.FIOType = config.FileIOType
+	_ = os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+	return option
+}
+
+func TestWriteBatch_Commit(t *testing.T) {
+	option := batchTestOptions("flydb-column-batch")
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+
+	column, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, column.CreateColumnFamily("users"))
+	assert.Nil(t, column.CreateColumnFamily("emails"))
+	assert.Nil(t, column.Put("emails", []byte("old@flydb.io"), []byte("1")))
+
+	wb := column.NewWriteBatch()
+	assert.Nil(t, wb.Put("users", []byte("1"), []byte("new@flydb.io")))
+	assert.Nil(t, wb.Put("emails", []byte("new@flydb.io"), []byte("1")))
+	assert.Nil(t, wb.Delete("emails", []byte("old@flydb.io")))
+	assert.Equal(t, _const.ErrKeyIsEmpty, wb.Put("users", nil, nil))
+
+	// Nothing is visible before the commit
+	_, err = column.Get("users", []byte("1"))
+	assert.NotNil(t, err)
+	assert.Nil(t, wb.Commit())
+
+	value, err := column.Get("users", []byte("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new@flydb.io"), value)
+	value, err = column.Get("emails", []byte("new@flydb.io"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+	_, err = column.Get("emails", []byte("old@flydb.io"))
+	assert.NotNil(t, err)
+
+	// A batch naming a missing column family is not applied at all
+	wb = column.NewWriteBatch()
+	assert.Nil(t, wb.Put("users", []byte("2"), []byte("other@flydb.io")))
+	assert.Nil(t, wb.Put("missing", []byte("other@flydb.io"), []byte("2")))
+	assert.Equal(t, ErrColumnFamilyNotExists, wb.Commit())
+	_, err = column.Get("users", []byte("2"))
+	assert.NotNil(t, err)
+}
+
+func TestWriteBatch_Replay(t *testing.T) {
+	option := batchTestOptions("flydb-column-batch-replay")
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+
+	col, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, col.CreateColumnFamily("users"))
+	assert.Nil(t, col.CreateColumnFamily("emails"))
+	assert.Nil(t, col.Put("users", []byte("0"), []byte("only users")))
+
+	wb := col.NewWriteBatch()
+	assert.Nil(t, wb.Put("users", []byte("1"), []byte("a@flydb.io")))
+	assert.Nil(t, wb.Put("emails", []byte("a@flydb.io"), []byte("1")))
+	assert.Nil(t, wb.Commit())
+
+	// A batch torn before its commit marker
+	record := memory.NewBatchRecord([]memory.BatchEntry{
+		{ColumnFamily: "users", Key: []byte("2"), Value: []byte("b@flydb.io")},
+		{ColumnFamily: "emails", Key: []byte("b@flydb.io"), Value: []byte("2")},
+	})
+	record.Value = record.Value[:len(record.Value)-1]
+	assert.Nil(t, col.(*column).wal.Write(record))
+	assert.Nil(t, col.(*column).wal.Save())
+
+	// Reopen without closing, the column families replay the shared wal
+	reopened, err := NewColumn(option)
+	assert.Nil(t, err)
+	list, err := reopened.ListColumnFamilies()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"default", "users", "emails"}, list)
+
+	value, err := reopened.Get("users", []byte("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("a@flydb.io"), value)
+	value, err = reopened.Get("emails", []byte("a@flydb.io"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	// Writes stay in their own column family
+	_, err = reopened.Get("emails", []byte("0"))
+	assert.NotNil(t, err)
+
+	// The torn batch is dropped as a whole
+	_, err = reopened.Get("users", []byte("2"))
+	assert.NotNil(t, err)
+	_, err = reopened.Get("emails", []byte("b@flydb.io"))
+	assert.NotNil(t, err)
+}
+
+// walSegments counts the segment files of the shared wal
+func walSegments(t *testing.T, option config.ColumnOptions) int {
+	files, err := filepath.Glob(filepath.Join(option.WalOptions.DirPath, "*.wal"))
+	assert.Nil(t, err)
+	return len(files)
+}
+
+func TestColumn_CheckpointWal(t *testing.T) {
+	option := batchTestOptions("flydb-column-checkpoint")
+	option.DbMemoryOptions.MemSize = 4 * 1024
+	option.WalOptions.FileSize = 8 * 1024
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+
+	col, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, col.CreateColumnFamily("hot"))
+	assert.Nil(t, col.CreateColumnFamily("cold"))
+
+	// A write that is not flushed yet keeps the wal from the segment it is in
+	assert.Nil(t, col.Put("cold", []byte("key"), []byte("value")))
+	value := make([]byte, 100)
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, col.Put("hot", []byte(fmt.Sprintf("key-%d", i)), value))
+	}
+	assert.Nil(t, col.(*column).checkpoint())
+	grown := walSegments(t, option)
+	assert.Greater(t, grown, 10)
+
+	// Once every family has flushed, the segments before are deleted
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, col.Put("cold", []byte(fmt.Sprintf("key-%d", i)), value))
+	}
+	assert.Eventually(t, func() bool {
+		return walSegments(t, option) < grown/2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	got, err := col.Get("cold", []byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), got)
+	got, err = col.Get("hot", []byte("key-0"))
+	assert.Nil(t, err)
+	assert.Equal(t, value, got)
+}
+
+func TestColumn_CheckpointWalDurabilityNone(t *testing.T) {
+	option := batchTestOptions("flydb-column-checkpoint-none")
+	option.DbMemoryOptions.MemSize = 4 * 1024
+	option.WalOptions.FileSize = 8 * 1024
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+
+	col, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, col.CreateColumnFamily("hot"))
+	assert.Nil(t, col.CreateColumnFamilyWithOptions("volatile", config.ColumnFamilyOptions{Durability: config.DurabilityNone}))
+
+	// A write that is not flushed in a family without a wal does not hold the wal back
+	assert.Nil(t, col.Put("volatile", []byte("key"), []byte("value")))
+	value := make([]byte, 100)
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, col.Put("hot", []byte(fmt.Sprintf("key-%d", i)), value))
+	}
+	assert.Nil(t, col.(*column).checkpoint())
+	assert.Less(t, walSegments(t, option), 5)
+
+	got, err := col.Get("volatile", []byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), got)
+}
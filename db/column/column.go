@@ -2,13 +2,13 @@ package column
 
 import (
 	"errors"
-	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
 	"github.com/ByteStorage/FlyDB/db/memory"
 	"github.com/ByteStorage/FlyDB/lib/wal"
+	"go.uber.org/zap"
 	"io/ioutil"
 	"os"
-	"strings"
+	"path/filepath"
 	"sync"
 )
 
@@ -16,6 +16,10 @@ import (
 type Column interface {
 	// CreateColumnFamily create column family
 	CreateColumnFamily(name string) error
+	// CreateColumnFamilyWithOptions create column family with its own options
+	CreateColumnFamilyWithOptions(name string, opts config.ColumnFamilyOptions) error
+	// GetColumnFamilyOptions returns the options of a column family
+	GetColumnFamilyOptions(name string) (config.ColumnFamilyOptions, error)
 	// DropColumnFamily drop column family
 	DropColumnFamily(name string) error
 	// ListColumnFamilies list column families
@@ -28,8 +32,26 @@ type Column interface {
 	Delete(cf string, key []byte) error
 	// Keys returns all keys in the column family
 	Keys(cf string) ([][]byte, error)
+	// NewWriteBatch creates a batch of writes to several column families, committed atomically
+	NewWriteBatch() *WriteBatch
+	// NewIterator iterates over the keys of the column family, within the prefix and range of opts
+	NewIterator(cf string, opts config.IteratorOptions) (*Iterator, error)
+	// NewSnapshot takes a consistent snapshot of all column families
+	NewSnapshot() *Snapshot
+	// RenameColumnFamily renames a column family
+	RenameColumnFamily(oldName string, newName string) error
+	// ExportColumnFamily writes the data and options of a column family to a directory
+	ExportColumnFamily(cf string, dir string) error
+	// ImportColumnFamily creates a column family from a directory written by ExportColumnFamily
+	ImportColumnFamily(name string, dir string) error
 }
 
+var (
+	ErrColumnFamilyExists      = errors.New("column family already exists")
+	ErrColumnFamilyNotExists   = errors.New("column family not exists")
+	ErrColumnFamilyNameInvalid = errors.New("column family name must be a plain directory name")
+)
+
 // NewColumn create a column family
 func NewColumn(option config.ColumnOptions) (Column, error) {
 	// create wal, all column family share a wal
@@ -37,48 +59,47 @@ func NewColumn(option config.ColumnOptions) (Column, error) {
 	if err != nil {
 		return nil, err
 	}
+	go w.AsyncSave()
 
-	// load column family
-	col, err := loadColumn(option)
+	// set wal, the wal is a global wal of all column family
+	option.DbMemoryOptions.Wal = w
+
+	// every flush of a column family may let the wal be truncated further
+	checkpoints := make(chan struct{}, 1)
+	option.DbMemoryOptions.OnFlush = func() {
+		select {
+		case checkpoints <- struct{}{}:
+		default:
+		}
+	}
+
+	// load column family, with the options they were created with
+	columnFamily, m, err := loadColumn(option)
 	if err != nil {
 		return nil, err
 	}
+	c := &column{
+		option:       option,
+		mux:          sync.RWMutex{},
+		columnFamily: columnFamily,
+		manifest:     m,
+		wal:          w,
+	}
+	go c.checkpointLoop(checkpoints)
 
 	// if column family exists, return it
-	if len(col) > 0 {
-		columnFamily := make(map[string]*memory.Db)
-		for k, v := range col {
-			columnFamily[k] = v
-		}
-		return &column{
-			option:       option,
-			mux:          sync.RWMutex{},
-			columnFamily: columnFamily,
-			wal:          w,
-		}, nil
+	if len(columnFamily) > 0 {
+		return c, nil
 	}
 
 	// if column family not exists, create a new column family
 	if option.DbMemoryOptions.ColumnName == "" {
 		option.DbMemoryOptions.ColumnName = "default"
 	}
-
-	// set wal, the wal is a global wal of all column family
-	option.DbMemoryOptions.Wal = w
-
-	// create a new db
-	db, err := memory.NewDB(option.DbMemoryOptions)
-	if err != nil {
+	if err := c.CreateColumnFamily(option.DbMemoryOptions.ColumnName); err != nil {
 		return nil, err
 	}
-	return &column{
-		option: option,
-		mux:    sync.RWMutex{},
-		columnFamily: map[string]*memory.Db{
-			option.DbMemoryOptions.ColumnName: db,
-		},
-		wal: w,
-	}, nil
+	return c, nil
 }
 
 // column is a column family, it contains a wal and a map of column family
@@ -88,6 +109,7 @@ type column struct {
 	mux          sync.RWMutex          // protect column family
 	wal          *wal.Wal              // wal of all column family
 	columnFamily map[string]*memory.Db // column family map
+	manifest     *manifest             // options and wal ids of every column family
 	option       config.ColumnOptions  // column family options
 }
 
@@ -103,18 +125,39 @@ type column struct {
 //     If a column family with the same name already exists or an error occurs during creation,
 //     it returns the corresponding error message.
 func (c *column) CreateColumnFamily(name string) error {
+	return c.CreateColumnFamilyWithOptions(name, config.ColumnFamilyOptions{})
+}
+
+// CreateColumnFamilyWithOptions creates a new column family with its own options.
+// Zero fields of opts are taken from the options of the column. The options
+// are recorded in the manifest, so that the family is loaded with them again.
+func (c *column) CreateColumnFamilyWithOptions(name string, opts config.ColumnFamilyOptions) error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
-	if _, ok := c.columnFamily[name]; ok {
-		return errors.New("column family already exists")
+	if err := c.checkNewName(name); err != nil {
+		return err
 	}
-	c.option.DbMemoryOptions.ColumnName = name
-	db, err := memory.NewDB(c.option.DbMemoryOptions)
+	opts = resolveFamilyOptions(c.option.DbMemoryOptions, opts)
+	id := c.manifest.newID()
+	db, err := memory.NewDB(familyMemoryOptions(c.option.DbMemoryOptions, name, id, opts))
 	if err != nil {
 		return err
 	}
 	c.columnFamily[name] = db
-	return nil
+	c.manifest.Families[name] = opts
+	c.manifest.IDs[name] = id
+	return c.saveManifest()
+}
+
+// GetColumnFamilyOptions returns the options a column family was created with
+func (c *column) GetColumnFamilyOptions(name string) (config.ColumnFamilyOptions, error) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	opts, ok := c.manifest.Families[name]
+	if !ok {
+		return config.ColumnFamilyOptions{}, ErrColumnFamilyNotExists
+	}
+	return opts, nil
 }
 
 // DropColumnFamily deletes a column family with the specified name.
@@ -131,15 +174,59 @@ func (c *column) CreateColumnFamily(name string) error {
 func (c *column) DropColumnFamily(name string) error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
-	if _, ok := c.columnFamily[name]; !ok {
-		return errors.New("column family not exists")
+	db, ok := c.columnFamily[name]
+	if !ok {
+		return ErrColumnFamilyNotExists
+	}
+	if err := db.Close(); err != nil {
+		return err
 	}
 	err := os.RemoveAll(c.option.DbMemoryOptions.Option.DirPath + "/" + name)
 	if err != nil {
 		return err
 	}
 	delete(c.columnFamily, name)
-	return nil
+	delete(c.manifest.Families, name)
+	delete(c.manifest.IDs, name)
+	return c.saveManifest()
+}
+
+// RenameColumnFamily gives a column family a new name, its data directory is renamed with it.
+// The family keeps its id in the shared wal, so its writes are replayed under the new name.
+// Snapshots that include the family must be released first.
+func (c *column) RenameColumnFamily(oldName string, newName string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	db, ok := c.columnFamily[oldName]
+	if !ok {
+		return ErrColumnFamilyNotExists
+	}
+	if err := c.checkNewName(newName); err != nil {
+		return err
+	}
+
+	// the data is flushed to the data directory before it moves
+	if err := db.Close(); err != nil {
+		return err
+	}
+	base := filepath.Clean(c.option.DbMemoryOptions.Option.DirPath)
+	if err := os.Rename(filepath.Join(base, oldName), filepath.Join(base, newName)); err != nil {
+		return err
+	}
+	opts := c.manifest.Families[oldName]
+	id := c.manifest.walID(oldName)
+	db, err := memory.NewDB(familyMemoryOptions(c.option.DbMemoryOptions, newName, id, opts))
+	if err != nil {
+		return err
+	}
+
+	delete(c.columnFamily, oldName)
+	delete(c.manifest.Families, oldName)
+	delete(c.manifest.IDs, oldName)
+	c.columnFamily[newName] = db
+	c.manifest.Families[newName] = opts
+	c.manifest.IDs[newName] = id
+	return c.saveManifest()
 }
 
 // ListColumnFamilies returns a list of all existing column families in the database.
@@ -158,55 +245,194 @@ func (c *column) ListColumnFamilies() ([]string, error) {
 	return list, nil
 }
 
+// Put writes a key/value pair to the column family
 func (c *column) Put(cf string, key []byte, value []byte) error {
-	return c.columnFamily[cf].Put(key, value)
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	db, ok := c.columnFamily[cf]
+	if !ok {
+		return ErrColumnFamilyNotExists
+	}
+	return db.Put(key, encodeValue(value, c.manifest.Families[cf].DefaultTTL))
 }
 
+// Get reads a value from the column family
 func (c *column) Get(cf string, key []byte) ([]byte, error) {
-	return c.columnFamily[cf].Get(key)
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	db, ok := c.columnFamily[cf]
+	if !ok {
+		return nil, ErrColumnFamilyNotExists
+	}
+	value, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue(value, c.manifest.Families[cf].DefaultTTL)
 }
 
+// Delete removes a key from the column family
 func (c *column) Delete(cf string, key []byte) error {
-	return c.columnFamily[cf].Delete(key)
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	db, ok := c.columnFamily[cf]
+	if !ok {
+		return ErrColumnFamilyNotExists
+	}
+	return db.Delete(key)
 }
 
+// Keys returns all keys in the column family
 func (c *column) Keys(cf string) ([][]byte, error) {
-	return c.columnFamily[cf].Keys()
+	it, err := c.NewIterator(cf, config.DefaultIteratorOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	keys := make([][]byte, 0)
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys, it.Err()
+}
+
+// NewIterator iterates over the keys of the column family in order, expired values are left out.
+// The iterator sees the column family as it was when the iterator was created.
+func (c *column) NewIterator(cf string, opts config.IteratorOptions) (*Iterator, error) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	db, ok := c.columnFamily[cf]
+	if !ok {
+		return nil, ErrColumnFamilyNotExists
+	}
+	return newIterator(db.NewIterator(opts), c.manifest.Families[cf].DefaultTTL), nil
+}
+
+// checkNewName checks that a column family can be created under name
+// Hold the mutex before accessing this method
+func (c *column) checkNewName(name string) error {
+	if _, ok := c.columnFamily[name]; ok {
+		return ErrColumnFamilyExists
+	}
+	if name == "" || name != filepath.Base(name) || name == manifestFileName {
+		return ErrColumnFamilyNameInvalid
+	}
+	return nil
 }
 
 // loadColumn loads and initializes column families from the specified base directory path.
+// The manifest in the base directory lists the column families and their options.
+// Without a manifest, every directory under the base path except the wal is loaded
+// with the options of the column, and a manifest is written for them.
 //
 // Parameters:
 // - option: Configuration options for loading the column families.
 //
 // Returns:
 // - A map where keys are column family names and values are corresponding in-memory databases (memory.Db).
-// - If the base directory does not exist, an error is returned.
+// - The manifest, with the options of the column families.
 // - If there are any errors while loading or initializing column families, an error is returned.
-func loadColumn(option config.ColumnOptions) (map[string]*memory.Db, error) {
-	base := option.DbMemoryOptions.Option.DirPath
-	base = strings.Trim(base, "/")
-	// Check if the base path exists
+func loadColumn(option config.ColumnOptions) (map[string]*memory.Db, *manifest, error) {
+	base := filepath.Clean(option.DbMemoryOptions.Option.DirPath)
+	columns := make(map[string]*memory.Db)
+	// Nothing to load from a new base path
 	if _, err := os.Stat(base); os.IsNotExist(err) {
-		return nil, fmt.Errorf("directory does not exist: %s", base)
+		return columns, newManifest(), nil
+	}
+
+	m, err := loadManifest(base)
+	if err != nil {
+		return nil, nil, err
 	}
+	legacy := m == nil
+	if legacy {
+		// the writes of legacy column families are tagged with their names in the wal
+		m = newManifest()
+		if m.Families, err = scanColumnFamilies(option); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for name, opts := range m.Families {
+		db, err := memory.NewDB(familyMemoryOptions(option.DbMemoryOptions, name, m.walID(name), opts))
+		if err != nil {
+			return nil, nil, err
+		}
+		columns[name] = db
+	}
+	if legacy && len(m.Families) > 0 {
+		if err := saveManifest(base, m); err != nil {
+			return nil, nil, err
+		}
+	}
+	return columns, m, nil
+}
+
+// scanColumnFamilies lists the directories under the base path that are column families
+func scanColumnFamilies(option config.ColumnOptions) (map[string]config.ColumnFamilyOptions, error) {
+	base := filepath.Clean(option.DbMemoryOptions.Option.DirPath)
 	// List all directories under the base path
 	dirs, err := ioutil.ReadDir(base)
 	if err != nil {
 		return nil, err
 	}
-	columns := make(map[string]*memory.Db)
+	families := make(map[string]config.ColumnFamilyOptions)
 	for _, dir := range dirs {
 		if dir.IsDir() {
 			colName := dir.Name()
-			dirPath := base + "/" + colName
-			option.DbMemoryOptions.Option.DirPath = dirPath
-			db, err := memory.NewDB(option.DbMemoryOptions)
-			if err != nil {
-				return nil, err
+			// the shared wal is not a column family
+			if filepath.Join(base, colName) == filepath.Clean(option.WalOptions.DirPath) {
+				continue
 			}
-			columns[colName] = db
+			families[colName] = resolveFamilyOptions(option.DbMemoryOptions, config.ColumnFamilyOptions{})
+		}
+	}
+	return families, nil
+}
+
+// checkpointLoop truncates the shared wal whenever a column family has flushed
+func (c *column) checkpointLoop(checkpoints <-chan struct{}) {
+	for range checkpoints {
+		if err := c.checkpoint(); err != nil {
+			zap.L().Warn("checkpoint column wal error", zap.Error(err))
 		}
 	}
-	return columns, nil
+}
+
+// checkpoint moves the checkpoint of the shared wal to the oldest position
+// a column family still needs, which deletes the segments before it.
+// A family with DurabilityNone needs none, its writes are not recovered from the wal.
+func (c *column) checkpoint() error {
+	// No write is in flight while the positions are taken,
+	// so every record before the end of the wal is in a memTable already
+	c.mux.Lock()
+	end := c.wal.Position()
+	pos := end
+	for name, db := range c.columnFamily {
+		if c.manifest.Families[name].Durability == config.DurabilityNone {
+			continue
+		}
+		if p := db.WalCheckpoint(end); p.Before(pos) {
+			pos = p
+		}
+	}
+	c.mux.Unlock()
+
+	// The flushed writes must be on disk before the wal forgets them
+	c.mux.RLock()
+	for _, db := range c.columnFamily {
+		if err := db.Sync(); err != nil {
+			c.mux.RUnlock()
+			return err
+		}
+	}
+	c.mux.RUnlock()
+	return c.wal.Checkpoint(pos)
+}
+
+// saveManifest writes the options of all column families to the manifest
+// Hold the mutex before accessing this method
+func (c *column) saveManifest() error {
+	return saveManifest(filepath.Clean(c.option.DbMemoryOptions.Option.DirPath), c.manifest)
 }
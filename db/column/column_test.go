@@ -35,6 +35,8 @@ func CleanWalTest() {
 	if err != nil {
 		return
 	}
+	_ = os.RemoveAll("./default")
+	_ = os.Remove("./" + manifestFileName)
 }
 
 func TestColumn_CreateColumnFamily(t *testing.T) {
@@ -0,0 +1,127 @@
+package column
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	"github.com/ByteStorage/FlyDB/db/fileio"
+	"github.com/ByteStorage/FlyDB/db/memory"
+	"github.com/ByteStorage/FlyDB/lib/backup"
+	"os"
+	"path/filepath"
+)
+
+// exportOptionsFileName is the file of an exported column family that holds its options
+const exportOptionsFileName = "CF_OPTIONS"
+
+var (
+	ErrExportDirNotEmpty = errors.New("export directory is not empty")
+	ErrNotAnExport       = errors.New("directory does not hold an exported column family")
+)
+
+// ExportColumnFamily writes the data of a column family, as of a snapshot, to dir,
+// together with the options it was created with. The column family stays usable;
+// dir can be copied to another node and imported there with ImportColumnFamily.
+// Expired values are left out, the others keep their expiry time.
+func (c *column) ExportColumnFamily(cf string, dir string) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return ErrExportDirNotEmpty
+	}
+
+	c.mux.RLock()
+	db, ok := c.columnFamily[cf]
+	if !ok {
+		c.mux.RUnlock()
+		return ErrColumnFamilyNotExists
+	}
+	opts := c.manifest.Families[cf]
+	snapshot := db.NewSnapshot()
+	c.mux.RUnlock()
+	defer snapshot.Release()
+
+	engineOptions := c.option.DbMemoryOptions.Option
+	engineOptions.DirPath = dir
+	engineOptions.IndexType = opts.IndexType
+	engineOptions.DataFileSize = opts.DataFileSize
+	engineOptions.FIOType = config.FileIOType
+	export, err := engine.NewDB(engineOptions)
+	if err != nil {
+		return err
+	}
+
+	it := snapshot.NewIterator(config.DefaultIteratorOptions)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		value, err := it.Value()
+		if err != nil {
+			_ = export.Close()
+			return err
+		}
+		if _, err := decodeValue(value, opts.DefaultTTL); err != nil {
+			continue
+		}
+		if err := export.Put(it.Key(), value); err != nil {
+			_ = export.Close()
+			return err
+		}
+	}
+	if err := export.Sync(); err != nil {
+		_ = export.Close()
+		return err
+	}
+	if err := export.Close(); err != nil {
+		return err
+	}
+
+	buf, err := json.MarshalIndent(opts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, exportOptionsFileName), buf, fileio.DataFilePerm)
+}
+
+// ImportColumnFamily creates the column family name from a directory written by ExportColumnFamily,
+// with the options the exported family was created with. The files are copied, dir is left as is.
+func (c *column) ImportColumnFamily(name string, dir string) error {
+	buf, err := os.ReadFile(filepath.Join(dir, exportOptionsFileName))
+	if os.IsNotExist(err) {
+		return ErrNotAnExport
+	}
+	if err != nil {
+		return err
+	}
+	var opts config.ColumnFamilyOptions
+	if err := json.Unmarshal(buf, &opts); err != nil {
+		return err
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if err := c.checkNewName(name); err != nil {
+		return err
+	}
+	opts = resolveFamilyOptions(c.option.DbMemoryOptions, opts)
+	target := filepath.Join(filepath.Clean(c.option.DbMemoryOptions.Option.DirPath), name)
+	// a directory left over from a dropped or failed family must not mix with the import
+	if err := os.RemoveAll(target); err != nil {
+		return err
+	}
+	if err := backup.CopyDir(dir, target); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(target, exportOptionsFileName)); err != nil {
+		return err
+	}
+
+	// the imported family gets a new id, no record of the shared wal belongs to it
+	id := c.manifest.newID()
+	db, err := memory.NewDB(familyMemoryOptions(c.option.DbMemoryOptions, name, id, opts))
+	if err != nil {
+		return err
+	}
+	c.columnFamily[name] = db
+	c.manifest.Families[name] = opts
+	c.manifest.IDs[name] = id
+	return c.saveManifest()
+}
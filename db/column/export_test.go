@@ -0,0 +1,90 @@
+package column
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestColumn_RenameColumnFamily(t *testing.T) {
+	option := batchTestOptions("flydb-column-rename")
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+
+	col, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, col.CreateColumnFamily("users"))
+	assert.Nil(t, col.Put("users", []byte("1"), []byte("alice")))
+
+	assert.Nil(t, col.RenameColumnFamily("users", "accounts"))
+	assert.Equal(t, ErrColumnFamilyNotExists, col.RenameColumnFamily("users", "people"))
+	assert.Equal(t, ErrColumnFamilyExists, col.RenameColumnFamily("accounts", "default"))
+	value, err := col.Get("accounts", []byte("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("alice"), value)
+	_, err = col.Get("users", []byte("1"))
+	assert.Equal(t, ErrColumnFamilyNotExists, err)
+
+	// A new family under the old name does not get the writes of the renamed one from the wal
+	assert.Nil(t, col.CreateColumnFamily("users"))
+	assert.Nil(t, col.Put("accounts", []byte("2"), []byte("bob")))
+	reopened, err := NewColumn(option)
+	assert.Nil(t, err)
+	keys, err := reopened.Keys("users")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+	keys, err = reopened.Keys("accounts")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("2")}, keys)
+}
+
+func TestColumn_ExportImportColumnFamily(t *testing.T) {
+	option := batchTestOptions("flydb-column-export")
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+	target := batchTestOptions("flydb-column-import")
+	defer os.RemoveAll(target.DbMemoryOptions.Option.DirPath)
+	exportDir := filepath.Join(os.TempDir(), "flydb-column-export-dir")
+	_ = os.RemoveAll(exportDir)
+	defer os.RemoveAll(exportDir)
+
+	col, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, col.CreateColumnFamilyWithOptions("sessions", config.ColumnFamilyOptions{
+		IndexType:  config.SkipList,
+		DefaultTTL: time.Hour,
+	}))
+	assert.Nil(t, col.Put("sessions", []byte("token1"), []byte("alice")))
+	assert.Nil(t, col.Put("sessions", []byte("token2"), []byte("bob")))
+	assert.Nil(t, col.Delete("sessions", []byte("token2")))
+	opts, err := col.GetColumnFamilyOptions("sessions")
+	assert.Nil(t, err)
+
+	assert.Nil(t, col.ExportColumnFamily("sessions", exportDir))
+	assert.Equal(t, ErrExportDirNotEmpty, col.ExportColumnFamily("sessions", exportDir))
+	assert.Equal(t, ErrColumnFamilyNotExists, col.ExportColumnFamily("missing", t.TempDir()))
+	assert.Equal(t, ErrNotAnExport, col.ImportColumnFamily("copy", t.TempDir()))
+
+	// Import on another node, under another name
+	other, err := NewColumn(target)
+	assert.Nil(t, err)
+	assert.Nil(t, other.ImportColumnFamily("imported", exportDir))
+	assert.Equal(t, ErrColumnFamilyExists, other.ImportColumnFamily("imported", exportDir))
+	imported, err := other.GetColumnFamilyOptions("imported")
+	assert.Nil(t, err)
+	assert.Equal(t, opts, imported)
+	value, err := other.Get("imported", []byte("token1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("alice"), value)
+	_, err = other.Get("imported", []byte("token2"))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+
+	// The imported family is in the manifest of its new node
+	reopened, err := NewColumn(target)
+	assert.Nil(t, err)
+	value, err = reopened.Get("imported", []byte("token1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("alice"), value)
+}
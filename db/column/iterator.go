@@ -0,0 +1,77 @@
+package column
+
+import (
+	"github.com/ByteStorage/FlyDB/db/memory"
+	"time"
+)
+
+// Iterator iterates over the keys of a column family in order.
+// The values of a family with a default ttl are returned without their expiry time,
+// and expired values are skipped.
+type Iterator struct {
+	it    *memory.Iterator
+	ttl   time.Duration
+	value []byte
+	err   error
+}
+
+func newIterator(it *memory.Iterator, ttl time.Duration) *Iterator {
+	iterator := &Iterator{it: it, ttl: ttl}
+	iterator.skipExpired()
+	return iterator
+}
+
+// Rewind moves to the first key
+func (it *Iterator) Rewind() {
+	it.it.Rewind()
+	it.skipExpired()
+}
+
+// Seek moves to the first key >= key, or <= key for a reverse iterator
+func (it *Iterator) Seek(key []byte) {
+	it.it.Seek(key)
+	it.skipExpired()
+}
+
+// Next moves to the next key
+func (it *Iterator) Next() {
+	it.it.Next()
+	it.skipExpired()
+}
+
+func (it *Iterator) Valid() bool {
+	return it.err == nil && it.it.Valid()
+}
+
+func (it *Iterator) Key() []byte {
+	return it.it.Key()
+}
+
+func (it *Iterator) Value() []byte {
+	return it.value
+}
+
+// Err returns the error that ended the iteration early, if any
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+func (it *Iterator) Close() {
+	it.it.Close()
+}
+
+// skipExpired moves to the next key whose value has not expired, and decodes its value
+func (it *Iterator) skipExpired() {
+	for ; it.it.Valid(); it.it.Next() {
+		value, err := it.it.Value()
+		if err != nil {
+			it.err = err
+			return
+		}
+		if value, err = decodeValue(value, it.ttl); err == nil {
+			it.value = value
+			return
+		}
+	}
+	it.value = nil
+}
@@ -0,0 +1,126 @@
+package column
+
+import (
+	"encoding/json"
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/fileio"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// manifestFileName is the file in the base directory that lists the column families
+const manifestFileName = "CF_MANIFEST"
+
+// manifest lists the column families and the options they were created with
+type manifest struct {
+	Families map[string]config.ColumnFamilyOptions `json:"families"`
+	// IDs tag the writes of the families in the shared wal. A family keeps its id
+	// when it is renamed, and the id of a dropped family is not handed out again,
+	// so old wal records never reach another family. Families without an id use their name.
+	IDs    map[string]string `json:"ids,omitempty"`
+	NextID uint64            `json:"next_id,omitempty"`
+}
+
+// newManifest returns an empty manifest
+func newManifest() *manifest {
+	return &manifest{
+		Families: make(map[string]config.ColumnFamilyOptions),
+		IDs:      make(map[string]string),
+	}
+}
+
+// newID returns an id no column family has had before
+func (m *manifest) newID() string {
+	m.NextID++
+	return "#" + strconv.FormatUint(m.NextID, 10)
+}
+
+// walID returns the id of a column family
+func (m *manifest) walID(name string) string {
+	if id, ok := m.IDs[name]; ok {
+		return id
+	}
+	return name
+}
+
+// loadManifest reads the manifest in dir, it returns nil if there is none
+func loadManifest(dir string) (*manifest, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := newManifest()
+	if err := json.Unmarshal(buf, m); err != nil {
+		return nil, err
+	}
+	if m.Families == nil {
+		m.Families = make(map[string]config.ColumnFamilyOptions)
+	}
+	if m.IDs == nil {
+		m.IDs = make(map[string]string)
+	}
+	return m, nil
+}
+
+// saveManifest writes the manifest to dir, replacing it atomically
+func saveManifest(dir string, m *manifest) error {
+	buf, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	fileName := filepath.Join(dir, manifestFileName)
+	tmpName := fileName + ".tmp"
+	if err := os.WriteFile(tmpName, buf, fileio.DataFilePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, fileName)
+}
+
+// resolveFamilyOptions fills the zero fields of opts from the options of the column
+func resolveFamilyOptions(base config.DbMemoryOptions, opts config.ColumnFamilyOptions) config.ColumnFamilyOptions {
+	if opts.IndexType == 0 {
+		opts.IndexType = base.Option.IndexType
+	}
+	if opts.IndexType == 0 {
+		opts.IndexType = config.ARTWithBloom
+	}
+	if opts.DataFileSize == 0 {
+		opts.DataFileSize = base.Option.DataFileSize
+	}
+	if opts.MemSize == 0 {
+		opts.MemSize = base.MemSize
+	}
+	if opts.Durability == 0 {
+		opts.Durability = base.Durability
+	}
+	if opts.Durability == 0 {
+		opts.Durability = config.DurabilityAsync
+		if base.Option.SyncWrite {
+			opts.Durability = config.DurabilitySync
+		}
+	}
+	return opts
+}
+
+// familyMemoryOptions returns the memory table options of a column family
+func familyMemoryOptions(base config.DbMemoryOptions, name string, id string, opts config.ColumnFamilyOptions) config.DbMemoryOptions {
+	base.ColumnName = name
+	base.WalID = id
+	if opts.IndexType != 0 {
+		base.Option.IndexType = opts.IndexType
+	}
+	if opts.DataFileSize != 0 {
+		base.Option.DataFileSize = opts.DataFileSize
+	}
+	if opts.MemSize != 0 {
+		base.MemSize = opts.MemSize
+	}
+	if opts.Durability != 0 {
+		base.Durability = opts.Durability
+	}
+	return base
+}
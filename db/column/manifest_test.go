@@ -0,0 +1,101 @@
+package column
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestColumn_FamilyOptions(t *testing.T) {
+	option := batchTestOptions("flydb-column-manifest")
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+
+	col, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, col.CreateColumnFamilyWithOptions("sessions", config.ColumnFamilyOptions{
+		MemSize:    1024,
+		DefaultTTL: 50 * time.Millisecond,
+	}))
+	assert.Nil(t, col.CreateColumnFamilyWithOptions("audit", config.ColumnFamilyOptions{
+		IndexType:    config.SkipList,
+		DataFileSize: 1024 * 1024,
+		Durability:   config.DurabilitySync,
+	}))
+	assert.Equal(t, ErrColumnFamilyNameInvalid, col.CreateColumnFamily("../audit"))
+
+	sessions, err := col.GetColumnFamilyOptions("sessions")
+	assert.Nil(t, err)
+	assert.Equal(t, config.ColumnFamilyOptions{
+		IndexType:    config.ARTWithBloom,
+		DataFileSize: option.DbMemoryOptions.Option.DataFileSize,
+		MemSize:      1024,
+		Durability:   config.DurabilityAsync,
+		DefaultTTL:   50 * time.Millisecond,
+	}, sessions)
+	audit, err := col.GetColumnFamilyOptions("audit")
+	assert.Nil(t, err)
+	_, err = col.GetColumnFamilyOptions("missing")
+	assert.Equal(t, ErrColumnFamilyNotExists, err)
+
+	// Values of a family with a default ttl expire
+	assert.Nil(t, col.Put("sessions", []byte("token"), []byte("user")))
+	assert.Nil(t, col.Put("audit", []byte("event"), []byte("login")))
+	value, err := col.Get("sessions", []byte("token"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("user"), value)
+	time.Sleep(100 * time.Millisecond)
+	_, err = col.Get("sessions", []byte("token"))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+	keys, err := col.Keys("sessions")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+	value, err = col.Get("audit", []byte("event"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("login"), value)
+
+	// The families are loaded with their options again
+	reopened, err := NewColumn(option)
+	assert.Nil(t, err)
+	list, err := reopened.ListColumnFamilies()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"default", "sessions", "audit"}, list)
+	opts, err := reopened.GetColumnFamilyOptions("sessions")
+	assert.Nil(t, err)
+	assert.Equal(t, sessions, opts)
+	opts, err = reopened.GetColumnFamilyOptions("audit")
+	assert.Nil(t, err)
+	assert.Equal(t, audit, opts)
+	value, err = reopened.Get("audit", []byte("event"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("login"), value)
+
+	// The manifest follows drops
+	assert.Nil(t, reopened.DropColumnFamily("sessions"))
+	m, err := loadManifest(option.DbMemoryOptions.Option.DirPath)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(m.Families))
+}
+
+func TestColumn_LegacyWithoutManifest(t *testing.T) {
+	option := batchTestOptions("flydb-column-legacy")
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+
+	col, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, col.CreateColumnFamily("users"))
+	assert.Nil(t, os.Remove(filepath.Join(option.DbMemoryOptions.Option.DirPath, manifestFileName)))
+
+	// The directories are the column families, the wal directory is not one
+	reopened, err := NewColumn(option)
+	assert.Nil(t, err)
+	list, err := reopened.ListColumnFamilies()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"default", "users"}, list)
+	m, err := loadManifest(option.DbMemoryOptions.Option.DirPath)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(m.Families))
+}
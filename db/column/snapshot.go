@@ -0,0 +1,68 @@
+package column
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/memory"
+)
+
+// Snapshot is a consistent view of all column families at the time it was taken.
+// No write, not even part of a batch, is seen by one family of the snapshot and missed by another.
+// The column families keep flushing while it is held, it pins their memory tables until it is released.
+type Snapshot struct {
+	snapshots map[string]*memory.Snapshot
+	families  map[string]config.ColumnFamilyOptions
+}
+
+// NewSnapshot takes a snapshot of all column families
+func (c *column) NewSnapshot() *Snapshot {
+	// writes hold the read lock, so none is in progress while the families are copied
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	s := &Snapshot{
+		snapshots: make(map[string]*memory.Snapshot, len(c.columnFamily)),
+		families:  make(map[string]config.ColumnFamilyOptions, len(c.columnFamily)),
+	}
+	for name, db := range c.columnFamily {
+		s.snapshots[name] = db.NewSnapshot()
+		s.families[name] = c.manifest.Families[name]
+	}
+	return s
+}
+
+// ListColumnFamilies returns the column families of the snapshot
+func (s *Snapshot) ListColumnFamilies() []string {
+	list := make([]string, 0, len(s.snapshots))
+	for name := range s.snapshots {
+		list = append(list, name)
+	}
+	return list
+}
+
+// Get reads a value from the column family at the time of the snapshot
+func (s *Snapshot) Get(cf string, key []byte) ([]byte, error) {
+	snapshot, ok := s.snapshots[cf]
+	if !ok {
+		return nil, ErrColumnFamilyNotExists
+	}
+	value, err := snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue(value, s.families[cf].DefaultTTL)
+}
+
+// NewIterator iterates over the keys of the column family at the time of the snapshot
+func (s *Snapshot) NewIterator(cf string, opts config.IteratorOptions) (*Iterator, error) {
+	snapshot, ok := s.snapshots[cf]
+	if !ok {
+		return nil, ErrColumnFamilyNotExists
+	}
+	return newIterator(snapshot.NewIterator(opts), s.families[cf].DefaultTTL), nil
+}
+
+// Release releases the snapshots of all column families
+func (s *Snapshot) Release() {
+	for _, snapshot := range s.snapshots {
+		snapshot.Release()
+	}
+}
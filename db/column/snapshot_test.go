@@ -0,0 +1,76 @@
+package column
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestColumn_IteratorAndSnapshot(t *testing.T) {
+	option := batchTestOptions("flydb-column-snapshot")
+	defer os.RemoveAll(option.DbMemoryOptions.Option.DirPath)
+
+	col, err := NewColumn(option)
+	assert.Nil(t, err)
+	assert.Nil(t, col.CreateColumnFamily("users"))
+	assert.Nil(t, col.CreateColumnFamily("emails"))
+	for _, key := range []string{"user:1", "user:2", "user:3", "group:1"} {
+		assert.Nil(t, col.Put("users", []byte(key), []byte("v1")))
+	}
+	assert.Nil(t, col.Put("emails", []byte("a@flydb.io"), []byte("user:1")))
+
+	// Prefix and range
+	it, err := col.NewIterator("users", config.IteratorOptions{Prefix: []byte("user:")})
+	assert.Nil(t, err)
+	keys := make([]string, 0)
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, string(it.Key()))
+		assert.Equal(t, []byte("v1"), it.Value())
+	}
+	assert.Nil(t, it.Err())
+	it.Close()
+	assert.Equal(t, []string{"user:1", "user:2", "user:3"}, keys)
+
+	it, err = col.NewIterator("users", config.IteratorOptions{Start: []byte("user:2"), End: []byte("user:3")})
+	assert.Nil(t, err)
+	assert.True(t, it.Valid())
+	assert.Equal(t, []byte("user:2"), it.Key())
+	it.Next()
+	assert.False(t, it.Valid())
+	it.Close()
+	_, err = col.NewIterator("missing", config.DefaultIteratorOptions)
+	assert.Equal(t, ErrColumnFamilyNotExists, err)
+
+	// The snapshot sees neither part of a later batch
+	snapshot := col.NewSnapshot()
+	wb := col.NewWriteBatch()
+	assert.Nil(t, wb.Put("users", []byte("user:4"), []byte("v1")))
+	assert.Nil(t, wb.Put("emails", []byte("d@flydb.io"), []byte("user:4")))
+	assert.Nil(t, wb.Delete("users", []byte("user:1")))
+	assert.Nil(t, wb.Commit())
+
+	_, err = snapshot.Get("users", []byte("user:4"))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+	_, err = snapshot.Get("emails", []byte("d@flydb.io"))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+	value, err := snapshot.Get("users", []byte("user:1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v1"), value)
+	assert.ElementsMatch(t, []string{"default", "users", "emails"}, snapshot.ListColumnFamilies())
+
+	it, err = snapshot.NewIterator("users", config.IteratorOptions{Prefix: []byte("user:")})
+	assert.Nil(t, err)
+	count := 0
+	for ; it.Valid(); it.Next() {
+		count++
+	}
+	it.Close()
+	assert.Equal(t, 3, count)
+	snapshot.Release()
+
+	value, err = col.Get("users", []byte("user:4"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v1"), value)
+}
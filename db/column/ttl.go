@@ -0,0 +1,37 @@
+package column
+
+import (
+	"encoding/binary"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"time"
+)
+
+// expireSize is the size of the expiry time in front of the values of a family with a default ttl
+const expireSize = 8
+
+// encodeValue puts the expiry time in front of a value of a family with a default ttl
+func encodeValue(value []byte, ttl time.Duration) []byte {
+	if ttl <= 0 {
+		return value
+	}
+	buf := make([]byte, expireSize+len(value))
+	binary.BigEndian.PutUint64(buf, uint64(time.Now().Add(ttl).UnixNano()))
+	copy(buf[expireSize:], value)
+	return buf
+}
+
+// decodeValue strips the expiry time from a value of a family with a default ttl,
+// it returns ErrKeyNotFound if the value has expired
+func decodeValue(buf []byte, ttl time.Duration) ([]byte, error) {
+	if ttl <= 0 {
+		return buf, nil
+	}
+	if len(buf) < expireSize {
+		return nil, _const.ErrKeyNotFound
+	}
+	expire := int64(binary.BigEndian.Uint64(buf))
+	if time.Now().UnixNano() >= expire {
+		return nil, _const.ErrKeyNotFound
+	}
+	return buf[expireSize:], nil
+}
@@ -12,19 +12,25 @@ const (
 	DataFileSuffix      = ".data"
 	HintFileSuffix      = "hintIndex"
 	MergeFinaFileSuffix = "mergeFina"
+
+	// BloomFilterFileSuffix names the bloom filter saved with the hint file
+	BloomFilterFileSuffix = "bloomFilter"
 )
 
 // DataFile represents a data file.
 type DataFile struct {
-	FileID    uint32           // File ID
-	WriteOff  int64            // Position where the file is currently being written
-	IoManager fileio.IOManager // IO read/write operations
+	FileID     uint32           // File ID
+	WriteOff   int64            // Position where the file is currently being written
+	IoManager  fileio.IOManager // IO read/write operations
+	Header     *FileHeader      // Format header, nil for legacy headerless files
+	HeaderSize int64            // Offset of the first log record in the file
+	mapped     bool             // Sealed and read in place through its mapping, see Mapped
 }
 
 // OpenDataFile opens a new data file.
-func OpenDataFile(dirPath string, fileID uint32, fileSize int64, fioType int8) (*DataFile, error) {
+func OpenDataFile(dirPath string, fileID uint32, fileSize int64, fioType int8, faults *fileio.FaultInjector) (*DataFile, error) {
 	fileName := GetDataFileName(dirPath, fileID)
-	return newDataFile(fileName, fileID, fileSize, fioType)
+	return newDataFile(fileName, fileID, fileSize, fioType, faults, FileFlagNone)
 }
 
 // GetDataFileName returns the file name for a data file.
@@ -33,31 +39,124 @@ func GetDataFileName(dirPath string, fileID uint32) string {
 }
 
 // OpenHintFile opens the hint index file.
-func OpenHintFile(dirPath string, fileSize int64, fioType int8) (*DataFile, error) {
+func OpenHintFile(dirPath string, fileSize int64, fioType int8, faults *fileio.FaultInjector) (*DataFile, error) {
 	fileName := filepath.Join(dirPath, HintFileSuffix)
-	return newDataFile(fileName, 0, fileSize, fioType)
+	return newDataFile(fileName, 0, fileSize, fioType, faults, FileFlagHint)
 }
 
 // OpenMergeFinaFile opens the file that indicates merge completion.
-func OpenMergeFinaFile(dirPath string, fileSize int64, fioType int8) (*DataFile, error) {
+func OpenMergeFinaFile(dirPath string, fileSize int64, fioType int8, faults *fileio.FaultInjector) (*DataFile, error) {
 	fileName := filepath.Join(dirPath, MergeFinaFileSuffix)
-	return newDataFile(fileName, 0, fileSize, fioType)
+	return newDataFile(fileName, 0, fileSize, fioType, faults, FileFlagMergeFina)
+}
+
+// OpenExportFile opens a file used to export or import a keyspace.
+func OpenExportFile(fileName string, fioType int8) (*DataFile, error) {
+	return newDataFile(fileName, 0, fileio.DefaultFileSize, fioType, nil, FileFlagExport)
 }
 
-func newDataFile(dirPath string, fileID uint32, fileSize int64, fioType int8) (*DataFile, error) {
+// OpenReadOnlyFile opens an existing data, hint or merge completion file for reading only.
+// Unlike the other open functions, it neither creates the file nor writes a header to it.
+func OpenReadOnlyFile(fileName string, fileID uint32) (*DataFile, error) {
+	ioManager, err := fileio.NewReadOnlyFileIOManager(fileName)
+	if err != nil {
+		return nil, err
+	}
+	dataFile := &DataFile{
+		FileID:    fileID,
+		WriteOff:  0,
+		IoManager: ioManager,
+	}
+	size, err := ioManager.Size()
+	if err == nil {
+		err = dataFile.readHeader(size)
+	}
+	if err != nil {
+		_ = ioManager.Close()
+		return nil, err
+	}
+	return dataFile, nil
+}
+
+func newDataFile(dirPath string, fileID uint32, fileSize int64, fioType int8, faults *fileio.FaultInjector, flags uint16) (*DataFile, error) {
 	// Initialize the IOManager interface
-	ioManager, err := fileio.NewIOManager(dirPath, fileSize, fioType)
+	ioManager, err := fileio.NewIOManager(dirPath, fileSize, fioType, faults)
 	if err != nil {
 		return nil, err
 	}
-	return &DataFile{
+	dataFile := &DataFile{
 		FileID:    fileID,
 		WriteOff:  0,
 		IoManager: ioManager,
-	}, nil
+	}
+	if err := dataFile.initHeader(flags); err != nil {
+		_ = ioManager.Close()
+		return nil, err
+	}
+	return dataFile, nil
+}
+
+// initHeader writes a format header to an empty file,
+// or reads the header of an existing one.
+// Existing files that do not start with a header are treated as legacy files.
+func (df *DataFile) initHeader(flags uint16) error {
+	size, err := df.IoManager.Size()
+	if err != nil {
+		return err
+	}
+
+	// A new file, write the header of the current format
+	if size == 0 {
+		header := newFileHeader(flags)
+		if err := df.Write(EncodeFileHeader(header)); err != nil {
+			return err
+		}
+		df.Header = header
+		df.HeaderSize = FileHeaderSize
+		return nil
+	}
+	return df.readHeader(size)
+}
+
+// readHeader reads the header of a file of the given size, if it starts with one.
+func (df *DataFile) readHeader(size int64) error {
+	if size == 0 {
+		return nil
+	}
+	var headerBytes int64 = FileHeaderSize
+	if size < headerBytes {
+		headerBytes = size
+	}
+	buf, err := df.readNBytes(headerBytes, 0)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	header, ok, err := DecodeFileHeader(buf)
+	if err != nil {
+		return err
+	}
+	if ok {
+		df.Header = header
+		df.HeaderSize = FileHeaderSize
+	}
+	return nil
+}
+
+// Version returns the on-disk format version of the data file.
+func (df *DataFile) Version() uint16 {
+	if df.Header == nil {
+		return LegacyFormatVersion
+	}
+	return df.Header.Version
+}
+
+// IsLegacy reports whether the data file was written without a format header.
+func (df *DataFile) IsLegacy() bool {
+	return df.Header == nil
 }
 
 // ReadLogRecord reads a log record from the data file based on the offset.
+// The key and value of a record read from a mapped file are not copied, see Mapped.
 func (df *DataFile) ReadLogRecord(offset int64) (*LogRecord, int64, error) {
 	fileSize, err := df.IoManager.Size()
 	if err != nil {
@@ -97,14 +196,16 @@ func (df *DataFile) ReadLogRecord(offset int64) (*LogRecord, int64, error) {
 			return nil, 0, err
 		}
 		// Decode
-		logRecord.Key = kvBuf[:keySize]
+		logRecord.Key = kvBuf[:keySize:keySize]
 		logRecord.Value = kvBuf[keySize:]
 	}
 
 	// Verify CRC (check data integrity)
+	// The record and its size are still returned so that callers
+	// inspecting a damaged file can report it and skip over it
 	crc := getLogRecordCRC(logRecord, headerBuf[crc32.Size:headerSize])
 	if crc != header.crc {
-		return nil, 0, ErrInvalidCRC
+		return logRecord, recordSize, ErrInvalidCRC
 	}
 	return logRecord, recordSize, nil
 }
@@ -132,11 +233,45 @@ func (df *DataFile) Sync() error {
 	return df.IoManager.Sync()
 }
 
+// Truncate discards everything in the data file after size bytes
+// and moves the write position there.
+func (df *DataFile) Truncate(size int64) error {
+	if err := df.IoManager.Truncate(size); err != nil {
+		return err
+	}
+	df.WriteOff = size
+	return nil
+}
+
+// Seal tells the IO manager that the data file will not be written again,
+// so that it can switch to read-only access.
+func (df *DataFile) Seal() error {
+	if sealer, ok := df.IoManager.(fileio.Sealer); ok {
+		if err := sealer.Seal(); err != nil {
+			return err
+		}
+	}
+	_, df.mapped = df.IoManager.(fileio.Slicer)
+	return nil
+}
+
+// Mapped reports whether the records read from the data file point into its mapping,
+// so that a caller must copy what it keeps after the file may be closed.
+func (df *DataFile) Mapped() bool {
+	return df.mapped
+}
+
 func (df *DataFile) Close() error {
 	return df.IoManager.Close()
 }
 
+// readNBytes returns n bytes at offset, in place if the file is mapped, see Mapped
 func (df *DataFile) readNBytes(n int64, offset int64) (b []byte, err error) {
+	if df.mapped {
+		if b, ok := df.IoManager.(fileio.Slicer).Slice(n, offset); ok {
+			return b, nil
+		}
+	}
 	b = make([]byte, n)
 	_, err = df.IoManager.Read(b, offset)
 	return
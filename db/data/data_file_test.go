@@ -3,6 +3,7 @@ package data
 import (
 	"github.com/ByteStorage/FlyDB/db/fileio"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"os"
 	"testing"
 )
@@ -10,21 +11,21 @@ import (
 const DefaultFileSize = 256 * 1024 * 1024
 
 func TestOpenDataFile(t *testing.T) {
-	dataFile1, err := OpenDataFile(os.TempDir(), 0, DefaultFileSize, fileio.FileIOType)
+	dataFile1, err := OpenDataFile(os.TempDir(), 0, DefaultFileSize, fileio.FileIOType, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, dataFile1)
 
-	dataFile2, err := OpenDataFile(os.TempDir(), 1, DefaultFileSize, fileio.FileIOType)
+	dataFile2, err := OpenDataFile(os.TempDir(), 1, DefaultFileSize, fileio.FileIOType, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, dataFile2)
 
-	dataFile3, err := OpenDataFile(os.TempDir(), 1, DefaultFileSize, fileio.FileIOType)
+	dataFile3, err := OpenDataFile(os.TempDir(), 1, DefaultFileSize, fileio.FileIOType, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, dataFile3)
 }
 
 func TestDataFile_Write(t *testing.T) {
-	dataFile, err := OpenDataFile(os.TempDir(), 12312, DefaultFileSize, fileio.FileIOType)
+	dataFile, err := OpenDataFile(os.TempDir(), 12312, DefaultFileSize, fileio.FileIOType, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, dataFile)
 
@@ -39,7 +40,7 @@ func TestDataFile_Write(t *testing.T) {
 }
 
 func TestDataFile_Close(t *testing.T) {
-	dataFile, err := OpenDataFile(os.TempDir(), 1111111, DefaultFileSize, fileio.FileIOType)
+	dataFile, err := OpenDataFile(os.TempDir(), 1111111, DefaultFileSize, fileio.FileIOType, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, dataFile)
 
@@ -48,7 +49,7 @@ func TestDataFile_Close(t *testing.T) {
 }
 
 func TestDataFile_Sync(t *testing.T) {
-	dataFile, err := OpenDataFile(os.TempDir(), 2222222, DefaultFileSize, fileio.FileIOType)
+	dataFile, err := OpenDataFile(os.TempDir(), 2222222, DefaultFileSize, fileio.FileIOType, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, dataFile)
 
@@ -57,7 +58,7 @@ func TestDataFile_Sync(t *testing.T) {
 }
 
 func TestDataFile_ReadLogRecord(t *testing.T) {
-	dataFile, err := OpenDataFile(os.TempDir(), 123, DefaultFileSize, fileio.FileIOType)
+	dataFile, err := OpenDataFile(os.TempDir(), 123, DefaultFileSize, fileio.FileIOType, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, dataFile)
 
@@ -71,7 +72,7 @@ func TestDataFile_ReadLogRecord(t *testing.T) {
 	err = dataFile.Write(buf1)
 	assert.Nil(t, err)
 
-	readRec1, readSize1, err := dataFile.ReadLogRecord(0)
+	readRec1, readSize1, err := dataFile.ReadLogRecord(dataFile.HeaderSize)
 	assert.Nil(t, err)
 	assert.Equal(t, size, readSize1)
 	assert.Equal(t, record1, readRec1)
@@ -85,7 +86,7 @@ func TestDataFile_ReadLogRecord(t *testing.T) {
 	buf2, size2 := EncodeLogRecord(record2)
 	err = dataFile.Write(buf2)
 	assert.Nil(t, err)
-	readRec2, readSize2, err := dataFile.ReadLogRecord(16)
+	readRec2, readSize2, err := dataFile.ReadLogRecord(dataFile.HeaderSize + size)
 	assert.Equal(t, size2, readSize2)
 	assert.Equal(t, record2, readRec2)
 
@@ -98,8 +99,130 @@ func TestDataFile_ReadLogRecord(t *testing.T) {
 	buf3, size3 := EncodeLogRecord(record3)
 	err = dataFile.Write(buf3)
 	assert.Nil(t, err)
-	readRec3, readSize3, err := dataFile.ReadLogRecord(size + size2)
+	readRec3, readSize3, err := dataFile.ReadLogRecord(dataFile.HeaderSize + size + size2)
 	assert.Equal(t, size3, readSize3)
 	assert.Equal(t, record3, readRec3)
 
 }
+
+func TestDataFile_ReadLogRecordMapped(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "TestDataFile_ReadLogRecordMapped")
+	defer os.RemoveAll(dir)
+	dataFile, err := OpenDataFile(dir, 1, DefaultFileSize, fileio.MmapIOType, nil)
+	assert.Nil(t, err)
+	defer dataFile.Close()
+
+	record := &LogRecord{Key: []byte("name"), Value: []byte("flydb"), Type: LogRecordNormal}
+	buf, size := EncodeLogRecord(record)
+	assert.Nil(t, dataFile.Write(buf))
+	assert.False(t, dataFile.Mapped())
+
+	// A sealed file is read in place
+	assert.Nil(t, dataFile.Seal())
+	assert.True(t, dataFile.Mapped())
+	read, readSize, err := dataFile.ReadLogRecord(dataFile.HeaderSize)
+	assert.Nil(t, err)
+	assert.Equal(t, size, readSize)
+	assert.Equal(t, record, read)
+	mapped, ok := dataFile.IoManager.(fileio.Slicer).Slice(int64(len(read.Value)), dataFile.HeaderSize+size-int64(len(read.Value)))
+	assert.True(t, ok)
+	assert.Same(t, &mapped[0], &read.Value[0])
+	assert.Equal(t, len(read.Key), cap(read.Key))
+}
+
+func TestDataFile_Header(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-header")
+	defer os.RemoveAll(dir)
+
+	// A new data file starts with a header of the current format
+	dataFile, err := OpenDataFile(dir, 0, DefaultFileSize, fileio.FileIOType, nil)
+	assert.Nil(t, err)
+	assert.False(t, dataFile.IsLegacy())
+	assert.Equal(t, CurrentFormatVersion, dataFile.Version())
+	assert.Equal(t, int64(FileHeaderSize), dataFile.WriteOff)
+
+	record := &LogRecord{Key: []byte("name"), Value: []byte("flydb")}
+	buf, _ := EncodeLogRecord(record)
+	assert.Nil(t, dataFile.Write(buf))
+	assert.Nil(t, dataFile.Close())
+
+	// Reopen and read the header back
+	dataFile, err = OpenDataFile(dir, 0, DefaultFileSize, fileio.FileIOType, nil)
+	assert.Nil(t, err)
+	assert.False(t, dataFile.IsLegacy())
+	assert.Equal(t, int64(FileHeaderSize), dataFile.HeaderSize)
+	readRec, _, err := dataFile.ReadLogRecord(dataFile.HeaderSize)
+	assert.Nil(t, err)
+	assert.Equal(t, record.Key, readRec.Key)
+	assert.Nil(t, dataFile.Close())
+}
+
+func TestDataFile_LegacyFile(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-legacy")
+	defer os.RemoveAll(dir)
+
+	// Write a record without a header, as older versions did
+	fio, err := fileio.NewFileIOManager(GetDataFileName(dir, 0))
+	assert.Nil(t, err)
+	record := &LogRecord{Key: []byte("name"), Value: []byte("flydb")}
+	buf, size := EncodeLogRecord(record)
+	_, err = fio.Write(buf)
+	assert.Nil(t, err)
+	assert.Nil(t, fio.Close())
+
+	dataFile, err := OpenDataFile(dir, 0, DefaultFileSize, fileio.FileIOType, nil)
+	assert.Nil(t, err)
+	assert.True(t, dataFile.IsLegacy())
+	assert.Equal(t, LegacyFormatVersion, dataFile.Version())
+	assert.Equal(t, int64(0), dataFile.HeaderSize)
+
+	readRec, readSize, err := dataFile.ReadLogRecord(0)
+	assert.Nil(t, err)
+	assert.Equal(t, size, readSize)
+	assert.Equal(t, record.Value, readRec.Value)
+}
+
+func TestOpenReadOnlyFile(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-read-only")
+	defer os.RemoveAll(dir)
+
+	// An empty file stays empty
+	fileName := GetDataFileName(dir, 0)
+	assert.Nil(t, os.WriteFile(fileName, nil, fileio.DataFilePerm))
+	dataFile, err := OpenReadOnlyFile(fileName, 0)
+	assert.Nil(t, err)
+	assert.True(t, dataFile.IsLegacy())
+	_, _, err = dataFile.ReadLogRecord(0)
+	assert.Equal(t, io.EOF, err)
+	assert.NotNil(t, dataFile.Write([]byte("flydb")))
+	assert.Nil(t, dataFile.Close())
+	info, err := os.Stat(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), info.Size())
+
+	// A missing file is not created
+	_, err = OpenReadOnlyFile(GetDataFileName(dir, 1), 1)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDecodeFileHeader(t *testing.T) {
+	header := &FileHeader{Version: CurrentFormatVersion, Flags: FileFlagHint, CreatedAt: 1234}
+	buf := EncodeFileHeader(header)
+	assert.Equal(t, FileHeaderSize, len(buf))
+
+	decoded, ok, err := DecodeFileHeader(buf)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, header, decoded)
+
+	// Corrupted header
+	buf[8] ^= 0xff
+	_, ok, err = DecodeFileHeader(buf)
+	assert.True(t, ok)
+	assert.Equal(t, ErrInvalidFileHeader, err)
+
+	// Newer format version
+	buf = EncodeFileHeader(&FileHeader{Version: CurrentFormatVersion + 1})
+	_, _, err = DecodeFileHeader(buf)
+	assert.Equal(t, ErrUnsupportedFormatVersion, err)
+}
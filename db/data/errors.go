@@ -3,5 +3,7 @@ package data
 import "errors"
 
 var (
-	ErrInvalidCRC = errors.New("InvalidCrcError : invalid crc value, log record maybe corrupted")
+	ErrInvalidCRC               = errors.New("InvalidCrcError : invalid crc value, log record maybe corrupted")
+	ErrInvalidFileHeader        = errors.New("InvalidFileHeaderError : invalid file header, data file maybe corrupted")
+	ErrUnsupportedFormatVersion = errors.New("UnsupportedFormatVersionError : data file format version is newer than supported")
 )
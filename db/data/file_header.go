@@ -0,0 +1,91 @@
+package data
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"time"
+)
+
+// fileMagic identifies a file written by FlyDB with a format header.
+const fileMagic = "FLYD"
+
+const (
+	// LegacyFormatVersion is the version reported for files written before
+	// format headers were introduced. Such files start directly with a log record.
+	LegacyFormatVersion uint16 = 0
+
+	// CurrentFormatVersion is the on-disk format version written to new files.
+	CurrentFormatVersion uint16 = 1
+)
+
+// FileHeaderSize is the fixed size of the header at the start of every new file.
+const FileHeaderSize = 32
+
+// File header flags describe what kind of records a file holds.
+const (
+	FileFlagNone      uint16 = 0
+	FileFlagHint      uint16 = 1 << 0 // The file stores hint index records
+	FileFlagMergeFina uint16 = 1 << 1 // The file marks a finished merge
+	FileFlagExport    uint16 = 1 << 2 // The file holds an exported keyspace
+)
+
+// FileHeader is the header written at the beginning of data, hint and merge files.
+// +---------+-----------+---------+--------------+-----------+---------+
+// |  magic  |  version  |  flags  |  created at  |  reserved |   crc   |
+// +---------+-----------+---------+--------------+-----------+---------+
+// | 4 bytes |  2 bytes  | 2 bytes |   8 bytes    |  12 bytes | 4 bytes |
+// +---------+-----------+---------+--------------+-----------+---------+
+type FileHeader struct {
+	Version   uint16 // Format version of the records that follow
+	Flags     uint16 // Kind of the file, see FileFlag constants
+	CreatedAt int64  // Creation time in unix nanoseconds
+}
+
+// newFileHeader returns a header of the current format version.
+func newFileHeader(flags uint16) *FileHeader {
+	return &FileHeader{
+		Version:   CurrentFormatVersion,
+		Flags:     flags,
+		CreatedAt: time.Now().UnixNano(),
+	}
+}
+
+// EncodeFileHeader encodes a FileHeader into its fixed size representation.
+func EncodeFileHeader(header *FileHeader) []byte {
+	buf := make([]byte, FileHeaderSize)
+	copy(buf[:4], fileMagic)
+	binary.LittleEndian.PutUint16(buf[4:6], header.Version)
+	binary.LittleEndian.PutUint16(buf[6:8], header.Flags)
+	binary.LittleEndian.PutUint64(buf[8:16], uint64(header.CreatedAt))
+
+	crc := crc32.ChecksumIEEE(buf[:FileHeaderSize-crc32.Size])
+	binary.LittleEndian.PutUint32(buf[FileHeaderSize-crc32.Size:], crc)
+	return buf
+}
+
+// DecodeFileHeader decodes a FileHeader from the start of a file.
+// It returns false if buf does not start with a format header,
+// which means the file was written in the legacy headerless format.
+func DecodeFileHeader(buf []byte) (*FileHeader, bool, error) {
+	if len(buf) < len(fileMagic) || string(buf[:len(fileMagic)]) != fileMagic {
+		return nil, false, nil
+	}
+	if len(buf) < FileHeaderSize {
+		return nil, true, ErrInvalidFileHeader
+	}
+
+	crc := binary.LittleEndian.Uint32(buf[FileHeaderSize-crc32.Size : FileHeaderSize])
+	if crc != crc32.ChecksumIEEE(buf[:FileHeaderSize-crc32.Size]) {
+		return nil, true, ErrInvalidFileHeader
+	}
+
+	header := &FileHeader{
+		Version:   binary.LittleEndian.Uint16(buf[4:6]),
+		Flags:     binary.LittleEndian.Uint16(buf[6:8]),
+		CreatedAt: int64(binary.LittleEndian.Uint64(buf[8:16])),
+	}
+	if header.Version > CurrentFormatVersion {
+		return nil, true, ErrUnsupportedFormatVersion
+	}
+	return header, true, nil
+}
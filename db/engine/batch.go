@@ -96,6 +96,19 @@ func (wb *WriteBatch) Commit() error {
 		return _const.ErrExceedMaxBatchNum
 	}
 
+	// The records are indexed before a checkpoint may be saved after them
+	wb.db.indexLock.RLock()
+	err := wb.commit()
+	wb.db.indexLock.RUnlock()
+	if err != nil {
+		return err
+	}
+	return wb.db.saveCheckpointIfDue()
+}
+
+// commit writes the records of the batch and indexes them
+// Hold the mutex and the index lock before accessing this method
+func (wb *WriteBatch) commit() error {
 	// Gets the current, most recent transaction sequence number
 	transSeq := atomic.AddUint64(&wb.db.transSeqNo, 1)
 
@@ -148,7 +161,6 @@ func (wb *WriteBatch) Commit() error {
 	wb.temporaryDataWrites = make(map[string]*data.LogRecord)
 
 	return nil
-
 }
 
 // encodeLogRecordKeyWithSeq Key+Seq Number coding
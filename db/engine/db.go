@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
 	data2 "github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/db/fileio"
 	"github.com/ByteStorage/FlyDB/db/index"
 	"github.com/ByteStorage/FlyDB/lib/backup"
 	"github.com/ByteStorage/FlyDB/lib/const"
@@ -55,6 +56,11 @@ type DB struct {
 	index      index.Indexer              // Memory index
 	transSeqNo uint64                     // Transaction sequence number, globally increasing
 	isMerging  bool                       // Whether are merging
+	readOnly   bool                       // Opened by OpenReadOnly, nothing is written to the directory
+	// indexLock is held shared by a write from appending its records until they are indexed,
+	// and exclusively while the checkpoint of a persistent index is saved
+	indexLock     sync.RWMutex
+	checkpointDue bool // a data file was filled since the last checkpoint of a persistent index
 }
 
 // NewDB open a new db instance
@@ -66,6 +72,15 @@ func NewDB(options config.Options) (*DB, error) {
 	}
 
 	// check data dir, if not exist, create it
+	// An in-memory database has no files on disk, so it always starts out empty
+	if options.FIOType == config.MemIOType {
+		return &DB{
+			options:    options,
+			lock:       new(sync.RWMutex),
+			olderFiles: make(map[uint32]*data2.DataFile),
+			index:      index.NewIndexer(options.IndexType, options.DirPath),
+		}, nil
+	}
 	if _, err := os.Stat(options.DirPath); os.IsNotExist(err) {
 		if err := os.MkdirAll(options.DirPath, os.ModePerm); err != nil {
 			return nil, err
@@ -77,19 +92,29 @@ func NewDB(options config.Options) (*DB, error) {
 		options:    options,
 		lock:       new(sync.RWMutex),
 		olderFiles: make(map[uint32]*data2.DataFile),
-		index:      index.NewIndexer(options.IndexType, options.DirPath),
 	}
 
 	// load merge files
 	if err := db.loadMergeFiles(); err != nil {
 		return nil, err
 	}
+	// A merge may have brought a new persistent index, it is opened once it is in place
+	db.index = index.NewIndexer(options.IndexType, options.DirPath)
 
 	// load data files
 	if err := db.loadDataFiles(); err != nil {
 		return nil, err
 	}
 
+	// A persistent index only needs the data written after its checkpoint
+	if persistentIndex, ok := db.index.(index.PersistentIndexer); ok {
+		if err := db.loadPersistentIndex(persistentIndex); err != nil {
+			_ = persistentIndex.Close()
+			return nil, err
+		}
+		return db, nil
+	}
+
 	// load index from hint file
 	if err := db.loadIndexFromHintFile(); err != nil {
 		return nil, err
@@ -103,9 +128,13 @@ func NewDB(options config.Options) (*DB, error) {
 }
 
 func checkOptions(options config.Options) error {
-	if options.DirPath == "" {
+	// The dir path of an in-memory database is only used to name its files
+	if options.DirPath == "" && options.FIOType != config.MemIOType {
 		return _const.ErrOptionDirPathIsEmpty
 	}
+	if options.FIOType == config.MemIOType && options.IndexType == config.BPlusTree {
+		return _const.ErrOptionIndexNeedsDisk
+	}
 	if options.DataFileSize <= 0 {
 		return _const.ErrOptionDataFileSizeNotPositive
 	}
@@ -115,11 +144,18 @@ func checkOptions(options config.Options) error {
 // Close the db instance
 func (db *DB) Close() error {
 	zap.L().Info("close db", zap.Any("options", db.options))
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// A persistent index keeps its file open even if nothing was written
+	if persistentIndex, ok := db.index.(index.PersistentIndexer); ok {
+		if err := db.closePersistentIndex(persistentIndex); err != nil {
+			return err
+		}
+	}
 	if db.activeFile == nil {
 		return nil
 	}
-	db.lock.Lock()
-	defer db.lock.Unlock()
 
 	// close active file
 	if err := db.activeFile.Close(); err != nil {
@@ -137,11 +173,11 @@ func (db *DB) Close() error {
 // Sync the db instance
 func (db *DB) Sync() error {
 	zap.L().Info("sync db", zap.Any("options", db.options))
+	db.lock.Lock()
+	defer db.lock.Unlock()
 	if db.activeFile == nil {
 		return nil
 	}
-	db.lock.Lock()
-	defer db.lock.Unlock()
 	return db.activeFile.Sync()
 }
 
@@ -161,17 +197,20 @@ func (db *DB) Put(key []byte, value []byte) error {
 	}
 
 	// append log record
+	db.indexLock.RLock()
 	pos, err := db.appendLogRecordWithLock(logRecord)
 	if err != nil {
+		db.indexLock.RUnlock()
 		return err
 	}
 
 	// update index
-	if ok := db.index.Put(key, pos); !ok {
+	ok := db.index.Put(key, pos)
+	db.indexLock.RUnlock()
+	if !ok {
 		return _const.ErrIndexUpdateFailed
 	}
-
-	return nil
+	return db.saveCheckpointIfDue()
 }
 
 // appendLogRecord ethod added lock logic split,
@@ -184,6 +223,9 @@ func (db *DB) appendLogRecordWithLock(logRecord *data2.LogRecord) (*data2.LogRec
 
 // appendLogRecord Append data to a file
 func (db *DB) appendLogRecord(logRecord *data2.LogRecord) (*data2.LogRecordPst, error) {
+	if db.readOnly {
+		return nil, _const.ErrDBIsReadOnly
+	}
 	// Check whether the active data file exists
 	// Initializes the data file if empty
 	if db.activeFile == nil {
@@ -201,12 +243,16 @@ func (db *DB) appendLogRecord(logRecord *data2.LogRecord) (*data2.LogRecordPst,
 		}
 
 		// Converts the current active file to the old data file
+		if err := db.activeFile.Seal(); err != nil {
+			return nil, err
+		}
 		db.olderFiles[db.activeFile.FileID] = db.activeFile
 
 		// Open a new active file
 		if err := db.setActiveDataFile(); err != nil {
 			return nil, err
 		}
+		db.checkpointDue = true
 	}
 
 	writeOff := db.activeFile.WriteOff
@@ -240,7 +286,7 @@ func (db *DB) setActiveDataFile() error {
 
 	// Open a new data file
 	dataFile, err := data2.OpenDataFile(db.options.DirPath, initialFileID,
-		db.options.DataFileSize, db.options.FIOType)
+		db.options.DataFileSize, db.options.FIOType, db.options.FaultInjector)
 	if err != nil {
 		return err
 	}
@@ -274,6 +320,7 @@ func (db *DB) Get(key []byte) ([]byte, error) {
 func (db *DB) GetListKeys() [][]byte {
 	// Retrieve an iterator for the index
 	iterator := db.index.Iterator(false)
+	defer iterator.Close()
 
 	// Create a slice to store the keys
 	keys := make([][]byte, db.index.Size())
@@ -299,6 +346,7 @@ func (db *DB) Fold(f func(key []byte, value []byte) bool) error {
 
 	// Retrieve an iterator for the index
 	iterator := db.index.Iterator(false)
+	defer iterator.Close()
 
 	// Iterate over the index
 	for iterator.Rewind(); iterator.Valid(); iterator.Next() {
@@ -342,7 +390,10 @@ func (db *DB) getValueByPosition(logRecordPst *data2.LogRecordPst) ([]byte, erro
 	if logRecord.Type == data2.LogRecordDeleted {
 		return nil, _const.ErrKeyNotFound
 	}
-
+	// The mapping of the file is gone once a merge closes it
+	if dataFile.Mapped() {
+		return append([]byte(nil), logRecord.Value...), nil
+	}
 	return logRecord.Value, nil
 }
 
@@ -367,17 +418,20 @@ func (db *DB) Delete(key []byte) error {
 	}
 
 	// Write to the data file
+	db.indexLock.RLock()
 	_, err := db.appendLogRecordWithLock(logRecord)
 	if err != nil {
+		db.indexLock.RUnlock()
 		return err
 	}
 
 	// Removes key from memory index
 	ok := db.index.Delete(key)
+	db.indexLock.RUnlock()
 	if !ok {
 		return _const.ErrIndexUpdateFailed
 	}
-	return nil
+	return db.saveCheckpointIfDue()
 }
 
 // Load the data file from disk
@@ -408,7 +462,12 @@ func (db *DB) loadDataFiles() error {
 
 	// Walk through each file id and open the corresponding data file
 	for i, fid := range fileIds {
-		dataFile, err := data2.OpenDataFile(db.options.DirPath, uint32(fid), db.options.DataFileSize, db.options.FIOType)
+		var dataFile *data2.DataFile
+		if db.readOnly {
+			dataFile, err = data2.OpenReadOnlyFile(data2.GetDataFileName(db.options.DirPath, uint32(fid)), uint32(fid))
+		} else {
+			dataFile, err = data2.OpenDataFile(db.options.DirPath, uint32(fid), db.options.DataFileSize, db.options.FIOType, db.options.FaultInjector)
+		}
 		if err != nil {
 			return err
 		}
@@ -417,6 +476,9 @@ func (db *DB) loadDataFiles() error {
 			db.activeFile = dataFile
 		} else {
 			// Note It is an old data file
+			if err := dataFile.Seal(); err != nil {
+				return err
+			}
 			db.olderFiles[uint32(fid)] = dataFile
 		}
 	}
@@ -432,32 +494,44 @@ func (db *DB) loadIndexFromDataFiles() error {
 	}
 
 	// Check whether the merge occurred
-	var hasMerge bool = false
-	var nonMergeFileId uint32 = 0
+	// If so, the files that participated in the merge have been loaded from the hint file
+	nonMergeFileId, err := db.getNonMergeFileIdIfMerged()
+	if err != nil {
+		return err
+	}
+	return db.replayDataFiles(&data2.LogRecordPst{Fid: nonMergeFileId}, nonTransactionSeqNo, nil)
+}
+
+// getNonMergeFileIdIfMerged returns the id of the first file that did not
+// participate in the last merge, or 0 if there has been no merge
+func (db *DB) getNonMergeFileIdIfMerged() (uint32, error) {
 	mergeFileName := filepath.Join(db.options.DirPath, data2.MergeFinaFileSuffix)
-	// If a file exists, retrieve the id of the file that did not participate in the merge
-	if _, err := os.Stat(mergeFileName); err == nil {
-		// Check if the merge file exists
-		// If it exists, determine the ID of the most recently non-merged file
-		fileId, err := db.getRecentlyNonMergeFileId(db.options.DirPath)
-		if err != nil {
-			return err
-		}
-		nonMergeFileId = fileId
-		hasMerge = true
+	if _, err := os.Stat(mergeFileName); err != nil {
+		return 0, nil
+	}
+	return db.getRecentlyNonMergeFileId(db.options.DirPath)
+}
+
+// replayDataFiles applies the records from position start onwards to the index.
+// seqNo is the largest transaction sequence number seen before start.
+// If resumable is not nil, it is called after every record that leaves no transaction open,
+// with the position and the sequence number a replay could start over from.
+func (db *DB) replayDataFiles(start *data2.LogRecordPst, seqNo uint64, resumable func(next *data2.LogRecordPst, seqNo uint64) error) error {
+	// If there is no file, the database is empty
+	if len(db.fileIds) == 0 {
+		return nil
 	}
 
 	// Define a function to update the in-memory index
 	updataIndex := func(key []byte, typ data2.LogRecrdType, pst *data2.LogRecordPst) {
-		var ok bool
 		if typ == data2.LogRecordDeleted {
 			// If the log record type is 'deleted', delete the key from the index
-			ok = db.index.Delete(key)
-		} else {
-			// Otherwise, update the key with the new position in the index
-			ok = db.index.Put(key, pst)
+			// The key may already be gone when replaying after a persistent index checkpoint
+			db.index.Delete(key)
+			return
 		}
-		if !ok {
+		// Otherwise, update the key with the new position in the index
+		if ok := db.index.Put(key, pst); !ok {
 			// Panic if the index update fails
 			panic(_const.ErrIndexUpdateFailed)
 		}
@@ -465,14 +539,14 @@ func (db *DB) loadIndexFromDataFiles() error {
 
 	// Temporary transaction data
 	transactionRecords := make(map[uint64][]*data2.TransactionRecord)
-	var currentSeqNo = nonTransactionSeqNo
+	var currentSeqNo = seqNo
 
 	// Iterate through all file ids, processing records in the file
 	for i, fid := range db.fileIds {
 		var fileID = uint32(fid)
-		// If the id is smaller than that of the file that did not participate in the merge recently,
-		// the hint file has been loaded
-		if hasMerge && fileID < nonMergeFileId {
+		// Files before the start position have already been loaded,
+		// from the hint file or into the persistent index
+		if fileID < start.Fid {
 			continue
 		}
 
@@ -483,8 +557,11 @@ func (db *DB) loadIndexFromDataFiles() error {
 			dataFile = db.olderFiles[fileID]
 		}
 
-		// Obtain data
-		var offset int64 = 0
+		// Obtain data, records start right after the file header
+		var offset = dataFile.HeaderSize
+		if fileID == start.Fid && start.Offset > offset {
+			offset = start.Offset
+		}
 		for {
 			logRecord, size, err := dataFile.ReadLogRecord(offset)
 			if err != nil {
@@ -500,8 +577,12 @@ func (db *DB) loadIndexFromDataFiles() error {
 				Offset: offset,
 			}
 
-			// Parse the key and get the transaction sequence number
+			// Parse the key and get the transaction sequence number,
+			// the index keeps the key after a merge closed the file it was read from
 			realKey, seqNo := parseLogRecordKeyAndSeq(logRecord.Key)
+			if dataFile.Mapped() {
+				realKey = append([]byte(nil), realKey...)
+			}
 			if seqNo == nonTransactionSeqNo {
 				// Non-transactional operation
 				updataIndex(realKey, logRecord.Type, logRecordPst)
@@ -531,11 +612,21 @@ func (db *DB) loadIndexFromDataFiles() error {
 
 			// Increments offset, next read from a new position
 			offset += size
+
+			if resumable != nil && len(transactionRecords) == 0 {
+				if err := resumable(&data2.LogRecordPst{Fid: fileID, Offset: offset}, currentSeqNo); err != nil {
+					return err
+				}
+			}
 		}
 
 		// If it is a current active file, update writeOff for this file
-		if i == len(db.fileIds)-1 {
-			db.activeFile.WriteOff = offset
+		// and drop whatever follows the last record, a torn write
+		// or the unused tail of a mapping left behind by a crash
+		if i == len(db.fileIds)-1 && !db.readOnly {
+			if err := db.activeFile.Truncate(offset); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -547,6 +638,11 @@ func (db *DB) loadIndexFromDataFiles() error {
 
 // Backup the database to the specified directory
 func (db *DB) Backup(dir string) error {
+	// There is no directory to copy
+	if db.options.FIOType == config.MemIOType {
+		return db.SnapshotTo(dir)
+	}
+
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
@@ -554,10 +650,41 @@ func (db *DB) Backup(dir string) error {
 	return backup.CopyDir(db.options.DirPath, dir)
 }
 
+// SnapshotTo writes the data files of the database to dir as regular files,
+// which can be reopened later with any of the on-disk IO types.
+// It is the way to persist an in-memory database, dir should not hold another database.
+func (db *DB) SnapshotTo(dir string) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	for _, dataFile := range db.sortedDataFiles() {
+		size, err := dataFile.IoManager.Size()
+		if err != nil {
+			return err
+		}
+		buf := make([]byte, size)
+		if _, err := dataFile.IoManager.Read(buf, 0); err != nil && err != io.EOF {
+			return err
+		}
+		fileName := data2.GetDataFileName(dir, dataFile.FileID)
+		if err := os.WriteFile(fileName, buf, fileio.DataFilePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Clean the DB data directory after the test is complete
 func (db *DB) Clean() {
 	if db != nil {
 		_ = db.Close()
+		// An in-memory database did not create its directory
+		if db.options.FIOType == config.MemIOType {
+			return
+		}
 		err := os.RemoveAll(db.options.DirPath)
 		if err != nil {
 			_ = fmt.Errorf("clean db error: %v", err)
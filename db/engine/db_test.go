@@ -3,6 +3,7 @@ package engine
 import (
 	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/data"
 	"github.com/ByteStorage/FlyDB/lib/const"
 	"github.com/ByteStorage/FlyDB/lib/randkv"
 	"github.com/stretchr/testify/assert"
@@ -450,3 +451,69 @@ func TestDB_Backup(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, db1)
 }
+
+func TestDB_MMapCrashRecovery(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-mmap-crash")
+	opts.DirPath = dir
+	opts.FIOType = config.MmapIOType
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	assert.Nil(t, db.Sync())
+
+	// Without Close the file keeps the unused tail of its mapping, like after a crash
+	fileName := data.GetDataFileName(dir, 0)
+	info, err := os.Stat(fileName)
+	assert.Nil(t, err)
+	mappedSize := info.Size()
+	logicalSize := db.activeFile.WriteOff
+	assert.Greater(t, mappedSize, logicalSize)
+
+	db2, err := NewDB(opts)
+	defer destroyDB(db2)
+	assert.Nil(t, err)
+	assert.Equal(t, logicalSize, db2.activeFile.WriteOff)
+	assert.Nil(t, db2.Put(randkv.GetTestKey(100), randkv.GetTestKey(100)))
+	for i := 0; i <= 100; i++ {
+		val, err := db2.Get(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(i), val)
+	}
+	assert.Nil(t, db2.Close())
+
+	info, err = os.Stat(fileName)
+	assert.Nil(t, err)
+	assert.Less(t, info.Size(), mappedSize)
+}
+
+func TestDB_MappedReads(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-mapped-reads")
+	opts.DirPath = dir
+	opts.DataFileSize = 64 * 1024
+	opts.FIOType = config.MmapIOType
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+	for i := 0; i < 200; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.RandomValue(1024)))
+	}
+	assert.Nil(t, db.Close())
+
+	// The older files are sealed when the database is reopened, and read in place
+	db, err = NewDB(opts)
+	defer destroyDB(db)
+	assert.Nil(t, err)
+	assert.True(t, db.olderFiles[0].Mapped())
+	value, err := db.Get(randkv.GetTestKey(0))
+	assert.Nil(t, err)
+	want := append([]byte(nil), value...)
+
+	// The value and the keys of the index outlive the mappings
+	assert.Nil(t, db.Merge())
+	assert.Nil(t, db.Close())
+	assert.Equal(t, want, value)
+	assert.Equal(t, 200, len(db.GetListKeys()))
+}
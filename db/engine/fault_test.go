@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/fileio"
+	"github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/randkv"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestDB_FaultIO_Crash(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-fault-crash")
+	opts.DirPath = dir
+	opts.FIOType = config.FaultIOType
+	fi := fileio.NewFaultInjector()
+	opts.FaultInjector = fi
+
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	assert.Nil(t, db.Sync())
+	for i := 100; i < 200; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	assert.Nil(t, fi.Crash())
+
+	// The writes since the last sync are gone
+	db, err = NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+	for i := 0; i < 200; i++ {
+		val, err := db.Get(randkv.GetTestKey(i))
+		if i < 100 {
+			assert.Nil(t, err)
+			assert.Equal(t, randkv.GetTestKey(i), val)
+		} else {
+			assert.Equal(t, _const.ErrKeyNotFound, err)
+		}
+	}
+}
+
+func TestDB_FaultIO_TornWrite(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-fault-torn")
+	opts.DirPath = dir
+	opts.FIOType = config.FaultIOType
+	fi := fileio.NewFaultInjector()
+	opts.FaultInjector = fi
+
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Put([]byte("key-1"), []byte("value-1")))
+
+	fi.FailWritesAfter(10, true)
+	assert.Equal(t, fileio.ErrInjectedFault, db.Put([]byte("key-2"), []byte("value-2")))
+	fi.Reset()
+	assert.Nil(t, db.Close())
+
+	// The torn record at the end of the file is ignored on replay
+	db, err = NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+	val, err := db.Get([]byte("key-1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value-1"), val)
+	_, err = db.Get([]byte("key-2"))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+}
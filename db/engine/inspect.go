@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	data2 "github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/db/index"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// OpenReadOnly opens the database in options.DirPath for inspection, while no other instance writes to it.
+// Nothing is written to the directory: the files are opened read-only, a merge that was not
+// moved into place yet is left alone, and a torn write at the end of the active file is kept.
+// The index is held in memory whatever options.IndexType says. Writes and merges fail with ErrDBIsReadOnly.
+func OpenReadOnly(options config.Options) (*DB, error) {
+	if options.DirPath == "" {
+		return nil, _const.ErrOptionDirPathIsEmpty
+	}
+	if _, err := os.Stat(options.DirPath); err != nil {
+		return nil, err
+	}
+	// A persistent index would be created and written in the directory
+	if options.IndexType == config.BPlusTree {
+		options.IndexType = config.ART
+	}
+
+	db := &DB{
+		options:    options,
+		lock:       new(sync.RWMutex),
+		olderFiles: make(map[uint32]*data2.DataFile),
+		index:      index.NewIndexer(options.IndexType, options.DirPath),
+		readOnly:   true,
+	}
+	if err := db.loadDataFiles(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := db.loadIndexFromHintFile(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := db.loadIndexFromDataFiles(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// FileStat describes the log records stored in a single data file.
+type FileStat struct {
+	FileID      uint32 // File ID
+	Version     uint16 // On-disk format version of the file
+	Size        int64  // Total bytes of log records in the file
+	Records     int    // Number of log records in the file
+	LiveRecords int    // Number of records still referenced by the index
+	LiveBytes   int64  // Bytes of the records still referenced by the index
+	End         int64  // Offset the last record ends at
+	TornBytes   int64  // Bytes after the last record, a torn write that opening the database truncates at End
+}
+
+// GarbageRatio returns the fraction of the file that a merge would reclaim.
+func (fs *FileStat) GarbageRatio() float64 {
+	if fs.Size == 0 {
+		return 0
+	}
+	return 1 - float64(fs.LiveBytes)/float64(fs.Size)
+}
+
+// FileStats scans every data file and reports how many of its records are still live.
+func (db *DB) FileStats() ([]*FileStat, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	var stats []*FileStat
+	for _, dataFile := range db.sortedDataFiles() {
+		stat := &FileStat{
+			FileID:  dataFile.FileID,
+			Version: dataFile.Version(),
+		}
+
+		var offset = dataFile.HeaderSize
+		for {
+			logRecord, size, err := dataFile.ReadLogRecord(offset)
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, err
+			}
+
+			stat.Records++
+			stat.Size += size
+
+			// A record is live if the index still points at it
+			realKey, _ := parseLogRecordKeyAndSeq(logRecord.Key)
+			if pst := db.index.Get(realKey); pst != nil && pst.Fid == dataFile.FileID && pst.Offset == offset {
+				stat.LiveRecords++
+				stat.LiveBytes += size
+			}
+			offset += size
+		}
+		fileSize, err := dataFile.IoManager.Size()
+		if err != nil {
+			return nil, err
+		}
+		stat.End = offset
+		stat.TornBytes = fileSize - offset
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// sortedDataFiles returns the older files followed by the active file, ordered by file id
+// Hold a mutex before accessing this method
+func (db *DB) sortedDataFiles() []*data2.DataFile {
+	files := make([]*data2.DataFile, 0, len(db.olderFiles)+1)
+	for _, file := range db.olderFiles {
+		files = append(files, file)
+	}
+	if db.activeFile != nil {
+		files = append(files, db.activeFile)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].FileID < files[j].FileID
+	})
+	return files
+}
+
+// ParseLogRecordKey splits a key read from a data file into
+// the user key and the transaction sequence number
+func ParseLogRecordKey(key []byte) ([]byte, uint64) {
+	return parseLogRecordKeyAndSeq(key)
+}
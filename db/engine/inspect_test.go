@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	data2 "github.com/ByteStorage/FlyDB/db/data"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/randkv"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestDB_FileStats(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-file-stats")
+	opts.DirPath = dir
+	db, err := NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+
+	// Empty database
+	stats, err := db.FileStats()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(stats))
+
+	for i := 0; i < 100; i++ {
+		err := db.Put(randkv.GetTestKey(i), randkv.RandomValue(24))
+		assert.Nil(t, err)
+	}
+	for i := 0; i < 50; i++ {
+		err := db.Delete(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+	}
+
+	stats, err = db.FileStats()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(stats))
+	assert.Equal(t, 150, stats[0].Records)
+	assert.Equal(t, 50, stats[0].LiveRecords)
+	assert.True(t, stats[0].GarbageRatio() > 0.5)
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-read-only")
+	opts.DirPath = dir
+	opts.FIOType = config.FileIOType
+	db, err := NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	assert.Nil(t, db.Close())
+	// A torn write at the end of the active file
+	fileName := data2.GetDataFileName(dir, 0)
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_APPEND, 0)
+	assert.Nil(t, err)
+	_, err = f.Write([]byte{1, 2, 3})
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+	before, err := os.Stat(fileName)
+	assert.Nil(t, err)
+
+	readOnly, err := OpenReadOnly(opts)
+	assert.Nil(t, err)
+	var count int
+	assert.Nil(t, readOnly.Fold(func(key []byte, value []byte) bool {
+		count++
+		return true
+	}))
+	assert.Equal(t, 100, count)
+	stats, err := readOnly.FileStats()
+	assert.Nil(t, err)
+	assert.Equal(t, 100, stats[0].LiveRecords)
+	assert.Equal(t, before.Size()-3, stats[0].End)
+	assert.Equal(t, int64(3), stats[0].TornBytes)
+	assert.Equal(t, _const.ErrDBIsReadOnly, readOnly.Put([]byte("key"), []byte("value")))
+	assert.Equal(t, _const.ErrDBIsReadOnly, readOnly.Merge())
+	assert.Nil(t, readOnly.Close())
+
+	// The directory is left as it was
+	after, err := os.Stat(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, before.Size(), after.Size())
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(entries))
+}
+
+func TestParseLogRecordKey(t *testing.T) {
+	key, seq := ParseLogRecordKey(encodeLogRecordKeyWithSeq([]byte("flydb"), 5))
+	assert.Equal(t, []byte("flydb"), key)
+	assert.Equal(t, uint64(5), seq)
+}
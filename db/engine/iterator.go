@@ -11,35 +11,104 @@ type Iterator struct {
 	indexIter index.Iterator
 	db        *DB
 	options   config.IteratorOptions
+	lower     []byte // Smallest key of the prefix and the range, inclusive, nil if unbounded
+	upper     []byte // Key the prefix and the range stop before, exclusive, nil if unbounded
 }
 
-// NewIterator Initializes the iterator
+// NewIterator Initializes the iterator.
+// An index that can iterate over a range is only read in the range of the prefix and the options,
+// others are copied whole.
 func (db *DB) NewIterator(opt config.IteratorOptions) *Iterator {
-	indexIter := db.index.Iterator(opt.Reverse)
-	return &Iterator{
+	lower, upper := iteratorBounds(opt)
+	var indexIter index.Iterator
+	if rangeIndex, ok := db.index.(index.RangeIndexer); ok && !opt.Snapshot {
+		indexIter = rangeIndex.RangeIterator(opt.Reverse, lower, upper)
+	} else {
+		indexIter = db.index.Iterator(opt.Reverse)
+	}
+	it := &Iterator{
 		indexIter: indexIter,
 		db:        db,
 		options:   opt,
+		lower:     lower,
+		upper:     upper,
+	}
+	// Move to the first key of the prefix or range
+	it.Rewind()
+	return it
+}
+
+// iteratorBounds returns the range of the keys that have the prefix and lie between start and end
+func iteratorBounds(opt config.IteratorOptions) (lower, upper []byte) {
+	lower, upper = opt.Start, opt.End
+	if len(opt.Prefix) == 0 {
+		return lower, upper
 	}
+	if lower == nil || bytes.Compare(opt.Prefix, lower) > 0 {
+		lower = opt.Prefix
+	}
+	if end := prefixEnd(opt.Prefix); end != nil && (upper == nil || bytes.Compare(end, upper) < 0) {
+		upper = end
+	}
+	return lower, upper
+}
+
+// prefixEnd returns the first key after all keys with the prefix, or nil if there is none
+func prefixEnd(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			end := append([]byte(nil), prefix[:i+1]...)
+			end[i]++
+			return end
+		}
+	}
+	return nil
 }
 
 func (it *Iterator) Rewind() {
 	it.indexIter.Rewind()
-	it.skipToNext()
+	// Start at the first key of the range
+	if !it.options.Reverse && it.lower != nil {
+		it.indexIter.Seek(it.lower)
+	}
+	if it.options.Reverse && it.upper != nil {
+		it.seekBefore(it.upper)
+	}
 }
 
 func (it *Iterator) Seek(key []byte) {
+	if !it.options.Reverse && it.lower != nil && bytes.Compare(key, it.lower) < 0 {
+		key = it.lower
+	}
+	if it.options.Reverse && it.upper != nil && bytes.Compare(key, it.upper) >= 0 {
+		it.seekBefore(it.upper)
+		return
+	}
 	it.indexIter.Seek(key)
-	it.skipToNext()
+}
+
+// seekBefore moves a reverse iterator to the last key before key
+func (it *Iterator) seekBefore(key []byte) {
+	it.indexIter.Seek(key)
+	if it.indexIter.Valid() && bytes.Equal(it.indexIter.Key(), key) {
+		it.indexIter.Next()
+	}
 }
 
 func (it *Iterator) Next() {
 	it.indexIter.Next()
-	it.skipToNext()
 }
 
 func (it *Iterator) Valid() bool {
-	return it.indexIter.Valid()
+	return it.indexIter.Valid() && !it.pastRange(it.indexIter.Key())
+}
+
+// pastRange reports whether the iteration has left the prefix and the range of the options
+func (it *Iterator) pastRange(key []byte) bool {
+	if it.options.Reverse {
+		return it.lower != nil && bytes.Compare(key, it.lower) < 0
+	}
+	return it.upper != nil && bytes.Compare(key, it.upper) >= 0
 }
 
 func (it *Iterator) Key() []byte {
@@ -57,22 +126,3 @@ func (it *Iterator) Value() ([]byte, error) {
 func (it *Iterator) Close() {
 	it.indexIter.Close()
 }
-
-// According to the key passed by Prefix,
-// the key is equal to the key prefix in the iterator.
-// If it is not equal, the next iteration is performed
-func (it *Iterator) skipToNext() {
-	prefixLen := len(it.options.Prefix)
-	if prefixLen == 0 {
-		return
-	}
-
-	for ; it.indexIter.Valid(); it.indexIter.Next() {
-		key := it.indexIter.Key()
-
-		// Check if the key has the desired prefix
-		if prefixLen <= len(key) && bytes.Equal(it.options.Prefix, key[:prefixLen]) {
-			break
-		}
-	}
-}
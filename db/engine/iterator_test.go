@@ -95,3 +95,78 @@ func TestDB_Iterator_Multi_Value(t *testing.T) {
 	}
 
 }
+
+func TestDB_Iterator_Range(t *testing.T) {
+	opt := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-iterator-range")
+	opt.DirPath = dir
+	db, err := NewDB(opt)
+	defer db.Clean()
+	assert.Nil(t, err)
+
+	for n := 0; n < 10; n++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(n), randkv.GetTestKey(n)))
+	}
+
+	collect := func(opt config.IteratorOptions) [][]byte {
+		var keys [][]byte
+		iterator := db.NewIterator(opt)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			keys = append(keys, iterator.Key())
+		}
+		return keys
+	}
+	assert.Equal(t, [][]byte{randkv.GetTestKey(3), randkv.GetTestKey(4), randkv.GetTestKey(5)},
+		collect(config.IteratorOptions{Start: randkv.GetTestKey(3), End: randkv.GetTestKey(6)}))
+	assert.Equal(t, [][]byte{randkv.GetTestKey(5), randkv.GetTestKey(4), randkv.GetTestKey(3)},
+		collect(config.IteratorOptions{Start: randkv.GetTestKey(3), End: randkv.GetTestKey(6), Reverse: true}))
+	assert.Equal(t, 2, len(collect(config.IteratorOptions{End: randkv.GetTestKey(2)})))
+	assert.Equal(t, 2, len(collect(config.IteratorOptions{Start: randkv.GetTestKey(8), Reverse: true})))
+}
+
+func TestDB_Iterator_Prefix(t *testing.T) {
+	for _, indexType := range []config.IndexerType{config.Btree, config.ART, config.SkipList} {
+		opt := config.DefaultOptions
+		opt.DirPath, _ = os.MkdirTemp("", "flydb-iterator-prefix")
+		opt.IndexType = indexType
+		db, err := NewDB(opt)
+		assert.Nil(t, err)
+
+		for _, key := range []string{"a", "b", "ba", "bb", "bc", "b\xff", "c"} {
+			assert.Nil(t, db.Put([]byte(key), []byte(key)))
+		}
+		collect := func(opt config.IteratorOptions, seek string) []string {
+			var keys []string
+			iterator := db.NewIterator(opt)
+			defer iterator.Close()
+			if seek != "" {
+				iterator.Seek([]byte(seek))
+			}
+			for ; iterator.Valid(); iterator.Next() {
+				keys = append(keys, string(iterator.Key()))
+			}
+			return keys
+		}
+		prefix := []byte("b")
+		assert.Equal(t, []string{"b", "ba", "bb", "bc", "b\xff"}, collect(config.IteratorOptions{Prefix: prefix}, ""))
+		assert.Equal(t, []string{"b\xff", "bc", "bb", "ba", "b"}, collect(config.IteratorOptions{Prefix: prefix, Reverse: true}, ""))
+		assert.Equal(t, []string{"bb", "bc"}, collect(config.IteratorOptions{Prefix: prefix, Start: []byte("bb"), End: []byte("bd")}, ""))
+		assert.Equal(t, []string{"ba", "b"}, collect(config.IteratorOptions{Prefix: prefix, End: []byte("bb"), Reverse: true}, ""))
+		assert.Equal(t, []string{"b", "ba", "bb", "bc", "b\xff"}, collect(config.IteratorOptions{Prefix: prefix}, "a"))
+		assert.Equal(t, []string{"b\xff", "bc", "bb", "ba", "b"}, collect(config.IteratorOptions{Prefix: prefix, Reverse: true}, "c"))
+		assert.Empty(t, collect(config.IteratorOptions{Prefix: []byte("d")}, ""))
+
+		// A snapshot iterator does not see the writes made after it was created
+		iterator := db.NewIterator(config.IteratorOptions{Prefix: prefix, Snapshot: true})
+		assert.Nil(t, db.Put([]byte("bd"), []byte("bd")))
+		var keys []string
+		for ; iterator.Valid(); iterator.Next() {
+			keys = append(keys, string(iterator.Key()))
+		}
+		iterator.Close()
+		assert.Equal(t, []string{"b", "ba", "bb", "bc", "b\xff"}, keys)
+
+		db.Clean()
+	}
+}
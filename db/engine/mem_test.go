@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/randkv"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestNewDB_MemIO(t *testing.T) {
+	opts := config.DefaultOptions
+	opts.DirPath = ""
+	opts.FIOType = config.MemIOType
+	opts.DataFileSize = 64 * 1024
+	db, err := NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+
+	for i := 0; i < 5000; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.RandomValue(24)))
+	}
+	for i := 0; i < 2500; i++ {
+		assert.Nil(t, db.Delete(randkv.GetTestKey(i)))
+	}
+	assert.Greater(t, len(db.olderFiles), 1)
+
+	for i := 0; i < 5000; i++ {
+		_, err := db.Get(randkv.GetTestKey(i))
+		if i < 2500 {
+			assert.Equal(t, _const.ErrKeyNotFound, err)
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+
+	// A persistent index can not be used without a disk
+	opts.IndexType = config.BPlusTree
+	_, err = NewDB(opts)
+	assert.Equal(t, _const.ErrOptionIndexNeedsDisk, err)
+}
+
+func TestDB_Merge_MemIO(t *testing.T) {
+	opts := config.DefaultOptions
+	opts.FIOType = config.MemIOType
+	opts.DataFileSize = 64 * 1024
+	db, err := NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+
+	for i := 0; i < 5000; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	for i := 0; i < 2500; i++ {
+		assert.Nil(t, db.Delete(randkv.GetTestKey(i)))
+	}
+	before := len(db.olderFiles)
+	assert.Nil(t, db.Merge())
+	assert.Less(t, len(db.olderFiles), before)
+
+	stats, err := db.FileStats()
+	assert.Nil(t, err)
+	for _, stat := range stats {
+		assert.Equal(t, stat.Records, stat.LiveRecords)
+	}
+	for i := 2500; i < 5000; i++ {
+		val, err := db.Get(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(i), val)
+	}
+}
+
+func TestDB_SnapshotTo(t *testing.T) {
+	opts := config.DefaultOptions
+	opts.FIOType = config.MemIOType
+	opts.DataFileSize = 64 * 1024
+	db, err := NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+
+	for i := 0; i < 5000; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+
+	dir, _ := os.MkdirTemp("", "flydb-snapshot")
+	assert.Nil(t, db.SnapshotTo(dir))
+
+	diskOpts := config.DefaultOptions
+	diskOpts.DirPath = dir
+	diskOpts.DataFileSize = 64 * 1024
+	diskOpts.FIOType = config.FileIOType
+	reopened, err := NewDB(diskOpts)
+	defer reopened.Clean()
+	assert.Nil(t, err)
+	assert.Equal(t, 5000, len(reopened.GetListKeys()))
+	val, err := reopened.Get(randkv.GetTestKey(42))
+	assert.Nil(t, err)
+	assert.Equal(t, randkv.GetTestKey(42), val)
+}
@@ -1,7 +1,10 @@
 package engine
 
 import (
+	"github.com/ByteStorage/FlyDB/config"
 	data2 "github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/db/index"
+	"github.com/ByteStorage/FlyDB/lib/bloom"
 	"github.com/ByteStorage/FlyDB/lib/const"
 	"io"
 	"os"
@@ -9,12 +12,15 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"sync"
 )
 
 // merge folder name
 var (
 	mergeDirName = "dbmerge"
 	mergeFinaKey = "mergeFina.finished"
+
+	rebuildTmpSuffix = ".rebuild"
 )
 
 // Merge Clear the invalid data and generate the hint index file
@@ -23,6 +29,12 @@ func (db *DB) Merge() error {
 	if db.activeFile == nil {
 		return nil
 	}
+	if db.readOnly {
+		return _const.ErrDBIsReadOnly
+	}
+	if db.options.FIOType == config.MemIOType {
+		return db.mergeInMemory()
+	}
 	db.lock.Lock()
 	// If the merge is in progress, return directly
 	if db.isMerging {
@@ -41,6 +53,10 @@ func (db *DB) Merge() error {
 	}
 
 	// Converts the currently active file to the old data file
+	if err := db.activeFile.Seal(); err != nil {
+		db.lock.Unlock()
+		return err
+	}
 	db.olderFiles[db.activeFile.FileID] = db.activeFile
 	// Open a new active file
 	if err := db.setActiveDataFile(); err != nil {
@@ -75,23 +91,40 @@ func (db *DB) Merge() error {
 		return err
 	}
 
-	// Open a temporary new instance and modify the configuration item
+	// The merged files are written through a bare instance, it needs no index
 	mergeOptions := db.options
 	mergeOptions.DirPath = mergePath
 	mergeOptions.SyncWrite = false
-	mergeDB, err := NewDB(mergeOptions)
-	if err != nil {
-		return err
+	mergeDB := &DB{
+		options:    mergeOptions,
+		lock:       new(sync.RWMutex),
+		olderFiles: make(map[uint32]*data2.DataFile),
+	}
+
+	// A persistent index is built anew next to the merged files and moved into place with them
+	var mergeIndex *mergeIndexWriter
+	if _, ok := db.index.(index.PersistentIndexer); ok {
+		mergeIndex = newMergeIndexWriter(index.NewIndexer(db.options.IndexType, mergePath).(index.PersistentIndexer), noMergeFileId)
 	}
 
 	// Open the hint file storage index
-	hintFile, err := data2.OpenHintFile(mergePath, db.options.DataFileSize, db.options.FIOType)
+	hintFile, err := data2.OpenHintFile(mergePath, db.options.DataFileSize, db.options.FIOType, db.options.FaultInjector)
 	if err != nil {
 		return err
 	}
+
+	// Build a fresh bloom filter from the live keys, which drops the deleted ones
+	var filter *bloom.ScalableFilter
+	if _, ok := db.index.(index.FilterIndexer); ok {
+		capacity := uint32(db.index.Size())
+		if capacity < index.DefaultBloomCapacity {
+			capacity = index.DefaultBloomCapacity
+		}
+		filter = bloom.NewScalableFilter(capacity, index.DefaultBloomFpRate)
+	}
 	// Walk through each data file
 	for _, files := range mergeFiles {
-		var offset int64 = 0
+		var offset = files.HeaderSize
 		for {
 			logRecord, size, err := files.ReadLogRecord(offset)
 
@@ -120,6 +153,14 @@ func (db *DB) Merge() error {
 				if err := hintFile.WriteHintRecord(realKey, recordPst); err != nil {
 					return err
 				}
+				if filter != nil {
+					filter.Add(realKey)
+				}
+				if mergeIndex != nil {
+					if err := mergeIndex.put(realKey, recordPst); err != nil {
+						return err
+					}
+				}
 			}
 			// Incremental offest
 			offset += size
@@ -130,12 +171,28 @@ func (db *DB) Merge() error {
 	if err := hintFile.Sync(); err != nil {
 		return err
 	}
+	if err := hintFile.Close(); err != nil {
+		return err
+	}
 	if err := mergeDB.Sync(); err != nil {
 		return err
 	}
+	if mergeIndex != nil {
+		if err := mergeIndex.close(); err != nil {
+			return err
+		}
+	}
+	if err := mergeDB.Close(); err != nil {
+		return err
+	}
+	if filter != nil {
+		if err := filter.SaveFile(filepath.Join(mergePath, data2.BloomFilterFileSuffix)); err != nil {
+			return err
+		}
+	}
 
 	// Write a file that identifies the merge completion
-	mergeFinaFile, err := data2.OpenMergeFinaFile(mergePath, db.options.DataFileSize, db.options.FIOType)
+	mergeFinaFile, err := data2.OpenMergeFinaFile(mergePath, db.options.DataFileSize, db.options.FIOType, db.options.FaultInjector)
 	if err != nil {
 		return err
 	}
@@ -155,8 +212,270 @@ func (db *DB) Merge() error {
 		return err
 	}
 
+	return mergeFinaFile.Close()
+
+}
+
+// mergeIndexWriter fills the persistent index of a merge,
+// in transactions of a bounded size so that the whole index is never held by one
+type mergeIndexWriter struct {
+	index   index.PersistentIndexer
+	keys    [][]byte
+	psts    []*data2.LogRecordPst
+	cp      *index.Checkpoint
+	written bool
+}
+
+// newMergeIndexWriter writes to persistentIndex, whose checkpoint is at the first file
+// the merge leaves as it is: the records from there on are replayed on startup
+func newMergeIndexWriter(persistentIndex index.PersistentIndexer, noMergeFileId uint32) *mergeIndexWriter {
+	return &mergeIndexWriter{
+		index: persistentIndex,
+		cp: &index.Checkpoint{
+			Fid:      noMergeFileId,
+			SeqNo:    nonTransactionSeqNo,
+			MergeFid: noMergeFileId,
+		},
+	}
+}
+
+// put adds an entry, a full batch is committed
+func (w *mergeIndexWriter) put(key []byte, pst *data2.LogRecordPst) error {
+	w.keys = append(w.keys, key)
+	w.psts = append(w.psts, pst)
+	if len(w.keys) < persistentIndexBatchSize {
+		return nil
+	}
+	return w.commit()
+}
+
+// commit writes the pending entries with the checkpoint
+func (w *mergeIndexWriter) commit() error {
+	err := w.index.Update(false, func(indexer index.Indexer) (*index.Checkpoint, error) {
+		for i, key := range w.keys {
+			indexer.Put(key, w.psts[i])
+		}
+		return w.cp, nil
+	})
+	w.keys, w.psts = w.keys[:0], w.psts[:0]
+	w.written = true
+	return err
+}
+
+// close commits the last entries, the checkpoint is saved even if there were none
+func (w *mergeIndexWriter) close() error {
+	if len(w.keys) > 0 || !w.written {
+		if err := w.commit(); err != nil {
+			_ = w.index.Close()
+			return err
+		}
+	}
+	return w.index.Close()
+}
+
+// mergeInMemory rewrites the live records of an in-memory database into new files.
+// There is no directory to move the merged files from on the next start,
+// so they replace the old ones right away, with the lock held for the whole merge.
+func (db *DB) mergeInMemory() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	if db.isMerging {
+		return _const.ErrMergeIsProgress
+	}
+	db.isMerging = true
+	defer func() {
+		db.isMerging = false
+	}()
+
+	// The merged files are written through a bare instance, file ids start at 0 again
+	mergeDB := &DB{
+		options:    db.options,
+		olderFiles: make(map[uint32]*data2.DataFile),
+	}
+	var keys [][]byte
+	var psts []*data2.LogRecordPst
+	iterator := db.index.Iterator(false)
+	for iterator.Rewind(); iterator.Valid(); iterator.Next() {
+		pst := iterator.Value()
+		dataFile := db.olderFiles[pst.Fid]
+		if pst.Fid == db.activeFile.FileID {
+			dataFile = db.activeFile
+		}
+		if dataFile == nil {
+			iterator.Close()
+			return _const.ErrDataFailNotFound
+		}
+		logRecord, _, err := dataFile.ReadLogRecord(pst.Offset)
+		if err != nil {
+			iterator.Close()
+			return err
+		}
+
+		// Clear transaction flag
+		logRecord.Key = encodeLogRecordKeyWithSeq(iterator.Key(), nonTransactionSeqNo)
+		recordPst, err := mergeDB.appendLogRecord(logRecord)
+		if err != nil {
+			iterator.Close()
+			return err
+		}
+		keys = append(keys, iterator.Key())
+		psts = append(psts, recordPst)
+	}
+	iterator.Close()
+
+	// Swap in the merged files and point the index at them
+	if err := db.activeFile.Close(); err != nil {
+		return err
+	}
+	for _, file := range db.olderFiles {
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+	db.activeFile = mergeDB.activeFile
+	db.olderFiles = mergeDB.olderFiles
+	for i, key := range keys {
+		db.index.Put(key, psts[i])
+	}
 	return nil
+}
 
+// Upgrade rewrites the data files in options.DirPath that were written in the
+// legacy headerless format into the current on-disk format.
+// The rewrite goes through Merge, and the database is reopened afterwards
+// so that the merged files replace the legacy ones.
+func Upgrade(options config.Options) error {
+	db, err := NewDB(options)
+	if err != nil {
+		return err
+	}
+	if !db.hasLegacyFiles() {
+		return db.Close()
+	}
+	return db.mergeAndReload()
+}
+
+// MergeOffline merges the database in options.DirPath while no other instance has it open.
+// Unlike Merge, the merged files have replaced the old ones when it returns.
+func MergeOffline(options config.Options) error {
+	db, err := NewDB(options)
+	if err != nil {
+		return err
+	}
+	return db.mergeAndReload()
+}
+
+// mergeAndReload merges and closes db, then reopens the database
+// to move the merged files into place
+func (db *DB) mergeAndReload() error {
+	if err := db.Merge(); err != nil {
+		_ = db.Close()
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+
+	reopened, err := NewDB(db.options)
+	if err != nil {
+		return err
+	}
+	return reopened.Close()
+}
+
+// RebuildHintFile rebuilds the hint file in options.DirPath from the data files,
+// ignoring the existing hint file, which may be missing or corrupted.
+func RebuildHintFile(options config.Options) error {
+	hintFileName := filepath.Join(options.DirPath, data2.HintFileSuffix)
+	mergeFinaFileName := filepath.Join(options.DirPath, data2.MergeFinaFileSuffix)
+	mergeFinaTmpName := mergeFinaFileName + rebuildTmpSuffix
+
+	// Without the hint file, every data file has to be replayed,
+	// so the merge completion file is moved aside while the index is rebuilt.
+	// If the rebuild is interrupted, the next start simply replays every data file.
+	if err := os.Remove(hintFileName); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	// The bloom filter only matches the hint file it was saved with
+	if err := os.Remove(filepath.Join(options.DirPath, data2.BloomFilterFileSuffix)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	hasMergeFina := false
+	if _, err := os.Stat(mergeFinaFileName); err == nil {
+		if err := os.Rename(mergeFinaFileName, mergeFinaTmpName); err != nil {
+			return err
+		}
+		hasMergeFina = true
+	}
+
+	db, err := NewDB(options)
+	if err != nil {
+		return err
+	}
+
+	// Write every live index entry to a temporary hint file, then move it into place
+	hintTmpDir := filepath.Join(options.DirPath, data2.HintFileSuffix+rebuildTmpSuffix)
+	if err := os.MkdirAll(hintTmpDir, os.ModePerm); err != nil {
+		_ = db.Close()
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(hintTmpDir)
+	}()
+	hintFile, err := data2.OpenHintFile(hintTmpDir, options.DataFileSize, options.FIOType, options.FaultInjector)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	iterator := db.index.Iterator(false)
+	for iterator.Rewind(); iterator.Valid(); iterator.Next() {
+		if err := hintFile.WriteHintRecord(iterator.Key(), iterator.Value()); err != nil {
+			iterator.Close()
+			_ = hintFile.Close()
+			_ = db.Close()
+			return err
+		}
+	}
+	iterator.Close()
+
+	if err := hintFile.Sync(); err != nil {
+		_ = hintFile.Close()
+		_ = db.Close()
+		return err
+	}
+	if err := hintFile.Close(); err != nil {
+		_ = db.Close()
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(hintTmpDir, data2.HintFileSuffix), hintFileName); err != nil {
+		return err
+	}
+
+	// The new hint file covers every live key, so it is safe to skip the merged files again
+	if hasMergeFina {
+		return os.Rename(mergeFinaTmpName, mergeFinaFileName)
+	}
+	return nil
+}
+
+// hasLegacyFiles reports whether any data file lacks a format header
+func (db *DB) hasLegacyFiles() bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.activeFile != nil && db.activeFile.IsLegacy() {
+		return true
+	}
+	for _, file := range db.olderFiles {
+		if file.IsLegacy() {
+			return true
+		}
+	}
+	return false
 }
 
 func (db *DB) getMergePath() string {
@@ -227,6 +546,12 @@ func (db *DB) loadMergeFiles() error {
 		}
 	}
 
+	// A bloom filter left by an earlier merge does not match the new hint file
+	filterFileName := filepath.Join(db.options.DirPath, data2.BloomFilterFileSuffix)
+	if err := os.Remove(filterFileName); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
 	// Move the new data file to the data directory
 	for _, fileName := range mergeFileNames {
 		mergeSrcPath := filepath.Join(mergePath, fileName)
@@ -244,13 +569,22 @@ func (db *DB) loadMergeFiles() error {
 
 // Gets the id of the file that did not participate in the merge recently
 func (db *DB) getRecentlyNonMergeFileId(dirPath string) (uint32, error) {
-	mergeFinaFile, err := data2.OpenMergeFinaFile(dirPath, db.options.DataFileSize, db.options.FIOType)
+	var mergeFinaFile *data2.DataFile
+	var err error
+	if db.readOnly {
+		mergeFinaFile, err = data2.OpenReadOnlyFile(filepath.Join(dirPath, data2.MergeFinaFileSuffix), 0)
+	} else {
+		mergeFinaFile, err = data2.OpenMergeFinaFile(dirPath, db.options.DataFileSize, db.options.FIOType, db.options.FaultInjector)
+	}
 	if err != nil {
 		return 0, err
 	}
+	defer func() {
+		_ = mergeFinaFile.Close()
+	}()
 
-	// Read the log record at offset 0 from mergeFinaFile
-	record, _, err := mergeFinaFile.ReadLogRecord(0)
+	// Read the first log record after the header of mergeFinaFile
+	record, _, err := mergeFinaFile.ReadLogRecord(mergeFinaFile.HeaderSize)
 	if err != nil {
 		return 0, err
 	}
@@ -274,13 +608,32 @@ func (db *DB) loadIndexFromHintFile() error {
 	}
 
 	// Open hint file
-	hintFile, err := data2.OpenHintFile(db.options.DirPath, db.options.DataFileSize, db.options.FIOType)
+	var hintFile *data2.DataFile
+	var err error
+	if db.readOnly {
+		hintFile, err = data2.OpenReadOnlyFile(hintFileName, 0)
+	} else {
+		hintFile, err = data2.OpenHintFile(db.options.DirPath, db.options.DataFileSize, db.options.FIOType, db.options.FaultInjector)
+	}
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = hintFile.Close()
+	}()
+
+	// Reuse the bloom filter saved with the hint file, so its keys need not be hashed again
+	put := db.index.Put
+	if filterIndex, ok := db.index.(index.FilterIndexer); ok {
+		filterFileName := filepath.Join(db.options.DirPath, data2.BloomFilterFileSuffix)
+		if filter, err := bloom.LoadScalableFilter(filterFileName); err == nil {
+			filterIndex.SetFilter(filter)
+			put = filterIndex.PutUnfiltered
+		}
+	}
 
 	// Read the index in the file
-	var offset int64 = 0
+	var offset = hintFile.HeaderSize
 	for {
 		logRecord, size, err := hintFile.ReadLogRecord(offset)
 		if err != nil {
@@ -292,7 +645,7 @@ func (db *DB) loadIndexFromHintFile() error {
 
 		// Decode to get the actual index location
 		pst := data2.DecodeLogRecordPst(logRecord.Value)
-		db.index.Put(logRecord.Key, pst)
+		put(logRecord.Key, pst)
 		offset += size
 	}
 	return nil
@@ -2,9 +2,14 @@ package engine
 
 import (
 	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/db/fileio"
+	"github.com/ByteStorage/FlyDB/db/index"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
 	"github.com/ByteStorage/FlyDB/lib/randkv"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -96,3 +101,148 @@ func TestDB_Merge3(t *testing.T) {
 	keys := db2.GetListKeys()
 	assert.Equal(t, 0, len(keys))
 }
+
+func TestUpgrade(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-upgrade")
+	opts.DirPath = dir
+	opts.FIOType = config.FileIOType
+	defer os.RemoveAll(dir)
+
+	// Write a headerless data file, as older versions did
+	fio, err := fileio.NewFileIOManager(data.GetDataFileName(dir, 0))
+	assert.Nil(t, err)
+	for i := 0; i < 100; i++ {
+		buf, _ := data.EncodeLogRecord(&data.LogRecord{
+			Key:   encodeLogRecordKeyWithSeq(randkv.GetTestKey(i), nonTransactionSeqNo),
+			Value: randkv.GetTestKey(i),
+		})
+		_, err = fio.Write(buf)
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, fio.Close())
+
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+	assert.True(t, db.hasLegacyFiles())
+	assert.Nil(t, db.Close())
+
+	err = Upgrade(opts)
+	assert.Nil(t, err)
+
+	db, err = NewDB(opts)
+	assert.Nil(t, err)
+	defer db.Clean()
+	assert.False(t, db.hasLegacyFiles())
+	for i := 0; i < 100; i++ {
+		val, err := db.Get(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(i), val)
+	}
+}
+
+func TestMergeOffline(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-merge-offline")
+	opts.DirPath = dir
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+
+	for i := 0; i < 1000; i++ {
+		err := db.Put(randkv.GetTestKey(i), randkv.RandomValue(128))
+		assert.Nil(t, err)
+	}
+	for i := 0; i < 500; i++ {
+		err := db.Delete(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, db.Close())
+
+	err = MergeOffline(opts)
+	assert.Nil(t, err)
+
+	db, err = NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+	stats, err := db.FileStats()
+	assert.Nil(t, err)
+	for _, stat := range stats {
+		assert.Equal(t, stat.Records, stat.LiveRecords)
+	}
+	assert.Equal(t, 500, len(db.GetListKeys()))
+}
+
+func TestRebuildHintFile(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-rebuild-hint")
+	opts.DirPath = dir
+	opts.FIOType = config.FileIOType
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+
+	for i := 0; i < 1000; i++ {
+		err := db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, db.Close())
+	assert.Nil(t, MergeOffline(opts))
+
+	// Corrupt the hint file
+	hintFileName := filepath.Join(dir, data.HintFileSuffix)
+	hint, err := os.ReadFile(hintFileName)
+	assert.Nil(t, err)
+	hint[len(hint)/2] ^= 0xff
+	assert.Nil(t, os.WriteFile(hintFileName, hint, 0644))
+	_, err = NewDB(opts)
+	assert.NotNil(t, err)
+
+	err = RebuildHintFile(opts)
+	assert.Nil(t, err)
+
+	db, err = NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+	for i := 0; i < 1000; i++ {
+		val, err := db.Get(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(i), val)
+	}
+}
+
+func TestMerge_BloomFilter(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-merge-bloom")
+	opts.DirPath = dir
+	opts.IndexType = config.ARTWithBloom
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+
+	for i := 0; i < 5000; i++ {
+		err := db.Put(randkv.GetTestKey(i), randkv.RandomValue(32))
+		assert.Nil(t, err)
+	}
+	for i := 0; i < 2000; i++ {
+		err := db.Delete(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, db.Close())
+	assert.Nil(t, MergeOffline(opts))
+
+	// The filter saved by the merge sits next to the hint file
+	_, err = os.Stat(filepath.Join(dir, data.BloomFilterFileSuffix))
+	assert.Nil(t, err)
+
+	db, err = NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+	filter := db.index.(index.FilterIndexer).Filter()
+	assert.Equal(t, uint32(3000), filter.Count())
+	for i := 0; i < 5000; i++ {
+		_, err := db.Get(randkv.GetTestKey(i))
+		if i < 2000 {
+			assert.Equal(t, _const.ErrKeyNotFound, err)
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package engine
+
+import (
+	data2 "github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/db/index"
+	"math"
+)
+
+// persistentIndexBatchSize is how many entries are written to a persistent index per transaction,
+// when a merge builds a new one or when one is brought up to date on startup
+const persistentIndexBatchSize = 10000
+
+// rebuildCheckpoint is committed with the batches of a rebuild that the replay can not resume from.
+// It never points into the data files, so that the rebuild starts over after a crash.
+var rebuildCheckpoint = &index.Checkpoint{Fid: math.MaxUint32}
+
+// loadPersistentIndex brings a persistent index up to date with the data files.
+// Normally only the records written after the saved checkpoint are replayed.
+// After a merge has replaced the data files, or if there is no usable checkpoint,
+// the index is rebuilt from the hint file and the data files instead.
+// Either way the changes are committed in batches, each with the checkpoint a crash resumes from.
+func (db *DB) loadPersistentIndex(persistentIndex index.PersistentIndexer) error {
+	cp, err := persistentIndex.LoadCheckpoint()
+	if err != nil {
+		return err
+	}
+	nonMergeFileId, err := db.getNonMergeFileIdIfMerged()
+	if err != nil {
+		return err
+	}
+	reset := cp == nil || cp.MergeFid != nonMergeFileId || !db.checkpointInRange(cp)
+
+	// Route the replay through the batches of the persistent index
+	loader := newPersistentIndexLoader(db, persistentIndex, reset)
+	db.index = loader
+	defer func() {
+		db.index = persistentIndex
+	}()
+
+	resumable := func(next *data2.LogRecordPst, seqNo uint64) error {
+		if len(loader.pending) < persistentIndexBatchSize {
+			return nil
+		}
+		return loader.commit(&index.Checkpoint{Fid: next.Fid, Offset: next.Offset, SeqNo: seqNo, MergeFid: nonMergeFileId})
+	}
+	if reset {
+		// The entries of the hint file are only complete together, a crash in between rebuilds again
+		loader.fill = rebuildCheckpoint
+		if err := db.loadIndexFromHintFile(); err != nil {
+			return err
+		}
+		loader.fill = nil
+		if err := db.replayDataFiles(&data2.LogRecordPst{Fid: nonMergeFileId}, nonTransactionSeqNo, resumable); err != nil {
+			return err
+		}
+	} else {
+		if err := db.replayDataFiles(&data2.LogRecordPst{Fid: cp.Fid, Offset: cp.Offset}, cp.SeqNo, resumable); err != nil {
+			return err
+		}
+	}
+	return loader.commit(db.newCheckpoint(nonMergeFileId))
+}
+
+// persistentIndexLoader is the index while a persistent index is brought up to date.
+// It keeps the changes of a replay until commit writes them to the persistent index
+// in one transaction, with the checkpoint to resume from.
+type persistentIndexLoader struct {
+	db      *DB
+	index   index.PersistentIndexer
+	reset   bool                           // Whether the next commit drops the entries of the index first
+	pending map[string]*data2.LogRecordPst // Changes since the last commit, nil for a deleted key
+	fill    *index.Checkpoint              // If not nil, a full batch is committed right away with it
+	err     error                          // Error of a commit made by Put or Delete
+}
+
+func newPersistentIndexLoader(db *DB, persistentIndex index.PersistentIndexer, reset bool) *persistentIndexLoader {
+	return &persistentIndexLoader{
+		db:      db,
+		index:   persistentIndex,
+		reset:   reset,
+		pending: make(map[string]*data2.LogRecordPst),
+	}
+}
+
+func (l *persistentIndexLoader) Put(key []byte, pst *data2.LogRecordPst) bool {
+	l.pending[string(key)] = pst
+	l.commitIfFull()
+	return true
+}
+
+func (l *persistentIndexLoader) Get(key []byte) *data2.LogRecordPst {
+	if pst, ok := l.pending[string(key)]; ok {
+		return pst
+	}
+	return l.index.Get(key)
+}
+
+func (l *persistentIndexLoader) Delete(key []byte) bool {
+	l.pending[string(key)] = nil
+	l.commitIfFull()
+	return true
+}
+
+// Size returns the number of entries committed so far
+func (l *persistentIndexLoader) Size() int {
+	return l.index.Size()
+}
+
+// Iterator iterates over the entries committed so far
+func (l *persistentIndexLoader) Iterator(reverse bool) index.Iterator {
+	return l.index.Iterator(reverse)
+}
+
+// commitIfFull commits a full batch with the fill checkpoint, if there is one
+func (l *persistentIndexLoader) commitIfFull() {
+	if l.fill == nil || l.err != nil || len(l.pending) < persistentIndexBatchSize {
+		return
+	}
+	l.err = l.commit(l.fill)
+}
+
+// commit writes the pending changes to the persistent index together with cp.
+// The data file cp points into is synced first, so that the checkpoint never
+// runs ahead of the data that is on disk.
+func (l *persistentIndexLoader) commit(cp *index.Checkpoint) error {
+	if l.err != nil {
+		return l.err
+	}
+	if dataFile := l.db.dataFileOf(cp.Fid); dataFile != nil {
+		if err := dataFile.Sync(); err != nil {
+			return err
+		}
+	}
+	err := l.index.Update(l.reset, func(indexer index.Indexer) (*index.Checkpoint, error) {
+		for key, pst := range l.pending {
+			if pst == nil {
+				indexer.Delete([]byte(key))
+			} else {
+				indexer.Put([]byte(key), pst)
+			}
+		}
+		return cp, nil
+	})
+	if err != nil {
+		return err
+	}
+	l.reset = false
+	l.pending = make(map[string]*data2.LogRecordPst)
+	return nil
+}
+
+// checkpointInRange reports whether the checkpoint points into the current data files
+func (db *DB) checkpointInRange(cp *index.Checkpoint) bool {
+	if db.activeFile == nil {
+		return false
+	}
+	if cp.Fid > db.activeFile.FileID {
+		return false
+	}
+	dataFile := db.dataFileOf(cp.Fid)
+	if dataFile == nil {
+		return false
+	}
+	size, err := dataFile.IoManager.Size()
+	return err == nil && cp.Offset <= size
+}
+
+// dataFileOf returns the data file with the given id, nil if there is none
+// Hold a mutex before accessing this method
+func (db *DB) dataFileOf(fid uint32) *data2.DataFile {
+	if db.activeFile != nil && fid == db.activeFile.FileID {
+		return db.activeFile
+	}
+	return db.olderFiles[fid]
+}
+
+// newCheckpoint returns a checkpoint at the current end of the active file
+// Hold a mutex before accessing this method
+func (db *DB) newCheckpoint(mergeFid uint32) *index.Checkpoint {
+	cp := &index.Checkpoint{
+		SeqNo:    db.transSeqNo,
+		MergeFid: mergeFid,
+	}
+	if db.activeFile != nil {
+		cp.Fid = db.activeFile.FileID
+		cp.Offset = db.activeFile.WriteOff
+	}
+	return cp
+}
+
+// saveCheckpoint moves the checkpoint of a persistent index to the end of the data
+// Hold a mutex before accessing this method, no write may be between appending and indexing
+func (db *DB) saveCheckpoint(persistentIndex index.PersistentIndexer) error {
+	cp, err := persistentIndex.LoadCheckpoint()
+	if err != nil {
+		return err
+	}
+	// The merge a checkpoint refers to only changes when the database is opened
+	var mergeFid uint32
+	if cp != nil {
+		mergeFid = cp.MergeFid
+	}
+	// The records before the checkpoint are not replayed again, they must be on disk
+	if db.activeFile != nil {
+		if err := db.activeFile.Sync(); err != nil {
+			return err
+		}
+	}
+	return persistentIndex.SaveCheckpoint(db.newCheckpoint(mergeFid))
+}
+
+// saveCheckpointIfDue saves the checkpoint of a persistent index once a data file was filled,
+// so that a crash only leaves the records of the last data files to be replayed
+func (db *DB) saveCheckpointIfDue() error {
+	persistentIndex, ok := db.index.(index.PersistentIndexer)
+	if !ok {
+		return nil
+	}
+	db.lock.RLock()
+	due := db.checkpointDue
+	db.lock.RUnlock()
+	if !due {
+		return nil
+	}
+
+	// Wait for the writes that are being indexed
+	db.indexLock.Lock()
+	defer db.indexLock.Unlock()
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	if !db.checkpointDue {
+		return nil
+	}
+	db.checkpointDue = false
+	return db.saveCheckpoint(persistentIndex)
+}
+
+// closePersistentIndex moves the checkpoint to the end of the data and closes the index
+// Hold a mutex before accessing this method
+func (db *DB) closePersistentIndex(persistentIndex index.PersistentIndexer) error {
+	if err := db.saveCheckpoint(persistentIndex); err != nil {
+		return err
+	}
+	return persistentIndex.Close()
+}
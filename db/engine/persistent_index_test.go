@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"errors"
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/index"
+	"github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/randkv"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func openBPlusTreeDB(t *testing.T, dir string) (*DB, config.Options) {
+	opts := config.DefaultOptions
+	opts.DirPath = dir
+	opts.DataFileSize = 64 * 1024
+	opts.IndexType = config.BPlusTree
+	opts.FIOType = config.FileIOType
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+	assert.NotNil(t, db)
+	return db, opts
+}
+
+// crash closes the data files and the index without saving a checkpoint
+func crash(db *DB) {
+	_ = db.index.(index.PersistentIndexer).Close()
+	_ = db.activeFile.Close()
+	for _, file := range db.olderFiles {
+		_ = file.Close()
+	}
+}
+
+func TestDB_BPlusTreeIndex(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-bptree-index")
+	defer os.RemoveAll(dir)
+
+	db, opts := openBPlusTreeDB(t, dir)
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	assert.Nil(t, db.Close())
+
+	// After a clean close the checkpoint is at the end of the data
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+	cp, err := db.index.(index.PersistentIndexer).LoadCheckpoint()
+	assert.Nil(t, err)
+	assert.Equal(t, db.activeFile.FileID, cp.Fid)
+	assert.Equal(t, db.activeFile.WriteOff, cp.Offset)
+	assert.Equal(t, 1000, len(db.GetListKeys()))
+
+	// Records written after the checkpoint are replayed after a crash
+	for i := 1000; i < 1500; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, db.Delete(randkv.GetTestKey(i)))
+	}
+	crash(db)
+
+	db, err = NewDB(opts)
+	assert.Nil(t, err)
+	defer db.Clean()
+	assert.Equal(t, 1400, len(db.GetListKeys()))
+	for i := 100; i < 1500; i++ {
+		val, err := db.Get(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(i), val)
+	}
+	_, err = db.Get(randkv.GetTestKey(0))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+}
+
+func TestDB_BPlusTreeIndexMerge(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-bptree-merge")
+	defer os.RemoveAll(dir)
+
+	db, opts := openBPlusTreeDB(t, dir)
+	for i := 0; i < 2000; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.RandomValue(64)))
+	}
+	for i := 0; i < 2000; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	for i := 0; i < 500; i++ {
+		assert.Nil(t, db.Delete(randkv.GetTestKey(i)))
+	}
+	assert.Nil(t, db.Merge())
+	// The merge builds the index of the merged files next to them
+	_, err := os.Stat(filepath.Join(db.getMergePath(), "bptree-index"))
+	assert.Nil(t, err)
+	for i := 2000; i < 2100; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	crash(db)
+
+	// The index of the merge replaces the old one, the writes after the merge are replayed into it
+	db, err = NewDB(opts)
+	assert.Nil(t, err)
+	defer db.Clean()
+	cp, err := db.index.(index.PersistentIndexer).LoadCheckpoint()
+	assert.Nil(t, err)
+	nonMergeFileId, err := db.getNonMergeFileIdIfMerged()
+	assert.Nil(t, err)
+	assert.Equal(t, nonMergeFileId, cp.MergeFid)
+	assert.Equal(t, 1600, len(db.GetListKeys()))
+	for i := 500; i < 2100; i++ {
+		val, err := db.Get(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(i), val)
+	}
+	stats, err := db.FileStats()
+	assert.Nil(t, err)
+	for _, stat := range stats {
+		assert.Equal(t, stat.Records, stat.LiveRecords)
+	}
+}
+
+func TestDB_BPlusTreeIndexCheckpointOnFullFile(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-bptree-checkpoint")
+	defer os.RemoveAll(dir)
+
+	db, opts := openBPlusTreeDB(t, dir)
+	for i := 0; i < 2000; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.RandomValue(64)))
+	}
+	assert.Greater(t, len(db.olderFiles), 0)
+
+	// The checkpoint was saved in the active file when it was started, not only on close
+	cp, err := db.index.(index.PersistentIndexer).LoadCheckpoint()
+	assert.Nil(t, err)
+	assert.NotNil(t, cp)
+	assert.Equal(t, db.activeFile.FileID, cp.Fid)
+	crash(db)
+
+	db, err = NewDB(opts)
+	assert.Nil(t, err)
+	defer db.Clean()
+	assert.Equal(t, 2000, len(db.GetListKeys()))
+}
+
+// failingIndex is a persistent index whose updates fail from the given one on
+type failingIndex struct {
+	index.PersistentIndexer
+	updates  int
+	failFrom int
+}
+
+func (f *failingIndex) Update(reset bool, fn func(indexer index.Indexer) (*index.Checkpoint, error)) error {
+	if f.updates++; f.updates >= f.failFrom {
+		return errors.New("update failed")
+	}
+	return f.PersistentIndexer.Update(reset, fn)
+}
+
+func TestDB_BPlusTreeIndexRebuildInBatches(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-bptree-rebuild")
+	defer os.RemoveAll(dir)
+
+	db, opts := openBPlusTreeDB(t, dir)
+	records := persistentIndexBatchSize*2 + 500
+	for i := 0; i < records; i++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(i), randkv.GetTestKey(i)))
+	}
+	assert.Nil(t, db.Close())
+	db, err := NewDB(opts)
+	assert.Nil(t, err)
+	defer db.Clean()
+	persistentIndex := db.index.(index.PersistentIndexer)
+
+	// A checkpoint out of the data files makes the index be rebuilt, the third batch fails
+	assert.Nil(t, persistentIndex.SaveCheckpoint(rebuildCheckpoint))
+	failing := &failingIndex{PersistentIndexer: persistentIndex, failFrom: 3}
+	assert.NotNil(t, db.loadPersistentIndex(failing))
+	assert.Equal(t, 3, failing.updates)
+
+	// The first batches were committed with a checkpoint that the next load resumes from
+	cp, err := persistentIndex.LoadCheckpoint()
+	assert.Nil(t, err)
+	assert.True(t, db.checkpointInRange(cp))
+	assert.Greater(t, cp.Offset, int64(0))
+	assert.Equal(t, persistentIndexBatchSize*2, persistentIndex.Size())
+	assert.Nil(t, db.loadPersistentIndex(persistentIndex))
+	assert.Equal(t, records, len(db.GetListKeys()))
+	for _, i := range []int{0, persistentIndexBatchSize, records - 1} {
+		val, err := db.Get(randkv.GetTestKey(i))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(i), val)
+	}
+}
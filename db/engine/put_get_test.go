@@ -3,9 +3,11 @@ package engine
 import (
 	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/fileio"
 	"github.com/ByteStorage/FlyDB/lib/randkv"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -36,3 +38,63 @@ func TestPutAndGet(t *testing.T) {
 	end = time.Now()
 	fmt.Println("get time: ", end.Sub(start).String())
 }
+
+func TestPutAndGet_HashMapIndex(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-hashmap")
+	opts.DirPath = dir
+	opts.DataFileSize = 64 * 1024 * 1024
+	opts.IndexType = config.HashMap
+	db, err := NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+	assert.NotNil(t, db)
+
+	for n := 0; n < 10000; n++ {
+		err = db.Put(randkv.GetTestKey(n), randkv.GetTestKey(n))
+		assert.Nil(t, err)
+	}
+	for n := 0; n < 10000; n++ {
+		val, err := db.Get(randkv.GetTestKey(n))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(n), val)
+	}
+
+	// Range scans still work, keys come back in order
+	iter := db.NewIterator(config.IteratorOptions{Prefix: []byte("flydb-key-00000000")})
+	defer iter.Close()
+	var count int
+	for iter.Rewind(); iter.Valid(); iter.Next() {
+		count++
+	}
+	assert.Equal(t, 10, count)
+}
+
+func TestPutAndGet_DirectIO(t *testing.T) {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "flydb-direct-io")
+	opts.DirPath = dir
+	opts.DataFileSize = 1024 * 1024
+	opts.FIOType = config.DirectIOType
+	db, err := NewDB(opts)
+	if runtime.GOOS != "linux" {
+		assert.Equal(t, fileio.ErrDirectIOUnsupported, err)
+		return
+	}
+	assert.Nil(t, err)
+
+	for n := 0; n < 20000; n++ {
+		err = db.Put(randkv.GetTestKey(n), randkv.GetTestKey(n))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, db.Close())
+
+	db, err = NewDB(opts)
+	defer db.Clean()
+	assert.Nil(t, err)
+	for n := 0; n < 20000; n++ {
+		val, err := db.Get(randkv.GetTestKey(n))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(n), val)
+	}
+}
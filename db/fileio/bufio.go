@@ -54,6 +54,21 @@ func (b *Bufio) Size() (int64, error) {
 	return stat.Size(), nil
 }
 
+// Truncate file, after flushing the buffer
+func (b *Bufio) Truncate(size int64) error {
+	if err := b.Flush(); err != nil {
+		return err
+	}
+	fileSize, err := b.Size()
+	if err != nil {
+		return err
+	}
+	if size < 0 || size > fileSize {
+		return ErrTruncateOutOfRange
+	}
+	return b.fd.Truncate(size)
+}
+
 // Flush buffer
 func (b *Bufio) Flush() error {
 	return b.wr.Flush()
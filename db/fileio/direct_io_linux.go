@@ -0,0 +1,240 @@
+//go:build linux
+
+package fileio
+
+import (
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var _ IOManager = (*DirectIO)(nil)
+
+const (
+	// directBlockSize is the alignment O_DIRECT requires for buffers, offsets and lengths
+	directBlockSize = 4096
+
+	// directBufferSize is the size of the write buffer, a multiple of directBlockSize
+	directBufferSize = 256 * 1024
+)
+
+// DirectIO File IO that bypasses the page cache
+// The file is opened with O_DIRECT, so every transfer has to be block aligned.
+// Writes collect in an aligned buffer that is written out whenever it is full.
+// On Sync the final partial block is padded to a full block, written,
+// and the file is cut back to its logical size; the partial block stays
+// in the buffer and is written again once more data follows.
+type DirectIO struct {
+	fd       *os.File
+	size     int64  // logical size of the file
+	buf      []byte // aligned write buffer, holds the file content from bufStart to size
+	bufStart int64  // file offset of the first byte in buf, block aligned
+	dirty    bool   // whether buf holds data that has not been written
+	lock     *sync.RWMutex
+}
+
+// NewDirectIOManager opens a file for direct IO
+func NewDirectIOManager(fileName string) (*DirectIO, error) {
+	fd, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR|syscall.O_DIRECT, DataFilePerm)
+	if err != nil {
+		return nil, err
+	}
+	info, err := fd.Stat()
+	if err != nil {
+		_ = fd.Close()
+		return nil, err
+	}
+
+	dio := &DirectIO{
+		fd:   fd,
+		size: info.Size(),
+		buf:  alignedBlock(directBufferSize)[:0],
+		lock: new(sync.RWMutex),
+	}
+	if err := dio.loadTail(); err != nil {
+		_ = fd.Close()
+		return nil, err
+	}
+	return dio, nil
+}
+
+// alignedBlock allocates a zeroed buffer of n bytes whose address is block aligned
+func alignedBlock(n int) []byte {
+	raw := make([]byte, n+directBlockSize)
+	shift := 0
+	if rem := int(uintptr(unsafe.Pointer(&raw[0])) & (directBlockSize - 1)); rem != 0 {
+		shift = directBlockSize - rem
+	}
+	return raw[shift : shift+n : shift+n]
+}
+
+// alignDown rounds an offset down to a block boundary
+func alignDown(offset int64) int64 {
+	return offset &^ (directBlockSize - 1)
+}
+
+// alignUp rounds an offset up to a block boundary
+func alignUp(offset int64) int64 {
+	return alignDown(offset + directBlockSize - 1)
+}
+
+// loadTail reads the partial last block of the file into the buffer,
+// so that appends can rewrite it as a whole
+// Hold the write lock before accessing this method
+func (dio *DirectIO) loadTail() error {
+	dio.bufStart = alignDown(dio.size)
+	dio.buf = dio.buf[:dio.size-dio.bufStart]
+	if len(dio.buf) == 0 {
+		return nil
+	}
+	block := dio.buf[:directBlockSize]
+	n, err := dio.fd.ReadAt(block, dio.bufStart)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if int64(n) < dio.size-dio.bufStart {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+// flush writes the buffer to the file, padding the last block.
+// Full blocks are dropped from the buffer afterwards, the partial one is kept.
+// Hold the write lock before accessing this method
+func (dio *DirectIO) flush() error {
+	if !dio.dirty {
+		return nil
+	}
+	length := alignUp(int64(len(dio.buf)))
+	padded := dio.buf[:length]
+	for i := len(dio.buf); i < len(padded); i++ {
+		padded[i] = 0
+	}
+	if _, err := dio.fd.WriteAt(padded, dio.bufStart); err != nil {
+		return err
+	}
+	// Remove the padding from the file
+	if length != int64(len(dio.buf)) {
+		if err := dio.fd.Truncate(dio.size); err != nil {
+			return err
+		}
+	}
+
+	tailStart := alignDown(int64(len(dio.buf)))
+	tail := copy(dio.buf[:cap(dio.buf)], dio.buf[tailStart:])
+	dio.buf = dio.buf[:tail]
+	dio.bufStart += tailStart
+	dio.dirty = false
+	return nil
+}
+
+// Read reads len(b) bytes from offset, like os.File.ReadAt
+// Bytes that are still buffered are served from the buffer,
+// the rest is read from disk through an aligned buffer.
+func (dio *DirectIO) Read(b []byte, offset int64) (int, error) {
+	dio.lock.RLock()
+	defer dio.lock.RUnlock()
+	if offset < 0 || offset >= dio.size {
+		return 0, io.EOF
+	}
+	end := offset + int64(len(b))
+	if end > dio.size {
+		end = dio.size
+	}
+
+	var n int
+	// The part before the buffer is on disk
+	if offset < dio.bufStart {
+		diskEnd := end
+		if diskEnd > dio.bufStart {
+			diskEnd = dio.bufStart
+		}
+		alignedOff := alignDown(offset)
+		block := alignedBlock(int(alignUp(diskEnd) - alignedOff))
+		read, err := dio.fd.ReadAt(block, alignedOff)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		if int64(read) < diskEnd-alignedOff {
+			return 0, io.ErrUnexpectedEOF
+		}
+		n = copy(b, block[offset-alignedOff:diskEnd-alignedOff])
+		offset = diskEnd
+	}
+	// The rest is in the buffer
+	if offset < end {
+		n += copy(b[n:], dio.buf[offset-dio.bufStart:end-dio.bufStart])
+	}
+
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Write appends b to the file, writing the buffer out whenever it fills up
+func (dio *DirectIO) Write(b []byte) (int, error) {
+	dio.lock.Lock()
+	defer dio.lock.Unlock()
+
+	var written int
+	for written < len(b) {
+		if len(dio.buf) == cap(dio.buf) {
+			if err := dio.flush(); err != nil {
+				return written, err
+			}
+		}
+		n := copy(dio.buf[len(dio.buf):cap(dio.buf)], b[written:])
+		dio.buf = dio.buf[:len(dio.buf)+n]
+		dio.size += int64(n)
+		dio.dirty = true
+		written += n
+	}
+	return written, nil
+}
+
+// Sync writes the buffer, including the padded partial block, and syncs the file
+func (dio *DirectIO) Sync() error {
+	dio.lock.Lock()
+	defer dio.lock.Unlock()
+	if err := dio.flush(); err != nil {
+		return err
+	}
+	return dio.fd.Sync()
+}
+
+// Close writes the buffer and closes the file
+func (dio *DirectIO) Close() error {
+	dio.lock.Lock()
+	defer dio.lock.Unlock()
+	if err := dio.flush(); err != nil {
+		return err
+	}
+	return dio.fd.Close()
+}
+
+// Size returns the logical size of the file, including buffered bytes
+func (dio *DirectIO) Size() (int64, error) {
+	dio.lock.RLock()
+	defer dio.lock.RUnlock()
+	return dio.size, nil
+}
+
+// Truncate discards everything after size bytes
+func (dio *DirectIO) Truncate(size int64) error {
+	dio.lock.Lock()
+	defer dio.lock.Unlock()
+	if size < 0 || size > dio.size {
+		return ErrTruncateOutOfRange
+	}
+	if err := dio.flush(); err != nil {
+		return err
+	}
+	if err := dio.fd.Truncate(size); err != nil {
+		return err
+	}
+	dio.size = size
+	return dio.loadTail()
+}
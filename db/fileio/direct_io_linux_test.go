@@ -0,0 +1,90 @@
+//go:build linux
+
+package fileio
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func openDirectIO(t *testing.T, path string) *DirectIO {
+	dio, err := NewDirectIOManager(path)
+	if err == syscall.EINVAL {
+		t.Skip("the file system does not support O_DIRECT")
+	}
+	assert.Nil(t, err)
+	return dio
+}
+
+func TestDirectIO_WriteAndRead(t *testing.T) {
+	path := filepath.Join("/tmp", "a.data")
+	defer destoryFile(path)
+	dio := openDirectIO(t, path)
+
+	// Cross the buffer size several times with unaligned writes
+	record := []byte("bitcask kv storage")
+	for i := 0; i < 50000; i++ {
+		n, err := dio.Write(record)
+		assert.Equal(t, len(record), n)
+		assert.Nil(t, err)
+	}
+	size, err := dio.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(50000*len(record)), size)
+
+	// Reads from disk, from the buffer, and across both
+	b := make([]byte, len(record))
+	for _, i := range []int{0, 1, 14563, 49999} {
+		n, err := dio.Read(b, int64(i*len(record)))
+		assert.Equal(t, len(record), n)
+		assert.Nil(t, err)
+		assert.Equal(t, record, b)
+	}
+	span := make([]byte, 10000)
+	_, err = dio.Read(span, dio.bufStart-5000)
+	assert.Nil(t, err)
+	_, err = dio.Read(b, size-1)
+	assert.Equal(t, io.EOF, err)
+
+	assert.Nil(t, dio.Close())
+}
+
+func TestDirectIO_SyncPartialBlock(t *testing.T) {
+	path := filepath.Join("/tmp", "a.data")
+	defer destoryFile(path)
+	dio := openDirectIO(t, path)
+
+	_, err := dio.Write([]byte("key-a"))
+	assert.Nil(t, err)
+	assert.Nil(t, dio.Sync())
+
+	// The padding of the partial block is not part of the file
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), info.Size())
+
+	// The partial block is written again with what follows it
+	_, err = dio.Write([]byte("key-b"))
+	assert.Nil(t, err)
+	assert.Nil(t, dio.Close())
+
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key-akey-b"), content)
+
+	// Appends after reopening keep the existing content
+	dio = openDirectIO(t, path)
+	_, err = dio.Write(bytes.Repeat([]byte("c"), 5000))
+	assert.Nil(t, err)
+	assert.Nil(t, dio.Truncate(15))
+	assert.Nil(t, dio.Close())
+
+	content, err = os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key-akey-bccccc"), content)
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package fileio
+
+// NewDirectIOManager is not available outside linux
+func NewDirectIOManager(fileName string) (IOManager, error) {
+	return nil, ErrDirectIOUnsupported
+}
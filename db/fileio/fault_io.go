@@ -0,0 +1,243 @@
+package fileio
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"sync"
+)
+
+var _ IOManager = (*FaultIO)(nil)
+
+var (
+	ErrInjectedFault  = errors.New("InjectedFaultError : the operation failed by fault injection")
+	ErrSimulatedCrash = errors.New("SimulatedCrashError : the file was lost in a simulated crash")
+)
+
+// FaultInjector decides which faults the FaultIO files attached to it run into.
+// It is attached to a database through config.Options.FaultInjector, and every file
+// the database opens with FaultIOType, including those of a merge, shares it.
+// Until a fault is programmed, the files behave like standard file IO.
+type FaultInjector struct {
+	lock *sync.Mutex
+	rand *rand.Rand
+
+	writeBudget int64 // Bytes that may still be written, negative for no limit
+	tornWrites  bool  // Whether the write that exceeds the budget is partially applied
+	syncBudget  int64 // Bytes that may still be written before syncs fail, negative for no limit
+	flipRate    float64
+
+	files map[*FaultIO]struct{} // Open files, which lose their unsynced writes on Crash
+}
+
+// NewFaultInjector returns an injector with no faults programmed.
+func NewFaultInjector() *FaultInjector {
+	return &FaultInjector{
+		lock:        new(sync.Mutex),
+		rand:        rand.New(rand.NewSource(1)),
+		writeBudget: -1,
+		syncBudget:  -1,
+		files:       make(map[*FaultIO]struct{}),
+	}
+}
+
+// FailWritesAfter makes writes fail once n more bytes have been written.
+// If torn is true, the write that crosses the limit is applied up to the limit
+// before it fails, like a write interrupted by a power loss.
+func (fi *FaultInjector) FailWritesAfter(n int64, torn bool) {
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	fi.writeBudget = n
+	fi.tornWrites = torn
+}
+
+// FailSyncsAfter makes syncs fail once n more bytes have been written.
+func (fi *FaultInjector) FailSyncsAfter(n int64) {
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	fi.syncBudget = n
+}
+
+// FlipBitsOnRead flips one random bit in the data returned by a read with the given probability.
+// The random source is seeded, so a test sees the same faults on every run.
+func (fi *FaultInjector) FlipBitsOnRead(rate float64) {
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	fi.flipRate = rate
+}
+
+// Reset clears the programmed faults.
+func (fi *FaultInjector) Reset() {
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	fi.writeBudget = -1
+	fi.tornWrites = false
+	fi.syncBudget = -1
+	fi.flipRate = 0
+}
+
+// Crash simulates a crash of the process: every open file loses the writes
+// since its last successful Sync, and any further operation on it fails.
+// Files opened afterwards see what survived.
+func (fi *FaultInjector) Crash() error {
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	for fio := range fi.files {
+		fio.crashed = true
+		_ = fio.inner.Close()
+		if err := os.Truncate(fio.path, fio.synced); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	fi.files = make(map[*FaultIO]struct{})
+	return nil
+}
+
+// FaultIO File IO that runs into the faults programmed on its FaultInjector
+type FaultIO struct {
+	inner    *FileIO
+	path     string
+	injector *FaultInjector
+	synced   int64 // Size of the file at the last successful sync
+	crashed  bool
+}
+
+// NewFaultIOManager opens a file with standard file IO and attaches it to injector.
+// Without an injector, the file never fails.
+func NewFaultIOManager(fileName string, injector *FaultInjector) (*FaultIO, error) {
+	inner, err := NewFileIOManager(fileName)
+	if err != nil {
+		return nil, err
+	}
+	size, err := inner.Size()
+	if err != nil {
+		_ = inner.Close()
+		return nil, err
+	}
+
+	if injector == nil {
+		// Nothing is programmed, an injector of its own never fails
+		injector = NewFaultInjector()
+	}
+	fio := &FaultIO{
+		inner:    inner,
+		path:     fileName,
+		injector: injector,
+		synced:   size,
+	}
+	injector.lock.Lock()
+	injector.files[fio] = struct{}{}
+	injector.lock.Unlock()
+	return fio, nil
+}
+
+func (fio *FaultIO) Read(b []byte, offset int64) (int, error) {
+	fi := fio.injector
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	if fio.crashed {
+		return 0, ErrSimulatedCrash
+	}
+	n, err := fio.inner.Read(b, offset)
+	if n > 0 && fi.flipRate > 0 && fi.rand.Float64() < fi.flipRate {
+		bit := fi.rand.Intn(n * 8)
+		b[bit/8] ^= 1 << (bit % 8)
+	}
+	return n, err
+}
+
+func (fio *FaultIO) Write(b []byte) (int, error) {
+	fi := fio.injector
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	if fio.crashed {
+		return 0, ErrSimulatedCrash
+	}
+
+	if fi.writeBudget >= 0 && int64(len(b)) > fi.writeBudget {
+		if !fi.tornWrites || fi.writeBudget == 0 {
+			return 0, ErrInjectedFault
+		}
+		n, err := fio.inner.Write(b[:fi.writeBudget])
+		fi.consume(int64(n))
+		if err != nil {
+			return n, err
+		}
+		return n, ErrInjectedFault
+	}
+
+	n, err := fio.inner.Write(b)
+	fi.consume(int64(n))
+	return n, err
+}
+
+// consume charges written bytes to the budgets
+// Hold the injector mutex before accessing this method
+func (fi *FaultInjector) consume(n int64) {
+	if fi.writeBudget >= 0 {
+		fi.writeBudget -= n
+	}
+	if fi.syncBudget >= 0 {
+		fi.syncBudget -= n
+		if fi.syncBudget < 0 {
+			fi.syncBudget = 0
+		}
+	}
+}
+
+func (fio *FaultIO) Sync() error {
+	fi := fio.injector
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	if fio.crashed {
+		return ErrSimulatedCrash
+	}
+	if fi.syncBudget == 0 {
+		return ErrInjectedFault
+	}
+	if err := fio.inner.Sync(); err != nil {
+		return err
+	}
+	size, err := fio.inner.Size()
+	if err != nil {
+		return err
+	}
+	fio.synced = size
+	return nil
+}
+
+// Close closes the file, a closed file keeps what was written to it
+func (fio *FaultIO) Close() error {
+	fi := fio.injector
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
+	if fio.crashed {
+		return nil
+	}
+	delete(fi.files, fio)
+	return fio.inner.Close()
+}
+
+func (fio *FaultIO) Size() (int64, error) {
+	fio.injector.lock.Lock()
+	defer fio.injector.lock.Unlock()
+	if fio.crashed {
+		return 0, ErrSimulatedCrash
+	}
+	return fio.inner.Size()
+}
+
+func (fio *FaultIO) Truncate(size int64) error {
+	fio.injector.lock.Lock()
+	defer fio.injector.lock.Unlock()
+	if fio.crashed {
+		return ErrSimulatedCrash
+	}
+	if err := fio.inner.Truncate(size); err != nil {
+		return err
+	}
+	if fio.synced > size {
+		fio.synced = size
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package fileio
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFaultIO_FailWritesAfter(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-fault")
+	defer destoryFile(dir)
+	fi := NewFaultInjector()
+
+	fio, err := NewFaultIOManager(filepath.Join(dir, "a.data"), fi)
+	assert.Nil(t, err)
+	defer fio.Close()
+
+	fi.FailWritesAfter(8, false)
+	_, err = fio.Write([]byte("key-a"))
+	assert.Nil(t, err)
+	n, err := fio.Write([]byte("key-b"))
+	assert.Equal(t, 0, n)
+	assert.Equal(t, ErrInjectedFault, err)
+
+	// A torn write keeps the bytes up to the limit
+	fi.FailWritesAfter(3, true)
+	n, err = fio.Write([]byte("key-c"))
+	assert.Equal(t, 3, n)
+	assert.Equal(t, ErrInjectedFault, err)
+	size, err := fio.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), size)
+
+	fi.Reset()
+	_, err = fio.Write([]byte("key-d"))
+	assert.Nil(t, err)
+}
+
+func TestFaultIO_FailSyncsAfter(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-fault")
+	defer destoryFile(dir)
+	fi := NewFaultInjector()
+
+	fio, err := NewFaultIOManager(filepath.Join(dir, "a.data"), fi)
+	assert.Nil(t, err)
+	defer fio.Close()
+
+	fi.FailSyncsAfter(10)
+	_, err = fio.Write([]byte("key-a"))
+	assert.Nil(t, err)
+	assert.Nil(t, fio.Sync())
+	_, err = fio.Write([]byte("key-b"))
+	assert.Nil(t, err)
+	assert.Equal(t, ErrInjectedFault, fio.Sync())
+}
+
+func TestFaultIO_FlipBitsOnRead(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-fault")
+	defer destoryFile(dir)
+	fi := NewFaultInjector()
+
+	fio, err := NewFaultIOManager(filepath.Join(dir, "a.data"), fi)
+	assert.Nil(t, err)
+	defer fio.Close()
+	_, err = fio.Write([]byte("key-a"))
+	assert.Nil(t, err)
+
+	fi.FlipBitsOnRead(1)
+	b := make([]byte, 5)
+	_, err = fio.Read(b, 0)
+	assert.Nil(t, err)
+	assert.NotEqual(t, []byte("key-a"), b)
+
+	fi.FlipBitsOnRead(0)
+	_, err = fio.Read(b, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key-a"), b)
+}
+
+func TestFaultIO_Crash(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-fault")
+	defer destoryFile(dir)
+	fi := NewFaultInjector()
+
+	path := filepath.Join(dir, "a.data")
+	fio, err := NewFaultIOManager(path, fi)
+	assert.Nil(t, err)
+	_, err = fio.Write([]byte("key-a"))
+	assert.Nil(t, err)
+	assert.Nil(t, fio.Sync())
+	_, err = fio.Write([]byte("key-b"))
+	assert.Nil(t, err)
+
+	assert.Nil(t, fi.Crash())
+	_, err = fio.Write([]byte("key-c"))
+	assert.Equal(t, ErrSimulatedCrash, err)
+
+	// Only the synced write survived
+	fio, err = NewFaultIOManager(path, fi)
+	assert.Nil(t, err)
+	defer fio.Close()
+	size, err := fio.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), size)
+}
@@ -22,6 +22,16 @@ func NewFileIOManager(fileName string) (*FileIO, error) {
 	return &FileIO{fd: fd}, nil
 }
 
+// NewReadOnlyFileIOManager opens an existing file with standard file I/O for reading only,
+// writes to it fail.
+func NewReadOnlyFileIOManager(fileName string) (*FileIO, error) {
+	fd, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return &FileIO{fd: fd}, nil
+}
+
 func (fio *FileIO) Read(b []byte, offset int64) (int, error) {
 	return fio.fd.ReadAt(b, offset)
 }
@@ -45,3 +55,14 @@ func (fio *FileIO) Size() (int64, error) {
 	}
 	return stat.Size(), nil
 }
+
+func (fio *FileIO) Truncate(size int64) error {
+	fileSize, err := fio.Size()
+	if err != nil {
+		return err
+	}
+	if size < 0 || size > fileSize {
+		return ErrTruncateOutOfRange
+	}
+	return fio.fd.Truncate(size)
+}
@@ -1,5 +1,7 @@
 package fileio
 
+import "errors"
+
 // 0644 Indicates that a file is created.
 // The file owner can read and write the file,
 // but others can only read the file
@@ -8,13 +10,16 @@ const DataFilePerm = 0644
 const DefaultFileSize = 256 * 1024 * 1024
 
 const (
-	FileIOType = iota + 1 // Standard File IO
-	BufIOType             // File IO with buffer
-	MmapIOType            // Memory Mapping IO
+	FileIOType   = iota + 1 // Standard File IO
+	BufIOType               // File IO with buffer
+	MmapIOType              // Memory Mapping IO
+	MemIOType               // In-memory IO, nothing is written to disk
+	FaultIOType             // Standard File IO with injected faults, for testing
+	DirectIOType            // File IO that bypasses the page cache, linux only
 )
 
 // IOManager is an abstract IO management interface that can accommodate different IO types.
-// Currently, it supports standard file IO.
+// Currently, it supports standard file IO, buffered IO, mmap, direct IO and in-memory files.
 type IOManager interface {
 	// Read reads the corresponding data from the file at the given position.
 	Read([]byte, int64) (int, error)
@@ -30,10 +35,33 @@ type IOManager interface {
 
 	// Size gets the file size.
 	Size() (int64, error)
+
+	// Truncate discards everything after the first size bytes of the file.
+	Truncate(size int64) error
+}
+
+// Sealer is implemented by IO managers that can serve a file more efficiently
+// once it will not be written again.
+type Sealer interface {
+	// Seal makes the file read-only.
+	Seal() error
 }
 
-// NewIOManager get IOManager based on type
-func NewIOManager(filename string, fileSize int64, fioType int8) (IOManager, error) {
+// Slicer is implemented by IO managers that can hand out the content of a file
+// without copying it.
+type Slicer interface {
+	// Slice returns n bytes at offset, pointing into the file rather than copied.
+	// They are valid until the file is closed, ok is false if they can not be handed out.
+	Slice(n int64, offset int64) (b []byte, ok bool)
+}
+
+var (
+	ErrTruncateOutOfRange  = errors.New("TruncateOutOfRangeError : truncate size is larger than the file")
+	ErrDirectIOUnsupported = errors.New("DirectIOUnsupportedError : direct IO is only supported on linux")
+)
+
+// NewIOManager get IOManager based on type, faults is the injector of FaultIOType files and may be nil
+func NewIOManager(filename string, fileSize int64, fioType int8, faults *FaultInjector) (IOManager, error) {
 	switch fioType {
 	case FileIOType:
 		return NewFileIOManager(filename)
@@ -41,6 +69,12 @@ func NewIOManager(filename string, fileSize int64, fioType int8) (IOManager, err
 		return NewBufIOManager(filename)
 	case MmapIOType:
 		return NewMMapIOManager(filename, fileSize)
+	case MemIOType:
+		return NewMemIOManager(filename)
+	case FaultIOType:
+		return NewFaultIOManager(filename, faults)
+	case DirectIOType:
+		return NewDirectIOManager(filename)
 	}
 	return NewMMapIOManager(filename, fileSize)
 }
@@ -0,0 +1,101 @@
+package fileio
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+var _ IOManager = (*MemIO)(nil)
+
+// memInitialCapacity is the capacity of the buffer of a new in-memory file
+const memInitialCapacity = 4 * 1024
+
+// MemIO In-memory file IO
+// The file lives in a growable buffer and never touches the disk,
+// its content is lost once it is closed.
+type MemIO struct {
+	name string
+	buf  []byte
+	lock *sync.RWMutex
+}
+
+// NewMemIOManager initializes an empty in-memory file
+// The name is only kept for identification, no file is created.
+func NewMemIOManager(fileName string) (*MemIO, error) {
+	return &MemIO{
+		name: fileName,
+		buf:  make([]byte, 0, memInitialCapacity),
+		lock: new(sync.RWMutex),
+	}, nil
+}
+
+// Read reads len(b) bytes from offset, like os.File.ReadAt
+func (mio *MemIO) Read(b []byte, offset int64) (int, error) {
+	mio.lock.RLock()
+	defer mio.lock.RUnlock()
+	if mio.buf == nil {
+		return 0, os.ErrClosed
+	}
+	if offset < 0 || offset >= int64(len(mio.buf)) {
+		return 0, io.EOF
+	}
+	n := copy(b, mio.buf[offset:])
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Write appends b to the end of the file
+func (mio *MemIO) Write(b []byte) (int, error) {
+	mio.lock.Lock()
+	defer mio.lock.Unlock()
+	if mio.buf == nil {
+		return 0, os.ErrClosed
+	}
+	mio.buf = append(mio.buf, b...)
+	return len(b), nil
+}
+
+// Sync is a no-op, there is nothing to persist
+func (mio *MemIO) Sync() error {
+	return nil
+}
+
+// Close releases the buffer
+func (mio *MemIO) Close() error {
+	mio.lock.Lock()
+	defer mio.lock.Unlock()
+	mio.buf = nil
+	return nil
+}
+
+// Size returns the number of bytes written to the file
+func (mio *MemIO) Size() (int64, error) {
+	mio.lock.RLock()
+	defer mio.lock.RUnlock()
+	if mio.buf == nil {
+		return 0, os.ErrClosed
+	}
+	return int64(len(mio.buf)), nil
+}
+
+// Truncate discards everything after size bytes
+func (mio *MemIO) Truncate(size int64) error {
+	mio.lock.Lock()
+	defer mio.lock.Unlock()
+	if mio.buf == nil {
+		return os.ErrClosed
+	}
+	if size < 0 || size > int64(len(mio.buf)) {
+		return ErrTruncateOutOfRange
+	}
+	mio.buf = mio.buf[:size]
+	return nil
+}
+
+// Name returns the name the file was created with
+func (mio *MemIO) Name() string {
+	return mio.name
+}
@@ -0,0 +1,70 @@
+package fileio
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestMemIO_WriteAndRead(t *testing.T) {
+	mio, err := NewMemIOManager("a.data")
+	assert.Nil(t, err)
+
+	n, err := mio.Write([]byte("key-a"))
+	assert.Equal(t, 5, n)
+	assert.Nil(t, err)
+	_, err = mio.Write([]byte("key-b"))
+	assert.Nil(t, err)
+
+	size, err := mio.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), size)
+
+	b := make([]byte, 5)
+	n, err = mio.Read(b, 5)
+	assert.Equal(t, 5, n)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key-b"), b)
+
+	// Reading past the end behaves like ReadAt
+	n, err = mio.Read(b, 8)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, io.EOF, err)
+	_, err = mio.Read(b, 10)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestMemIO_Grow(t *testing.T) {
+	mio, err := NewMemIOManager("a.data")
+	assert.Nil(t, err)
+
+	chunk := make([]byte, 1000)
+	for i := 0; i < 100; i++ {
+		chunk[0] = byte(i)
+		_, err := mio.Write(chunk)
+		assert.Nil(t, err)
+	}
+	size, err := mio.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100000), size)
+
+	b := make([]byte, 1)
+	_, err = mio.Read(b, 99000)
+	assert.Nil(t, err)
+	assert.Equal(t, byte(99), b[0])
+}
+
+func TestMemIO_Close(t *testing.T) {
+	mio, err := NewMemIOManager("a.data")
+	assert.Nil(t, err)
+	_, err = mio.Write([]byte("key-a"))
+	assert.Nil(t, err)
+	assert.Nil(t, mio.Sync())
+	assert.Nil(t, mio.Close())
+
+	_, err = mio.Write([]byte("key-b"))
+	assert.Equal(t, os.ErrClosed, err)
+	_, err = mio.Size()
+	assert.Equal(t, os.ErrClosed, err)
+}
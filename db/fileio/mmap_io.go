@@ -3,96 +3,245 @@ package fileio
 import (
 	"errors"
 	"github.com/edsrzf/mmap-go"
+	"io"
 	"os"
+	"sync"
 )
 
+var (
+	_ IOManager = (*MMapIO)(nil)
+	_ Slicer    = (*MMapIO)(nil)
+)
+
+const (
+	// mmapMinMapSize is the smallest mapping of a writable file
+	mmapMinMapSize = 1024 * 1024
+
+	// mmapMaxGrowStep caps how much a mapping grows at once, smaller mappings double
+	mmapMaxGrowStep = 64 * 1024 * 1024
+)
+
+var ErrMMapReadOnly = errors.New("MMapReadOnlyError : the file is mapped read-only")
+
+// MMapIO Memory mapped file IO
+// A writable file is mapped a bit larger than its content and remapped in growing steps,
+// so the file on disk is only as large as the data written to it plus one step.
+// Once a file will not be written again, Seal maps it read-only at its exact size.
+// Read copies out of the mapping, so a caller may keep the bytes it read after the file
+// is closed and unmapped, for example by a merge. Slice hands out the read-only mapping
+// of a sealed file in place, the caller must not keep those bytes past Close.
 type MMapIO struct {
-	fd       *os.File  // system file descriptor
-	data     mmap.MMap // the mapping area corresponding to the file
-	dirty    bool      // has changed
-	offset   int64     // next write location
-	fileSize int64     // max file size
+	fd       *os.File      // system file descriptor
+	data     mmap.MMap     // the mapping area corresponding to the file
+	dirty    bool          // has changed
+	offset   int64         // next write location, the logical size of the file
+	fileSize int64         // expected max file size, bounds the first mapping
+	readOnly bool          // mapped with PROT_READ
+	lock     *sync.RWMutex // guards the mapping while it is replaced
 }
 
 // NewMMapIOManager Initialize Mmap IO
+// The file is mapped read-write, fileSize only bounds the size of the first mapping.
 func NewMMapIOManager(fileName string, fileSize int64) (*MMapIO, error) {
-	mmapIO := &MMapIO{fileSize: fileSize}
-
 	fd, err := os.OpenFile(
 		fileName,
-		os.O_CREATE|os.O_RDWR|os.O_APPEND,
+		os.O_CREATE|os.O_RDWR,
 		DataFilePerm,
 	)
 	if err != nil {
 		return nil, err
 	}
-	info, _ := fd.Stat()
-
-	// Expand files to maximum file size, crop when saving
-	if err := fd.Truncate(fileSize); err != nil {
+	info, err := fd.Stat()
+	if err != nil {
+		_ = fd.Close()
 		return nil, err
 	}
 
-	// Building mappings between memory and disk files
-	b, err := mmap.Map(fd, mmap.RDWR, 0)
-	if err != nil {
+	// After a crash the file still contains the unused tail of its last mapping,
+	// the owner trims it with Truncate once it knows where the content ends
+	mmapIO := &MMapIO{
+		fd:       fd,
+		offset:   info.Size(),
+		fileSize: fileSize,
+		lock:     new(sync.RWMutex),
+	}
+	if err := mmapIO.remap(mmapIO.offset); err != nil {
+		_ = fd.Close()
 		return nil, err
 	}
-
-	mmapIO.fd = fd
-	mmapIO.data = b
-	mmapIO.offset = info.Size()
 	return mmapIO, nil
 }
 
-// Read Copy data from the mapping area to byte slice
+// remap maps the file read-write with room for at least size bytes
+// Hold the write lock before accessing this method
+func (mio *MMapIO) remap(size int64) error {
+	mapSize := int64(len(mio.data))
+	if mapSize < mmapMinMapSize {
+		mapSize = mmapMinMapSize
+		if mio.fileSize > 0 && mio.fileSize < mapSize {
+			mapSize = mio.fileSize
+		}
+	}
+	for mapSize < size {
+		if mapSize < mmapMaxGrowStep {
+			mapSize *= 2
+		} else {
+			mapSize += mmapMaxGrowStep
+		}
+	}
+
+	if err := mio.UnMap(); err != nil {
+		return err
+	}
+	if err := mio.fd.Truncate(mapSize); err != nil {
+		return err
+	}
+	data, err := mmap.Map(mio.fd, mmap.RDWR, 0)
+	if err != nil {
+		return err
+	}
+	mio.data = data
+	return nil
+}
+
+// Read Copy data from the mapping area to byte slice, like os.File.ReadAt
 func (mio *MMapIO) Read(b []byte, offset int64) (int, error) {
-	return copy(b, mio.data[offset:]), nil
+	mio.lock.RLock()
+	defer mio.lock.RUnlock()
+	if offset < 0 || offset >= mio.offset {
+		return 0, io.EOF
+	}
+	n := copy(b, mio.data[offset:mio.offset])
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
 }
 
-// Write Copy data from byte slice to the mapping area
+// Slice returns n bytes at offset of a sealed file without copying them, valid until Close.
+// A writable mapping is replaced as the file grows, so ok is false until the file is sealed.
+func (mio *MMapIO) Slice(n int64, offset int64) ([]byte, bool) {
+	mio.lock.RLock()
+	defer mio.lock.RUnlock()
+	if !mio.readOnly || n < 0 || offset < 0 || offset+n > int64(len(mio.data)) {
+		return nil, false
+	}
+	return mio.data[offset : offset+n : offset+n], true
+}
+
+// Write Copy data from byte slice to the mapping area, growing it when it is full
 func (mio *MMapIO) Write(b []byte) (int, error) {
-	oldOffset := mio.offset
+	mio.lock.Lock()
+	defer mio.lock.Unlock()
+	if mio.readOnly {
+		return 0, ErrMMapReadOnly
+	}
+
 	newOffset := mio.offset + int64(len(b))
-	if newOffset > mio.fileSize {
-		return 0, errors.New("exceed file max content length")
+	if newOffset > int64(len(mio.data)) {
+		if err := mio.remap(newOffset); err != nil {
+			return 0, err
+		}
 	}
 
+	n := copy(mio.data[mio.offset:], b)
 	mio.offset = newOffset
 	mio.dirty = true
-	return copy(mio.data[oldOffset:], b), nil
+	return n, nil
 }
 
 // Sync Synchronize data from memory to disk
 func (mio *MMapIO) Sync() error {
+	mio.lock.Lock()
+	defer mio.lock.Unlock()
+	return mio.sync()
+}
+
+// sync flushes the mapping if it has changed
+// Hold the write lock before accessing this method
+func (mio *MMapIO) sync() error {
 	if !mio.dirty {
 		return nil
 	}
-
 	if err := mio.data.Flush(); err != nil {
 		return err
 	}
-
 	mio.dirty = false
 	return nil
 }
 
-// Close file
-func (mio *MMapIO) Close() (err error) {
-	if err = mio.fd.Truncate(mio.offset); err != nil {
+// Truncate discards everything after size bytes
+func (mio *MMapIO) Truncate(size int64) error {
+	mio.lock.Lock()
+	defer mio.lock.Unlock()
+	if mio.readOnly {
+		return ErrMMapReadOnly
+	}
+	if size < 0 || size > mio.offset {
+		return ErrTruncateOutOfRange
+	}
+	// Zero the discarded bytes, the mapping past offset must read as unwritten
+	for i := size; i < mio.offset; i++ {
+		mio.data[i] = 0
+	}
+	mio.offset = size
+	mio.dirty = true
+	return nil
+}
+
+// Seal trims the file to its content and maps it read-only,
+// it can not be written afterwards
+func (mio *MMapIO) Seal() error {
+	mio.lock.Lock()
+	defer mio.lock.Unlock()
+	if mio.readOnly {
+		return nil
+	}
+	if err := mio.trim(); err != nil {
 		return err
 	}
-	if err = mio.Sync(); err != nil {
+	mio.readOnly = true
+	// An empty file can not be mapped, there is nothing to read anyway
+	if mio.offset == 0 {
+		return nil
+	}
+	data, err := mmap.MapRegion(mio.fd, int(mio.offset), mmap.RDONLY, 0, 0)
+	if err != nil {
 		return err
 	}
-	if err = mio.UnMap(); err != nil {
-		panic(err)
+	mio.data = data
+	return nil
+}
+
+// trim flushes and unmaps the file, then cuts it back to its logical size
+// Hold the write lock before accessing this method
+func (mio *MMapIO) trim() error {
+	if mio.readOnly {
+		return mio.UnMap()
+	}
+	if err := mio.sync(); err != nil {
+		return err
+	}
+	if err := mio.UnMap(); err != nil {
+		return err
+	}
+	return mio.fd.Truncate(mio.offset)
+}
+
+// Close file, trimming it to its logical size
+func (mio *MMapIO) Close() (err error) {
+	mio.lock.Lock()
+	defer mio.lock.Unlock()
+	if err = mio.trim(); err != nil {
+		return err
 	}
 	return mio.fd.Close()
 }
 
 // Size return the size of current file
 func (mio *MMapIO) Size() (int64, error) {
+	mio.lock.RLock()
+	defer mio.lock.RUnlock()
 	return mio.offset, nil
 }
 
@@ -2,6 +2,8 @@ package fileio
 
 import (
 	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -117,3 +119,100 @@ func TestMMapIO_Read_Speed(t *testing.T) {
 		//assert.Equal(t, []byte("bitcask kv"), b1)
 	}
 }
+
+func TestMMapIO_Grow(t *testing.T) {
+	path := filepath.Join("/tmp", "a.data")
+	mio, err := NewMMapIOManager(path, 1024)
+	defer destoryFile(path)
+	assert.Nil(t, err)
+
+	// Writes keep going past the size of the first mapping
+	for i := 0; i < 1000; i++ {
+		n, err := mio.Write([]byte("bitcask kv"))
+		assert.Equal(t, 10, n)
+		assert.Nil(t, err)
+	}
+	size, err := mio.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10000), size)
+
+	b := make([]byte, 10)
+	_, err = mio.Read(b, 9990)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("bitcask kv"), b)
+	_, err = mio.Read(b, 10000)
+	assert.Equal(t, io.EOF, err)
+
+	// The file on disk is trimmed to its content on close
+	assert.Nil(t, mio.Close())
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10000), info.Size())
+}
+
+func TestMMapIO_Seal(t *testing.T) {
+	path := filepath.Join("/tmp", "a.data")
+	mio, err := NewMMapIOManager(path, DefaultFileSize)
+	defer destoryFile(path)
+	assert.Nil(t, err)
+
+	_, err = mio.Write([]byte("key-a"))
+	assert.Nil(t, err)
+	assert.Nil(t, mio.Seal())
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), info.Size())
+
+	b := make([]byte, 5)
+	_, err = mio.Read(b, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key-a"), b)
+
+	_, err = mio.Write([]byte("key-b"))
+	assert.Equal(t, ErrMMapReadOnly, err)
+	assert.Nil(t, mio.Close())
+}
+
+func TestMMapIO_Slice(t *testing.T) {
+	path := filepath.Join("/tmp", "a.data")
+	mio, err := NewMMapIOManager(path, DefaultFileSize)
+	defer destoryFile(path)
+	assert.Nil(t, err)
+
+	_, err = mio.Write([]byte("key-akey-b"))
+	assert.Nil(t, err)
+
+	// A writable mapping may be replaced, it is not handed out
+	_, ok := mio.Slice(5, 0)
+	assert.False(t, ok)
+
+	assert.Nil(t, mio.Seal())
+	b, ok := mio.Slice(5, 5)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("key-b"), b)
+	assert.Equal(t, 5, cap(b))
+	_, ok = mio.Slice(5, 6)
+	assert.False(t, ok)
+	assert.Nil(t, mio.Close())
+}
+
+func TestMMapIO_Truncate(t *testing.T) {
+	path := filepath.Join("/tmp", "a.data")
+	mio, err := NewMMapIOManager(path, DefaultFileSize)
+	defer destoryFile(path)
+	assert.Nil(t, err)
+
+	_, err = mio.Write([]byte("key-akey-b"))
+	assert.Nil(t, err)
+	assert.Nil(t, mio.Truncate(5))
+	assert.Equal(t, ErrTruncateOutOfRange, mio.Truncate(6))
+	_, err = mio.Write([]byte("key-c"))
+	assert.Nil(t, err)
+
+	b := make([]byte, 10)
+	_, err = mio.Read(b, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key-akey-c"), b)
+	assert.Nil(t, mio.Close())
+}
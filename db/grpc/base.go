@@ -15,10 +15,12 @@ import (
 	"github.com/ByteStorage/FlyDB/config"
 	"github.com/ByteStorage/FlyDB/db/grpc/service"
 	"github.com/ByteStorage/FlyDB/lib/proto/ghash"
+	"github.com/ByteStorage/FlyDB/lib/proto/gkeys"
 	"github.com/ByteStorage/FlyDB/lib/proto/glist"
 	"github.com/ByteStorage/FlyDB/lib/proto/gset"
 	"github.com/ByteStorage/FlyDB/lib/proto/gstring"
 	"github.com/ByteStorage/FlyDB/lib/proto/gzset"
+	"github.com/ByteStorage/FlyDB/structure"
 )
 
 type Base interface {
@@ -42,40 +44,36 @@ func NewService(options config.Options, addr string) (Base, error) {
 		server: grpc.NewServer(),
 		base:   make([]service.Base, 0),
 	}
-	// start string structure service
-	stringService, err := service.NewStringService(options)
+	// the structures share one keyspace, so that the key commands work on any of them
+	ks, err := structure.NewKeyspace(options)
 	if err != nil {
 		return nil, err
 	}
+
+	// start key service
+	keysService := service.NewKeysService(ks)
+	baseService.RegisterService(keysService)
+	gkeys.RegisterGKeysServiceServer(baseService.server, keysService)
+
+	// start string structure service
+	stringService := service.NewStringService(ks)
 	baseService.RegisterService(stringService)
 	gstring.RegisterGStringServiceServer(baseService.server, stringService)
 
 	// start hash structure service
-	hashService, err := service.NewHashService(options)
-	if err != nil {
-		return nil, err
-	}
+	hashService := service.NewHashService(ks)
 	baseService.RegisterService(hashService)
 	ghash.RegisterGHashServiceServer(baseService.server, hashService)
 
-	listService, err := service.NewListService(options)
-	if err != nil {
-		return nil, err
-	}
+	listService := service.NewListService(ks)
 	baseService.RegisterService(listService)
 	glist.RegisterGListServiceServer(baseService.server, listService)
 
-	setService, err := service.NewSetService(options)
-	if err != nil {
-		return nil, err
-	}
+	setService := service.NewSetService(ks)
 	baseService.RegisterService(setService)
 	gset.RegisterGSetServiceServer(baseService.server, setService)
 
-	zsetService, err := service.NewZSetService(options)
-	if err != nil {
-		return nil, err
-	}
+	zsetService := service.NewZSetService(ks)
 	baseService.RegisterService(zsetService)
 	gzset.RegisterGZSetServiceServer(baseService.server, zsetService)
 
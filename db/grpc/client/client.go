@@ -5,6 +5,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/ByteStorage/FlyDB/lib/proto/ghash"
+	"github.com/ByteStorage/FlyDB/lib/proto/gkeys"
 	"github.com/ByteStorage/FlyDB/lib/proto/glist"
 	"github.com/ByteStorage/FlyDB/lib/proto/gset"
 	"github.com/ByteStorage/FlyDB/lib/proto/gstring"
@@ -20,6 +21,7 @@ type Client struct {
 	gListServiceClient   glist.GListServiceClient
 	gSetServiceClient    gset.GSetServiceClient
 	gZSetServiceClient   gzset.GZSetServiceClient
+	gKeysServiceClient   gkeys.GKeysServiceClient
 }
 
 func NewClient(addr string) (*Client, error) {
@@ -147,6 +149,25 @@ func (c *Client) newZSetGrpcClient() (gzset.GZSetServiceClient, error) {
 	return c.gZSetServiceClient, nil
 }
 
+func (c *Client) newKeysGrpcClient() (gkeys.GKeysServiceClient, error) {
+	var (
+		conn *grpc.ClientConn
+		err  error
+	)
+
+	if c.gKeysServiceClient != nil {
+		return c.gKeysServiceClient, nil
+	}
+
+	if conn, err = c.getGrpcConn(); err != nil {
+		return nil, err
+	}
+
+	c.gKeysServiceClient = gkeys.NewGKeysServiceClient(conn)
+
+	return c.gKeysServiceClient, nil
+}
+
 func (c *Client) Close() error {
 	if c.conn != nil {
 		if err := c.conn.Close(); err != nil {
@@ -313,3 +313,23 @@ func (c *Client) HType(key, field string) (string, error) {
 
 	return typeResp.Type, nil
 }
+
+// HScan returns a page of the fields of a hash that match a pattern, with their values,
+// and the cursor of the next page, which is "0" once every field was visited
+func (c *Client) HScan(key, cursor, match string, count int64) (string, map[string]string, error) {
+	client, err := c.newHashGrpcClient()
+	if err != nil {
+		return "", nil, err
+	}
+
+	scanResp, err := client.HScan(context.Background(), &ghash.GHashScanRequest{Key: key, Cursor: cursor, Match: match, Count: count})
+	if err != nil {
+		return "", nil, err
+	}
+
+	fields := make(map[string]string, len(scanResp.Fields))
+	for i, field := range scanResp.Fields {
+		fields[field] = scanResp.Values[i]
+	}
+	return scanResp.Cursor, fields, nil
+}
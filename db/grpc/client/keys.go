@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ByteStorage/FlyDB/lib/proto/gkeys"
+)
+
+// KeyType returns the type of the value of a key of any data structure, or "none"
+func (c *Client) KeyType(key string) (string, error) {
+	client, err := c.newKeysGrpcClient()
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Type(context.Background(), &gkeys.TypeRequest{Key: key})
+	if err != nil {
+		return "", err
+	}
+	return resp.Type, nil
+}
+
+// DelKeys deletes keys of any data structure, it returns how many of them existed
+func (c *Client) DelKeys(keys ...string) (int64, error) {
+	client, err := c.newKeysGrpcClient()
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Del(context.Background(), &gkeys.DelRequest{Keys: keys})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+// ExistsKeys returns how many of the keys exist
+func (c *Client) ExistsKeys(keys ...string) (int64, error) {
+	client, err := c.newKeysGrpcClient()
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Exists(context.Background(), &gkeys.ExistsRequest{Keys: keys})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+// RenameKey moves the value of a key to newKey
+func (c *Client) RenameKey(key, newKey string) error {
+	client, err := c.newKeysGrpcClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.Rename(context.Background(), &gkeys.RenameRequest{Key: key, NewKey: newKey})
+	if err != nil {
+		return err
+	}
+	if !resp.Ok {
+		return errors.New("rename failed")
+	}
+	return nil
+}
+
+// ExpireKey sets the time to live of a key in seconds
+func (c *Client) ExpireKey(key string, ttl int64) error {
+	client, err := c.newKeysGrpcClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.Expire(context.Background(), &gkeys.ExpireRequest{Key: key, Ttl: ttl})
+	if err != nil {
+		return err
+	}
+	if !resp.Ok {
+		return errors.New("expire failed")
+	}
+	return nil
+}
+
+// PersistKey removes the expiry time of a key
+func (c *Client) PersistKey(key string) error {
+	client, err := c.newKeysGrpcClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.Persist(context.Background(), &gkeys.PersistRequest{Key: key})
+	if err != nil {
+		return err
+	}
+	if !resp.Ok {
+		return errors.New("persist failed")
+	}
+	return nil
+}
+
+// KeyTTL returns the seconds a key has left to live, or -1 if it does not expire
+func (c *Client) KeyTTL(key string) (int64, error) {
+	client, err := c.newKeysGrpcClient()
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.TTL(context.Background(), &gkeys.TTLRequest{Key: key})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Ttl, nil
+}
+
+// Scan returns a page of the keys that match a pattern and, unless typ is empty, hold that type,
+// and the cursor of the next page, which is "0" once the scan is complete
+func (c *Client) Scan(cursor, match string, count int64, typ string) (string, []string, error) {
+	client, err := c.newKeysGrpcClient()
+	if err != nil {
+		return "", nil, err
+	}
+	resp, err := client.Scan(context.Background(), &gkeys.ScanRequest{Cursor: cursor, Match: match, Count: count, Type: typ})
+	if err != nil {
+		return "", nil, err
+	}
+	return resp.Cursor, resp.Keys, nil
+}
@@ -3,8 +3,10 @@ package client
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ByteStorage/FlyDB/lib/proto/glist"
+	"google.golang.org/grpc"
 )
 
 func (c *Client) LPush(key string, value interface{}) error {
@@ -417,3 +419,180 @@ func (c *Client) LTrim(key string, start, stop int) error {
 	}
 	return nil
 }
+
+// LInsert inserts value before or after pivot, and returns the length of the list, -1 if pivot is not in it
+func (c *Client) LInsert(key string, before bool, pivot, value interface{}) (int, error) {
+	client, err := c.newListGrpcClient()
+	if err != nil {
+		return 0, errors.New("new grpc client error: " + err.Error())
+	}
+
+	pivotValue, err := toListValue(pivot)
+	if err != nil {
+		return 0, err
+	}
+	element, err := toListValue(value)
+	if err != nil {
+		return 0, err
+	}
+	req := &glist.GListLInsertRequest{Key: key, Before: before, Pivot: pivotValue, Element: element}
+
+	resp, err := client.LInsert(context.Background(), req)
+	if err != nil {
+		return 0, errors.New("client LInsert failed: " + err.Error())
+	}
+	return int(resp.Length), nil
+}
+
+// LPos returns the indexes of the occurrences of value, see structure.ListStructure.LPos
+func (c *Client) LPos(key string, value interface{}, rank, count, maxLen int) ([]int, error) {
+	client, err := c.newListGrpcClient()
+	if err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	element, err := toListValue(value)
+	if err != nil {
+		return nil, err
+	}
+	req := &glist.GListLPosRequest{
+		Key:     key,
+		Element: element,
+		Rank:    int32(rank),
+		Count:   int32(count),
+		MaxLen:  int32(maxLen),
+	}
+
+	resp, err := client.LPos(context.Background(), req)
+	if err != nil {
+		return nil, errors.New("client LPos failed: " + err.Error())
+	}
+	positions := make([]int, len(resp.Positions))
+	for i, position := range resp.Positions {
+		positions[i] = int(position)
+	}
+	return positions, nil
+}
+
+// LMove moves the value at the from end (LEFT or RIGHT) of source to the to end of destination, and returns it
+func (c *Client) LMove(source, destination, from, to string) (interface{}, error) {
+	client, err := c.newListGrpcClient()
+	if err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	req := &glist.GListLMoveRequest{Source: source, Destination: destination, From: from, To: to}
+
+	resp, err := client.LMove(context.Background(), req)
+	if err != nil {
+		return nil, errors.New("client LMove failed: " + err.Error())
+	}
+	return fromListValue(resp.Element)
+}
+
+// toListValue converts a value to gRPC-compatible format
+func toListValue(value interface{}) (*glist.Value, error) {
+	switch v := value.(type) {
+	case string:
+		return &glist.Value{Value: &glist.Value_StringValue{StringValue: v}}, nil
+	case int32:
+		return &glist.Value{Value: &glist.Value_Int32Value{Int32Value: v}}, nil
+	case int64:
+		return &glist.Value{Value: &glist.Value_Int64Value{Int64Value: v}}, nil
+	case float32:
+		return &glist.Value{Value: &glist.Value_Float32Value{Float32Value: v}}, nil
+	case float64:
+		return &glist.Value{Value: &glist.Value_Float64Value{Float64Value: v}}, nil
+	case bool:
+		return &glist.Value{Value: &glist.Value_BoolValue{BoolValue: v}}, nil
+	case []byte:
+		return &glist.Value{Value: &glist.Value_BytesValue{BytesValue: v}}, nil
+	}
+	return nil, errors.New("unknown value type")
+}
+
+// fromListValue returns the value held by a gRPC value
+func fromListValue(value *glist.Value) (interface{}, error) {
+	switch v := value.GetValue().(type) {
+	case *glist.Value_StringValue:
+		return v.StringValue, nil
+	case *glist.Value_Int32Value:
+		return v.Int32Value, nil
+	case *glist.Value_Int64Value:
+		return v.Int64Value, nil
+	case *glist.Value_Float32Value:
+		return v.Float32Value, nil
+	case *glist.Value_Float64Value:
+		return v.Float64Value, nil
+	case *glist.Value_BoolValue:
+		return v.BoolValue, nil
+	case *glist.Value_BytesValue:
+		return v.BytesValue, nil
+	}
+	return nil, errors.New("unknown value type")
+}
+
+// ErrListTimeout is returned by BLPop, BRPop and BLMove if no value was taken before their timeout
+var ErrListTimeout = errors.New("timed out waiting for a list")
+
+// BLPop removes and returns the leftmost value of the first non-empty list of keys, and the key of that list.
+// It waits for a push at most timeout, until ctx is done if timeout is 0. Cancelling ctx cancels the wait on the server.
+func (c *Client) BLPop(ctx context.Context, timeout time.Duration, keys ...string) (string, interface{}, error) {
+	client, err := c.newListGrpcClient()
+	if err != nil {
+		return "", nil, errors.New("new grpc client error: " + err.Error())
+	}
+	return blockingPop(ctx, client.BLPop, timeout, keys)
+}
+
+// BRPop removes and returns the rightmost value of the first non-empty list of keys, it waits like BLPop
+func (c *Client) BRPop(ctx context.Context, timeout time.Duration, keys ...string) (string, interface{}, error) {
+	client, err := c.newListGrpcClient()
+	if err != nil {
+		return "", nil, errors.New("new grpc client error: " + err.Error())
+	}
+	return blockingPop(ctx, client.BRPop, timeout, keys)
+}
+
+func blockingPop(ctx context.Context, pop func(context.Context, *glist.GListBPopRequest, ...grpc.CallOption) (*glist.GListBPopResponse, error),
+	timeout time.Duration, keys []string) (string, interface{}, error) {
+	req := &glist.GListBPopRequest{Keys: keys, Timeout: timeout.Seconds()}
+
+	resp, err := pop(ctx, req)
+	if err != nil {
+		return "", nil, errors.New("client blocking pop failed: " + err.Error())
+	}
+	if resp.TimedOut {
+		return "", nil, ErrListTimeout
+	}
+	value, err := fromListValue(resp.Element)
+	if err != nil {
+		return "", nil, err
+	}
+	return resp.Key, value, nil
+}
+
+// BLMove is LMove waiting like BLPop if the source list is empty or does not exist
+func (c *Client) BLMove(ctx context.Context, source, destination, from, to string, timeout time.Duration) (interface{}, error) {
+	client, err := c.newListGrpcClient()
+	if err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	req := &glist.GListBLMoveRequest{
+		Source:      source,
+		Destination: destination,
+		From:        from,
+		To:          to,
+		Timeout:     timeout.Seconds(),
+	}
+
+	resp, err := client.BLMove(ctx, req)
+	if err != nil {
+		return nil, errors.New("client BLMove failed: " + err.Error())
+	}
+	if resp.TimedOut {
+		return nil, ErrListTimeout
+	}
+	return fromListValue(resp.Element)
+}
@@ -180,3 +180,18 @@ func (c *Client) SInterStore(destinationKey string, keys []string) error {
 	}
 	return nil
 }
+
+// SScan returns a page of the members of a set that match a pattern,
+// and the cursor of the next page, which is "0" once every member was visited
+func (c *Client) SScan(key, cursor, match string, count int64) (string, []string, error) {
+	client, err := c.newSetGrpcClient()
+	if err != nil {
+		return "", nil, errors.New("new grpc client error: " + err.Error())
+	}
+	req := &gset.SScanRequest{Key: key, Cursor: cursor, Match: match, Count: count}
+	sscan, err := client.SScan(context.Background(), req)
+	if err != nil {
+		return "", nil, err
+	}
+	return sscan.Cursor, sscan.Members, nil
+}
@@ -12,15 +12,27 @@ type Range struct {
 	End   int32
 }
 
+// ScoreRange is a range of scores, min and max included
+type ScoreRange struct {
+	Min float64
+	Max float64
+}
+
 type Incr struct {
 	Member string
-	Inc    int32
+	Inc    float64
 }
 
 func (c *Client) ZAdd(key string, member *gzset.ZSetValue) (*gzset.ZAddResponse, error) {
+	return c.ZAddWithFlags(key, member, nil)
+}
+
+// ZAddWithFlags adds a member to a sorted set as allowed by flags, with incr the score
+// of the member is added to its current score and the response holds the new score
+func (c *Client) ZAddWithFlags(key string, member *gzset.ZSetValue, flags *gzset.ZAddFlags) (*gzset.ZAddResponse, error) {
 	var (
 		client   gzset.GZSetServiceClient
-		request  = &gzset.ZAddRequest{Key: key, Member: member}
+		request  = &gzset.ZAddRequest{Key: key, Member: member, Flags: flags}
 		response *gzset.ZAddResponse
 		err      error
 	)
@@ -37,9 +49,14 @@ func (c *Client) ZAdd(key string, member *gzset.ZSetValue) (*gzset.ZAddResponse,
 }
 
 func (c *Client) ZAdds(key string, member []*gzset.ZSetValue) (*gzset.ZAddsResponse, error) {
+	return c.ZAddsWithFlags(key, member, nil)
+}
+
+// ZAddsWithFlags adds members to a sorted set as allowed by flags, which must not set incr
+func (c *Client) ZAddsWithFlags(key string, member []*gzset.ZSetValue, flags *gzset.ZAddFlags) (*gzset.ZAddsResponse, error) {
 	var (
 		client   gzset.GZSetServiceClient
-		request  = &gzset.ZAddsRequest{Key: key, Members: member}
+		request  = &gzset.ZAddsRequest{Key: key, Members: member, Flags: flags}
 		response *gzset.ZAddsResponse
 		err      error
 	)
@@ -169,10 +186,10 @@ func (c *Client) ZRange(key string, requestRange *Range) (*gzset.ZRangeResponse,
 	return response, nil
 }
 
-func (c *Client) ZCount(key string, requestRange *Range) (*gzset.ZCountResponse, error) {
+func (c *Client) ZCount(key string, requestRange *ScoreRange) (*gzset.ZCountResponse, error) {
 	var (
 		client   gzset.GZSetServiceClient
-		request  = &gzset.ZCountRequest{Key: key, Min: requestRange.Start, Max: requestRange.End}
+		request  = &gzset.ZCountRequest{Key: key, Min: requestRange.Min, Max: requestRange.Max}
 		response *gzset.ZCountResponse
 		err      error
 	)
@@ -244,3 +261,308 @@ func (c *Client) ZIncrBy(key string, incr *Incr) (*gzset.ZIncrByResponse, error)
 
 	return response, nil
 }
+
+// ZScan returns a page of the members of a sorted set that match a pattern,
+// and the cursor of the next page, which is "0" once every member was visited
+func (c *Client) ZScan(key, cursor, match string, count int64) (*gzset.ZScanResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZScanRequest{Key: key, Cursor: cursor, Match: match, Count: count}
+		response *gzset.ZScanResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZScan(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZRangeByScore returns the members of a sorted set whose score is between min and max,
+// written as in Redis, a nil limit returns all of them
+func (c *Client) ZRangeByScore(key, min, max string, limit *gzset.Limit) (*gzset.ZRangeByScoreResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZRangeByScoreRequest{Key: key, Min: min, Max: max, Limit: limit}
+		response *gzset.ZRangeByScoreResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZRangeByScore(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZRevRangeByScore returns the members of a sorted set whose score is between min and max
+// from the highest score, a nil limit returns all of them
+func (c *Client) ZRevRangeByScore(key, max, min string, limit *gzset.Limit) (*gzset.ZRangeByScoreResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZRangeByScoreRequest{Key: key, Min: min, Max: max, Limit: limit}
+		response *gzset.ZRangeByScoreResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZRevRangeByScore(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZRangeByLex returns the members of a sorted set between min and max,
+// written as in Redis, a nil limit returns all of them
+func (c *Client) ZRangeByLex(key, min, max string, limit *gzset.Limit) (*gzset.ZRangeByLexResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZRangeByLexRequest{Key: key, Min: min, Max: max, Limit: limit}
+		response *gzset.ZRangeByLexResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZRangeByLex(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZPopMin removes and returns up to count members of a sorted set with the lowest scores
+func (c *Client) ZPopMin(key string, count int64) (*gzset.ZPopResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZPopRequest{Key: key, Count: count}
+		response *gzset.ZPopResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZPopMin(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZPopMax removes and returns up to count members of a sorted set with the highest scores
+func (c *Client) ZPopMax(key string, count int64) (*gzset.ZPopResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZPopRequest{Key: key, Count: count}
+		response *gzset.ZPopResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZPopMax(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZRemRangeByScore removes the members of a sorted set whose score is between min and max
+func (c *Client) ZRemRangeByScore(key, min, max string) (*gzset.ZRemRangeResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZRemRangeByScoreRequest{Key: key, Min: min, Max: max}
+		response *gzset.ZRemRangeResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZRemRangeByScore(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZRemRangeByRank removes the members of a sorted set from rank start to rank stop, inclusive
+func (c *Client) ZRemRangeByRank(key string, start, stop int64) (*gzset.ZRemRangeResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZRemRangeByRankRequest{Key: key, Start: start, Stop: stop}
+		response *gzset.ZRemRangeResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZRemRangeByRank(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZMScore returns the scores of members of a sorted set
+func (c *Client) ZMScore(key string, members []string) (*gzset.ZMScoreResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZMScoreRequest{Key: key, Members: members}
+		response *gzset.ZMScoreResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZMScore(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZUnion returns the union of sorted sets, weights holds one weight per key or none,
+// aggregate is "sum", "min" or "max"
+func (c *Client) ZUnion(keys []string, weights []float64, aggregate string) (*gzset.ZCombineResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZCombineRequest{Keys: keys, Weights: weights, Aggregate: aggregate}
+		response *gzset.ZCombineResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZUnion(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZInter returns the intersection of sorted sets, see ZUnion
+func (c *Client) ZInter(keys []string, weights []float64, aggregate string) (*gzset.ZCombineResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZCombineRequest{Keys: keys, Weights: weights, Aggregate: aggregate}
+		response *gzset.ZCombineResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZInter(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZDiff returns the members of the first sorted set that are in none of the others
+func (c *Client) ZDiff(keys []string) (*gzset.ZCombineResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZCombineRequest{Keys: keys}
+		response *gzset.ZCombineResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZDiff(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZUnionStore writes the union of sorted sets to destination, see ZUnion
+func (c *Client) ZUnionStore(destination string, keys []string, weights []float64, aggregate string) (*gzset.ZCombineStoreResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZCombineStoreRequest{Destination: destination, Keys: keys, Weights: weights, Aggregate: aggregate}
+		response *gzset.ZCombineStoreResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZUnionStore(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZInterStore writes the intersection of sorted sets to destination, see ZUnion
+func (c *Client) ZInterStore(destination string, keys []string, weights []float64, aggregate string) (*gzset.ZCombineStoreResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZCombineStoreRequest{Destination: destination, Keys: keys, Weights: weights, Aggregate: aggregate}
+		response *gzset.ZCombineStoreResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZInterStore(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ZDiffStore writes the difference of sorted sets to destination, see ZDiff
+func (c *Client) ZDiffStore(destination string, keys []string) (*gzset.ZCombineStoreResponse, error) {
+	var (
+		client   gzset.GZSetServiceClient
+		request  = &gzset.ZCombineStoreRequest{Destination: destination, Keys: keys}
+		response *gzset.ZCombineStoreResponse
+		err      error
+	)
+
+	if client, err = c.newZSetGrpcClient(); err != nil {
+		return nil, errors.New("new grpc client error: " + err.Error())
+	}
+
+	if response, err = client.ZDiffStore(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
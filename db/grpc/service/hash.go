@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"github.com/ByteStorage/FlyDB/config"
 	"github.com/ByteStorage/FlyDB/lib/proto/ghash"
 	"github.com/ByteStorage/FlyDB/structure"
 )
@@ -18,12 +17,8 @@ type hash struct {
 	ghash.GHashServiceServer
 }
 
-func NewHashService(options config.Options) (HashService, error) {
-	dbh, err := structure.NewHashStructure(options)
-	if err != nil {
-		return nil, err
-	}
-	return &hash{dbh: dbh}, nil
+func NewHashService(ks *structure.Keyspace) HashService {
+	return &hash{dbh: structure.NewHashStructureWithKeyspace(ks)}
 }
 
 func (s *hash) CloseDb() error {
@@ -261,3 +256,16 @@ func (s *hash) HType(ctx context.Context, req *ghash.GHashTypeRequest) (*ghash.G
 	}
 	return &ghash.GHashTypeResponse{Type: hashType}, nil
 }
+
+func (s *hash) HScan(ctx context.Context, req *ghash.GHashScanRequest) (*ghash.GHashScanResponse, error) {
+	cursor, pairs, err := s.dbh.HScan(req.Key, req.Cursor, req.Match, int(req.Count))
+	if err != nil {
+		return &ghash.GHashScanResponse{}, err
+	}
+	resp := &ghash.GHashScanResponse{Cursor: cursor}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		resp.Fields = append(resp.Fields, pairs[i])
+		resp.Values = append(resp.Values, pairs[i+1])
+	}
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"github.com/ByteStorage/FlyDB/lib/proto/gkeys"
+	"github.com/ByteStorage/FlyDB/structure"
+)
+
+type KeysService interface {
+	Base
+	gkeys.GKeysServiceServer
+}
+
+// keys serves the commands that work on a key of any data structure
+type keys struct {
+	ks *structure.Keyspace
+	gkeys.GKeysServiceServer
+}
+
+func NewKeysService(ks *structure.Keyspace) KeysService {
+	return &keys{ks: ks}
+}
+
+func (k *keys) CloseDb() error {
+	return k.ks.Close()
+}
+
+func (k *keys) Type(ctx context.Context, req *gkeys.TypeRequest) (*gkeys.TypeResponse, error) {
+	typ, err := k.ks.Type(req.Key)
+	if err != nil {
+		return &gkeys.TypeResponse{}, err
+	}
+	return &gkeys.TypeResponse{Type: typ}, nil
+}
+
+func (k *keys) Del(ctx context.Context, req *gkeys.DelRequest) (*gkeys.DelResponse, error) {
+	count, err := k.ks.Del(req.Keys...)
+	if err != nil {
+		return &gkeys.DelResponse{}, err
+	}
+	return &gkeys.DelResponse{Count: int64(count)}, nil
+}
+
+func (k *keys) Exists(ctx context.Context, req *gkeys.ExistsRequest) (*gkeys.ExistsResponse, error) {
+	count, err := k.ks.Exists(req.Keys...)
+	if err != nil {
+		return &gkeys.ExistsResponse{}, err
+	}
+	return &gkeys.ExistsResponse{Count: int64(count)}, nil
+}
+
+func (k *keys) Rename(ctx context.Context, req *gkeys.RenameRequest) (*gkeys.RenameResponse, error) {
+	if err := k.ks.Rename(req.Key, req.NewKey); err != nil {
+		return &gkeys.RenameResponse{}, err
+	}
+	return &gkeys.RenameResponse{Ok: true}, nil
+}
+
+func (k *keys) Expire(ctx context.Context, req *gkeys.ExpireRequest) (*gkeys.ExpireResponse, error) {
+	if err := k.ks.Expire(req.Key, req.Ttl); err != nil {
+		return &gkeys.ExpireResponse{}, err
+	}
+	return &gkeys.ExpireResponse{Ok: true}, nil
+}
+
+func (k *keys) Persist(ctx context.Context, req *gkeys.PersistRequest) (*gkeys.PersistResponse, error) {
+	if err := k.ks.Persist(req.Key); err != nil {
+		return &gkeys.PersistResponse{}, err
+	}
+	return &gkeys.PersistResponse{Ok: true}, nil
+}
+
+func (k *keys) TTL(ctx context.Context, req *gkeys.TTLRequest) (*gkeys.TTLResponse, error) {
+	ttl, err := k.ks.TTL(req.Key)
+	if err != nil {
+		return &gkeys.TTLResponse{}, err
+	}
+	return &gkeys.TTLResponse{Ttl: ttl}, nil
+}
+
+func (k *keys) Scan(ctx context.Context, req *gkeys.ScanRequest) (*gkeys.ScanResponse, error) {
+	cursor, keys, err := k.ks.Scan(req.Cursor, req.Match, int(req.Count), req.Type)
+	if err != nil {
+		return &gkeys.ScanResponse{}, err
+	}
+	return &gkeys.ScanResponse{Cursor: cursor, Keys: keys}, nil
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/ByteStorage/FlyDB/config"
+	"math"
+	"time"
+
 	"github.com/ByteStorage/FlyDB/lib/proto/glist"
 	"github.com/ByteStorage/FlyDB/structure"
 )
@@ -22,12 +25,8 @@ func (l *list) CloseDb() error {
 	return l.dbs.Stop()
 }
 
-func NewListService(options config.Options) (ListService, error) {
-	listStructure, err := structure.NewListStructure(options)
-	if err != nil {
-		return nil, err
-	}
-	return &list{dbs: listStructure}, nil
+func NewListService(ks *structure.Keyspace) ListService {
+	return &list{dbs: structure.NewListStructureWithKeyspace(ks)}
 }
 
 func (l *list) LPush(ctx context.Context, req *glist.GListLPushRequest) (*glist.GListLPushResponse, error) {
@@ -305,3 +304,153 @@ func (l *list) LTrim(ctx context.Context, req *glist.GListLTrimRequest) (*glist.
 
 	return &glist.GListLTrimResponse{Ok: true}, nil
 }
+
+func (l *list) LInsert(ctx context.Context, req *glist.GListLInsertRequest) (*glist.GListLInsertResponse, error) {
+	pivot, err := fromListValue(req.Pivot)
+	if err != nil {
+		return nil, err
+	}
+	element, err := fromListValue(req.Element)
+	if err != nil {
+		return nil, err
+	}
+	length, err := l.dbs.LInsert(req.Key, req.Before, pivot, element)
+	if err != nil {
+		return nil, err
+	}
+	return &glist.GListLInsertResponse{Length: int32(length)}, nil
+}
+
+func (l *list) LPos(ctx context.Context, req *glist.GListLPosRequest) (*glist.GListLPosResponse, error) {
+	element, err := fromListValue(req.Element)
+	if err != nil {
+		return nil, err
+	}
+	rank := int(req.Rank)
+	if rank == 0 {
+		rank = 1
+	}
+	positions, err := l.dbs.LPos(req.Key, element, rank, int(req.Count), int(req.MaxLen))
+	if err != nil {
+		return nil, err
+	}
+	resp := &glist.GListLPosResponse{Positions: make([]int32, len(positions))}
+	for i, position := range positions {
+		resp.Positions[i] = int32(position)
+	}
+	return resp, nil
+}
+
+func (l *list) LMove(ctx context.Context, req *glist.GListLMoveRequest) (*glist.GListLMoveResponse, error) {
+	from, err := structure.ParseListEnd(req.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := structure.ParseListEnd(req.To)
+	if err != nil {
+		return nil, err
+	}
+	value, err := l.dbs.LMove(req.Source, req.Destination, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &glist.GListLMoveResponse{Element: toListValue(value)}, nil
+}
+
+func (l *list) BLPop(ctx context.Context, req *glist.GListBPopRequest) (*glist.GListBPopResponse, error) {
+	return l.blockingPop(ctx, req, l.dbs.BLPop)
+}
+
+func (l *list) BRPop(ctx context.Context, req *glist.GListBPopRequest) (*glist.GListBPopResponse, error) {
+	return l.blockingPop(ctx, req, l.dbs.BRPop)
+}
+
+// blockingPop waits with pop until it takes a value, the timeout is over or the client cancels ctx
+func (l *list) blockingPop(ctx context.Context, req *glist.GListBPopRequest,
+	pop func(context.Context, time.Duration, ...string) (string, interface{}, error)) (*glist.GListBPopResponse, error) {
+	timeout, err := blockTimeout(req.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	key, value, err := pop(ctx, timeout, req.Keys...)
+	if errors.Is(err, structure.ErrListTimeout) {
+		return &glist.GListBPopResponse{TimedOut: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &glist.GListBPopResponse{Key: key, Element: toListValue(value)}, nil
+}
+
+func (l *list) BLMove(ctx context.Context, req *glist.GListBLMoveRequest) (*glist.GListBLMoveResponse, error) {
+	from, err := structure.ParseListEnd(req.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := structure.ParseListEnd(req.To)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := blockTimeout(req.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	value, err := l.dbs.BLMove(ctx, req.Source, req.Destination, from, to, timeout)
+	if errors.Is(err, structure.ErrListTimeout) {
+		return &glist.GListBLMoveResponse{TimedOut: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &glist.GListBLMoveResponse{Element: toListValue(value)}, nil
+}
+
+// blockTimeout converts a timeout in seconds, 0 for none
+func blockTimeout(seconds float64) (time.Duration, error) {
+	if seconds < 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, structure.ErrInvalidArgs
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// fromListValue returns the value held by a glist value
+func fromListValue(value *glist.Value) (interface{}, error) {
+	switch v := value.GetValue().(type) {
+	case *glist.Value_StringValue:
+		return v.StringValue, nil
+	case *glist.Value_Int32Value:
+		return v.Int32Value, nil
+	case *glist.Value_Int64Value:
+		return v.Int64Value, nil
+	case *glist.Value_Float32Value:
+		return v.Float32Value, nil
+	case *glist.Value_Float64Value:
+		return v.Float64Value, nil
+	case *glist.Value_BoolValue:
+		return v.BoolValue, nil
+	case *glist.Value_BytesValue:
+		return v.BytesValue, nil
+	}
+	return nil, fmt.Errorf("unsupported value type")
+}
+
+// toListValue returns a glist value holding value, an empty one if its type is not supported
+func toListValue(value interface{}) *glist.Value {
+	switch v := value.(type) {
+	case string:
+		return &glist.Value{Value: &glist.Value_StringValue{StringValue: v}}
+	case int32:
+		return &glist.Value{Value: &glist.Value_Int32Value{Int32Value: v}}
+	case int64:
+		return &glist.Value{Value: &glist.Value_Int64Value{Int64Value: v}}
+	case float32:
+		return &glist.Value{Value: &glist.Value_Float32Value{Float32Value: v}}
+	case float64:
+		return &glist.Value{Value: &glist.Value_Float64Value{Float64Value: v}}
+	case bool:
+		return &glist.Value{Value: &glist.Value_BoolValue{BoolValue: v}}
+	case []byte:
+		return &glist.Value{Value: &glist.Value_BytesValue{BytesValue: v}}
+	}
+	return &glist.Value{}
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"github.com/ByteStorage/FlyDB/config"
 	"github.com/ByteStorage/FlyDB/lib/proto/gset"
 	"github.com/ByteStorage/FlyDB/structure"
 )
@@ -21,12 +20,8 @@ func (l *set) CloseDb() error {
 	return l.dbs.Stop()
 }
 
-func NewSetService(options config.Options) (SetService, error) {
-	setStructure, err := structure.NewSetStructure(options)
-	if err != nil {
-		return nil, err
-	}
-	return &set{dbs: setStructure}, nil
+func NewSetService(ks *structure.Keyspace) SetService {
+	return &set{dbs: structure.NewSetStructureWithKeyspace(ks)}
 }
 
 func (s *set) SAdd(ctx context.Context, req *gset.SAddRequest) (*gset.EmptyResponse, error) {
@@ -79,6 +74,14 @@ func (s *set) SMembers(ctx context.Context, req *gset.SMembersRequest) (*gset.SM
 	return &gset.SMembersResponse{Members: members}, nil
 }
 
+func (s *set) SScan(ctx context.Context, req *gset.SScanRequest) (*gset.SScanResponse, error) {
+	cursor, members, err := s.dbs.SScan(req.Key, req.Cursor, req.Match, int(req.Count))
+	if err != nil {
+		return &gset.SScanResponse{}, err
+	}
+	return &gset.SScanResponse{Cursor: cursor, Members: members}, nil
+}
+
 func (s *set) SIsMember(ctx context.Context, req *gset.SIsMemberRequest) (*gset.SIsMemberResponse, error) {
 	isMember, err := s.dbs.SIsMember(req.Key, req.Member)
 	if err != nil {
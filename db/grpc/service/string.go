@@ -23,14 +23,10 @@ func (s *str) CloseDb() error {
 	return s.dbs.Stop()
 }
 
-func NewStringService(options config.Options) (StringService, error) {
-	stringStructure, err := structure.NewStringStructure(options)
-	if err != nil {
-		return nil, err
-	}
+func NewStringService(ks *structure.Keyspace) StringService {
 	return &str{
-		dbs: stringStructure,
-	}, nil
+		dbs: structure.NewStringStructureWithKeyspace(ks),
+	}
 }
 
 func (s *str) NewFlyDBService(ctx context.Context, req *gstring.FlyDBOption) (*gstring.NewFlyDBResponse, error) {
@@ -2,12 +2,9 @@ package service
 
 import (
 	"context"
-	"errors"
-	"math"
 
 	pbany "github.com/golang/protobuf/ptypes/any"
 
-	"github.com/ByteStorage/FlyDB/config"
 	"github.com/ByteStorage/FlyDB/lib/encoding"
 	"github.com/ByteStorage/FlyDB/lib/proto/gzset"
 	"github.com/ByteStorage/FlyDB/structure"
@@ -25,52 +22,78 @@ type zSet struct {
 	gzset.GZSetServiceServer
 }
 
-func NewZSetService(options config.Options) (ZSetService, error) {
-	zSetStructure, err := structure.NewZSetStructure(options)
-	if err != nil {
-		return nil, err
-	}
-	return &zSet{dbs: zSetStructure}, nil
+func NewZSetService(ks *structure.Keyspace) ZSetService {
+	return &zSet{dbs: structure.NewZSetStructureWithKeyspace(ks)}
 }
 
 func (z *zSet) CloseDb() error {
 	return z.dbs.Stop()
 }
 
-func (z *zSet) checkScoreIntIsInt32(score int) bool {
-	return score >= math.MinInt32 && score <= math.MaxInt32
+func (z *zSet) zAddOptions(flags *gzset.ZAddFlags) structure.ZAddOptions {
+	return structure.ZAddOptions{
+		NX: flags.GetNx(),
+		XX: flags.GetXx(),
+		GT: flags.GetGt(),
+		LT: flags.GetLt(),
+		CH: flags.GetCh(),
+	}
 }
 
 func (z *zSet) ZAdd(_ context.Context, request *gzset.ZAddRequest) (*gzset.ZAddResponse, error) {
 	var (
-		err error
+		err    error
+		count  int
+		score  float64
+		exists bool
+		member = request.GetMember()
 	)
 
-	if err = z.dbs.ZAdd(request.Key, int(request.Member.Score), request.Member.Member,
-		string(request.Member.Value.Value)); err != nil {
+	if request.Flags.GetIncr() {
+		if score, exists, err = z.dbs.ZAddIncr(request.Key, z.zAddOptions(request.Flags), member.GetScore(),
+			member.GetMember(), string(member.GetValue().GetValue())); err != nil {
+			return &gzset.ZAddResponse{Success: false}, err
+		}
+		return &gzset.ZAddResponse{Success: true, Count: z.boolToCount(exists), Score: score, Exists: exists}, nil
+	}
+
+	if count, err = z.dbs.ZAddWithOptions(request.Key, z.zAddOptions(request.Flags),
+		structure.NewZSetValue(member.GetScore(), member.GetMember(), string(member.GetValue().GetValue()))); err != nil {
 		return &gzset.ZAddResponse{Success: false}, err
 	}
 
-	return &gzset.ZAddResponse{Success: true}, nil
+	return &gzset.ZAddResponse{Success: true, Count: int32(count)}, nil
 }
 
 func (z *zSet) ZAdds(_ context.Context, request *gzset.ZAddsRequest) (*gzset.ZAddsResponse, error) {
-	for _, member := range request.Members {
-		var (
-			err error
-		)
+	var (
+		err    error
+		count  int
+		values = make([]structure.ZSetValue, 0, len(request.Members))
+	)
 
-		if !z.checkScoreIntIsInt32(int(member.Score)) {
-			return &gzset.ZAddsResponse{Success: false}, nil
-		}
+	// incr takes a single member, see ZAdd
+	if request.Flags.GetIncr() {
+		return &gzset.ZAddsResponse{Success: false}, structure.ErrInvalidArgs
+	}
 
-		if err = z.dbs.ZAdd(request.Key, int(member.Score), member.Member, string(member.Value.GetValue())); err != nil {
-			return &gzset.ZAddsResponse{Success: false}, err
-		}
+	for _, member := range request.Members {
+		values = append(values, structure.NewZSetValue(member.GetScore(), member.GetMember(),
+			string(member.GetValue().GetValue())))
+	}
 
+	if count, err = z.dbs.ZAddWithOptions(request.Key, z.zAddOptions(request.Flags), values...); err != nil {
+		return &gzset.ZAddsResponse{Success: false}, err
 	}
 
-	return &gzset.ZAddsResponse{Success: true}, nil
+	return &gzset.ZAddsResponse{Success: true, Count: int32(count)}, nil
+}
+
+func (z *zSet) boolToCount(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
 }
 
 func (z *zSet) ZRem(_ context.Context, request *gzset.ZRemRequest) (*gzset.ZRemResponse, error) {
@@ -92,14 +115,14 @@ func (z *zSet) ZRems(_ context.Context, request *gzset.ZRemsRequest) (*gzset.ZRe
 func (z *zSet) ZScore(_ context.Context, request *gzset.ZScoreRequest) (*gzset.ZScoreResponse, error) {
 	var (
 		err   error
-		score int
+		score float64
 	)
 
 	if score, err = z.dbs.ZScore(request.Key, request.Member); err != nil {
 		return &gzset.ZScoreResponse{}, err
 	}
 
-	return &gzset.ZScoreResponse{Score: int32(score), Exists: true}, nil
+	return &gzset.ZScoreResponse{Score: score, Exists: true}, nil
 }
 
 func (z *zSet) ZRank(_ context.Context, request *gzset.ZRankRequest) (*gzset.ZRankResponse, error) {
@@ -198,7 +221,7 @@ func (z *zSet) ZCount(_ context.Context, request *gzset.ZCountRequest) (*gzset.Z
 		count int
 	)
 
-	if count, err = z.dbs.ZCount(request.Key, int(request.Min), int(request.Max)); err != nil {
+	if count, err = z.dbs.ZCount(request.Key, request.Min, request.Max); err != nil {
 		return &gzset.ZCountResponse{}, err
 	}
 
@@ -239,10 +262,10 @@ func (z *zSet) ZCard(_ context.Context, request *gzset.ZCardRequest) (*gzset.ZCa
 func (z *zSet) ZIncrBy(_ context.Context, request *gzset.ZIncrByRequest) (*gzset.ZIncrByResponse, error) {
 	var (
 		err      error
-		newScore int
+		newScore float64
 	)
 
-	if err = z.dbs.ZIncrBy(request.Key, request.Member, int(request.IncBy)); err != nil {
+	if err = z.dbs.ZIncrBy(request.Key, request.Member, request.IncBy); err != nil {
 		return &gzset.ZIncrByResponse{}, err
 	}
 
@@ -250,9 +273,309 @@ func (z *zSet) ZIncrBy(_ context.Context, request *gzset.ZIncrByRequest) (*gzset
 		return &gzset.ZIncrByResponse{}, err
 	}
 
-	if !z.checkScoreIntIsInt32(newScore) {
-		return &gzset.ZIncrByResponse{NewScore: 0, Exists: true}, errors.New("new score is not int32")
+	return &gzset.ZIncrByResponse{NewScore: newScore, Exists: true}, nil
+}
+
+func (z *zSet) ZScan(_ context.Context, request *gzset.ZScanRequest) (*gzset.ZScanResponse, error) {
+	var (
+		err             error
+		cursor          string
+		members         []structure.ZSetValue
+		responseMembers []*gzset.ZSetValue
+	)
+
+	if cursor, members, err = z.dbs.ZScan(request.Key, request.Cursor, request.Match, int(request.Count)); err != nil {
+		return &gzset.ZScanResponse{}, err
+	}
+
+	if responseMembers, err = z.structureZSetValueList2GZSetValueList(members); err != nil {
+		return &gzset.ZScanResponse{}, err
+	}
+
+	return &gzset.ZScanResponse{Cursor: cursor, Members: responseMembers}, nil
+}
+
+func (z *zSet) limit(limit *gzset.Limit) (offset, count int) {
+	if limit == nil {
+		return 0, -1
+	}
+	return int(limit.Offset), int(limit.Count)
+}
+
+func (z *zSet) scoreBounds(min, max string) (structure.ScoreBound, structure.ScoreBound, error) {
+	minBound, err := structure.ParseScoreBound(min)
+	if err != nil {
+		return structure.ScoreBound{}, structure.ScoreBound{}, err
+	}
+	maxBound, err := structure.ParseScoreBound(max)
+	return minBound, maxBound, err
+}
+
+func (z *zSet) ZRangeByScore(_ context.Context, request *gzset.ZRangeByScoreRequest) (*gzset.ZRangeByScoreResponse, error) {
+	var (
+		err             error
+		min, max        structure.ScoreBound
+		members         []structure.ZSetValue
+		responseMembers []*gzset.ZSetValue
+	)
+
+	if min, max, err = z.scoreBounds(request.Min, request.Max); err != nil {
+		return &gzset.ZRangeByScoreResponse{}, err
+	}
+
+	offset, count := z.limit(request.Limit)
+	if members, err = z.dbs.ZRangeByScore(request.Key, min, max, offset, count); err != nil {
+		return &gzset.ZRangeByScoreResponse{}, err
+	}
+
+	if responseMembers, err = z.structureZSetValueList2GZSetValueList(members); err != nil {
+		return &gzset.ZRangeByScoreResponse{}, err
+	}
+
+	return &gzset.ZRangeByScoreResponse{Members: responseMembers}, nil
+}
+
+// ZRevRangeByScore takes the bounds in the order of ZRangeByScore, min then max
+func (z *zSet) ZRevRangeByScore(_ context.Context, request *gzset.ZRangeByScoreRequest) (*gzset.ZRangeByScoreResponse, error) {
+	var (
+		err             error
+		min, max        structure.ScoreBound
+		members         []structure.ZSetValue
+		responseMembers []*gzset.ZSetValue
+	)
+
+	if min, max, err = z.scoreBounds(request.Min, request.Max); err != nil {
+		return &gzset.ZRangeByScoreResponse{}, err
+	}
+
+	offset, count := z.limit(request.Limit)
+	if members, err = z.dbs.ZRevRangeByScore(request.Key, max, min, offset, count); err != nil {
+		return &gzset.ZRangeByScoreResponse{}, err
+	}
+
+	if responseMembers, err = z.structureZSetValueList2GZSetValueList(members); err != nil {
+		return &gzset.ZRangeByScoreResponse{}, err
+	}
+
+	return &gzset.ZRangeByScoreResponse{Members: responseMembers}, nil
+}
+
+func (z *zSet) ZRangeByLex(_ context.Context, request *gzset.ZRangeByLexRequest) (*gzset.ZRangeByLexResponse, error) {
+	var (
+		err             error
+		min, max        structure.LexBound
+		members         []structure.ZSetValue
+		responseMembers []*gzset.ZSetValue
+	)
+
+	if min, err = structure.ParseLexBound(request.Min); err != nil {
+		return &gzset.ZRangeByLexResponse{}, err
+	}
+	if max, err = structure.ParseLexBound(request.Max); err != nil {
+		return &gzset.ZRangeByLexResponse{}, err
+	}
+
+	offset, count := z.limit(request.Limit)
+	if members, err = z.dbs.ZRangeByLex(request.Key, min, max, offset, count); err != nil {
+		return &gzset.ZRangeByLexResponse{}, err
+	}
+
+	if responseMembers, err = z.structureZSetValueList2GZSetValueList(members); err != nil {
+		return &gzset.ZRangeByLexResponse{}, err
+	}
+
+	return &gzset.ZRangeByLexResponse{Members: responseMembers}, nil
+}
+
+func (z *zSet) ZPopMin(_ context.Context, request *gzset.ZPopRequest) (*gzset.ZPopResponse, error) {
+	var (
+		err             error
+		members         []structure.ZSetValue
+		responseMembers []*gzset.ZSetValue
+	)
+
+	if members, err = z.dbs.ZPopMin(request.Key, int(request.Count)); err != nil {
+		return &gzset.ZPopResponse{}, err
+	}
+
+	if responseMembers, err = z.structureZSetValueList2GZSetValueList(members); err != nil {
+		return &gzset.ZPopResponse{}, err
+	}
+
+	return &gzset.ZPopResponse{Members: responseMembers}, nil
+}
+
+func (z *zSet) ZPopMax(_ context.Context, request *gzset.ZPopRequest) (*gzset.ZPopResponse, error) {
+	var (
+		err             error
+		members         []structure.ZSetValue
+		responseMembers []*gzset.ZSetValue
+	)
+
+	if members, err = z.dbs.ZPopMax(request.Key, int(request.Count)); err != nil {
+		return &gzset.ZPopResponse{}, err
+	}
+
+	if responseMembers, err = z.structureZSetValueList2GZSetValueList(members); err != nil {
+		return &gzset.ZPopResponse{}, err
+	}
+
+	return &gzset.ZPopResponse{Members: responseMembers}, nil
+}
+
+func (z *zSet) ZRemRangeByScore(_ context.Context, request *gzset.ZRemRangeByScoreRequest) (*gzset.ZRemRangeResponse, error) {
+	var (
+		err      error
+		removed  int
+		min, max structure.ScoreBound
+	)
+
+	if min, max, err = z.scoreBounds(request.Min, request.Max); err != nil {
+		return &gzset.ZRemRangeResponse{}, err
+	}
+
+	if removed, err = z.dbs.ZRemRangeByScore(request.Key, min, max); err != nil {
+		return &gzset.ZRemRangeResponse{}, err
+	}
+
+	return &gzset.ZRemRangeResponse{Removed: int64(removed)}, nil
+}
+
+func (z *zSet) ZRemRangeByRank(_ context.Context, request *gzset.ZRemRangeByRankRequest) (*gzset.ZRemRangeResponse, error) {
+	var (
+		err     error
+		removed int
+	)
+
+	if removed, err = z.dbs.ZRemRangeByRank(request.Key, int(request.Start), int(request.Stop)); err != nil {
+		return &gzset.ZRemRangeResponse{}, err
+	}
+
+	return &gzset.ZRemRangeResponse{Removed: int64(removed)}, nil
+}
+
+func (z *zSet) ZMScore(_ context.Context, request *gzset.ZMScoreRequest) (*gzset.ZMScoreResponse, error) {
+	var (
+		err    error
+		scores []*float64
+	)
+
+	if scores, err = z.dbs.ZMScore(request.Key, request.Members...); err != nil {
+		return &gzset.ZMScoreResponse{}, err
+	}
+
+	response := &gzset.ZMScoreResponse{
+		Scores: make([]float64, len(scores)),
+		Exists: make([]bool, len(scores)),
+	}
+	for i, score := range scores {
+		if score != nil {
+			response.Scores[i], response.Exists[i] = *score, true
+		}
+	}
+	return response, nil
+}
+
+func (z *zSet) combineOptions(weights []float64, aggregate string) (structure.ZCombineOptions, error) {
+	var (
+		err  error
+		opts = structure.ZCombineOptions{}
+	)
+
+	if len(weights) > 0 {
+		opts.Weights = weights
+	}
+	opts.Aggregate, err = structure.ParseAggregate(aggregate)
+	return opts, err
+}
+
+// combine runs ZUnion, ZInter or ZDiff, which takes neither weights nor an aggregate
+func (z *zSet) combine(op string, keys []string, weights []float64, aggregate string) ([]structure.ZSetValue, error) {
+	if op == "diff" {
+		if len(weights) > 0 || aggregate != "" {
+			return nil, structure.ErrInvalidArgs
+		}
+		return z.dbs.ZDiff(keys...)
+	}
+
+	opts, err := z.combineOptions(weights, aggregate)
+	if err != nil {
+		return nil, err
+	}
+	if op == "union" {
+		return z.dbs.ZUnion(keys, opts)
+	}
+	return z.dbs.ZInter(keys, opts)
+}
+
+func (z *zSet) combineResponse(op string, request *gzset.ZCombineRequest) (*gzset.ZCombineResponse, error) {
+	var (
+		err             error
+		members         []structure.ZSetValue
+		responseMembers []*gzset.ZSetValue
+	)
+
+	if members, err = z.combine(op, request.Keys, request.Weights, request.Aggregate); err != nil {
+		return &gzset.ZCombineResponse{}, err
+	}
+
+	if responseMembers, err = z.structureZSetValueList2GZSetValueList(members); err != nil {
+		return &gzset.ZCombineResponse{}, err
+	}
+
+	return &gzset.ZCombineResponse{Members: responseMembers}, nil
+}
+
+func (z *zSet) ZUnion(_ context.Context, request *gzset.ZCombineRequest) (*gzset.ZCombineResponse, error) {
+	return z.combineResponse("union", request)
+}
+
+func (z *zSet) ZInter(_ context.Context, request *gzset.ZCombineRequest) (*gzset.ZCombineResponse, error) {
+	return z.combineResponse("inter", request)
+}
+
+func (z *zSet) ZDiff(_ context.Context, request *gzset.ZCombineRequest) (*gzset.ZCombineResponse, error) {
+	return z.combineResponse("diff", request)
+}
+
+func (z *zSet) combineStore(op string, request *gzset.ZCombineStoreRequest) (*gzset.ZCombineStoreResponse, error) {
+	var (
+		err   error
+		opts  structure.ZCombineOptions
+		count int
+	)
+
+	switch op {
+	case "diff":
+		if len(request.Weights) > 0 || request.Aggregate != "" {
+			return &gzset.ZCombineStoreResponse{}, structure.ErrInvalidArgs
+		}
+		count, err = z.dbs.ZDiffStore(request.Destination, request.Keys...)
+	case "union", "inter":
+		if opts, err = z.combineOptions(request.Weights, request.Aggregate); err != nil {
+			return &gzset.ZCombineStoreResponse{}, err
+		}
+		if op == "union" {
+			count, err = z.dbs.ZUnionStore(request.Destination, request.Keys, opts)
+		} else {
+			count, err = z.dbs.ZInterStore(request.Destination, request.Keys, opts)
+		}
+	}
+	if err != nil {
+		return &gzset.ZCombineStoreResponse{}, err
 	}
 
-	return &gzset.ZIncrByResponse{NewScore: int32(newScore), Exists: true}, nil
+	return &gzset.ZCombineStoreResponse{Count: int64(count)}, nil
+}
+
+func (z *zSet) ZUnionStore(_ context.Context, request *gzset.ZCombineStoreRequest) (*gzset.ZCombineStoreResponse, error) {
+	return z.combineStore("union", request)
+}
+
+func (z *zSet) ZInterStore(_ context.Context, request *gzset.ZCombineStoreRequest) (*gzset.ZCombineStoreResponse, error) {
+	return z.combineStore("inter", request)
+}
+
+func (z *zSet) ZDiffStore(_ context.Context, request *gzset.ZCombineStoreRequest) (*gzset.ZCombineStoreResponse, error) {
+	return z.combineStore("diff", request)
 }
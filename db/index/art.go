@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"github.com/ByteStorage/FlyDB/db/data"
 	art "github.com/plar/go-adaptive-radix-tree"
+	"math"
 	"sort"
 	"sync"
 )
 
+var _ RangeIndexer = (*AdaptiveRadixTree)(nil)
+
 // Adaptive Radix Tree Index
 // The following link is the ART library written by go.
 // If you need to know more about it, please go to the corresponding warehouse.
@@ -62,6 +65,81 @@ func (artree *AdaptiveRadixTree) Iterator(reverse bool) Iterator {
 	return NewARTreeIterator(artree.tree, reverse)
 }
 
+// RangeIterator returns an iterator over the keys from start to end that reads the tree in batches
+func (artree *AdaptiveRadixTree) RangeIterator(reverse bool, start, end []byte) Iterator {
+	return newRangeIterator(reverse, start, end, artRangeFetch(artree.tree, artree.lock, reverse, start, end))
+}
+
+// artRangeFetch reads a range of a tree for a range iterator, see rangeFetch.
+// The tree can not iterate backwards, so a reverse iterator reads the whole rest
+// of its range, from start to where it is, in one batch.
+func artRangeFetch(tree art.Tree, lock *sync.RWMutex, reverse bool, start, end []byte) rangeFetch {
+	return func(from []byte, exclusive bool, n int) ([]*Item, bool) {
+		lock.RLock()
+		defer lock.RUnlock()
+		if !reverse {
+			return artAscend(tree, from, exclusive, end, n)
+		}
+
+		var items []*Item
+		if from == nil {
+			items, _ = artAscend(tree, start, false, nil, math.MaxInt)
+		} else {
+			items, _ = artAscend(tree, start, false, from, math.MaxInt)
+			if value, found := tree.Search(from); found && !exclusive && (start == nil || bytes.Compare(from, start) >= 0) {
+				items = append(items, &Item{key: from, pst: value.(*data.LogRecordPst)})
+			}
+		}
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+		return items, false
+	}
+}
+
+// artAscend returns up to n entries of a tree in key order, from the first key at or past from,
+// or strictly past it if exclusive, and before end, and whether there may be more after them.
+// The tree can not seek, so the keys past from are found through the prefixes that follow from:
+// the keys that start with from, then those that start with from[:i] and a larger byte at i,
+// for i from the last byte of from to the first.
+func artAscend(tree art.Tree, from []byte, exclusive bool, end []byte, n int) ([]*Item, bool) {
+	items := make([]*Item, 0)
+	full := false
+	past := false
+	visit := func(prefix []byte) art.Callback {
+		return func(node art.Node) bool {
+			key := node.Key()
+			if node.Kind() != art.Leaf || !bytes.HasPrefix(key, prefix) || exclusive && bytes.Equal(key, from) {
+				return true
+			}
+			if end != nil && bytes.Compare(key, end) >= 0 {
+				past = true
+				return false
+			}
+			items = append(items, &Item{key: key, pst: node.Value().(*data.LogRecordPst)})
+			full = len(items) == n
+			return !full
+		}
+	}
+
+	if from == nil {
+		tree.ForEach(visit(nil))
+		return items, full
+	}
+	tree.ForEachPrefix(from, visit(from))
+	prefix := append([]byte(nil), from...)
+	for i := len(from) - 1; i >= 0 && !full && !past; i-- {
+		for b := int(from[i]) + 1; b <= 0xff && !full && !past; b++ {
+			prefix[i] = byte(b)
+			if end != nil && bytes.Compare(prefix[:i+1], end) >= 0 {
+				return items, false
+			}
+			tree.ForEachPrefix(prefix[:i+1], visit(prefix[:i+1]))
+		}
+	}
+	return items, full
+}
+
 // ART Index iterator
 type ARTreeIterator struct {
 	currIndex int     // The subscript position of the current traversal
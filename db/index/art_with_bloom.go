@@ -5,16 +5,42 @@ import (
 	"github.com/ByteStorage/FlyDB/lib/bloom"
 	art "github.com/plar/go-adaptive-radix-tree"
 	"sync"
+	"sync/atomic"
+)
+
+var (
+	_ FilterIndexer = (*AdaptiveRadixTreeWithBloom)(nil)
+	_ RangeIndexer  = (*AdaptiveRadixTreeWithBloom)(nil)
+)
+
+const (
+	// DefaultBloomCapacity is the capacity of the first layer of the bloom filter
+	DefaultBloomCapacity = 1024
+
+	// DefaultBloomFpRate is the target false positive rate of the bloom filter
+	DefaultBloomFpRate = 0.01
+
+	// minDeletesBeforeRebuild avoids rebuilding the filter of a small index over and over
+	minDeletesBeforeRebuild = 1024
 )
 
 // AdaptiveRadixTreeWithBloom Adaptive Radix Tree Index
 // The following link is the ART library written by go.
 // If you need to know more about it, please go to the corresponding warehouse.
 // https://github.com/plar/go-adaptive-radix-tree
+//
+// Lookups are screened by a scalable bloom filter, which grows with the keyspace.
+// Deleted keys stay in the filter until it is rebuilt from the tree,
+// which happens once there have been more deletes than live keys.
 type AdaptiveRadixTreeWithBloom struct {
-	tree   art.Tree
-	lock   *sync.RWMutex
-	filter *bloom.Filter
+	tree    art.Tree
+	lock    *sync.RWMutex
+	filter  *bloom.ScalableFilter
+	deletes int // Deletes since the filter was last built
+
+	// Lookups of missing keys, rejected by the filter or not
+	filterRejects  uint64
+	falsePositives uint64
 }
 
 // NewARTWithBloom Initializes the adaptive radix tree index
@@ -22,38 +48,55 @@ func NewARTWithBloom() *AdaptiveRadixTreeWithBloom {
 	return &AdaptiveRadixTreeWithBloom{
 		tree:   art.New(),
 		lock:   new(sync.RWMutex),
-		filter: bloom.NewBloomFilter(1000, 0.01),
+		filter: bloom.NewScalableFilter(DefaultBloomCapacity, DefaultBloomFpRate),
 	}
 }
 
 func (artree *AdaptiveRadixTreeWithBloom) Put(key []byte, pst *data.LogRecordPst) bool {
+	artree.lock.Lock()
+	defer artree.lock.Unlock()
+	if _, updated := artree.tree.Insert(key, pst); !updated {
+		artree.filter.Add(key)
+	}
+	return true
+}
+
+// PutUnfiltered stores the key without adding it to the filter
+func (artree *AdaptiveRadixTreeWithBloom) PutUnfiltered(key []byte, pst *data.LogRecordPst) bool {
 	artree.lock.Lock()
 	defer artree.lock.Unlock()
 	artree.tree.Insert(key, pst)
-	artree.filter.Add(key)
 	return true
 }
 
 func (artree *AdaptiveRadixTreeWithBloom) Get(key []byte) *data.LogRecordPst {
+	artree.lock.RLock()
+	defer artree.lock.RUnlock()
 	if !artree.filter.MayContainItem(key) {
+		atomic.AddUint64(&artree.filterRejects, 1)
 		return nil
 	}
-	artree.lock.RLock()
-	defer artree.lock.RUnlock()
 	value, found := artree.tree.Search(key)
 	if !found {
+		atomic.AddUint64(&artree.falsePositives, 1)
 		return nil
 	}
 	return value.(*data.LogRecordPst)
 }
 
 func (artree *AdaptiveRadixTreeWithBloom) Delete(key []byte) bool {
+	artree.lock.Lock()
+	defer artree.lock.Unlock()
 	if !artree.filter.MayContainItem(key) {
 		return false
 	}
-	artree.lock.Lock()
-	defer artree.lock.Unlock()
 	_, deleted := artree.tree.Delete(key)
+	if deleted {
+		artree.deletes++
+		if artree.deletes >= minDeletesBeforeRebuild && artree.deletes > artree.tree.Size() {
+			artree.rebuildFilter()
+		}
+	}
 	return deleted
 }
 
@@ -69,3 +112,50 @@ func (artree *AdaptiveRadixTreeWithBloom) Iterator(reverse bool) Iterator {
 	defer artree.lock.RUnlock()
 	return NewARTreeIterator(artree.tree, reverse)
 }
+
+// RangeIterator returns an iterator over the keys from start to end that reads the tree in batches
+func (artree *AdaptiveRadixTreeWithBloom) RangeIterator(reverse bool, start, end []byte) Iterator {
+	return newRangeIterator(reverse, start, end, artRangeFetch(artree.tree, artree.lock, reverse, start, end))
+}
+
+// Filter returns the bloom filter of the index
+func (artree *AdaptiveRadixTreeWithBloom) Filter() *bloom.ScalableFilter {
+	artree.lock.RLock()
+	defer artree.lock.RUnlock()
+	return artree.filter
+}
+
+// SetFilter replaces the bloom filter, e.g. with one loaded from disk
+func (artree *AdaptiveRadixTreeWithBloom) SetFilter(filter *bloom.ScalableFilter) {
+	artree.lock.Lock()
+	defer artree.lock.Unlock()
+	artree.filter = filter
+	artree.deletes = 0
+}
+
+// FalsePositiveRate returns the observed share of lookups of missing keys
+// that the filter failed to reject
+func (artree *AdaptiveRadixTreeWithBloom) FalsePositiveRate() float64 {
+	rejects := atomic.LoadUint64(&artree.filterRejects)
+	falsePositives := atomic.LoadUint64(&artree.falsePositives)
+	if rejects+falsePositives == 0 {
+		return 0
+	}
+	return float64(falsePositives) / float64(rejects+falsePositives)
+}
+
+// rebuildFilter builds a new filter sized for the keys currently in the tree
+// Hold a mutex before accessing this method
+func (artree *AdaptiveRadixTreeWithBloom) rebuildFilter() {
+	capacity := uint32(artree.tree.Size())
+	if capacity < DefaultBloomCapacity {
+		capacity = DefaultBloomCapacity
+	}
+	filter := bloom.NewScalableFilter(capacity, DefaultBloomFpRate)
+	artree.tree.ForEach(func(node art.Node) bool {
+		filter.Add(node.Key())
+		return true
+	})
+	artree.filter = filter
+	artree.deletes = 0
+}
@@ -0,0 +1,59 @@
+package index
+
+import (
+	"fmt"
+	"github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/lib/bloom"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdaptiveRadixTreeWithBloom_Grow(t *testing.T) {
+	artree := NewARTWithBloom()
+	for i := 0; i < 20000; i++ {
+		artree.Put([]byte(fmt.Sprintf("key-%d", i)), &data.LogRecordPst{Fid: 1, Offset: int64(i)})
+	}
+	assert.Equal(t, 20000, artree.Size())
+	assert.Greater(t, artree.Filter().Layers(), 1)
+
+	for i := 0; i < 20000; i++ {
+		assert.NotNil(t, artree.Get([]byte(fmt.Sprintf("key-%d", i))))
+	}
+	for i := 0; i < 20000; i++ {
+		assert.Nil(t, artree.Get([]byte(fmt.Sprintf("missing-%d", i))))
+	}
+	assert.Less(t, artree.FalsePositiveRate(), 0.05)
+}
+
+func TestAdaptiveRadixTreeWithBloom_Delete(t *testing.T) {
+	artree := NewARTWithBloom()
+	assert.False(t, artree.Delete([]byte("key-not-exist")))
+
+	for i := 0; i < 4000; i++ {
+		artree.Put([]byte(fmt.Sprintf("key-%d", i)), &data.LogRecordPst{Fid: 1, Offset: int64(i)})
+	}
+	for i := 0; i < 3000; i++ {
+		assert.True(t, artree.Delete([]byte(fmt.Sprintf("key-%d", i))))
+	}
+	assert.Equal(t, 1000, artree.Size())
+
+	// The filter was rebuilt once more keys had been deleted than were left
+	assert.Less(t, artree.Filter().Count(), uint32(4000))
+	for i := 3000; i < 4000; i++ {
+		assert.NotNil(t, artree.Get([]byte(fmt.Sprintf("key-%d", i))))
+	}
+}
+
+func TestAdaptiveRadixTreeWithBloom_SetFilter(t *testing.T) {
+	filter := bloom.NewScalableFilter(DefaultBloomCapacity, DefaultBloomFpRate)
+	filter.Add([]byte("key-1"))
+
+	artree := NewARTWithBloom()
+	artree.SetFilter(filter)
+	artree.PutUnfiltered([]byte("key-1"), &data.LogRecordPst{Fid: 1, Offset: 12})
+	artree.PutUnfiltered([]byte("key-2"), &data.LogRecordPst{Fid: 1, Offset: 24})
+
+	assert.NotNil(t, artree.Get([]byte("key-1")))
+	// Not in the filter, so the lookup is rejected
+	assert.Nil(t, artree.Get([]byte("key-2")))
+}
@@ -1,17 +1,24 @@
 package index
 
 import (
+	"encoding/binary"
 	"github.com/ByteStorage/FlyDB/db/data"
 	"go.etcd.io/bbolt"
 	"path/filepath"
 )
 
-var _ Indexer = (*BPlusTree)(nil)
+var _ PersistentIndexer = (*BPlusTree)(nil)
 
 const bPlusTreeIndexFileName = "bptree-index"
 
 var indexBucketName = []byte("flydb-buckte-index")
 
+// The meta bucket holds the checkpoint of the index
+var (
+	metaBucketName = []byte("flydb-bucket-meta")
+	checkpointKey  = []byte("checkpoint")
+)
+
 // BPlusTree B+ Tree Index
 // go.etcd.io/bbolt  This is the library that encapsulates b+ tree
 // Again, if you need to look at the source code for b+ trees,
@@ -36,6 +43,9 @@ func NewBPlusTree(dirPath string) *BPlusTree {
 	// After creating a bucket, a bucket is returned.
 	// The returned bucket can be used to Put, Get and other methods.
 	if err := bptree.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(metaBucketName); err != nil {
+			return err
+		}
 		_, err := tx.CreateBucketIfNotExists(indexBucketName)
 		return err
 	}); err != nil {
@@ -126,6 +136,133 @@ func (bptree *BPlusTree) Iterator(reverse bool) Iterator {
 	return newBptreeIterator(bptree.tree, reverse)
 }
 
+// LoadCheckpoint reads the checkpoint saved with the index, nil if there is none
+func (bptree *BPlusTree) LoadCheckpoint() (*Checkpoint, error) {
+	var cp *Checkpoint
+	err := bptree.tree.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(metaBucketName).Get(checkpointKey)
+		if len(value) != 0 {
+			cp = decodeCheckpoint(value)
+		}
+		return nil
+	})
+	return cp, err
+}
+
+// SaveCheckpoint saves the checkpoint of the index
+func (bptree *BPlusTree) SaveCheckpoint(cp *Checkpoint) error {
+	return bptree.tree.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(metaBucketName).Put(checkpointKey, encodeCheckpoint(cp))
+	})
+}
+
+// Update runs fn inside a single bbolt transaction.
+// If reset is true, every entry is dropped first.
+// The changes made through the Indexer passed to fn,
+// together with the checkpoint it returns, are committed atomically,
+// and none of them are if fn returns an error.
+func (bptree *BPlusTree) Update(reset bool, fn func(indexer Indexer) (*Checkpoint, error)) error {
+	return bptree.tree.Update(func(tx *bbolt.Tx) error {
+		if reset {
+			if err := tx.DeleteBucket(indexBucketName); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucket(indexBucketName); err != nil {
+				return err
+			}
+		}
+		cp, err := fn(&bptreeTx{tx: tx})
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(metaBucketName).Put(checkpointKey, encodeCheckpoint(cp))
+	})
+}
+
+// Close closes the underlying bbolt file
+func (bptree *BPlusTree) Close() error {
+	return bptree.tree.Close()
+}
+
+// encodeCheckpoint encodes a checkpoint
+func encodeCheckpoint(cp *Checkpoint) []byte {
+	buf := make([]byte, binary.MaxVarintLen32*2+binary.MaxVarintLen64*2)
+	var index = 0
+	index += binary.PutUvarint(buf[index:], uint64(cp.Fid))
+	index += binary.PutVarint(buf[index:], cp.Offset)
+	index += binary.PutUvarint(buf[index:], cp.SeqNo)
+	index += binary.PutUvarint(buf[index:], uint64(cp.MergeFid))
+	return buf[:index]
+}
+
+// decodeCheckpoint decodes a checkpoint
+func decodeCheckpoint(buf []byte) *Checkpoint {
+	var index = 0
+	fid, n := binary.Uvarint(buf[index:])
+	index += n
+	offset, n := binary.Varint(buf[index:])
+	index += n
+	seqNo, n := binary.Uvarint(buf[index:])
+	index += n
+	mergeFid, _ := binary.Uvarint(buf[index:])
+	return &Checkpoint{
+		Fid:      uint32(fid),
+		Offset:   offset,
+		SeqNo:    seqNo,
+		MergeFid: uint32(mergeFid),
+	}
+}
+
+// bptreeTx is an Indexer working inside a bbolt transaction
+type bptreeTx struct {
+	tx *bbolt.Tx
+}
+
+func (btx *bptreeTx) Put(key []byte, pst *data.LogRecordPst) bool {
+	if err := btx.tx.Bucket(indexBucketName).Put(key, data.EncodeLogRecordPst(pst)); err != nil {
+		panic(ErrPutValueFailed)
+	}
+	return true
+}
+
+func (btx *bptreeTx) Get(key []byte) *data.LogRecordPst {
+	value := btx.tx.Bucket(indexBucketName).Get(key)
+	if len(value) == 0 {
+		return nil
+	}
+	return data.DecodeLogRecordPst(value)
+}
+
+func (btx *bptreeTx) Delete(key []byte) bool {
+	bucket := btx.tx.Bucket(indexBucketName)
+	if value := bucket.Get(key); len(value) == 0 {
+		return false
+	}
+	if err := bucket.Delete(key); err != nil {
+		panic(ErrDeleteValueFailed)
+	}
+	return true
+}
+
+// Size counts the keys with a cursor, since bucket stats miss uncommitted changes
+func (btx *bptreeTx) Size() int {
+	var size int
+	cursor := btx.tx.Bucket(indexBucketName).Cursor()
+	for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+		size++
+	}
+	return size
+}
+
+func (btx *bptreeTx) Iterator(reverse bool) Iterator {
+	b := &bptreeIterator{
+		cursor:  btx.tx.Bucket(indexBucketName).Cursor(),
+		reverse: reverse,
+	}
+	b.Rewind()
+	return b
+}
+
 type bptreeIterator struct {
 	tx      *bbolt.Tx
 	cursor  *bbolt.Cursor
@@ -198,8 +335,14 @@ func (b *bptreeIterator) Valid() bool {
 // The return value is a byte array
 // If the iterator is not positioned at a valid key, nil is returned
 // The Key method does not return an error
+// The key is copied, because bbolt only keeps it valid until the transaction ends
 func (b *bptreeIterator) Key() []byte {
-	return b.currKey
+	if b.currKey == nil {
+		return nil
+	}
+	key := make([]byte, len(b.currKey))
+	copy(key, b.currKey)
+	return key
 }
 
 // Value Gets the value at the current iterator position
@@ -212,8 +355,12 @@ func (b *bptreeIterator) Value() *data.LogRecordPst {
 
 // Close Closes the iterator
 // The Close method does not return a value or an error
-// The Close method rolls back the transaction
+// The Close method rolls back the transaction,
+// unless the iterator was opened inside a transaction it does not own
 func (b bptreeIterator) Close() {
+	if b.tx == nil {
+		return
+	}
 	if err := b.tx.Rollback(); err != nil {
 		panic(ErrRollbackTxFailed)
 	}
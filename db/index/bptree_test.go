@@ -0,0 +1,80 @@
+package index
+
+import (
+	"errors"
+	"github.com/ByteStorage/FlyDB/db/data"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestBPlusTree_PutGetDelete(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-bptree")
+	defer os.RemoveAll(dir)
+	tree := NewBPlusTree(dir)
+	defer tree.Close()
+
+	assert.True(t, tree.Put([]byte("a"), &data.LogRecordPst{Fid: 1, Offset: 100}))
+	assert.True(t, tree.Put([]byte("b"), &data.LogRecordPst{Fid: 1, Offset: 200}))
+	pst := tree.Get([]byte("a"))
+	assert.Equal(t, uint32(1), pst.Fid)
+	assert.Equal(t, int64(100), pst.Offset)
+	assert.Equal(t, 2, tree.Size())
+
+	assert.True(t, tree.Delete([]byte("a")))
+	assert.False(t, tree.Delete([]byte("a")))
+	assert.Nil(t, tree.Get([]byte("a")))
+}
+
+func TestBPlusTree_Checkpoint(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-bptree-checkpoint")
+	defer os.RemoveAll(dir)
+	tree := NewBPlusTree(dir)
+
+	cp, err := tree.LoadCheckpoint()
+	assert.Nil(t, err)
+	assert.Nil(t, cp)
+
+	saved := &Checkpoint{Fid: 3, Offset: 1024, SeqNo: 7, MergeFid: 2}
+	assert.Nil(t, tree.SaveCheckpoint(saved))
+	assert.Nil(t, tree.Close())
+
+	// The checkpoint survives a restart
+	tree = NewBPlusTree(dir)
+	defer tree.Close()
+	cp, err = tree.LoadCheckpoint()
+	assert.Nil(t, err)
+	assert.Equal(t, saved, cp)
+}
+
+func TestBPlusTree_Update(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-bptree-update")
+	defer os.RemoveAll(dir)
+	tree := NewBPlusTree(dir)
+	defer tree.Close()
+
+	tree.Put([]byte("old"), &data.LogRecordPst{Fid: 1, Offset: 100})
+
+	// A failed update leaves nothing behind
+	err := tree.Update(true, func(indexer Indexer) (*Checkpoint, error) {
+		indexer.Put([]byte("new"), &data.LogRecordPst{Fid: 2, Offset: 100})
+		return nil, errors.New("update failed")
+	})
+	assert.NotNil(t, err)
+	assert.NotNil(t, tree.Get([]byte("old")))
+	assert.Nil(t, tree.Get([]byte("new")))
+
+	// A reset replaces every entry and the checkpoint at once
+	err = tree.Update(true, func(indexer Indexer) (*Checkpoint, error) {
+		assert.Nil(t, indexer.Get([]byte("old")))
+		indexer.Put([]byte("new"), &data.LogRecordPst{Fid: 2, Offset: 100})
+		assert.Equal(t, 1, indexer.Size())
+		return &Checkpoint{Fid: 2, Offset: 200}, nil
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, tree.Get([]byte("old")))
+	assert.NotNil(t, tree.Get([]byte("new")))
+	cp, err := tree.LoadCheckpoint()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(200), cp.Offset)
+}
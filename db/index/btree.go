@@ -12,6 +12,8 @@ import (
 	BTree index, which mainly encapsulates Google's btree library
 */
 
+var _ RangeIndexer = (*BTree)(nil)
+
 type BTree struct {
 	// Source code: Not thread-safe for writing, requires locking; reading does not require locking
 	tree *btree.BTree
@@ -68,6 +70,42 @@ func (bt *BTree) Iterator(reverse bool) Iterator {
 	return NewBTreeIterator(bt.tree, reverse)
 }
 
+// RangeIterator returns an iterator over the keys from start to end that reads the tree in batches
+func (bt *BTree) RangeIterator(reverse bool, start, end []byte) Iterator {
+	return newRangeIterator(reverse, start, end, func(from []byte, exclusive bool, n int) ([]*Item, bool) {
+		bt.lock.RLock()
+		defer bt.lock.RUnlock()
+
+		items := make([]*Item, 0, n)
+		visit := func(i btree.Item) bool {
+			item := i.(*Item)
+			if exclusive && bytes.Equal(item.key, from) {
+				return true
+			}
+			// Stop at the far bound of the range
+			if reverse && start != nil && bytes.Compare(item.key, start) < 0 {
+				return false
+			}
+			if !reverse && end != nil && bytes.Compare(item.key, end) >= 0 {
+				return false
+			}
+			items = append(items, item)
+			return len(items) < n
+		}
+		switch {
+		case from == nil && reverse:
+			bt.tree.Descend(visit)
+		case from == nil:
+			bt.tree.Ascend(visit)
+		case reverse:
+			bt.tree.DescendLessOrEqual(&Item{key: from}, visit)
+		default:
+			bt.tree.AscendGreaterOrEqual(&Item{key: from}, visit)
+		}
+		return items, len(items) == n
+	})
+}
+
 // BTreeIterator represents an iterator for BTree index.
 type BtreeIterator struct {
 	currIndex int     // Current index position during iteration
@@ -1,176 +1,225 @@
 package index
 
-//
-//import (
-//	"bytes"
-//	"sort"
-//	"sync"
-//
-//	"github.com/ByteStorage/FlyDB/engine/data"
-//	"github.com/cornelk/hashmap"
-//)
-//
-///*
-//hashmap index, encapsulates the hashmap library of cornelk
-//*/
-//
-//// HashMap struct
-//type HashMap struct {
-//	hashmap *hashmap.Map[string, *data.LogRecordPst]
-//	// To ensure Thread safety
-//	// multi thread writes need to be locked
-//	lock *sync.RWMutex
-//}
-//
-//// NewHashMap create a hashmap index
-//func NewHashMap() *HashMap {
-//	return &HashMap{
-//		hashmap: hashmap.New[string, *data.LogRecordPst](),
-//		lock:    new(sync.RWMutex),
-//	}
-//}
-//
-//// Implement the methods of the index interface
-//// Put stores the data location information of key into the index
-//func (hm *HashMap) Put(key []byte, pst *data.LogRecordPst) bool {
-//	hm.lock.Lock()
-//	defer hm.lock.Unlock()
-//
-//	hm.hashmap.Set(string(key), pst)
-//	return true
-//}
-//
-//// Get gains the data location of the key in the index
-//func (hm *HashMap) Get(key []byte) *data.LogRecordPst {
-//	value, ok := hm.hashmap.Get(string(key))
-//	if !ok {
-//		return nil
-//	}
-//	return value
-//}
-//
-//// Delete deletes data location of one key in index
-//func (hm *HashMap) Delete(key []byte) bool {
-//	hm.lock.Lock()
-//	hm.lock.Unlock()
-//
-//	return hm.hashmap.Del(string(key))
-//}
-//
-//// Size returns the size of the data in index
-//func (hm *HashMap) Size() int {
-//	return hm.hashmap.Len()
-//}
-//
-//// Iterator returns a index Iterator
-//func (hm *HashMap) Iterator(reverse bool) Iterator {
-//	// if the HashMap is empty, returns a default iterator
-//	if hm.hashmap == nil {
-//		return NewDefaultHashMapIterator(reverse)
-//	}
-//	hm.lock.RLock()
-//	defer hm.lock.RUnlock()
-//	// if the HashMap is not empty, returns a iterator
-//	return NewHashMapIterator(hm.hashmap, reverse)
-//}
-//
-//// HashMapIterator struct
-//type HashMapIterator struct {
-//	currIndex int     // The subscript position of the current traversal
-//	reverse   bool    // Whether it is reverse traversal
-//	values    []*Item // Key + Location index information
-//}
-//
-//// create a default HashMap Iterator for the empty HashMap
-//func NewDefaultHashMapIterator(reverse bool) *HashMapIterator {
-//	return &HashMapIterator{
-//		currIndex: 0,
-//		reverse:   reverse,
-//		values:    nil,
-//	}
-//}
-//
-//// create a HashMapIterator
-//func NewHashMapIterator(hm *hashmap.Map[string, *data.LogRecordPst], reverse bool) *HashMapIterator {
-//	// Use values slice to store all data in values
-//	values := make([]*Item, hm.Len())
-//
-//	// count the number of elements in the values slice
-//	var count int = 0
-//	// store all data into an slice values
-//	// We use range() method in the hashmap implement to do this
-//	// define an operator method
-//	saveFunc := func(key string, value *data.LogRecordPst) bool {
-//		count++
-//		item := &Item{
-//			key: []byte(key),
-//			pst: value,
-//		}
-//		values = append(values, item)
-//		return true
-//	}
-//	// call range() method
-//	hm.Range(saveFunc)
-//
-//	// filter out nil values
-//	values = values[count:]
-//
-//	// if reverse needed, reverse the slice
-//	if reverse {
-//		for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
-//			values[i], values[j] = values[j], values[i]
-//		}
-//	}
-//
-//	return &HashMapIterator{
-//		currIndex: 0,
-//		reverse:   reverse,
-//		values:    values,
-//	}
-//}
-//
-//// Rewind goes back to the begining of the Iterator,ie. the index of the first data
-//func (hmIt *HashMapIterator) Rewind() {
-//	hmIt.currIndex = 0
-//}
-//
-//// Seek finds a >= or <= target key according to the incoming key,
-//// and starts traversing from this target key
-//func (hmIt *HashMapIterator) Seek(key []byte) {
-//	if hmIt.reverse {
-//		hmIt.currIndex = sort.Search(len(hmIt.values), func(i int) bool {
-//			return bytes.Compare(hmIt.values[i].key, key) <= 0
-//		})
-//	} else {
-//		hmIt.currIndex = sort.Search(len(hmIt.values), func(i int) bool {
-//			return bytes.Compare(hmIt.values[i].key, key) >= 0
-//		})
-//	}
-//}
-//
-//// Next jumps to the next key
-//func (hmIt *HashMapIterator) Next() {
-//	hmIt.currIndex += 1
-//}
-//
-//// Valid refers to whether it is valid, that is,
-//// whether all keys have been traversed,
-//// used to exit the traverse ==> true->yes false-->no
-//func (hmIt *HashMapIterator) Valid() bool {
-//	return hmIt.currIndex < len(hmIt.values)
-//}
-//
-//// Key returns the key data at the current traversal position
-//func (hmIt *HashMapIterator) Key() []byte {
-//	return hmIt.values[hmIt.currIndex].key
-//}
-//
-//// Value returns the value data of the current traversal position
-//func (hmIt *HashMapIterator) Value() *data.LogRecordPst {
-//	return hmIt.values[hmIt.currIndex].pst
-//}
-//
-//// Close closes the iterator and releases the corresponding resources
-//func (hmIt *HashMapIterator) Close() {
-//	hmIt.values = nil
-//}
+import (
+	"bytes"
+	"container/heap"
+	"github.com/ByteStorage/FlyDB/db/data"
+	"sort"
+	"sync"
+)
+
+var _ Indexer = (*HashMap)(nil)
+
+// DefaultHashMapShards is the number of shards used by NewIndexer
+const DefaultHashMapShards = 64
+
+// HashMap Sharded hash index
+// Keys are partitioned into independently locked shards by their hash,
+// so writers to different shards never wait for each other.
+// It is tuned for Get/Put/Delete; iteration has to sort and merge every shard.
+type HashMap struct {
+	shards []*hashMapShard
+}
+
+type hashMapShard struct {
+	items map[string]*data.LogRecordPst
+	lock  *sync.RWMutex
+}
+
+// NewHashMap Initializes a hash index with the given number of shards
+func NewHashMap(shards int) *HashMap {
+	if shards <= 0 {
+		shards = DefaultHashMapShards
+	}
+	hm := &HashMap{shards: make([]*hashMapShard, shards)}
+	for i := range hm.shards {
+		hm.shards[i] = &hashMapShard{
+			items: make(map[string]*data.LogRecordPst),
+			lock:  new(sync.RWMutex),
+		}
+	}
+	return hm
+}
+
+// shard returns the shard that owns the key, chosen by its FNV-1a hash
+func (hm *HashMap) shard(key []byte) *hashMapShard {
+	var hash uint32 = 2166136261
+	for _, b := range key {
+		hash ^= uint32(b)
+		hash *= 16777619
+	}
+	return hm.shards[hash%uint32(len(hm.shards))]
+}
+
+// Put Inserts a key-value pair into the hash index
+func (hm *HashMap) Put(key []byte, pst *data.LogRecordPst) bool {
+	shard := hm.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	shard.items[string(key)] = pst
+	return true
+}
+
+// Get Gets the value corresponding to the key from the hash index
+func (hm *HashMap) Get(key []byte) *data.LogRecordPst {
+	shard := hm.shard(key)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	return shard.items[string(key)]
+}
+
+// Delete Deletes the key-value pair corresponding to the key from the hash index
+func (hm *HashMap) Delete(key []byte) bool {
+	shard := hm.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	if _, ok := shard.items[string(key)]; !ok {
+		return false
+	}
+	delete(shard.items, string(key))
+	return true
+}
+
+// Size Gets the number of key-value pairs in the hash index
+func (hm *HashMap) Size() int {
+	var size int
+	for _, shard := range hm.shards {
+		shard.lock.RLock()
+		size += len(shard.items)
+		shard.lock.RUnlock()
+	}
+	return size
+}
+
+// Iterator Gets the iterator of the hash index
+// Each shard is sorted on its own and the results are merged,
+// so the iterator traverses the keys in order like the other indexes
+func (hm *HashMap) Iterator(reverse bool) Iterator {
+	return NewHashMapIterator(hm, reverse)
+}
+
+// HashMapIterator Hash index iterator
+type HashMapIterator struct {
+	currIndex int     // The subscript position of the current traversal
+	reverse   bool    // Whether it is reverse traversal
+	values    []*Item // Key + Location index information
+}
+
+// NewHashMapIterator Initializes the hash index iterator
+func NewHashMapIterator(hm *HashMap, reverse bool) *HashMapIterator {
+	// Take a sorted snapshot of every shard
+	runs := make([][]*Item, 0, len(hm.shards))
+	var total int
+	for _, shard := range hm.shards {
+		shard.lock.RLock()
+		run := make([]*Item, 0, len(shard.items))
+		for key, pst := range shard.items {
+			run = append(run, &Item{key: []byte(key), pst: pst})
+		}
+		shard.lock.RUnlock()
+
+		sort.Slice(run, func(i, j int) bool {
+			return bytes.Compare(run[i].key, run[j].key) < 0
+		})
+		if len(run) > 0 {
+			runs = append(runs, run)
+			total += len(run)
+		}
+	}
+
+	// Merge the sorted shards
+	values := make([]*Item, 0, total)
+	h := &itemRunHeap{runs: runs}
+	heap.Init(h)
+	for h.Len() > 0 {
+		run := h.runs[0]
+		values = append(values, run[0])
+		if len(run) > 1 {
+			h.runs[0] = run[1:]
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+
+	// Reverse the values slice if reverse is true
+	if reverse {
+		for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+
+	return &HashMapIterator{
+		currIndex: 0,
+		reverse:   reverse,
+		values:    values,
+	}
+}
+
+// Rewind Resets the iterator to the beginning
+func (hmIt *HashMapIterator) Rewind() {
+	hmIt.currIndex = 0
+}
+
+// Seek Positions the iterator to the first key
+// that is greater or equal to the specified key,
+// or less or equal to it when traversing in reverse
+func (hmIt *HashMapIterator) Seek(key []byte) {
+	if hmIt.reverse {
+		hmIt.currIndex = sort.Search(len(hmIt.values), func(i int) bool {
+			return bytes.Compare(hmIt.values[i].key, key) <= 0
+		})
+	} else {
+		hmIt.currIndex = sort.Search(len(hmIt.values), func(i int) bool {
+			return bytes.Compare(hmIt.values[i].key, key) >= 0
+		})
+	}
+}
+
+// Next Positions the iterator to the next key
+func (hmIt *HashMapIterator) Next() {
+	hmIt.currIndex += 1
+}
+
+// Valid Determines whether the iterator is positioned at a valid key
+func (hmIt *HashMapIterator) Valid() bool {
+	return hmIt.currIndex < len(hmIt.values)
+}
+
+// Key Gets the key at the current iterator position
+func (hmIt *HashMapIterator) Key() []byte {
+	return hmIt.values[hmIt.currIndex].key
+}
+
+// Value Gets the value at the current iterator position
+func (hmIt *HashMapIterator) Value() *data.LogRecordPst {
+	return hmIt.values[hmIt.currIndex].pst
+}
+
+// Close Closes the iterator
+func (hmIt *HashMapIterator) Close() {
+	hmIt.values = nil
+}
+
+// itemRunHeap is a min-heap of sorted runs ordered by their first key
+type itemRunHeap struct {
+	runs [][]*Item
+}
+
+func (h *itemRunHeap) Len() int { return len(h.runs) }
+
+func (h *itemRunHeap) Less(i, j int) bool {
+	return bytes.Compare(h.runs[i][0].key, h.runs[j][0].key) < 0
+}
+
+func (h *itemRunHeap) Swap(i, j int) { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+
+func (h *itemRunHeap) Push(x interface{}) { h.runs = append(h.runs, x.([]*Item)) }
+
+func (h *itemRunHeap) Pop() interface{} {
+	old := h.runs
+	n := len(old)
+	run := old[n-1]
+	h.runs = old[:n-1]
+	return run
+}
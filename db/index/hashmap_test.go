@@ -1,97 +1,122 @@
 package index
 
-//
-//import (
-//	"testing"
-//
-//	"github.com/ByteStorage/FlyDB/engine/data"
-//	"github.com/stretchr/testify/assert"
-//)
-//
-//func TestHashMap_Put(t *testing.T) {
-//	hm := NewHashMap()
-//
-//	res1 := hm.Put(nil, &data.LogRecordPst{Fid: 1, Offset: 100})
-//	assert.True(t, res1)
-//
-//	res2 := hm.Put([]byte("a"), &data.LogRecordPst{Fid: 1, Offset: 200})
-//	assert.True(t, res2)
-//}
-//
-//func TestHashMap_Get(t *testing.T) {
-//	hm := NewHashMap()
-//
-//	res1 := hm.Put(nil, &data.LogRecordPst{Fid: 1, Offset: 100})
-//	assert.True(t, res1)
-//
-//	pst1 := hm.Get(nil)
-//	assert.Equal(t, uint32(1), pst1.Fid)
-//	assert.Equal(t, int64(100), pst1.Offset)
-//
-//	res2 := hm.Put([]byte("a"), &data.LogRecordPst{Fid: 1, Offset: 200})
-//	assert.True(t, res2)
-//	res3 := hm.Put([]byte("a"), &data.LogRecordPst{Fid: 1, Offset: 300})
-//	assert.True(t, res3)
-//
-//	pst2 := hm.Get([]byte("a"))
-//	assert.Equal(t, uint32(1), pst2.Fid)
-//	assert.Equal(t, int64(300), pst2.Offset)
-//}
-//
-//func TestHashMap_Delete(t *testing.T) {
-//	hm := NewHashMap()
-//
-//	res1 := hm.Put(nil, &data.LogRecordPst{Fid: 1, Offset: 100})
-//	assert.True(t, res1)
-//	res2 := hm.Delete(nil)
-//	assert.True(t, res2)
-//
-//	res3 := hm.Put([]byte("abc"), &data.LogRecordPst{Fid: 11, Offset: 22})
-//	assert.True(t, res3)
-//	res4 := hm.Delete([]byte("abc"))
-//	assert.True(t, res4)
-//}
-
-//func TestHashMap_Iterator(t *testing.T) {
-//	hm1 := NewHashMap()
-//	// 1. HashMap is empty
-//
-//	iter1 := hm1.Iterator(false)
-//	assert.Equal(t, false, iter1.Valid())
-//
-//	// 2. HashMap is not empty
-//	hm1.Put([]byte("abc"), &data.LogRecordPst{Fid: 1, Offset: 12})
-//
-//	iter2 := hm1.Iterator(false)
-//	assert.True(t, iter2.Valid())
-//	assert.NotNil(t, iter2.Key())
-//	assert.NotNil(t, iter2.Value())
-//	iter2.Next()
-//	assert.Equal(t, false, iter2.Valid())
-//
-//	// 3. when there are multiple pieces of data
-//	hm1.Put([]byte("bcd"), &data.LogRecordPst{Fid: 2, Offset: 12})
-//	hm1.Put([]byte("efg"), &data.LogRecordPst{Fid: 3, Offset: 12})
-//	hm1.Put([]byte("def"), &data.LogRecordPst{Fid: 4, Offset: 12})
-//	iter3 := hm1.Iterator(false)
-//	for iter3.Rewind(); iter3.Valid(); iter3.Next() {
-//		assert.NotNil(t, iter3.Key())
-//	}
-//
-//	iter4 := hm1.Iterator(true)
-//	for iter4.Rewind(); iter4.Valid(); iter4.Next() {
-//		assert.NotNil(t, iter4.Key())
-//	}
-//
-//	// 4. Seek test
-//	iter5 := hm1.Iterator(false)
-//	for iter5.Seek([]byte("b")); iter5.Valid(); iter5.Next() {
-//		assert.NotNil(t, iter5.Key())
-//	}
-//
-//	iter6 := hm1.Iterator(true)
-//	for iter6.Seek([]byte("d")); iter6.Valid(); iter6.Next() {
-//		assert.NotNil(t, iter6.Key())
-//	}
-//
-//}
+import (
+	"bytes"
+	"fmt"
+	"github.com/ByteStorage/FlyDB/db/data"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestHashMap_Put(t *testing.T) {
+	hm := NewHashMap(DefaultHashMapShards)
+
+	res1 := hm.Put(nil, &data.LogRecordPst{Fid: 1, Offset: 100})
+	assert.True(t, res1)
+
+	res2 := hm.Put([]byte("a"), &data.LogRecordPst{Fid: 1, Offset: 200})
+	assert.True(t, res2)
+	assert.Equal(t, 2, hm.Size())
+}
+
+func TestHashMap_Get(t *testing.T) {
+	hm := NewHashMap(DefaultHashMapShards)
+
+	res1 := hm.Put(nil, &data.LogRecordPst{Fid: 1, Offset: 100})
+	assert.True(t, res1)
+
+	pst1 := hm.Get(nil)
+	assert.Equal(t, uint32(1), pst1.Fid)
+	assert.Equal(t, int64(100), pst1.Offset)
+
+	res2 := hm.Put([]byte("a"), &data.LogRecordPst{Fid: 1, Offset: 200})
+	assert.True(t, res2)
+	res3 := hm.Put([]byte("a"), &data.LogRecordPst{Fid: 1, Offset: 300})
+	assert.True(t, res3)
+
+	pst2 := hm.Get([]byte("a"))
+	assert.Equal(t, uint32(1), pst2.Fid)
+	assert.Equal(t, int64(300), pst2.Offset)
+}
+
+func TestHashMap_Delete(t *testing.T) {
+	hm := NewHashMap(DefaultHashMapShards)
+
+	res1 := hm.Put(nil, &data.LogRecordPst{Fid: 1, Offset: 100})
+	assert.True(t, res1)
+	res2 := hm.Delete(nil)
+	assert.True(t, res2)
+
+	res3 := hm.Put([]byte("abc"), &data.LogRecordPst{Fid: 11, Offset: 22})
+	assert.True(t, res3)
+	res4 := hm.Delete([]byte("abc"))
+	assert.True(t, res4)
+	res5 := hm.Delete([]byte("abc"))
+	assert.False(t, res5)
+	assert.Equal(t, 0, hm.Size())
+}
+
+func TestHashMap_Iterator(t *testing.T) {
+	hm1 := NewHashMap(DefaultHashMapShards)
+	// 1. HashMap is empty
+	iter1 := hm1.Iterator(false)
+	assert.Equal(t, false, iter1.Valid())
+
+	// 2. HashMap is not empty
+	hm1.Put([]byte("abc"), &data.LogRecordPst{Fid: 1, Offset: 12})
+	iter2 := hm1.Iterator(false)
+	assert.True(t, iter2.Valid())
+	assert.NotNil(t, iter2.Key())
+	assert.NotNil(t, iter2.Value())
+	iter2.Next()
+	assert.Equal(t, false, iter2.Valid())
+
+	// 3. Keys spread over the shards come back sorted
+	for i := 0; i < 1000; i++ {
+		hm1.Put([]byte(fmt.Sprintf("key-%04d", i)), &data.LogRecordPst{Fid: 1, Offset: int64(i)})
+	}
+	var prev []byte
+	var count int
+	iter3 := hm1.Iterator(false)
+	for iter3.Rewind(); iter3.Valid(); iter3.Next() {
+		assert.True(t, prev == nil || bytes.Compare(prev, iter3.Key()) < 0)
+		prev = iter3.Key()
+		count++
+	}
+	assert.Equal(t, 1001, count)
+
+	prev = nil
+	iter4 := hm1.Iterator(true)
+	for iter4.Rewind(); iter4.Valid(); iter4.Next() {
+		assert.True(t, prev == nil || bytes.Compare(prev, iter4.Key()) > 0)
+		prev = iter4.Key()
+	}
+
+	// 4. Seek test
+	iter5 := hm1.Iterator(false)
+	iter5.Seek([]byte("key-0500"))
+	assert.Equal(t, []byte("key-0500"), iter5.Key())
+
+	iter6 := hm1.Iterator(true)
+	iter6.Seek([]byte("key-0500x"))
+	assert.Equal(t, []byte("key-0500"), iter6.Key())
+}
+
+func TestHashMap_Concurrent(t *testing.T) {
+	hm := NewHashMap(8)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := []byte(fmt.Sprintf("%d-%d", w, i))
+				hm.Put(key, &data.LogRecordPst{Fid: uint32(w), Offset: int64(i)})
+				assert.NotNil(t, hm.Get(key))
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert.Equal(t, 8000, hm.Size())
+}
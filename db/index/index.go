@@ -3,6 +3,7 @@ package index
 import (
 	"bytes"
 	"github.com/ByteStorage/FlyDB/db/data"
+	"github.com/ByteStorage/FlyDB/lib/bloom"
 	"github.com/google/btree"
 )
 
@@ -28,6 +29,61 @@ type Indexer interface {
 	Iterator(reverse bool) Iterator
 }
 
+// PersistentIndexer is an Indexer that keeps its entries on disk across restarts.
+// Together with the entries it stores a checkpoint, so that on startup only the
+// log records written after the checkpoint have to be replayed into the index.
+type PersistentIndexer interface {
+	Indexer
+
+	// LoadCheckpoint returns the saved checkpoint, or nil if there is none.
+	LoadCheckpoint() (*Checkpoint, error)
+
+	// SaveCheckpoint saves a new checkpoint.
+	SaveCheckpoint(cp *Checkpoint) error
+
+	// Update applies the changes fn makes through the given Indexer and the checkpoint
+	// fn returns in one atomic step. If reset is true, all entries are dropped first.
+	Update(reset bool, fn func(indexer Indexer) (*Checkpoint, error)) error
+
+	// Close closes the index.
+	Close() error
+}
+
+// FilterIndexer is an Indexer that screens lookups with a bloom filter.
+// The filter can be saved next to the hint file and handed back on startup,
+// so the keys loaded from the hint file do not have to be hashed again.
+type FilterIndexer interface {
+	Indexer
+
+	// Filter returns the bloom filter of the index.
+	Filter() *bloom.ScalableFilter
+
+	// SetFilter replaces the bloom filter of the index.
+	SetFilter(filter *bloom.ScalableFilter)
+
+	// PutUnfiltered stores the key like Put, but does not add it to the filter.
+	PutUnfiltered(key []byte, pst *data.LogRecordPst) bool
+}
+
+// RangeIndexer is an Indexer that iterates over a range of keys without copying the whole index.
+type RangeIndexer interface {
+	Indexer
+
+	// RangeIterator returns an iterator over the keys from start, inclusive, to end, exclusive.
+	// A nil start or end leaves the range open on that side. The iterator reads the index
+	// as it moves, so writes made while it is open may or may not be seen by it.
+	RangeIterator(reverse bool, start, end []byte) Iterator
+}
+
+// Checkpoint records how far the data files have been applied to a persistent index.
+// Every log record before Fid/Offset is reflected in the index.
+type Checkpoint struct {
+	Fid      uint32 // File ID of the first record that may be missing from the index
+	Offset   int64  // Offset of that record in its data file
+	SeqNo    uint64 // Largest transaction sequence number seen so far
+	MergeFid uint32 // The first non-merged file id of the last merge the index reflects
+}
+
 type IndexType = int8
 
 const (
@@ -42,6 +98,12 @@ const (
 
 	// ARTWithBloom index With Bloom Filter
 	ARTWithBloom
+
+	// BPlusTreeIndex persistent B+ tree index stored in bbolt
+	BPlusTreeIndex
+
+	// HashMapIndex sharded hash index for point lookups
+	HashMapIndex
 )
 
 func NewIndexer(typeIndex IndexType, dirPath string) Indexer {
@@ -54,6 +116,10 @@ func NewIndexer(typeIndex IndexType, dirPath string) Indexer {
 		return NewSkipList()
 	case ARTWithBloom:
 		return NewARTWithBloom()
+	case BPlusTreeIndex:
+		return NewBPlusTree(dirPath)
+	case HashMapIndex:
+		return NewHashMap(DefaultHashMapShards)
 	default:
 		panic("unsupported index type")
 	}
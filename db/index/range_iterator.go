@@ -0,0 +1,85 @@
+package index
+
+import (
+	"bytes"
+	"github.com/ByteStorage/FlyDB/db/data"
+)
+
+// rangeBatchSize is how many entries a range iterator reads from its index at a time
+const rangeBatchSize = 128
+
+// rangeFetch returns entries of an index in the order of the iterator, starting at from:
+// the first key at or past from, or strictly past it if exclusive. It returns about n entries,
+// and whether there may be more after them. Entries past the far bound of the range are
+// not returned, the iterator keeps from within the near bound.
+type rangeFetch func(from []byte, exclusive bool, n int) (items []*Item, more bool)
+
+// rangeIterator iterates over a range of an index without copying the whole index.
+// It reads the entries in batches, holding the lock of the index only while a batch is read,
+// so writes to the index between two batches may or may not be seen by the iterator.
+type rangeIterator struct {
+	fetch   rangeFetch
+	reverse bool
+	start   []byte  // Smallest key of the range, inclusive, nil if unbounded
+	end     []byte  // Key the range stops before, exclusive, nil if unbounded
+	batch   []*Item // Entries read at the last fetch
+	pos     int     // Position of the current entry in batch
+	more    bool    // Whether there may be entries of the range after batch
+}
+
+func newRangeIterator(reverse bool, start, end []byte, fetch rangeFetch) *rangeIterator {
+	it := &rangeIterator{
+		fetch:   fetch,
+		reverse: reverse,
+		start:   start,
+		end:     end,
+	}
+	it.Rewind()
+	return it
+}
+
+func (it *rangeIterator) Rewind() {
+	it.load(nil, false)
+}
+
+func (it *rangeIterator) Seek(key []byte) {
+	it.load(key, false)
+}
+
+// load reads the batch that starts at from, keeping from within the near bound of the range
+func (it *rangeIterator) load(from []byte, exclusive bool) {
+	if it.reverse {
+		if it.end != nil && (from == nil || bytes.Compare(from, it.end) >= 0) {
+			from, exclusive = it.end, true
+		}
+	} else {
+		if it.start != nil && (from == nil || bytes.Compare(from, it.start) < 0) {
+			from, exclusive = it.start, false
+		}
+	}
+	it.batch, it.more = it.fetch(from, exclusive, rangeBatchSize)
+	it.pos = 0
+}
+
+func (it *rangeIterator) Next() {
+	it.pos++
+	if it.pos == len(it.batch) && it.more {
+		it.load(it.batch[len(it.batch)-1].key, true)
+	}
+}
+
+func (it *rangeIterator) Valid() bool {
+	return it.pos < len(it.batch)
+}
+
+func (it *rangeIterator) Key() []byte {
+	return it.batch[it.pos].key
+}
+
+func (it *rangeIterator) Value() *data.LogRecordPst {
+	return it.batch[it.pos].pst
+}
+
+func (it *rangeIterator) Close() {
+	it.batch = nil
+}
@@ -0,0 +1,96 @@
+package index
+
+import (
+	"bytes"
+	"github.com/ByteStorage/FlyDB/db/data"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestRangeIterator(t *testing.T) {
+	indexes := map[string]RangeIndexer{
+		"btree":          NewBTree(),
+		"art":            NewART(),
+		"art with bloom": NewARTWithBloom(),
+	}
+
+	// Short keys over a few bytes, so that keys are prefixes of each other and ranges end on 0xff
+	r := rand.New(rand.NewSource(1))
+	alphabet := []byte{0x00, 0x01, 'a', 'b', 0xfe, 0xff}
+	randomKey := func() []byte {
+		key := make([]byte, 1+r.Intn(4))
+		for i := range key {
+			key[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		return key
+	}
+	var keys [][]byte
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		key := randomKey()
+		if seen[string(key)] {
+			continue
+		}
+		seen[string(key)] = true
+		keys = append(keys, key)
+		for _, idx := range indexes {
+			idx.Put(key, &data.LogRecordPst{Fid: uint32(i)})
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+
+	// want returns the keys of the range in the order of the iterator, from seek on
+	want := func(reverse bool, start, end, seek []byte) [][]byte {
+		var result [][]byte
+		for _, key := range keys {
+			if start != nil && bytes.Compare(key, start) < 0 || end != nil && bytes.Compare(key, end) >= 0 {
+				continue
+			}
+			if seek != nil && (!reverse && bytes.Compare(key, seek) < 0 || reverse && bytes.Compare(key, seek) > 0) {
+				continue
+			}
+			result = append(result, key)
+		}
+		if reverse {
+			for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+				result[i], result[j] = result[j], result[i]
+			}
+		}
+		return result
+	}
+	optional := func() []byte {
+		if r.Intn(4) == 0 {
+			return nil
+		}
+		return randomKey()
+	}
+
+	for name, idx := range indexes {
+		for i := 0; i < 200; i++ {
+			reverse := r.Intn(2) == 0
+			start, end, seek := optional(), optional(), optional()
+
+			it := idx.RangeIterator(reverse, start, end)
+			if seek != nil {
+				it.Seek(seek)
+			}
+			var got [][]byte
+			for ; it.Valid(); it.Next() {
+				got = append(got, it.Key())
+			}
+			it.Close()
+			assert.Equal(t, want(reverse, start, end, seek), got, "%s: reverse %v, start %q, end %q, seek %q", name, reverse, start, end, seek)
+		}
+
+		// A range read in more than one batch is read whole
+		it := idx.RangeIterator(false, nil, nil)
+		count := 0
+		for it.Rewind(); it.Valid(); it.Next() {
+			count++
+		}
+		assert.Equal(t, len(keys), count, name)
+		assert.Greater(t, count, rangeBatchSize, name)
+	}
+}
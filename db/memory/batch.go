@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/wal"
+)
+
+// batchType is the record type of a batch in a wal shared by column families
+const batchType = byte(3)
+
+// batchCommitMarker ends every batch record, a batch without it is not replayed
+var batchCommitMarker = []byte("batch-fina")
+
+var ErrBatchNotCommitted = errors.New("BatchNotCommittedError : wal batch has no commit marker")
+
+// BatchEntry is a put or a delete of a batch, for one column family
+type BatchEntry struct {
+	ColumnFamily string // the WalID of the column family, or its name if it has none
+	Delete       bool
+	Key          []byte
+	Value        []byte
+}
+
+// NewBatchRecord encodes the entries of a batch as one wal record.
+// +-------+--- ... ---+--- ... ---+---------------+
+// | count | entry 1   | entry n   | commit marker |
+// +-------+--- ... ---+--- ... ---+---------------+
+// An entry is a type byte followed by the column family, key and value, each prefixed by its uvarint length.
+func NewBatchRecord(entries []BatchEntry) *wal.Record {
+	buf := new(bytes.Buffer)
+	putUvarint(buf, uint64(len(entries)))
+	for _, entry := range entries {
+		typ := putType
+		if entry.Delete {
+			typ = deleteType
+		}
+		buf.WriteByte(typ)
+		putBytes(buf, []byte(entry.ColumnFamily))
+		putBytes(buf, entry.Key)
+		putBytes(buf, entry.Value)
+	}
+	buf.Write(batchCommitMarker)
+	return &wal.Record{Type: batchType, Value: buf.Bytes()}
+}
+
+func putUvarint(buf *bytes.Buffer, n uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	buf.Write(tmp[:binary.PutUvarint(tmp[:], n)])
+}
+
+func putBytes(buf *bytes.Buffer, b []byte) {
+	putUvarint(buf, uint64(len(b)))
+	buf.Write(b)
+}
+
+// decodeBatch decodes the entries of a batch record
+func decodeBatch(value []byte) ([]BatchEntry, error) {
+	if !bytes.HasSuffix(value, batchCommitMarker) {
+		return nil, ErrBatchNotCommitted
+	}
+	reader := bytes.NewReader(value[:len(value)-len(batchCommitMarker)])
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, wal.ErrCorruptedRecord
+	}
+	entries := make([]BatchEntry, 0, count)
+	for i := uint64(0); i < count; i++ {
+		typ, err := reader.ReadByte()
+		if err != nil {
+			return nil, wal.ErrCorruptedRecord
+		}
+		cf, err := readBytes(reader)
+		if err != nil {
+			return nil, err
+		}
+		key, err := readBytes(reader)
+		if err != nil {
+			return nil, err
+		}
+		value, err := readBytes(reader)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, BatchEntry{
+			ColumnFamily: string(cf),
+			Delete:       typ == deleteType,
+			Key:          key,
+			Value:        value,
+		})
+	}
+	return entries, nil
+}
+
+func readBytes(reader *bytes.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(reader)
+	if err != nil || n > uint64(reader.Len()) {
+		return nil, wal.ErrCorruptedRecord
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	b := make([]byte, n)
+	_, _ = reader.Read(b)
+	return b, nil
+}
+
+// ApplyBatch writes the entries of a batch that belong to this column family to the memTables.
+// The batch must have been written to the wal already, as one record made by NewBatchRecord at pos.
+func (d *Db) ApplyBatch(entries []BatchEntry, pos wal.Position) error {
+	for _, entry := range entries {
+		if len(entry.Key) == 0 {
+			return _const.ErrKeyIsEmpty
+		}
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.slowdown()
+	d.recordPos = pos
+	return d.applyBatch(entries)
+}
+
+// applyBatch writes the entries of a batch that belong to this column family to the memTables
+// Hold the mutex before accessing this method
+func (d *Db) applyBatch(entries []BatchEntry) error {
+	for _, entry := range entries {
+		if entry.ColumnFamily != d.walID() {
+			continue
+		}
+		var err error
+		if entry.Delete {
+			err = d.delete(entry.Key)
+		} else {
+			err = d.put(entry.Key, entry.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
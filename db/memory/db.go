@@ -3,9 +3,9 @@ package memory
 import (
 	"github.com/ByteStorage/FlyDB/config"
 	"github.com/ByteStorage/FlyDB/db/engine"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
 	"github.com/ByteStorage/FlyDB/lib/wal"
 	"io"
-	"log"
 	"os"
 	"sync"
 )
@@ -14,22 +14,31 @@ const (
 	// Record types
 	putType    = byte(1)
 	deleteType = byte(2)
+
+	// errMsgBufferSize is how many error messages may wait to be written to the error log
+	errMsgBufferSize = 1024
 )
 
 type Db struct {
-	option            config.DbMemoryOptions
-	db                *engine.DB
-	mem               *MemTable
-	oldList           []*MemTable
-	wal               *wal.Wal
-	oldListChan       chan *MemTable
-	totalSize         int64
-	activeSize        int64
-	walTask           chan func()
-	errMsgCh          chan string
-	mux               sync.RWMutex
-	walDataMtList     []*MemTable
-	walDataMtListChan chan *MemTable
+	option      config.DbMemoryOptions
+	db          *engine.DB
+	mem         *MemTable
+	oldList     []*MemTable
+	wal         *wal.Wal
+	oldListChan chan *MemTable
+	totalSize   int64
+	activeSize  int64
+	errMsgCh    chan string
+	flushDone   chan struct{} // closed when the flusher has written every immutable memTable
+	recordPos   wal.Position  // wal position of the record being applied, the memTables hold the records before it
+	mux         sync.RWMutex
+	flushed     *sync.Cond             // signalled when a memTable has been flushed
+	closing     bool                   // Close was called, a failed flush is not tried again
+	flushedEnd  wal.Position           // wal position up to which the memTables are flushed
+	snapshots   map[*Snapshot]struct{} // snapshots that are not released
+	// snapshotLock is held by a flush while it keeps the old value of a key for the snapshots and overwrites it
+	snapshotLock sync.RWMutex
+	stats        FlushStats
 }
 
 // NewDB create a new db of wal and memTable
@@ -39,22 +48,43 @@ func NewDB(option config.DbMemoryOptions) (*Db, error) {
 
 	// dir path has been changed to dir path + column name
 	option.Option.DirPath = option.Option.DirPath + "/" + option.ColumnName
-	option.Option.IndexType = config.ARTWithBloom
+	if option.Option.IndexType == 0 {
+		option.Option.IndexType = config.ARTWithBloom
+	}
 	db, err := engine.NewDB(option.Option)
 	if err != nil {
 		return nil, err
 	}
 
+	if option.MaxImmutableMemTables <= 0 {
+		option.MaxImmutableMemTables = config.DefaultDbMemoryOptions.MaxImmutableMemTables
+	}
+	if option.SlowdownImmutableMemTables <= 0 {
+		option.SlowdownImmutableMemTables = option.MaxImmutableMemTables
+	}
+	if option.FlushWorkers <= 0 {
+		option.FlushWorkers = config.DefaultDbMemoryOptions.FlushWorkers
+	}
+	if option.Durability == 0 {
+		option.Durability = config.DurabilityAsync
+		if option.Option.SyncWrite {
+			option.Durability = config.DurabilitySync
+		}
+	}
+
 	w := option.Wal
 
-	// if wal is nil, create a new wal
+	// if wal is nil, create a new wal, unless writes are kept in memory only
 	// if wal is not nil, the wal was created by column family
-	if option.Wal == nil {
+	if option.Wal == nil && option.Durability != config.DurabilityNone {
 		walOptions := wal.Options{
 			DirPath:  option.Option.DirPath,
 			FileSize: option.FileSize,
 			SaveTime: option.SaveTime,
 			LogNum:   option.LogNum,
+			FIOType:  option.Option.FIOType,
+
+			FaultInjector: option.Option.FaultInjector,
 		}
 		w, err = wal.NewWal(walOptions)
 		if err != nil {
@@ -64,31 +94,37 @@ func NewDB(option config.DbMemoryOptions) (*Db, error) {
 
 	// initialize db
 	d := &Db{
-		mem:               mem,
-		db:                db,
-		option:            option,
-		oldList:           make([]*MemTable, 0),
-		oldListChan:       make(chan *MemTable, 1000000),
-		activeSize:        0,
-		totalSize:         0,
-		wal:               w,
-		walTask:           make(chan func(), 10000000),
-		mux:               sync.RWMutex{},
-		walDataMtList:     make([]*MemTable, 0),
-		walDataMtListChan: make(chan *MemTable, 1000000),
-		errMsgCh:          make(chan string, 1000000),
+		mem:         mem,
+		db:          db,
+		option:      option,
+		oldList:     make([]*MemTable, 0),
+		oldListChan: make(chan *MemTable, option.MaxImmutableMemTables+1),
+		activeSize:  0,
+		totalSize:   0,
+		wal:         w,
+		mux:         sync.RWMutex{},
+		errMsgCh:    make(chan string, errMsgBufferSize),
+		flushDone:   make(chan struct{}),
+		snapshots:   make(map[*Snapshot]struct{}),
 	}
+	d.flushed = sync.NewCond(&d.mux)
 
-	// when loading, the system will execute the every record in wal
-	d.load()
-	// async write to db
-	go d.async()
-	// async save wal
-	go d.wal.AsyncSave()
 	// async handler error message
 	go d.handlerErrMsg()
-	// async worker
-	go d.work()
+	// flush immutable memTables to db, already while the wal is replayed
+	go d.flushLoop()
+	// when loading, the system will execute the every record in wal
+	if d.wal != nil {
+		if err := d.load(); err != nil {
+			// the records replayed so far are flushed, the wal keeps all of them
+			_ = d.Close()
+			return nil, err
+		}
+	}
+	// async save wal, a wal shared by column family is saved by its owner
+	if option.Wal == nil && d.wal != nil {
+		go d.wal.AsyncSave()
+	}
 	return d, nil
 }
 
@@ -100,61 +136,45 @@ func (d *Db) handlerErrMsg() {
 	}
 }
 
-var putTypeInt = int64(1)
+// reportError hands a message to the error log. The log is best effort:
+// if it falls behind, the message is dropped rather than holding up the caller.
+func (d *Db) reportError(msg string) {
+	select {
+	case d.errMsgCh <- msg:
+	default:
+	}
+}
 
+// Put writes a key-value pair, it returns once the write is as durable as option.Durability demands
 func (d *Db) Put(key []byte, value []byte) error {
+	if len(key) == 0 {
+		return _const.ErrKeyIsEmpty
+	}
 	d.mux.Lock()
-	defer d.mux.Unlock()
-	// calculate key and value size
-	keyLen := int64(len(key))
-	valueLen := int64(len(value))
-
-	//d.walTask <- func() {
-	//	// Write to wal, try 3 times
-	//	ok := false
-	//	for i := 0; i < 3; i++ {
-	//		err := d.wal.Put(key, value)
-	//		if err == nil {
-	//			ok = true
-	//			break
-	//		}
-	//	}
-	//	if !ok {
-	//		err := d.wal.Delete(key)
-	//		if err != nil {
-	//			d.errMsgCh <- "write to wal error when delete the key: " + string(key) + " error: " + err.Error()
-	//		}
-	//	}
-	//}
-
-	// if sync write, save wal
-	if d.option.Option.SyncWrite {
-		err := d.wal.Save()
-		if err != nil {
-			return err
-		}
+	d.slowdown()
+	d.recordPos = d.walPosition()
+	if err := d.appendWal(putType, key, value); err != nil {
+		d.mux.Unlock()
+		return err
 	}
-
-	// if all memTable size > total memTable size, write to db
-	if d.totalSize > d.option.TotalMemSize {
-		return d.db.Put(key, value)
+	err := d.put(key, value)
+	pos := d.walPosition()
+	d.mux.Unlock()
+	if err != nil {
+		return err
 	}
+	return d.waitDurable(pos)
+}
 
-	// if active memTable size > define size, change to immutable memTable
-	if d.activeSize+keyLen+valueLen > d.option.MemSize {
-		// add to immutable memTable list
-		if putTypeInt == 1 {
-			d.addOldMemTable(d.mem)
-		} else {
-			d.addWalDataToMemTable(d.mem)
-			putTypeInt = 1
-		}
-		// create new active memTable
-		d.mem = NewMemTable()
-		d.activeSize = 0
-	}
+// put writes a key-value pair to the memTable
+// Hold the mutex before accessing this method
+func (d *Db) put(key []byte, value []byte) error {
+	// calculate key and value size
+	keyLen := int64(len(key))
+	valueLen := int64(len(value))
 
-	// write to active memTable
+	// write to active memTable, older versions of the key in the other layers are shadowed
+	d.rotateIfFull(keyLen + valueLen)
 	d.mem.Put(string(key), value)
 
 	// add size
@@ -163,206 +183,210 @@ func (d *Db) Put(key []byte, value []byte) error {
 	return nil
 }
 
-func (d *Db) Get(key []byte) ([]byte, error) {
-	d.mux.RLock()
-	defer d.mux.RUnlock()
-	// first get from memTable
-	value, err := d.mem.Get(string(key))
-	if err == nil {
-		return value, nil
+// appendWal writes a record to the wal, and syncs it in DurabilitySync mode
+// Hold the mutex before accessing this method, so that the wal and the memTable see writes in the same order
+func (d *Db) appendWal(typ byte, key []byte, value []byte) error {
+	if d.option.Durability == config.DurabilityNone {
+		return nil
 	}
-
-	mtList := append(append([]*MemTable(nil), d.walDataMtList...), d.oldList...)
-
-	// if active memTable not found, get from immutable memTable
-	for _, list := range mtList {
-		value, err = list.Get(string(key))
-		if err == nil {
-			return value, nil
-		}
+	record := &wal.Record{Type: typ, Key: key, Value: value}
+	// a wal shared by column family holds batches, which name the column family of every write
+	if d.option.Wal != nil {
+		record = NewBatchRecord([]BatchEntry{{
+			ColumnFamily: d.walID(),
+			Delete:       typ == deleteType,
+			Key:          key,
+			Value:        value,
+		}})
 	}
-
-	// if immutable memTable not found, get from db
-	return d.db.Get(key)
+	if err := d.wal.Write(record); err != nil {
+		return err
+	}
+	if d.option.Durability == config.DurabilitySync {
+		return d.wal.Save()
+	}
+	return nil
 }
 
-func (d *Db) Delete(key []byte) error {
-	d.mux.Lock()
-	defer d.mux.Unlock()
+// walID names the column family in the batches of a wal shared by column family
+func (d *Db) walID() string {
+	if d.option.WalID != "" {
+		return d.option.WalID
+	}
+	return d.option.ColumnName
+}
 
-	d.walTask <- func() {
-		// Write to wal, try 3 times
-		ok := false
-		for i := 0; i < 3; i++ {
-			err := d.wal.Delete(key)
-			if err == nil {
-				ok = true
-				break
-			}
-		}
-		if !ok {
-			err := d.wal.Delete(key)
-			if err != nil {
-				d.errMsgCh <- "write to wal error when delete the key: " + string(key) + " error: " + err.Error()
-			}
-		}
+// walPosition returns the end of the wal, or the zero position without a wal.
+func (d *Db) walPosition() wal.Position {
+	if d.wal == nil {
+		return wal.Position{}
 	}
+	return d.wal.Position()
+}
 
-	//err := d.pool.Submit(func() {
-	//	// Write to wal, try 3 times
-	//	ok := false
-	//	for i := 0; i < 3; i++ {
-	//		err := d.wal.Delete(key)
-	//		if err == nil {
-	//			ok = true
-	//			break
-	//		}
-	//	}
-	//	if !ok {
-	//		err := d.wal.Delete(key)
-	//		if err != nil {
-	//			d.errMsgCh <- "write to wal error when delete the key: " + string(key) + " error: " + err.Error()
-	//		}
-	//	}
-	//})
-	// get from active memTable
-	get, err := d.mem.Get(string(key))
-	if err == nil {
-		d.activeSize -= int64(len(key) + len(get))
-		d.totalSize -= int64(len(key) + len(get))
-		d.mem.Delete(string(key))
+// waitDurable waits until the wal is synced up to pos in DurabilityGroupCommit mode.
+// It is called without the mutex, so that writers queue up behind a running sync
+// and are covered together by the next one.
+func (d *Db) waitDurable(pos wal.Position) error {
+	if d.option.Durability != config.DurabilityGroupCommit {
 		return nil
 	}
-	// get from immutable memTable
-	mtList := append(append([]*MemTable(nil), d.walDataMtList...), d.oldList...)
-	for _, list := range mtList {
-		get, err = list.Get(string(key))
-		if err == nil {
-			d.totalSize -= int64(len(key) + len(get))
-			list.Delete(string(key))
-			return nil
-		}
-	}
-	// get from db
-	return d.db.Delete(key)
+	return d.wal.SyncTo(pos)
 }
 
-func (d *Db) Keys() ([][]byte, error) {
+// Get returns the value of a key from the newest layer that holds it.
+// A tombstone in a layer hides the values in the older ones.
+func (d *Db) Get(key []byte) ([]byte, error) {
 	d.mux.RLock()
 	defer d.mux.RUnlock()
-
-	// Create a slice to hold the keys
-	keys := make([][]byte, 0)
-
-	// Collect keys from the active MemTable
-	for key := range d.mem.table {
-		keys = append(keys, []byte(key))
-	}
-
-	// Collect keys from the immutable MemTables (WAL data MemTables)
-	for _, mt := range d.walDataMtList {
-		for key := range mt.table {
-			keys = append(keys, []byte(key))
+	// first get from active memTable, then from the immutable memTables
+	for _, mt := range d.layers() {
+		entry := mt.lookup(string(key))
+		if entry == nil {
+			continue
 		}
-	}
-
-	// Collect keys from the old MemTables (flushed MemTables)
-	for _, mt := range d.oldList {
-		for key := range mt.table {
-			keys = append(keys, []byte(key))
+		if entry.deleted {
+			return nil, _const.ErrKeyNotFound
 		}
+		return entry.value, nil
 	}
 
-	// Collect keys from the persistent storage (your DB implementation)
-	persistentKeys := d.db.GetListKeys()
-	keys = append(keys, persistentKeys...)
-
-	return keys, nil
+	// if no memTable holds the key, get from db
+	return d.db.Get(key)
 }
 
-func (d *Db) Close() error {
-	err := d.wal.Save()
+// Delete removes a key, it returns once the delete is as durable as option.Durability demands
+func (d *Db) Delete(key []byte) error {
+	if len(key) == 0 {
+		return _const.ErrKeyIsEmpty
+	}
+	d.mux.Lock()
+	d.slowdown()
+	d.recordPos = d.walPosition()
+	if err := d.appendWal(deleteType, key, nil); err != nil {
+		d.mux.Unlock()
+		return err
+	}
+	err := d.delete(key)
+	pos := d.walPosition()
+	d.mux.Unlock()
 	if err != nil {
 		return err
 	}
-	return d.db.Close()
+	return d.waitDurable(pos)
 }
 
-func (d *Db) work() {
-	for task := range d.walTask {
-		task()
-	}
+// delete writes a tombstone for a key to the active memTable, it is flushed to db as a delete
+// Hold the mutex before accessing this method
+func (d *Db) delete(key []byte) error {
+	d.rotateIfFull(int64(len(key)))
+	d.mem.PutTombstone(string(key))
+	d.activeSize += int64(len(key))
+	d.totalSize += int64(len(key))
+	return nil
 }
 
-func (d *Db) addOldMemTable(oldList *MemTable) {
-	d.oldListChan <- oldList
+// layers returns the memTables, newest first
+// Hold the mutex before accessing this method
+func (d *Db) layers() []*MemTable {
+	layers := make([]*MemTable, 0, len(d.oldList)+1)
+	layers = append(layers, d.mem)
+	for i := len(d.oldList) - 1; i >= 0; i-- {
+		layers = append(layers, d.oldList[i])
+	}
+	return layers
 }
 
-func (d *Db) addWalDataToMemTable(walDataMt *MemTable) {
-	d.walDataMtListChan <- walDataMt
+// Keys returns the keys of all layers in order, every key once
+func (d *Db) Keys() ([][]byte, error) {
+	it := d.NewIterator(config.DefaultIteratorOptions)
+	defer it.Close()
+
+	keys := make([][]byte, 0)
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys, nil
 }
 
-func (d *Db) async() {
-	for oldList := range d.oldListChan {
-		for key, value := range oldList.table {
-			// Write to db, try 3 times
-			ok := false
-			for i := 0; i < 3; i++ {
-				err := d.db.Put([]byte(key), value)
-				if err == nil {
-					ok = true
-					break
-				}
-			}
-			if !ok {
-				err := d.wal.Delete([]byte(key))
-				if err != nil {
-					d.errMsgCh <- "write to wal error when delete the key: " + string(key) + " error: " + err.Error()
-				}
-			}
-			d.totalSize -= int64(len(key) + len(value))
+// Close flushes the memTables to db and closes the db.
+// If a memTable cannot be flushed, ErrFlushFailed is returned and its writes are replayed from the wal on restart.
+// Puts and deletes must not be called any more.
+func (d *Db) Close() error {
+	d.mux.Lock()
+	if d.activeSize > 0 {
+		d.mem.walEnd = d.walPosition()
+		d.mem.size = d.activeSize
+		d.addOldMemTable(d.mem)
+		d.mem = NewMemTable()
+		d.activeSize = 0
+	}
+	d.closing = true
+	close(d.oldListChan)
+	d.mux.Unlock()
+	<-d.flushDone
+
+	// a wal shared by column family is closed by its owner
+	if d.option.Wal == nil && d.wal != nil {
+		if err := d.wal.Close(); err != nil {
+			return err
 		}
 	}
+	if err := d.db.Close(); err != nil {
+		return err
+	}
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	if len(d.oldList) > 0 {
+		return ErrFlushFailed
+	}
+	return nil
 }
 
 func (d *Db) Clean() {
 	d.db.Clean()
 }
 
-func (d *Db) load() {
+// load replays the records of the wal after its checkpoint into the memTables.
+// A record that cannot be read or applied stops the replay with its error.
+func (d *Db) load() error {
+	// Replaying may stall until a flush finishes, which needs the mutex
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
 	// Initialize reading from the start of the WAL.
 	d.wal.InitReading()
 
 	for {
+		d.recordPos = d.wal.ReadPosition()
 		record, err := d.wal.ReadNext()
 		if err == io.EOF {
-			break
+			return nil
 		}
 		if err != nil {
-			// Handle the error: log it, panic, return, etc.
-			log.Printf("Error reading from WAL: %v", err)
-			return
+			return err
 		}
 
 		switch record.Type {
 		case putType:
-			// Assuming Db has a Put method
-			putTypeInt = 0
-			err := d.Put(record.Key, record.Value)
-			if err != nil {
-				// Handle the error: log it, panic, return, etc.
-				log.Printf("Error applying PUT from WAL: %v", err)
-			}
+			err = d.put(record.Key, record.Value)
 		case deleteType:
-			// Assuming Db has a Delete method
-			err := d.Delete(record.Key)
-			if err != nil {
-				// Handle the error: log it, panic, return, etc.
-				log.Printf("Error applying DELETE from WAL: %v", err)
+			err = d.delete(record.Key)
+		case batchType:
+			// a batch is replayed as a whole or, without its commit marker, not at all
+			var entries []BatchEntry
+			entries, err = decodeBatch(record.Value)
+			if err == ErrBatchNotCommitted {
+				continue
+			}
+			if err == nil {
+				err = d.applyBatch(entries)
 			}
 		default:
-			// Handle unknown type.
-			log.Printf("Unknown record type in WAL: %v", record.Type)
+			err = wal.ErrUnknownRecord
+		}
+		if err != nil {
+			return err
 		}
 	}
 }
@@ -1,11 +1,17 @@
 package memory
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/fileio"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
 	"github.com/ByteStorage/FlyDB/lib/randkv"
+	"github.com/ByteStorage/FlyDB/lib/wal"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 )
@@ -62,3 +68,401 @@ func TestDb_Keys(t *testing.T) {
 	assert.Equal(t, 100, len(keys))
 	t.Log(keys)
 }
+
+func TestDb_Durability(t *testing.T) {
+	modes := []config.DurabilityMode{config.DurabilityAsync, config.DurabilityGroupCommit, config.DurabilitySync}
+	for _, mode := range modes {
+		memOpt := config.DefaultDbMemoryOptions
+		memOpt.Option.DirPath = filepath.Join(os.TempDir(), "flydb-memory-durability")
+		memOpt.Option.FIOType = config.FileIOType
+		memOpt.Durability = mode
+		_ = os.RemoveAll(memOpt.Option.DirPath)
+
+		db, err := NewDB(memOpt)
+		assert.Nil(t, err)
+		var wg sync.WaitGroup
+		for g := 0; g < 10; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for n := g * 100; n < (g+1)*100; n++ {
+					assert.Nil(t, db.Put(randkv.GetTestKey(n), randkv.GetTestKey(n)))
+				}
+			}(g)
+		}
+		wg.Wait()
+		assert.Nil(t, db.Delete(randkv.GetTestKey(0)))
+
+		// Reopen without closing, the writes are only in the wal
+		reopened, err := NewDB(memOpt)
+		assert.Nil(t, err)
+		for n := 1; n < 1000; n++ {
+			value, err := reopened.Get(randkv.GetTestKey(n))
+			assert.Nil(t, err)
+			assert.Equal(t, randkv.GetTestKey(n), value)
+		}
+		_, err = reopened.Get(randkv.GetTestKey(0))
+		assert.NotNil(t, err)
+		reopened.Clean()
+	}
+}
+
+func TestDb_DurabilityNone(t *testing.T) {
+	memOpt := config.DefaultDbMemoryOptions
+	memOpt.Option.DirPath = filepath.Join(os.TempDir(), "flydb-memory-durability-none")
+	memOpt.Option.FIOType = config.FileIOType
+	memOpt.Durability = config.DurabilityNone
+	_ = os.RemoveAll(memOpt.Option.DirPath)
+
+	db, err := NewDB(memOpt)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Put([]byte("key"), []byte("value")))
+
+	// Without a wal, a write that is only in memory does not survive a crash
+	crashed, err := NewDB(memOpt)
+	assert.Nil(t, err)
+	_, err = crashed.Get([]byte("key"))
+	assert.NotNil(t, err)
+
+	// Close flushes the memTables
+	assert.Nil(t, db.Close())
+	reopened, err := NewDB(memOpt)
+	assert.Nil(t, err)
+	value, err := reopened.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+	reopened.Clean()
+}
+
+func TestIterator_Tombstone(t *testing.T) {
+	newer := NewMemTable()
+	newer.Put("a", []byte("new"))
+	newer.PutTombstone("b")
+	older := NewMemTable()
+	older.Put("a", []byte("old"))
+	older.Put("b", []byte("old"))
+	older.Put("c", []byte("old"))
+
+	for _, reverse := range []bool{false, true} {
+		opt := config.IteratorOptions{Reverse: reverse}
+		it := &Iterator{
+			sources: []iteratorSource{newMemTableIterator(newer, opt), newMemTableIterator(older, opt)},
+			options: opt,
+		}
+		it.Rewind()
+		values := make(map[string]string)
+		var keys []string
+		for ; it.Valid(); it.Next() {
+			value, err := it.Value()
+			assert.Nil(t, err)
+			keys = append(keys, string(it.Key()))
+			values[string(it.Key())] = string(value)
+		}
+		if reverse {
+			assert.Equal(t, []string{"c", "a"}, keys)
+		} else {
+			assert.Equal(t, []string{"a", "c"}, keys)
+		}
+		assert.Equal(t, "new", values["a"])
+	}
+}
+
+func TestDb_NewIterator(t *testing.T) {
+	memOpt := config.DefaultDbMemoryOptions
+	memOpt.Option.DirPath = filepath.Join(os.TempDir(), "flydb-memory-iterator")
+	memOpt.Option.FIOType = config.FileIOType
+	memOpt.MemSize = 1024
+	_ = os.RemoveAll(memOpt.Option.DirPath)
+
+	db, err := NewDB(memOpt)
+	assert.Nil(t, err)
+	defer db.Clean()
+
+	// Spread the keys over the active memTable, immutable memTables and the engine
+	for n := 0; n < 100; n++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(n), []byte("old")))
+	}
+	for n := 10; n < 20; n++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(n), []byte("new")))
+	}
+	assert.Nil(t, db.Delete(randkv.GetTestKey(5)))
+
+	it := db.NewIterator(config.DefaultIteratorOptions)
+	var prev []byte
+	count := 0
+	for ; it.Valid(); it.Next() {
+		if prev != nil {
+			assert.Less(t, string(prev), string(it.Key()))
+		}
+		prev = it.Key()
+		assert.NotEqual(t, randkv.GetTestKey(5), it.Key())
+		value, err := it.Value()
+		assert.Nil(t, err)
+		if bytes.Compare(it.Key(), randkv.GetTestKey(10)) >= 0 && bytes.Compare(it.Key(), randkv.GetTestKey(20)) < 0 {
+			assert.Equal(t, []byte("new"), value)
+		} else {
+			assert.Equal(t, []byte("old"), value)
+		}
+		count++
+	}
+	it.Close()
+	assert.Equal(t, 99, count)
+
+	keys, err := db.Keys()
+	assert.Nil(t, err)
+	assert.Equal(t, 99, len(keys))
+
+	// Prefix and reverse
+	it = db.NewIterator(config.IteratorOptions{Prefix: []byte("flydb-key-00000000"), Reverse: true})
+	keys = keys[:0]
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	it.Close()
+	assert.Equal(t, 9, len(keys))
+	assert.Equal(t, randkv.GetTestKey(9), keys[0])
+
+	// Range scan from a key
+	it = db.NewIterator(config.DefaultIteratorOptions)
+	it.Seek(randkv.GetTestKey(95))
+	count = 0
+	for ; it.Valid(); it.Next() {
+		count++
+	}
+	it.Close()
+	assert.Equal(t, 5, count)
+}
+
+func TestDb_DeleteShadowsOlderLayers(t *testing.T) {
+	memOpt := config.DefaultDbMemoryOptions
+	memOpt.Option.DirPath = filepath.Join(os.TempDir(), "flydb-memory-tombstone")
+	memOpt.Option.FIOType = config.FileIOType
+	_ = os.RemoveAll(memOpt.Option.DirPath)
+
+	// Flush a value to disk
+	db, err := NewDB(memOpt)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Put([]byte("key"), []byte("disk")))
+	assert.Nil(t, db.Close())
+
+	// Shadow it in an immutable memTable, then delete it in the active one
+	memOpt.MemSize = 16
+	db, err = NewDB(memOpt)
+	assert.Nil(t, err)
+	defer db.Clean()
+	assert.Nil(t, db.Put([]byte("key"), []byte("memory")))
+	assert.Nil(t, db.Put([]byte("other"), []byte("value")))
+	value, err := db.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("memory"), value)
+
+	assert.Nil(t, db.Delete([]byte("key")))
+	_, err = db.Get([]byte("key"))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+	keys, err := db.Keys()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("other")}, keys)
+
+	// Put after delete, the newest version wins after the flush too
+	assert.Nil(t, db.Put([]byte("key"), []byte("again")))
+	assert.Nil(t, db.Delete([]byte("other")))
+	assert.Nil(t, db.Close())
+	db, err = NewDB(memOpt)
+	assert.Nil(t, err)
+	value, err = db.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("again"), value)
+	_, err = db.Get([]byte("other"))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+	assert.Equal(t, _const.ErrKeyIsEmpty, db.Delete(nil))
+}
+
+func TestDb_FlushBackpressure(t *testing.T) {
+	memOpt := config.DefaultDbMemoryOptions
+	memOpt.Option.DirPath = filepath.Join(os.TempDir(), "flydb-memory-flush")
+	memOpt.Option.FIOType = config.FileIOType
+	memOpt.MemSize = 256
+	memOpt.MaxImmutableMemTables = 2
+	memOpt.SlowdownImmutableMemTables = 1
+	memOpt.FlushWorkers = 4
+	_ = os.RemoveAll(memOpt.Option.DirPath)
+
+	db, err := NewDB(memOpt)
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for n := g * 500; n < (g+1)*500; n++ {
+				assert.Nil(t, db.Put(randkv.GetTestKey(n), randkv.GetTestKey(n)))
+				assert.LessOrEqual(t, db.FlushStats().ImmutableMemTables, 2)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	stats := db.FlushStats()
+	assert.Greater(t, stats.FlushedMemTables, uint64(0))
+	assert.Greater(t, stats.FlushedEntries, uint64(0))
+	assert.Greater(t, stats.FlushThroughput(), float64(0))
+	assert.Greater(t, stats.SlowdownCount, uint64(0))
+	assert.Equal(t, uint64(0), stats.FlushErrors)
+	for n := 0; n < 2000; n++ {
+		value, err := db.Get(randkv.GetTestKey(n))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(n), value)
+	}
+
+	assert.Nil(t, db.Close())
+	db, err = NewDB(memOpt)
+	assert.Nil(t, err)
+	defer db.Clean()
+	keys, err := db.Keys()
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, len(keys))
+}
+
+func TestDb_Snapshot(t *testing.T) {
+	memOpt := config.DefaultDbMemoryOptions
+	memOpt.Option.DirPath = filepath.Join(os.TempDir(), "flydb-memory-snapshot")
+	memOpt.Option.FIOType = config.FileIOType
+	memOpt.MemSize = 1024
+	memOpt.MaxImmutableMemTables = 2
+	_ = os.RemoveAll(memOpt.Option.DirPath)
+
+	db, err := NewDB(memOpt)
+	assert.Nil(t, err)
+	defer db.Clean()
+	for n := 0; n < 100; n++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(n), []byte("old")))
+	}
+
+	// Later writes fill new memTables, which are flushed while the snapshot is held,
+	// also when they are more than may wait to be flushed
+	snapshot := db.NewSnapshot()
+	for n := 0; n < 200; n++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(n), []byte("new")))
+	}
+	assert.Nil(t, db.Delete(randkv.GetTestKey(15)))
+	assert.Eventually(t, func() bool {
+		return db.FlushStats().FlushedMemTables > 5
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = snapshot.Get(randkv.GetTestKey(150))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+
+	value, err := snapshot.Get(randkv.GetTestKey(15))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("old"), value)
+	_, err = db.Get(randkv.GetTestKey(15))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+
+	// Range [10, 20) of the snapshot, and in reverse of the db
+	it := snapshot.NewIterator(config.IteratorOptions{Start: randkv.GetTestKey(10), End: randkv.GetTestKey(20)})
+	count := 0
+	for ; it.Valid(); it.Next() {
+		value, err := it.Value()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("old"), value)
+		count++
+	}
+	it.Close()
+	assert.Equal(t, 10, count)
+
+	it = snapshot.NewIterator(config.DefaultIteratorOptions)
+	count = 0
+	for ; it.Valid(); it.Next() {
+		count++
+	}
+	it.Close()
+	assert.Equal(t, 100, count)
+
+	it = db.NewIterator(config.IteratorOptions{Start: randkv.GetTestKey(10), End: randkv.GetTestKey(20), Reverse: true})
+	keys := make([][]byte, 0)
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	it.Close()
+	assert.Equal(t, 9, len(keys))
+	assert.Equal(t, randkv.GetTestKey(19), keys[0])
+	assert.Equal(t, randkv.GetTestKey(10), keys[8])
+
+	snapshot.Release()
+	assert.Nil(t, db.Close())
+	db, err = NewDB(memOpt)
+	assert.Nil(t, err)
+	value, err = db.Get(randkv.GetTestKey(20))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new"), value)
+}
+
+func TestDb_FlushRetry(t *testing.T) {
+	memOpt := config.DefaultDbMemoryOptions
+	memOpt.Option.DirPath = filepath.Join(os.TempDir(), "flydb-memory-flush-retry")
+	memOpt.Option.FIOType = config.FaultIOType
+	memOpt.MemSize = 1024
+	memOpt.Durability = config.DurabilityNone
+	_ = os.RemoveAll(memOpt.Option.DirPath)
+	fi := fileio.NewFaultInjector()
+	memOpt.Option.FaultInjector = fi
+
+	db, err := NewDB(memOpt)
+	assert.Nil(t, err)
+	defer db.Clean()
+
+	// A failed memTable stays readable and holds back the flush of the later ones
+	fi.FailWritesAfter(0, false)
+	for n := 0; n < 100; n++ {
+		assert.Nil(t, db.Put(randkv.GetTestKey(n), randkv.GetTestKey(n)))
+	}
+	assert.Eventually(t, func() bool {
+		return db.FlushStats().FlushErrors > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	stats := db.FlushStats()
+	assert.Equal(t, uint64(0), stats.FlushedMemTables)
+	assert.Greater(t, stats.ImmutableMemTables, 0)
+	for n := 0; n < 100; n++ {
+		value, err := db.Get(randkv.GetTestKey(n))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(n), value)
+	}
+
+	// Once db can be written again, the memTables are flushed in order
+	fi.Reset()
+	assert.Eventually(t, func() bool {
+		return db.FlushStats().ImmutableMemTables == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Nil(t, db.Close())
+	db, err = NewDB(memOpt)
+	assert.Nil(t, err)
+	for n := 0; n < 100; n++ {
+		value, err := db.Get(randkv.GetTestKey(n))
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(n), value)
+	}
+
+	// Close gives up on a memTable that cannot be flushed
+	fi.FailWritesAfter(0, false)
+	assert.Nil(t, db.Put([]byte("unflushed"), []byte("value")))
+	assert.Equal(t, ErrFlushFailed, db.Close())
+}
+
+func TestDb_LoadError(t *testing.T) {
+	memOpt := config.DefaultDbMemoryOptions
+	memOpt.Option.DirPath = filepath.Join(os.TempDir(), "flydb-memory-load-error")
+	memOpt.Option.FIOType = config.FileIOType
+	_ = os.RemoveAll(memOpt.Option.DirPath)
+	defer os.RemoveAll(memOpt.Option.DirPath)
+
+	db, err := NewDB(memOpt)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Put([]byte("key"), []byte("value")))
+	assert.Nil(t, db.wal.Write(&wal.Record{Type: 9, Key: []byte("key")}))
+	assert.Nil(t, db.wal.Save())
+
+	// A record that cannot be replayed fails the open instead of losing the writes after it
+	_, err = NewDB(memOpt)
+	assert.Equal(t, wal.ErrUnknownRecord, err)
+}
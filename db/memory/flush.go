@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"errors"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/wal"
+	"sync"
+	"time"
+)
+
+const (
+	// writeSlowdownDelay is how long a write waits once flushing falls behind
+	writeSlowdownDelay = time.Millisecond
+
+	// flushRetries is how often writing an entry to db is tried
+	flushRetries = 3
+
+	// flushRetryDelay is how long the flusher waits before it tries a failed memTable again
+	flushRetryDelay = 100 * time.Millisecond
+)
+
+// ErrFlushFailed is returned by Close if memTables could not be written to db
+var ErrFlushFailed = errors.New("FlushFailedError : memTables could not be written to db, only the wal holds their writes")
+
+// FlushStats are the counters of the flush scheduler
+type FlushStats struct {
+	ImmutableMemTables int           // memTables waiting to be flushed, or being flushed
+	FlushedMemTables   uint64        // memTables written to db
+	FlushedEntries     uint64        // values and tombstones written to db
+	FlushedBytes       uint64        // size of the keys and values written to db
+	FlushTime          time.Duration // time spent flushing
+	FlushErrors        uint64        // entries that could not be written to db
+	SlowdownCount      uint64        // writes that were delayed because flushing fell behind
+	SlowdownTime       time.Duration // time writes were delayed for
+	StallCount         uint64        // writes that waited for a flush to finish
+	StallTime          time.Duration // time writes stalled for
+}
+
+// FlushThroughput returns the flushed bytes per second of flush time
+func (s FlushStats) FlushThroughput() float64 {
+	if s.FlushTime <= 0 {
+		return 0
+	}
+	return float64(s.FlushedBytes) / s.FlushTime.Seconds()
+}
+
+// FlushStats returns the counters of the flush scheduler
+func (d *Db) FlushStats() FlushStats {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	stats := d.stats
+	stats.ImmutableMemTables = len(d.oldList)
+	return stats
+}
+
+// slowdown delays a write while many memTables wait to be flushed
+// Hold the mutex before accessing this method, it is released during the delay
+func (d *Db) slowdown() {
+	if len(d.oldList) < d.option.SlowdownImmutableMemTables {
+		return
+	}
+	d.mux.Unlock()
+	time.Sleep(writeSlowdownDelay)
+	d.mux.Lock()
+	d.stats.SlowdownCount++
+	d.stats.SlowdownTime += writeSlowdownDelay
+}
+
+// rotateIfFull turns the active memTable into an immutable one if size more bytes do not fit.
+// If too many memTables wait to be flushed already, it stalls until a flush finishes.
+// Hold the mutex before accessing this method, it is released while stalling
+func (d *Db) rotateIfFull(size int64) {
+	for d.activeSize > 0 && d.activeSize+size > d.option.MemSize {
+		full := len(d.oldList) >= d.option.MaxImmutableMemTables || d.totalSize > d.option.TotalMemSize
+		if !full || len(d.oldList) == 0 {
+			// add to immutable memTable list, its records end before the one being applied
+			d.mem.walEnd = d.recordPos
+			d.mem.size = d.activeSize
+			d.addOldMemTable(d.mem)
+			// create new active memTable
+			d.mem = NewMemTable()
+			d.activeSize = 0
+			return
+		}
+		// Another writer may have rotated the memTable while this one waited
+		start := time.Now()
+		d.flushed.Wait()
+		d.stats.StallCount++
+		d.stats.StallTime += time.Since(start)
+	}
+}
+
+// addOldMemTable hands a memTable to the flusher, it stays readable until it is flushed
+// Hold the mutex before accessing this method
+func (d *Db) addOldMemTable(oldList *MemTable) {
+	d.oldList = append(d.oldList, oldList)
+	d.oldListChan <- oldList
+}
+
+// flushLoop flushes the immutable memTables in the order they were filled,
+// so that db sees the writes to a key in the order they were made
+func (d *Db) flushLoop() {
+	defer close(d.flushDone)
+	for oldList := range d.oldListChan {
+		// A failed memTable stays readable and is tried again before the next one.
+		// Once the Db is closing, it is given up together with the ones after it,
+		// so that none of them is truncated from the wal.
+		for !d.flush(oldList) {
+			if d.isClosing() {
+				return
+			}
+			time.Sleep(flushRetryDelay)
+		}
+	}
+}
+
+// isClosing reports whether Close was called
+func (d *Db) isClosing() bool {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	return d.closing
+}
+
+// flush writes a memTable to db, spread over the flush workers, and reports whether all of it was written.
+// The keys of a memTable are unique, so the workers never write the same key.
+func (d *Db) flush(oldList *MemTable) bool {
+	// Snapshots taken from now on hold the memTable, the older ones may read its keys from db
+	d.mux.Lock()
+	snapshots := make([]*Snapshot, 0, len(d.snapshots))
+	for s := range d.snapshots {
+		snapshots = append(snapshots, s)
+	}
+	d.mux.Unlock()
+
+	start := time.Now()
+	entries := oldList.entries("")
+	workers := d.option.FlushWorkers
+	if workers > len(entries) {
+		workers = len(entries)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var bytes, failed uint64
+	chunk := (len(entries) + workers - 1) / workers
+	for w := 0; w*chunk < len(entries); w++ {
+		end := (w + 1) * chunk
+		if end > len(entries) {
+			end = len(entries)
+		}
+		part := entries[w*chunk : end]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var partBytes, partFailed uint64
+			for _, entry := range part {
+				if err := d.flushEntry(entry, snapshots); err != nil {
+					partFailed++
+					d.reportError("write to db error when flush the key: " + entry.key + " error: " + err.Error())
+					continue
+				}
+				partBytes += uint64(len(entry.key) + len(entry.value))
+			}
+			lock.Lock()
+			bytes += partBytes
+			failed += partFailed
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	d.mux.Lock()
+	d.stats.FlushErrors += failed
+	d.stats.FlushTime += time.Since(start)
+	if failed > 0 {
+		// The memTable is kept, and the wal keeps its records until it is flushed
+		d.mux.Unlock()
+		return false
+	}
+	for i, mt := range d.oldList {
+		if mt == oldList {
+			d.oldList = append(d.oldList[:i], d.oldList[i+1:]...)
+			break
+		}
+	}
+	d.totalSize -= oldList.size
+	d.stats.FlushedMemTables++
+	d.stats.FlushedEntries += uint64(len(entries))
+	d.stats.FlushedBytes += bytes
+	d.flushedEnd = oldList.walEnd
+	d.flushed.Broadcast()
+	d.mux.Unlock()
+
+	// The records of the memTable are in db now, the wal no longer needs them
+	// once db is synced. A wal shared by column family is truncated by its owner.
+	if d.option.OnFlush != nil {
+		d.option.OnFlush()
+	}
+	if d.option.Wal == nil && d.wal != nil {
+		if err := d.db.Sync(); err != nil {
+			d.reportError("sync db error: " + err.Error())
+			return true
+		}
+		if err := d.wal.Checkpoint(oldList.walEnd); err != nil {
+			d.reportError("checkpoint wal error: " + err.Error())
+		}
+	}
+	return true
+}
+
+// flushEntry writes a value or a tombstone to db.
+// The value it overwrites is kept for the snapshots that still read the key from db.
+func (d *Db) flushEntry(entry *memEntry, snapshots []*Snapshot) error {
+	if len(snapshots) > 0 {
+		d.snapshotLock.Lock()
+		defer d.snapshotLock.Unlock()
+		if err := d.keepForSnapshots(entry.key, snapshots); err != nil {
+			return err
+		}
+	}
+	var err error
+	for i := 0; i < flushRetries; i++ {
+		if entry.deleted {
+			err = d.db.Delete([]byte(entry.key))
+		} else {
+			err = d.db.Put([]byte(entry.key), entry.value)
+		}
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// WalCheckpoint returns the position of a shared wal before which db needs no record any more,
+// because their writes are flushed. end is the current end of the wal, it is returned
+// when no write waits to be flushed. Writes to db must be held off while it is called,
+// and db must be synced before the wal is truncated at the returned position.
+func (d *Db) WalCheckpoint(end wal.Position) wal.Position {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	if d.activeSize == 0 && len(d.oldList) == 0 {
+		return end
+	}
+	return d.flushedEnd
+}
+
+// Sync writes the flushed writes of db to disk
+func (d *Db) Sync() error {
+	return d.db.Sync()
+}
+
+// keepForSnapshots copies the value of a key in db to the snapshots that read it from there
+// Hold snapshotLock before accessing this method
+func (d *Db) keepForSnapshots(key string, snapshots []*Snapshot) error {
+	var value []byte
+	var read, found bool
+	for _, s := range snapshots {
+		if s.holds(key) {
+			continue
+		}
+		if !read {
+			v, err := d.db.Get([]byte(key))
+			if err != nil && err != _const.ErrKeyNotFound {
+				return err
+			}
+			value, found, read = v, err == nil, true
+		}
+		if found {
+			s.kept.Put(key, value)
+		} else {
+			s.kept.PutTombstone(key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package memory
+
+import (
+	"bytes"
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	"sort"
+)
+
+// iteratorSource is one layer merged by Iterator
+type iteratorSource interface {
+	Rewind()
+	Seek(key []byte)
+	Next()
+	Valid() bool
+	Key() []byte
+	Value() ([]byte, error)
+	// Deleted reports whether the current entry is a tombstone
+	Deleted() bool
+	Close()
+}
+
+// Iterator iterates over the keys of a Db in order.
+// It merges the active memTable, the immutable memTables and the engine;
+// a key is taken from the newest layer that holds it, and a tombstone there hides it.
+type Iterator struct {
+	sources []iteratorSource // newest layer first
+	options config.IteratorOptions
+	current int // index of the source of the current key, -1 after the last key
+}
+
+// NewIterator Initializes an iterator over a snapshot of the memTables and the engine
+func (d *Db) NewIterator(opt config.IteratorOptions) *Iterator {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	return newIterator(d.layers(), d.db, opt)
+}
+
+// newIterator merges the memTables, newest first, and the engine
+func newIterator(layers []*MemTable, db *engine.DB, opt config.IteratorOptions) *Iterator {
+	sources := make([]iteratorSource, 0, len(layers)+1)
+	for _, mt := range layers {
+		sources = append(sources, newMemTableIterator(mt, opt))
+	}
+	sources = append(sources, &engineIterator{Iterator: db.NewIterator(opt)})
+
+	it := &Iterator{
+		sources: sources,
+		options: opt,
+	}
+	it.Rewind()
+	return it
+}
+
+// Rewind moves to the first key, of the range if the options have one
+func (it *Iterator) Rewind() {
+	for _, source := range it.sources {
+		source.Rewind()
+		if !it.options.Reverse && it.options.Start != nil {
+			source.Seek(it.options.Start)
+		}
+		if it.options.Reverse && it.options.End != nil {
+			source.Seek(it.options.End)
+		}
+	}
+	it.skipDeleted()
+}
+
+// Seek moves to the first key >= key, or <= key for a reverse iterator
+func (it *Iterator) Seek(key []byte) {
+	for _, source := range it.sources {
+		source.Seek(key)
+	}
+	it.skipDeleted()
+}
+
+// Next moves to the next key
+func (it *Iterator) Next() {
+	if !it.Valid() {
+		return
+	}
+	it.advance(it.Key())
+	it.skipDeleted()
+}
+
+func (it *Iterator) Valid() bool {
+	return it.current >= 0
+}
+
+func (it *Iterator) Key() []byte {
+	return it.sources[it.current].Key()
+}
+
+func (it *Iterator) Value() ([]byte, error) {
+	return it.sources[it.current].Value()
+}
+
+func (it *Iterator) Close() {
+	for _, source := range it.sources {
+		source.Close()
+	}
+}
+
+// advance moves every source that is at key past it
+func (it *Iterator) advance(key []byte) {
+	for _, source := range it.sources {
+		if source.Valid() && bytes.Equal(source.Key(), key) {
+			source.Next()
+		}
+	}
+}
+
+// skipDeleted picks the next key, skipping the keys whose newest entry is a tombstone.
+// Keys before the range of the options are skipped, a key after it ends the iteration.
+func (it *Iterator) skipDeleted() {
+	for {
+		it.current = it.pick()
+		if it.current < 0 {
+			return
+		}
+		key := it.sources[it.current].Key()
+		if it.afterRange(key) {
+			it.current = -1
+			return
+		}
+		if !it.beforeRange(key) && !it.sources[it.current].Deleted() {
+			return
+		}
+		it.advance(key)
+	}
+}
+
+// beforeRange reports whether the iteration has not reached the range of the options yet
+func (it *Iterator) beforeRange(key []byte) bool {
+	if it.options.Reverse {
+		return it.options.End != nil && bytes.Compare(key, it.options.End) >= 0
+	}
+	return it.options.Start != nil && bytes.Compare(key, it.options.Start) < 0
+}
+
+// afterRange reports whether the iteration has left the range of the options
+func (it *Iterator) afterRange(key []byte) bool {
+	if it.options.Reverse {
+		return it.options.Start != nil && bytes.Compare(key, it.options.Start) < 0
+	}
+	return it.options.End != nil && bytes.Compare(key, it.options.End) >= 0
+}
+
+// pick returns the source with the smallest key, or the largest for a reverse iterator.
+// If several sources are at the same key, the newest one wins.
+func (it *Iterator) pick() int {
+	best := -1
+	for i, source := range it.sources {
+		if !source.Valid() {
+			continue
+		}
+		if best < 0 {
+			best = i
+			continue
+		}
+		cmp := bytes.Compare(source.Key(), it.sources[best].Key())
+		if (!it.options.Reverse && cmp < 0) || (it.options.Reverse && cmp > 0) {
+			best = i
+		}
+	}
+	return best
+}
+
+// memTableIterator iterates over the entries a memTable held when it was created
+type memTableIterator struct {
+	currIndex int
+	reverse   bool
+	entries   []*memEntry
+}
+
+func newMemTableIterator(m *MemTable, opt config.IteratorOptions) *memTableIterator {
+	entries := m.entries(string(opt.Prefix))
+	if opt.Reverse {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+	return &memTableIterator{
+		reverse: opt.Reverse,
+		entries: entries,
+	}
+}
+
+func (mi *memTableIterator) Rewind() {
+	mi.currIndex = 0
+}
+
+func (mi *memTableIterator) Seek(key []byte) {
+	// Binary search
+	if mi.reverse {
+		mi.currIndex = sort.Search(len(mi.entries), func(i int) bool {
+			return mi.entries[i].key <= string(key)
+		})
+	} else {
+		mi.currIndex = sort.Search(len(mi.entries), func(i int) bool {
+			return mi.entries[i].key >= string(key)
+		})
+	}
+}
+
+func (mi *memTableIterator) Next() {
+	mi.currIndex += 1
+}
+
+func (mi *memTableIterator) Valid() bool {
+	return mi.currIndex < len(mi.entries)
+}
+
+func (mi *memTableIterator) Key() []byte {
+	return []byte(mi.entries[mi.currIndex].key)
+}
+
+func (mi *memTableIterator) Value() ([]byte, error) {
+	return mi.entries[mi.currIndex].value, nil
+}
+
+func (mi *memTableIterator) Deleted() bool {
+	return mi.entries[mi.currIndex].deleted
+}
+
+func (mi *memTableIterator) Close() {
+	mi.entries = nil
+}
+
+// engineIterator is the oldest layer, the engine holds no tombstones
+type engineIterator struct {
+	*engine.Iterator
+}
+
+func (ei *engineIterator) Deleted() bool {
+	return false
+}
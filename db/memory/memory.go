@@ -2,19 +2,35 @@ package memory
 
 import (
 	"errors"
+	"github.com/ByteStorage/FlyDB/lib/wal"
+	"github.com/google/btree"
+	"strings"
 	"sync"
 )
 
-// MemTable is an in-memory table
+// memEntry is an entry of a MemTable, either a value or a tombstone
+type memEntry struct {
+	key     string
+	value   []byte
+	deleted bool // tombstone, the key was deleted
+}
+
+func (e *memEntry) Less(than btree.Item) bool {
+	return e.key < than.(*memEntry).key
+}
+
+// MemTable is an in-memory table, sorted by key
 type MemTable struct {
-	table map[string][]byte // key -> value
-	mutex sync.RWMutex      // protect table
+	table  *btree.BTree // key -> entry
+	mutex  sync.RWMutex // protect table
+	walEnd wal.Position // wal position after the last record of the table, set when it becomes immutable
+	size   int64        // bytes written to the table, set when it becomes immutable
 }
 
 // NewMemTable create a new MemTable
 func NewMemTable() *MemTable {
 	return &MemTable{
-		table: make(map[string][]byte),
+		table: btree.New(32),
 	}
 }
 
@@ -22,7 +38,14 @@ func NewMemTable() *MemTable {
 func (m *MemTable) Put(key string, value []byte) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	m.table[key] = value
+	m.table.ReplaceOrInsert(&memEntry{key: key, value: value})
+}
+
+// PutTombstone marks a key as deleted, hiding its value in older layers
+func (m *MemTable) PutTombstone(key string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.table.ReplaceOrInsert(&memEntry{key: key, deleted: true})
 }
 
 // Get a value from the table
@@ -30,17 +53,61 @@ func (m *MemTable) Get(key string) ([]byte, error) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
-	value, ok := m.table[key]
-	if !ok {
+	item := m.table.Get(&memEntry{key: key})
+	if item == nil || item.(*memEntry).deleted {
 		return nil, errors.New("key not found")
 	}
 
-	return value, nil
+	return item.(*memEntry).value, nil
+}
+
+// lookup returns the entry of a key, a value or a tombstone, or nil if the table does not hold the key
+func (m *MemTable) lookup(key string) *memEntry {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	item := m.table.Get(&memEntry{key: key})
+	if item == nil {
+		return nil
+	}
+	return item.(*memEntry)
 }
 
 // Delete a key from the table
 func (m *MemTable) Delete(key string) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	delete(m.table, key)
+	m.table.Delete(&memEntry{key: key})
+}
+
+// Len returns the number of entries in the table, tombstones included
+func (m *MemTable) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.table.Len()
+}
+
+// clone returns a copy of the table, later writes to either do not show in the other
+func (m *MemTable) clone() *MemTable {
+	// cloning makes the nodes of the table copy-on-write, which changes the table
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return &MemTable{table: m.table.Clone()}
+}
+
+// entries returns the entries with the given prefix in key order
+func (m *MemTable) entries(prefix string) []*memEntry {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	entries := make([]*memEntry, 0)
+	m.table.AscendGreaterOrEqual(&memEntry{key: prefix}, func(item btree.Item) bool {
+		entry := item.(*memEntry)
+		if !strings.HasPrefix(entry.key, prefix) {
+			return false
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	return entries
 }
@@ -30,3 +30,22 @@ func TestMemTable_Put(t *testing.T) {
 	assert.NotNil(t, value)
 	assert.Equal(t, "test", string(value))
 }
+
+func TestMemTable_Sorted(t *testing.T) {
+	table := NewMemTable()
+	table.Put("b", []byte("2"))
+	table.Put("a", []byte("1"))
+	table.Put("c", []byte("3"))
+	table.PutTombstone("ab")
+
+	_, err := table.Get("ab")
+	assert.NotNil(t, err)
+	assert.Equal(t, 4, table.Len())
+
+	var keys []string
+	for _, entry := range table.entries("") {
+		keys = append(keys, entry.key)
+	}
+	assert.Equal(t, []string{"a", "ab", "b", "c"}, keys)
+	assert.Equal(t, 2, len(table.entries("a")))
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+)
+
+// Snapshot is a consistent view of a Db at the time it was taken.
+// It pins the memTables of that time, and flushes go on while it is held:
+// before a flush overwrites a key the snapshot reads from db, the old value is kept in the snapshot.
+// Release snapshots once they are done, and before the Db is closed.
+type Snapshot struct {
+	db       *Db
+	layers   []*MemTable // newest first, the last is kept
+	kept     *MemTable   // values of db overwritten by flushes since the snapshot, tombstones for the keys they added
+	released bool
+}
+
+// NewSnapshot takes a snapshot of the memTables and the engine
+func (d *Db) NewSnapshot() *Snapshot {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	// the immutable memTables do not change any more, only the active one is copied
+	kept := NewMemTable()
+	layers := append(d.layers(), kept)
+	layers[0] = layers[0].clone()
+	s := &Snapshot{db: d, layers: layers, kept: kept}
+	d.snapshots[s] = struct{}{}
+	return s
+}
+
+// Get returns the value of a key at the time of the snapshot
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	// a flush must not overwrite the key between the lookups and the read from db
+	s.db.snapshotLock.RLock()
+	defer s.db.snapshotLock.RUnlock()
+	for _, mt := range s.layers {
+		entry := mt.lookup(string(key))
+		if entry == nil {
+			continue
+		}
+		if entry.deleted {
+			return nil, _const.ErrKeyNotFound
+		}
+		return entry.value, nil
+	}
+	return s.db.db.Get(key)
+}
+
+// NewIterator Initializes an iterator over the keys at the time of the snapshot
+func (s *Snapshot) NewIterator(opt config.IteratorOptions) *Iterator {
+	s.db.snapshotLock.RLock()
+	defer s.db.snapshotLock.RUnlock()
+	// flushes go on adding to kept while the iterator runs, the engine must not show their keys
+	opt.Snapshot = true
+	layers := append([]*MemTable(nil), s.layers...)
+	layers[len(layers)-1] = s.kept.clone()
+	return newIterator(layers, s.db.db, opt)
+}
+
+// Release unpins the memTables of the snapshot
+func (s *Snapshot) Release() {
+	s.db.mux.Lock()
+	defer s.db.mux.Unlock()
+	if s.released {
+		return
+	}
+	s.released = true
+	delete(s.db.snapshots, s)
+}
+
+// holds reports whether the snapshot reads key from its memTables rather than from db
+func (s *Snapshot) holds(key string) bool {
+	for _, mt := range s.layers {
+		if mt.lookup(key) != nil {
+			return true
+		}
+	}
+	return false
+}
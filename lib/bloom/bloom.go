@@ -7,9 +7,9 @@ import (
 
 // Filter represents a structure for the filter itself.
 type Filter struct {
-	bitSet    []bool // Bit array to hold the state of the data
-	size      uint32 // Size of the bit array
-	numHashes uint8  // Number of hash functions to use
+	bitSet    []uint64 // Bit array to hold the state of the data, 64 bits per word
+	size      uint32   // Size of the bit array
+	numHashes uint8    // Number of hash functions to use
 }
 
 // NewBloomFilter initializes a new Bloom filter based on the expected number of items and desired false positive rate.
@@ -18,9 +18,16 @@ func NewBloomFilter(expectedItems uint32, fpRate float64) *Filter {
 	size := uint32(-float64(expectedItems) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
 	// Calculate the optimal number of hash functions based on the size of bit array and expected number of items
 	numHashes := uint8(float64(size) / float64(expectedItems) * math.Ln2)
+	// Guard against degenerate parameters
+	if size == 0 {
+		size = 1
+	}
+	if numHashes == 0 {
+		numHashes = 1
+	}
 
 	return &Filter{
-		bitSet:    make([]bool, size),
+		bitSet:    make([]uint64, (size+63)/64),
 		size:      size,
 		numHashes: numHashes,
 	}
@@ -32,7 +39,7 @@ func (f *Filter) Add(item []byte) {
 	// For each hash value, find the position and set the bit to true
 	for i := uint8(0); i < f.numHashes; i++ {
 		position := hashes[i] % f.size
-		f.bitSet[position] = true
+		f.bitSet[position/64] |= 1 << (position % 64)
 	}
 }
 
@@ -43,7 +50,7 @@ func (f *Filter) MayContainItem(item []byte) bool {
 	hashes := f.hash(item)
 	for i := uint8(0); i < f.numHashes; i++ {
 		position := hashes[i] % f.size
-		if !f.bitSet[position] {
+		if f.bitSet[position/64]&(1<<(position%64)) == 0 {
 			return false
 		}
 	}
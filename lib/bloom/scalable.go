@@ -0,0 +1,224 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math"
+	"os"
+)
+
+const (
+	// growthFactor multiplies the capacity of every new layer
+	growthFactor = 2
+
+	// tighteningRatio multiplies the false positive rate of every new layer,
+	// which keeps the compound rate of all layers below the configured one
+	tighteningRatio = 0.8
+
+	// scalableFilterVersion is the version of the encoding written by MarshalBinary
+	scalableFilterVersion = 1
+)
+
+var ErrInvalidFilterData = errors.New("InvalidFilterDataError : bloom filter data is corrupted")
+
+// ScalableFilter is a Bloom filter that grows with the number of items.
+// Once the newest layer holds as many items as it was sized for,
+// a larger layer with a tighter false positive rate is added,
+// so the overall false positive rate stays close to the configured one.
+// It is not safe for concurrent use.
+type ScalableFilter struct {
+	layers   []*layer
+	capacity uint32  // Capacity of the first layer
+	fpRate   float64 // Target false positive rate of the whole filter
+}
+
+type layer struct {
+	filter   *Filter
+	capacity uint32 // Number of items the layer was sized for
+	count    uint32 // Number of items added to the layer
+}
+
+// NewScalableFilter creates a filter whose first layer holds initialCapacity items
+// and whose compound false positive rate stays below fpRate.
+func NewScalableFilter(initialCapacity uint32, fpRate float64) *ScalableFilter {
+	if initialCapacity == 0 {
+		initialCapacity = 1
+	}
+	sf := &ScalableFilter{
+		capacity: initialCapacity,
+		fpRate:   fpRate,
+	}
+	sf.addLayer()
+	return sf
+}
+
+// addLayer appends a layer larger and stricter than the previous one
+func (sf *ScalableFilter) addLayer() {
+	capacity := sf.capacity
+	layerFpRate := sf.fpRate * (1 - tighteningRatio)
+	for range sf.layers {
+		capacity *= growthFactor
+		layerFpRate *= tighteningRatio
+	}
+	sf.layers = append(sf.layers, &layer{
+		filter:   NewBloomFilter(capacity, layerFpRate),
+		capacity: capacity,
+	})
+}
+
+// Add inserts an item into the filter.
+func (sf *ScalableFilter) Add(item []byte) {
+	current := sf.layers[len(sf.layers)-1]
+	if current.count >= current.capacity {
+		sf.addLayer()
+		current = sf.layers[len(sf.layers)-1]
+	}
+	current.filter.Add(item)
+	current.count++
+}
+
+// MayContainItem checks if an item is possibly in the set.
+// If it returns false, the item is definitely not in the set.
+func (sf *ScalableFilter) MayContainItem(item []byte) bool {
+	// The newest layer is the most likely to contain recently added items
+	for i := len(sf.layers) - 1; i >= 0; i-- {
+		if sf.layers[i].filter.MayContainItem(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of items added to the filter.
+func (sf *ScalableFilter) Count() uint32 {
+	var count uint32
+	for _, l := range sf.layers {
+		count += l.count
+	}
+	return count
+}
+
+// Layers returns the number of layers of the filter.
+func (sf *ScalableFilter) Layers() int {
+	return len(sf.layers)
+}
+
+// MarshalBinary encodes the filter.
+// +---------+------------+----------+--------+----------------------------------------------+-------+
+// | version |  capacity  |  fpRate  | layers | capacity, count, size, hashes, words... x N  |  crc  |
+// +---------+------------+----------+--------+----------------------------------------------+-------+
+// | 1 byte  |  4 bytes   |  8 bytes | 4 bytes|                   variable                   |4 bytes|
+// +---------+------------+----------+--------+----------------------------------------------+-------+
+func (sf *ScalableFilter) MarshalBinary() ([]byte, error) {
+	size := 1 + 4 + 8 + 4
+	for _, l := range sf.layers {
+		size += 4 + 4 + 4 + 1 + 8*len(l.filter.bitSet)
+	}
+	buf := make([]byte, size+crc32.Size)
+
+	index := 0
+	buf[index] = scalableFilterVersion
+	index++
+	binary.LittleEndian.PutUint32(buf[index:], sf.capacity)
+	index += 4
+	binary.LittleEndian.PutUint64(buf[index:], math.Float64bits(sf.fpRate))
+	index += 8
+	binary.LittleEndian.PutUint32(buf[index:], uint32(len(sf.layers)))
+	index += 4
+	for _, l := range sf.layers {
+		binary.LittleEndian.PutUint32(buf[index:], l.capacity)
+		index += 4
+		binary.LittleEndian.PutUint32(buf[index:], l.count)
+		index += 4
+		binary.LittleEndian.PutUint32(buf[index:], l.filter.size)
+		index += 4
+		buf[index] = l.filter.numHashes
+		index++
+		for _, word := range l.filter.bitSet {
+			binary.LittleEndian.PutUint64(buf[index:], word)
+			index += 8
+		}
+	}
+	binary.LittleEndian.PutUint32(buf[index:], crc32.ChecksumIEEE(buf[:index]))
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary.
+func (sf *ScalableFilter) UnmarshalBinary(buf []byte) error {
+	if len(buf) < 1+4+8+4+crc32.Size || buf[0] != scalableFilterVersion {
+		return ErrInvalidFilterData
+	}
+	end := len(buf) - crc32.Size
+	if binary.LittleEndian.Uint32(buf[end:]) != crc32.ChecksumIEEE(buf[:end]) {
+		return ErrInvalidFilterData
+	}
+
+	index := 1
+	capacity := binary.LittleEndian.Uint32(buf[index:])
+	index += 4
+	fpRate := math.Float64frombits(binary.LittleEndian.Uint64(buf[index:]))
+	index += 8
+	numLayers := binary.LittleEndian.Uint32(buf[index:])
+	index += 4
+
+	layers := make([]*layer, 0, numLayers)
+	for i := uint32(0); i < numLayers; i++ {
+		if index+13 > end {
+			return ErrInvalidFilterData
+		}
+		l := &layer{
+			capacity: binary.LittleEndian.Uint32(buf[index:]),
+			count:    binary.LittleEndian.Uint32(buf[index+4:]),
+			filter: &Filter{
+				size:      binary.LittleEndian.Uint32(buf[index+8:]),
+				numHashes: buf[index+12],
+			},
+		}
+		index += 13
+		words := int((l.filter.size + 63) / 64)
+		if l.filter.size == 0 || index+8*words > end {
+			return ErrInvalidFilterData
+		}
+		l.filter.bitSet = make([]uint64, words)
+		for w := range l.filter.bitSet {
+			l.filter.bitSet[w] = binary.LittleEndian.Uint64(buf[index:])
+			index += 8
+		}
+		layers = append(layers, l)
+	}
+	if len(layers) == 0 {
+		return ErrInvalidFilterData
+	}
+
+	sf.capacity = capacity
+	sf.fpRate = fpRate
+	sf.layers = layers
+	return nil
+}
+
+// SaveFile writes the filter to a file, replacing it atomically.
+func (sf *ScalableFilter) SaveFile(path string) error {
+	buf, err := sf.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// LoadScalableFilter reads a filter written by SaveFile.
+func LoadScalableFilter(path string) (*ScalableFilter, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sf := &ScalableFilter{}
+	if err := sf.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	return sf, nil
+}
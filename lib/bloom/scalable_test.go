@@ -0,0 +1,63 @@
+package bloom
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScalableFilter_Grow(t *testing.T) {
+	filter := NewScalableFilter(100, 0.01)
+	for i := 0; i < 10000; i++ {
+		filter.Add([]byte(fmt.Sprintf("key-%d", i)))
+	}
+	assert.Equal(t, uint32(10000), filter.Count())
+	assert.True(t, filter.Layers() > 1)
+
+	// No false negatives
+	for i := 0; i < 10000; i++ {
+		assert.True(t, filter.MayContainItem([]byte(fmt.Sprintf("key-%d", i))))
+	}
+
+	// The false positive rate stays close to the target although the filter grew 100 times
+	var falsePositives int
+	for i := 0; i < 10000; i++ {
+		if filter.MayContainItem([]byte(fmt.Sprintf("absent-%d", i))) {
+			falsePositives++
+		}
+	}
+	assert.True(t, falsePositives < 300, "false positives: %d", falsePositives)
+}
+
+func TestScalableFilter_SaveAndLoad(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "flydb-bloom")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "bloom")
+
+	filter := NewScalableFilter(10, 0.01)
+	for i := 0; i < 100; i++ {
+		filter.Add([]byte(fmt.Sprintf("key-%d", i)))
+	}
+	assert.Nil(t, filter.SaveFile(path))
+
+	loaded, err := LoadScalableFilter(path)
+	assert.Nil(t, err)
+	assert.Equal(t, filter.Count(), loaded.Count())
+	assert.Equal(t, filter.Layers(), loaded.Layers())
+	for i := 0; i < 100; i++ {
+		assert.True(t, loaded.MayContainItem([]byte(fmt.Sprintf("key-%d", i))))
+	}
+
+	// Adding keeps working after a load
+	loaded.Add([]byte("new"))
+	assert.True(t, loaded.MayContainItem([]byte("new")))
+
+	// Corrupted data is rejected
+	buf, _ := os.ReadFile(path)
+	buf[len(buf)/2] ^= 0xff
+	assert.Nil(t, os.WriteFile(path, buf, 0644))
+	_, err = LoadScalableFilter(path)
+	assert.Equal(t, ErrInvalidFilterData, err)
+}
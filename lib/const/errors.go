@@ -11,8 +11,10 @@ var (
 	ErrDataDirectoryCorrupted = errors.New("DataDirectoryCorruptedError : the databases directory maybe corrupted")
 	ErrExceedMaxBatchNum      = errors.New("ExceedMaxBatchNumError : exceed the max batch num")
 	ErrMergeIsProgress        = errors.New("MergeIsProgressError : merge is in progress, try again later")
+	ErrDBIsReadOnly           = errors.New("DBReadOnlyError : the database was opened read-only")
 
 	ErrOptionDirPathIsEmpty          = errors.New("OptionDirPathError : database dir path is empty")
 	ErrOptionDataFileSizeNotPositive = errors.New("OptionDataFileSizeError : database data file size must be greater than 0")
 	ErrOptionAddrIsEmpty             = errors.New("OptionAddrError : database addr is empty")
+	ErrOptionIndexNeedsDisk          = errors.New("OptionIndexTypeError : the index type is persisted on disk and can not be used with in-memory IO")
 )
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.31.0
 // 	protoc        v3.6.1
 // source: lib/proto/ghash/db.proto
 
@@ -28,7 +28,6 @@ type GHashSetRequest struct {
 	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
 	// Types that are assignable to Value:
-	//
 	//	*GHashSetRequest_StringValue
 	//	*GHashSetRequest_Int32Value
 	//	*GHashSetRequest_Int64Value
@@ -295,7 +294,6 @@ type GHashGetResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Value:
-	//
 	//	*GHashGetResponse_StringValue
 	//	*GHashGetResponse_Int32Value
 	//	*GHashGetResponse_Int64Value
@@ -746,7 +744,6 @@ type GHashUpdateRequest struct {
 	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
 	// Types that are assignable to Value:
-	//
 	//	*GHashUpdateRequest_StringValue
 	//	*GHashUpdateRequest_Int32Value
 	//	*GHashUpdateRequest_Int64Value
@@ -1502,7 +1499,6 @@ type GHashSetNXRequest struct {
 	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
 	// Types that are assignable to Value:
-	//
 	//	*GHashSetNXRequest_StringValue
 	//	*GHashSetNXRequest_Int32Value
 	//	*GHashSetNXRequest_Int64Value
@@ -2193,6 +2189,140 @@ func (x *GHashExpireResponse) GetOk() bool {
 	return false
 }
 
+type GHashScanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Match  string `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	Count  int64  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *GHashScanRequest) Reset() {
+	*x = GHashScanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_ghash_db_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GHashScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GHashScanRequest) ProtoMessage() {}
+
+func (x *GHashScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_ghash_db_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GHashScanRequest.ProtoReflect.Descriptor instead.
+func (*GHashScanRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_ghash_db_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GHashScanRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GHashScanRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GHashScanRequest) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *GHashScanRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GHashScanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cursor string   `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Fields []string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Values []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *GHashScanResponse) Reset() {
+	*x = GHashScanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_ghash_db_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GHashScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GHashScanResponse) ProtoMessage() {}
+
+func (x *GHashScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_ghash_db_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GHashScanResponse.ProtoReflect.Descriptor instead.
+func (*GHashScanResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_ghash_db_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GHashScanResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GHashScanResponse) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *GHashScanResponse) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 var File_lib_proto_ghash_db_proto protoreflect.FileDescriptor
 
 var file_lib_proto_ghash_db_proto_rawDesc = []byte{
@@ -2368,78 +2498,95 @@ var file_lib_proto_ghash_db_proto_rawDesc = []byte{
 	0x79, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
 	0x74, 0x74, 0x6c, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x48, 0x61, 0x73, 0x68, 0x45, 0x78, 0x70, 0x69,
 	0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x32, 0xd7, 0x08, 0x0a, 0x0c, 0x47,
-	0x48, 0x61, 0x73, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x04, 0x48,
-	0x53, 0x65, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73,
-	0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68,
-	0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x04, 0x48, 0x47, 0x65, 0x74, 0x12, 0x16,
-	0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x47, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47,
-	0x48, 0x61, 0x73, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x39, 0x0a, 0x04, 0x48, 0x44, 0x65, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x68, 0x61, 0x73,
-	0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x44, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x44,
-	0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x07,
-	0x48, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e,
-	0x47, 0x48, 0x61, 0x73, 0x68, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68,
-	0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x39, 0x0a, 0x04, 0x48, 0x4c, 0x65, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68,
-	0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x4c, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x4c, 0x65,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x07, 0x48,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47,
-	0x48, 0x61, 0x73, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x42, 0x0a, 0x07, 0x48, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x61,
-	0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48,
-	0x61, 0x73, 0x68, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0c, 0x48, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x46, 0x6c,
-	0x6f, 0x61, 0x74, 0x12, 0x1e, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73,
-	0x68, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73,
-	0x68, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x07, 0x48, 0x44, 0x65, 0x63, 0x72, 0x42,
-	0x79, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x44,
-	0x65, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67,
-	0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x44, 0x65, 0x63, 0x72, 0x42, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x07, 0x48, 0x53,
-	0x74, 0x72, 0x4c, 0x65, 0x6e, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48,
-	0x61, 0x73, 0x68, 0x53, 0x74, 0x72, 0x4c, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x74,
-	0x72, 0x4c, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c,
-	0x0a, 0x05, 0x48, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e,
-	0x47, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x18, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x6f,
-	0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x06,
-	0x48, 0x53, 0x65, 0x74, 0x4e, 0x58, 0x12, 0x18, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47,
-	0x48, 0x61, 0x73, 0x68, 0x53, 0x65, 0x74, 0x4e, 0x58, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x65,
-	0x74, 0x4e, 0x58, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a,
-	0x05, 0x48, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47,
-	0x48, 0x61, 0x73, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x18, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x05, 0x48,
-	0x4b, 0x65, 0x79, 0x73, 0x12, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61,
-	0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
-	0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x03, 0x54, 0x54, 0x4c,
-	0x12, 0x16, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x54, 0x54,
-	0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68,
-	0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x54, 0x54, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x17, 0x2e, 0x67, 0x68,
-	0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x22, 0x68, 0x0a, 0x10, 0x47, 0x48,
+	0x61, 0x73, 0x68, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5b, 0x0a, 0x11, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x63, 0x61,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x32, 0x95, 0x09, 0x0a, 0x0c, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x39, 0x0a, 0x04, 0x48, 0x53, 0x65, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x68, 0x61,
+	0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68,
+	0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a,
+	0x04, 0x48, 0x47, 0x65, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48,
+	0x61, 0x73, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x04, 0x48, 0x44, 0x65, 0x6c,
+	0x12, 0x16, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x44, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68,
+	0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x44, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x07, 0x48, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x19,
+	0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x45, 0x78, 0x69, 0x73,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73,
+	0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x04, 0x48, 0x4c, 0x65, 0x6e, 0x12,
+	0x16, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x4c, 0x65, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e,
+	0x47, 0x48, 0x61, 0x73, 0x68, 0x4c, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x42, 0x0a, 0x07, 0x48, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x19, 0x2e,
+	0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68,
+	0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x07, 0x48, 0x49, 0x6e, 0x63, 0x72, 0x42,
+	0x79, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x49,
+	0x6e, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67,
+	0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0c, 0x48, 0x49,
+	0x6e, 0x63, 0x72, 0x42, 0x79, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x1e, 0x2e, 0x67, 0x68, 0x61,
+	0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x46, 0x6c,
+	0x6f, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x68, 0x61,
+	0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x46, 0x6c,
+	0x6f, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a,
+	0x07, 0x48, 0x44, 0x65, 0x63, 0x72, 0x42, 0x79, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68,
+	0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x44, 0x65, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73,
+	0x68, 0x44, 0x65, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x42, 0x0a, 0x07, 0x48, 0x53, 0x74, 0x72, 0x4c, 0x65, 0x6e, 0x12, 0x19, 0x2e, 0x67,
+	0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x74, 0x72, 0x4c, 0x65, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e,
+	0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x74, 0x72, 0x4c, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x05, 0x48, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x17,
+	0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x76, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e,
+	0x47, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x06, 0x48, 0x53, 0x65, 0x74, 0x4e, 0x58, 0x12, 0x18, 0x2e,
+	0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x65, 0x74, 0x4e, 0x58,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e,
+	0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x65, 0x74, 0x4e, 0x58, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x05, 0x48, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x2e,
+	0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47,
+	0x48, 0x61, 0x73, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3c, 0x0a, 0x05, 0x48, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x17, 0x2e, 0x67, 0x68,
+	0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61,
-	0x73, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x42, 0x0a, 0x07, 0x48, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x68,
-	0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47,
-	0x48, 0x61, 0x73, 0x68, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x42, 0x11, 0x5a, 0x0f, 0x6c, 0x69, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x67, 0x68, 0x61, 0x73, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x38, 0x0a, 0x03, 0x54, 0x54, 0x4c, 0x12, 0x16, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e,
+	0x47, 0x48, 0x61, 0x73, 0x68, 0x54, 0x54, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x54, 0x54, 0x4c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68,
+	0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x68,
+	0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x07, 0x48, 0x45, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x45, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x05, 0x48,
+	0x53, 0x63, 0x61, 0x6e, 0x12, 0x17, 0x2e, 0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61,
+	0x73, 0x68, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x67, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x47, 0x48, 0x61, 0x73, 0x68, 0x53, 0x63, 0x61, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x11, 0x5a, 0x0f, 0x6c, 0x69, 0x62,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x68, 0x61, 0x73, 0x68, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2454,7 +2601,7 @@ func file_lib_proto_ghash_db_proto_rawDescGZIP() []byte {
 	return file_lib_proto_ghash_db_proto_rawDescData
 }
 
-var file_lib_proto_ghash_db_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_lib_proto_ghash_db_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_lib_proto_ghash_db_proto_goTypes = []interface{}{
 	(*GHashSetRequest)(nil),          // 0: ghash.GHashSetRequest
 	(*GHashSetResponse)(nil),         // 1: ghash.GHashSetResponse
@@ -2490,6 +2637,8 @@ var file_lib_proto_ghash_db_proto_goTypes = []interface{}{
 	(*GHashSizeResponse)(nil),        // 31: ghash.GHashSizeResponse
 	(*GHashExpireRequest)(nil),       // 32: ghash.GHashExpireRequest
 	(*GHashExpireResponse)(nil),      // 33: ghash.GHashExpireResponse
+	(*GHashScanRequest)(nil),         // 34: ghash.GHashScanRequest
+	(*GHashScanResponse)(nil),        // 35: ghash.GHashScanResponse
 }
 var file_lib_proto_ghash_db_proto_depIdxs = []int32{
 	0,  // 0: ghash.GHashService.HSet:input_type -> ghash.GHashSetRequest
@@ -2509,25 +2658,27 @@ var file_lib_proto_ghash_db_proto_depIdxs = []int32{
 	28, // 14: ghash.GHashService.TTL:input_type -> ghash.GHashTTLRequest
 	30, // 15: ghash.GHashService.Size:input_type -> ghash.GHashSizeRequest
 	32, // 16: ghash.GHashService.HExpire:input_type -> ghash.GHashExpireRequest
-	1,  // 17: ghash.GHashService.HSet:output_type -> ghash.GHashSetResponse
-	3,  // 18: ghash.GHashService.HGet:output_type -> ghash.GHashGetResponse
-	5,  // 19: ghash.GHashService.HDel:output_type -> ghash.GHashDelResponse
-	7,  // 20: ghash.GHashService.HExists:output_type -> ghash.GHashExistsResponse
-	9,  // 21: ghash.GHashService.HLen:output_type -> ghash.GHashLenResponse
-	11, // 22: ghash.GHashService.HUpdate:output_type -> ghash.GHashUpdateResponse
-	13, // 23: ghash.GHashService.HIncrBy:output_type -> ghash.GHashIncrByResponse
-	15, // 24: ghash.GHashService.HIncrByFloat:output_type -> ghash.GHashIncrByFloatResponse
-	17, // 25: ghash.GHashService.HDecrBy:output_type -> ghash.GHashDecrByResponse
-	19, // 26: ghash.GHashService.HStrLen:output_type -> ghash.GHashStrLenResponse
-	21, // 27: ghash.GHashService.HMove:output_type -> ghash.GHashMoveResponse
-	23, // 28: ghash.GHashService.HSetNX:output_type -> ghash.GHashSetNXResponse
-	25, // 29: ghash.GHashService.HType:output_type -> ghash.GHashTypeResponse
-	27, // 30: ghash.GHashService.HKeys:output_type -> ghash.GHashKeysResponse
-	29, // 31: ghash.GHashService.TTL:output_type -> ghash.GHashTTLResponse
-	31, // 32: ghash.GHashService.Size:output_type -> ghash.GHashSizeResponse
-	33, // 33: ghash.GHashService.HExpire:output_type -> ghash.GHashExpireResponse
-	17, // [17:34] is the sub-list for method output_type
-	0,  // [0:17] is the sub-list for method input_type
+	34, // 17: ghash.GHashService.HScan:input_type -> ghash.GHashScanRequest
+	1,  // 18: ghash.GHashService.HSet:output_type -> ghash.GHashSetResponse
+	3,  // 19: ghash.GHashService.HGet:output_type -> ghash.GHashGetResponse
+	5,  // 20: ghash.GHashService.HDel:output_type -> ghash.GHashDelResponse
+	7,  // 21: ghash.GHashService.HExists:output_type -> ghash.GHashExistsResponse
+	9,  // 22: ghash.GHashService.HLen:output_type -> ghash.GHashLenResponse
+	11, // 23: ghash.GHashService.HUpdate:output_type -> ghash.GHashUpdateResponse
+	13, // 24: ghash.GHashService.HIncrBy:output_type -> ghash.GHashIncrByResponse
+	15, // 25: ghash.GHashService.HIncrByFloat:output_type -> ghash.GHashIncrByFloatResponse
+	17, // 26: ghash.GHashService.HDecrBy:output_type -> ghash.GHashDecrByResponse
+	19, // 27: ghash.GHashService.HStrLen:output_type -> ghash.GHashStrLenResponse
+	21, // 28: ghash.GHashService.HMove:output_type -> ghash.GHashMoveResponse
+	23, // 29: ghash.GHashService.HSetNX:output_type -> ghash.GHashSetNXResponse
+	25, // 30: ghash.GHashService.HType:output_type -> ghash.GHashTypeResponse
+	27, // 31: ghash.GHashService.HKeys:output_type -> ghash.GHashKeysResponse
+	29, // 32: ghash.GHashService.TTL:output_type -> ghash.GHashTTLResponse
+	31, // 33: ghash.GHashService.Size:output_type -> ghash.GHashSizeResponse
+	33, // 34: ghash.GHashService.HExpire:output_type -> ghash.GHashExpireResponse
+	35, // 35: ghash.GHashService.HScan:output_type -> ghash.GHashScanResponse
+	18, // [18:36] is the sub-list for method output_type
+	0,  // [0:18] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -2947,6 +3098,30 @@ func file_lib_proto_ghash_db_proto_init() {
 				return nil
 			}
 		}
+		file_lib_proto_ghash_db_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GHashScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_ghash_db_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GHashScanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_lib_proto_ghash_db_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*GHashSetRequest_StringValue)(nil),
@@ -2990,7 +3165,7 @@ func file_lib_proto_ghash_db_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lib_proto_ghash_db_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   34,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.2.0
+// - protoc-gen-go-grpc v1.3.0
 // - protoc             v3.6.1
 // source: lib/proto/ghash/db.proto
 
@@ -18,6 +18,27 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
+const (
+	GHashService_HSet_FullMethodName         = "/ghash.GHashService/HSet"
+	GHashService_HGet_FullMethodName         = "/ghash.GHashService/HGet"
+	GHashService_HDel_FullMethodName         = "/ghash.GHashService/HDel"
+	GHashService_HExists_FullMethodName      = "/ghash.GHashService/HExists"
+	GHashService_HLen_FullMethodName         = "/ghash.GHashService/HLen"
+	GHashService_HUpdate_FullMethodName      = "/ghash.GHashService/HUpdate"
+	GHashService_HIncrBy_FullMethodName      = "/ghash.GHashService/HIncrBy"
+	GHashService_HIncrByFloat_FullMethodName = "/ghash.GHashService/HIncrByFloat"
+	GHashService_HDecrBy_FullMethodName      = "/ghash.GHashService/HDecrBy"
+	GHashService_HStrLen_FullMethodName      = "/ghash.GHashService/HStrLen"
+	GHashService_HMove_FullMethodName        = "/ghash.GHashService/HMove"
+	GHashService_HSetNX_FullMethodName       = "/ghash.GHashService/HSetNX"
+	GHashService_HType_FullMethodName        = "/ghash.GHashService/HType"
+	GHashService_HKeys_FullMethodName        = "/ghash.GHashService/HKeys"
+	GHashService_TTL_FullMethodName          = "/ghash.GHashService/TTL"
+	GHashService_Size_FullMethodName         = "/ghash.GHashService/Size"
+	GHashService_HExpire_FullMethodName      = "/ghash.GHashService/HExpire"
+	GHashService_HScan_FullMethodName        = "/ghash.GHashService/HScan"
+)
+
 // GHashServiceClient is the client API for GHashService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
@@ -39,6 +60,7 @@ type GHashServiceClient interface {
 	TTL(ctx context.Context, in *GHashTTLRequest, opts ...grpc.CallOption) (*GHashTTLResponse, error)
 	Size(ctx context.Context, in *GHashSizeRequest, opts ...grpc.CallOption) (*GHashSizeResponse, error)
 	HExpire(ctx context.Context, in *GHashExpireRequest, opts ...grpc.CallOption) (*GHashExpireResponse, error)
+	HScan(ctx context.Context, in *GHashScanRequest, opts ...grpc.CallOption) (*GHashScanResponse, error)
 }
 
 type gHashServiceClient struct {
@@ -51,7 +73,7 @@ func NewGHashServiceClient(cc grpc.ClientConnInterface) GHashServiceClient {
 
 func (c *gHashServiceClient) HSet(ctx context.Context, in *GHashSetRequest, opts ...grpc.CallOption) (*GHashSetResponse, error) {
 	out := new(GHashSetResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HSet", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HSet_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +82,7 @@ func (c *gHashServiceClient) HSet(ctx context.Context, in *GHashSetRequest, opts
 
 func (c *gHashServiceClient) HGet(ctx context.Context, in *GHashGetRequest, opts ...grpc.CallOption) (*GHashGetResponse, error) {
 	out := new(GHashGetResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HGet", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HGet_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +91,7 @@ func (c *gHashServiceClient) HGet(ctx context.Context, in *GHashGetRequest, opts
 
 func (c *gHashServiceClient) HDel(ctx context.Context, in *GHashDelRequest, opts ...grpc.CallOption) (*GHashDelResponse, error) {
 	out := new(GHashDelResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HDel", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HDel_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +100,7 @@ func (c *gHashServiceClient) HDel(ctx context.Context, in *GHashDelRequest, opts
 
 func (c *gHashServiceClient) HExists(ctx context.Context, in *GHashExistsRequest, opts ...grpc.CallOption) (*GHashExistsResponse, error) {
 	out := new(GHashExistsResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HExists", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HExists_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +109,7 @@ func (c *gHashServiceClient) HExists(ctx context.Context, in *GHashExistsRequest
 
 func (c *gHashServiceClient) HLen(ctx context.Context, in *GHashLenRequest, opts ...grpc.CallOption) (*GHashLenResponse, error) {
 	out := new(GHashLenResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HLen", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HLen_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +118,7 @@ func (c *gHashServiceClient) HLen(ctx context.Context, in *GHashLenRequest, opts
 
 func (c *gHashServiceClient) HUpdate(ctx context.Context, in *GHashUpdateRequest, opts ...grpc.CallOption) (*GHashUpdateResponse, error) {
 	out := new(GHashUpdateResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HUpdate", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HUpdate_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +127,7 @@ func (c *gHashServiceClient) HUpdate(ctx context.Context, in *GHashUpdateRequest
 
 func (c *gHashServiceClient) HIncrBy(ctx context.Context, in *GHashIncrByRequest, opts ...grpc.CallOption) (*GHashIncrByResponse, error) {
 	out := new(GHashIncrByResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HIncrBy", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HIncrBy_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +136,7 @@ func (c *gHashServiceClient) HIncrBy(ctx context.Context, in *GHashIncrByRequest
 
 func (c *gHashServiceClient) HIncrByFloat(ctx context.Context, in *GHashIncrByFloatRequest, opts ...grpc.CallOption) (*GHashIncrByFloatResponse, error) {
 	out := new(GHashIncrByFloatResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HIncrByFloat", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HIncrByFloat_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +145,7 @@ func (c *gHashServiceClient) HIncrByFloat(ctx context.Context, in *GHashIncrByFl
 
 func (c *gHashServiceClient) HDecrBy(ctx context.Context, in *GHashDecrByRequest, opts ...grpc.CallOption) (*GHashDecrByResponse, error) {
 	out := new(GHashDecrByResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HDecrBy", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HDecrBy_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +154,7 @@ func (c *gHashServiceClient) HDecrBy(ctx context.Context, in *GHashDecrByRequest
 
 func (c *gHashServiceClient) HStrLen(ctx context.Context, in *GHashStrLenRequest, opts ...grpc.CallOption) (*GHashStrLenResponse, error) {
 	out := new(GHashStrLenResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HStrLen", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HStrLen_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +163,7 @@ func (c *gHashServiceClient) HStrLen(ctx context.Context, in *GHashStrLenRequest
 
 func (c *gHashServiceClient) HMove(ctx context.Context, in *GHashMoveRequest, opts ...grpc.CallOption) (*GHashMoveResponse, error) {
 	out := new(GHashMoveResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HMove", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HMove_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +172,7 @@ func (c *gHashServiceClient) HMove(ctx context.Context, in *GHashMoveRequest, op
 
 func (c *gHashServiceClient) HSetNX(ctx context.Context, in *GHashSetNXRequest, opts ...grpc.CallOption) (*GHashSetNXResponse, error) {
 	out := new(GHashSetNXResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HSetNX", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HSetNX_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +181,7 @@ func (c *gHashServiceClient) HSetNX(ctx context.Context, in *GHashSetNXRequest,
 
 func (c *gHashServiceClient) HType(ctx context.Context, in *GHashTypeRequest, opts ...grpc.CallOption) (*GHashTypeResponse, error) {
 	out := new(GHashTypeResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HType", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HType_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +190,7 @@ func (c *gHashServiceClient) HType(ctx context.Context, in *GHashTypeRequest, op
 
 func (c *gHashServiceClient) HKeys(ctx context.Context, in *GHashKeysRequest, opts ...grpc.CallOption) (*GHashKeysResponse, error) {
 	out := new(GHashKeysResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HKeys", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HKeys_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +199,7 @@ func (c *gHashServiceClient) HKeys(ctx context.Context, in *GHashKeysRequest, op
 
 func (c *gHashServiceClient) TTL(ctx context.Context, in *GHashTTLRequest, opts ...grpc.CallOption) (*GHashTTLResponse, error) {
 	out := new(GHashTTLResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/TTL", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_TTL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -186,7 +208,7 @@ func (c *gHashServiceClient) TTL(ctx context.Context, in *GHashTTLRequest, opts
 
 func (c *gHashServiceClient) Size(ctx context.Context, in *GHashSizeRequest, opts ...grpc.CallOption) (*GHashSizeResponse, error) {
 	out := new(GHashSizeResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/Size", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_Size_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -195,7 +217,16 @@ func (c *gHashServiceClient) Size(ctx context.Context, in *GHashSizeRequest, opt
 
 func (c *gHashServiceClient) HExpire(ctx context.Context, in *GHashExpireRequest, opts ...grpc.CallOption) (*GHashExpireResponse, error) {
 	out := new(GHashExpireResponse)
-	err := c.cc.Invoke(ctx, "/ghash.GHashService/HExpire", in, out, opts...)
+	err := c.cc.Invoke(ctx, GHashService_HExpire_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gHashServiceClient) HScan(ctx context.Context, in *GHashScanRequest, opts ...grpc.CallOption) (*GHashScanResponse, error) {
+	out := new(GHashScanResponse)
+	err := c.cc.Invoke(ctx, GHashService_HScan_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -223,6 +254,7 @@ type GHashServiceServer interface {
 	TTL(context.Context, *GHashTTLRequest) (*GHashTTLResponse, error)
 	Size(context.Context, *GHashSizeRequest) (*GHashSizeResponse, error)
 	HExpire(context.Context, *GHashExpireRequest) (*GHashExpireResponse, error)
+	HScan(context.Context, *GHashScanRequest) (*GHashScanResponse, error)
 	mustEmbedUnimplementedGHashServiceServer()
 }
 
@@ -281,6 +313,9 @@ func (UnimplementedGHashServiceServer) Size(context.Context, *GHashSizeRequest)
 func (UnimplementedGHashServiceServer) HExpire(context.Context, *GHashExpireRequest) (*GHashExpireResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HExpire not implemented")
 }
+func (UnimplementedGHashServiceServer) HScan(context.Context, *GHashScanRequest) (*GHashScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HScan not implemented")
+}
 func (UnimplementedGHashServiceServer) mustEmbedUnimplementedGHashServiceServer() {}
 
 // UnsafeGHashServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -304,7 +339,7 @@ func _GHashService_HSet_Handler(srv interface{}, ctx context.Context, dec func(i
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HSet",
+		FullMethod: GHashService_HSet_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HSet(ctx, req.(*GHashSetRequest))
@@ -322,7 +357,7 @@ func _GHashService_HGet_Handler(srv interface{}, ctx context.Context, dec func(i
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HGet",
+		FullMethod: GHashService_HGet_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HGet(ctx, req.(*GHashGetRequest))
@@ -340,7 +375,7 @@ func _GHashService_HDel_Handler(srv interface{}, ctx context.Context, dec func(i
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HDel",
+		FullMethod: GHashService_HDel_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HDel(ctx, req.(*GHashDelRequest))
@@ -358,7 +393,7 @@ func _GHashService_HExists_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HExists",
+		FullMethod: GHashService_HExists_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HExists(ctx, req.(*GHashExistsRequest))
@@ -376,7 +411,7 @@ func _GHashService_HLen_Handler(srv interface{}, ctx context.Context, dec func(i
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HLen",
+		FullMethod: GHashService_HLen_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HLen(ctx, req.(*GHashLenRequest))
@@ -394,7 +429,7 @@ func _GHashService_HUpdate_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HUpdate",
+		FullMethod: GHashService_HUpdate_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HUpdate(ctx, req.(*GHashUpdateRequest))
@@ -412,7 +447,7 @@ func _GHashService_HIncrBy_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HIncrBy",
+		FullMethod: GHashService_HIncrBy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HIncrBy(ctx, req.(*GHashIncrByRequest))
@@ -430,7 +465,7 @@ func _GHashService_HIncrByFloat_Handler(srv interface{}, ctx context.Context, de
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HIncrByFloat",
+		FullMethod: GHashService_HIncrByFloat_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HIncrByFloat(ctx, req.(*GHashIncrByFloatRequest))
@@ -448,7 +483,7 @@ func _GHashService_HDecrBy_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HDecrBy",
+		FullMethod: GHashService_HDecrBy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HDecrBy(ctx, req.(*GHashDecrByRequest))
@@ -466,7 +501,7 @@ func _GHashService_HStrLen_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HStrLen",
+		FullMethod: GHashService_HStrLen_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HStrLen(ctx, req.(*GHashStrLenRequest))
@@ -484,7 +519,7 @@ func _GHashService_HMove_Handler(srv interface{}, ctx context.Context, dec func(
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HMove",
+		FullMethod: GHashService_HMove_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HMove(ctx, req.(*GHashMoveRequest))
@@ -502,7 +537,7 @@ func _GHashService_HSetNX_Handler(srv interface{}, ctx context.Context, dec func
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HSetNX",
+		FullMethod: GHashService_HSetNX_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HSetNX(ctx, req.(*GHashSetNXRequest))
@@ -520,7 +555,7 @@ func _GHashService_HType_Handler(srv interface{}, ctx context.Context, dec func(
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HType",
+		FullMethod: GHashService_HType_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HType(ctx, req.(*GHashTypeRequest))
@@ -538,7 +573,7 @@ func _GHashService_HKeys_Handler(srv interface{}, ctx context.Context, dec func(
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HKeys",
+		FullMethod: GHashService_HKeys_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HKeys(ctx, req.(*GHashKeysRequest))
@@ -556,7 +591,7 @@ func _GHashService_TTL_Handler(srv interface{}, ctx context.Context, dec func(in
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/TTL",
+		FullMethod: GHashService_TTL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).TTL(ctx, req.(*GHashTTLRequest))
@@ -574,7 +609,7 @@ func _GHashService_Size_Handler(srv interface{}, ctx context.Context, dec func(i
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/Size",
+		FullMethod: GHashService_Size_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).Size(ctx, req.(*GHashSizeRequest))
@@ -592,7 +627,7 @@ func _GHashService_HExpire_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ghash.GHashService/HExpire",
+		FullMethod: GHashService_HExpire_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GHashServiceServer).HExpire(ctx, req.(*GHashExpireRequest))
@@ -600,6 +635,24 @@ func _GHashService_HExpire_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GHashService_HScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GHashScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GHashServiceServer).HScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GHashService_HScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GHashServiceServer).HScan(ctx, req.(*GHashScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GHashService_ServiceDesc is the grpc.ServiceDesc for GHashService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -675,6 +728,10 @@ var GHashService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HExpire",
 			Handler:    _GHashService_HExpire_Handler,
 		},
+		{
+			MethodName: "HScan",
+			Handler:    _GHashService_HScan_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lib/proto/ghash/db.proto",
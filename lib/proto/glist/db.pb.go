@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.31.0
 // 	protoc        v3.14.0
 // source: lib/proto/glist/db.proto
 
@@ -27,7 +27,6 @@ type GListLPushRequest struct {
 
 	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	// Types that are assignable to Value:
-	//
 	//	*GListLPushRequest_StringValue
 	//	*GListLPushRequest_Int32Value
 	//	*GListLPushRequest_Int64Value
@@ -287,7 +286,6 @@ type Value struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Value:
-	//
 	//	*Value_StringValue
 	//	*Value_Int32Value
 	//	*Value_Int64Value
@@ -486,7 +484,6 @@ type GListRPushRequest struct {
 
 	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	// Types that are assignable to Value:
-	//
 	//	*GListRPushRequest_StringValue
 	//	*GListRPushRequest_Int32Value
 	//	*GListRPushRequest_Int64Value
@@ -840,7 +837,6 @@ type GListLPopResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Value:
-	//
 	//	*GListLPopResponse_StringValue
 	//	*GListLPopResponse_Int32Value
 	//	*GListLPopResponse_Int64Value
@@ -1038,7 +1034,6 @@ type GListRPopResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Value:
-	//
 	//	*GListRPopResponse_StringValue
 	//	*GListRPopResponse_Int32Value
 	//	*GListRPopResponse_Int64Value
@@ -1403,7 +1398,6 @@ type GListLRemRequest struct {
 	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Count int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 	// Types that are assignable to Value:
-	//
 	//	*GListLRemRequest_StringValue
 	//	*GListLRemRequest_Int32Value
 	//	*GListLRemRequest_Int64Value
@@ -1678,7 +1672,6 @@ type GListLIndexResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Value:
-	//
 	//	*GListLIndexResponse_StringValue
 	//	*GListLIndexResponse_Int32Value
 	//	*GListLIndexResponse_Int64Value
@@ -1831,7 +1824,6 @@ type GListLSetRequest struct {
 	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Index int32  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
 	// Types that are assignable to Value:
-	//
 	//	*GListLSetRequest_StringValue
 	//	*GListLSetRequest_Int32Value
 	//	*GListLSetRequest_Int64Value
@@ -2147,6 +2139,620 @@ func (x *GListLTrimResponse) GetOk() bool {
 	return false
 }
 
+type GListLInsertRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key     string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Before  bool   `protobuf:"varint,2,opt,name=before,proto3" json:"before,omitempty"`
+	Pivot   *Value `protobuf:"bytes,3,opt,name=pivot,proto3" json:"pivot,omitempty"`
+	Element *Value `protobuf:"bytes,4,opt,name=element,proto3" json:"element,omitempty"`
+}
+
+func (x *GListLInsertRequest) Reset() {
+	*x = GListLInsertRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListLInsertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListLInsertRequest) ProtoMessage() {}
+
+func (x *GListLInsertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListLInsertRequest.ProtoReflect.Descriptor instead.
+func (*GListLInsertRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GListLInsertRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GListLInsertRequest) GetBefore() bool {
+	if x != nil {
+		return x.Before
+	}
+	return false
+}
+
+func (x *GListLInsertRequest) GetPivot() *Value {
+	if x != nil {
+		return x.Pivot
+	}
+	return nil
+}
+
+func (x *GListLInsertRequest) GetElement() *Value {
+	if x != nil {
+		return x.Element
+	}
+	return nil
+}
+
+type GListLInsertResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Length int32 `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (x *GListLInsertResponse) Reset() {
+	*x = GListLInsertResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListLInsertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListLInsertResponse) ProtoMessage() {}
+
+func (x *GListLInsertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListLInsertResponse.ProtoReflect.Descriptor instead.
+func (*GListLInsertResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GListLInsertResponse) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type GListLPosRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key     string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Element *Value `protobuf:"bytes,2,opt,name=element,proto3" json:"element,omitempty"`
+	Rank    int32  `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	Count   int32  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	MaxLen  int32  `protobuf:"varint,5,opt,name=maxLen,proto3" json:"maxLen,omitempty"`
+}
+
+func (x *GListLPosRequest) Reset() {
+	*x = GListLPosRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListLPosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListLPosRequest) ProtoMessage() {}
+
+func (x *GListLPosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListLPosRequest.ProtoReflect.Descriptor instead.
+func (*GListLPosRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GListLPosRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GListLPosRequest) GetElement() *Value {
+	if x != nil {
+		return x.Element
+	}
+	return nil
+}
+
+func (x *GListLPosRequest) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *GListLPosRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GListLPosRequest) GetMaxLen() int32 {
+	if x != nil {
+		return x.MaxLen
+	}
+	return 0
+}
+
+type GListLPosResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Positions []int32 `protobuf:"varint,1,rep,packed,name=positions,proto3" json:"positions,omitempty"`
+}
+
+func (x *GListLPosResponse) Reset() {
+	*x = GListLPosResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListLPosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListLPosResponse) ProtoMessage() {}
+
+func (x *GListLPosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListLPosResponse.ProtoReflect.Descriptor instead.
+func (*GListLPosResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GListLPosResponse) GetPositions() []int32 {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+type GListLMoveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source      string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	From        string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To          string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *GListLMoveRequest) Reset() {
+	*x = GListLMoveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListLMoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListLMoveRequest) ProtoMessage() {}
+
+func (x *GListLMoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListLMoveRequest.ProtoReflect.Descriptor instead.
+func (*GListLMoveRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GListLMoveRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GListLMoveRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *GListLMoveRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GListLMoveRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type GListLMoveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Element *Value `protobuf:"bytes,1,opt,name=element,proto3" json:"element,omitempty"`
+}
+
+func (x *GListLMoveResponse) Reset() {
+	*x = GListLMoveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListLMoveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListLMoveResponse) ProtoMessage() {}
+
+func (x *GListLMoveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListLMoveResponse.ProtoReflect.Descriptor instead.
+func (*GListLMoveResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GListLMoveResponse) GetElement() *Value {
+	if x != nil {
+		return x.Element
+	}
+	return nil
+}
+
+type GListBPopRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys    []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Timeout float64  `protobuf:"fixed64,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *GListBPopRequest) Reset() {
+	*x = GListBPopRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListBPopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListBPopRequest) ProtoMessage() {}
+
+func (x *GListBPopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListBPopRequest.ProtoReflect.Descriptor instead.
+func (*GListBPopRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GListBPopRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *GListBPopRequest) GetTimeout() float64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type GListBPopResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Element  *Value `protobuf:"bytes,2,opt,name=element,proto3" json:"element,omitempty"`
+	TimedOut bool   `protobuf:"varint,3,opt,name=timedOut,proto3" json:"timedOut,omitempty"`
+}
+
+func (x *GListBPopResponse) Reset() {
+	*x = GListBPopResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListBPopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListBPopResponse) ProtoMessage() {}
+
+func (x *GListBPopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListBPopResponse.ProtoReflect.Descriptor instead.
+func (*GListBPopResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GListBPopResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GListBPopResponse) GetElement() *Value {
+	if x != nil {
+		return x.Element
+	}
+	return nil
+}
+
+func (x *GListBPopResponse) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+type GListBLMoveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source      string  `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination string  `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	From        string  `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To          string  `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Timeout     float64 `protobuf:"fixed64,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *GListBLMoveRequest) Reset() {
+	*x = GListBLMoveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListBLMoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListBLMoveRequest) ProtoMessage() {}
+
+func (x *GListBLMoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListBLMoveRequest.ProtoReflect.Descriptor instead.
+func (*GListBLMoveRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GListBLMoveRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GListBLMoveRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *GListBLMoveRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GListBLMoveRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GListBLMoveRequest) GetTimeout() float64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type GListBLMoveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Element  *Value `protobuf:"bytes,1,opt,name=element,proto3" json:"element,omitempty"`
+	TimedOut bool   `protobuf:"varint,2,opt,name=timedOut,proto3" json:"timedOut,omitempty"`
+}
+
+func (x *GListBLMoveResponse) Reset() {
+	*x = GListBLMoveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_glist_db_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GListBLMoveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GListBLMoveResponse) ProtoMessage() {}
+
+func (x *GListBLMoveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_glist_db_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GListBLMoveResponse.ProtoReflect.Descriptor instead.
+func (*GListBLMoveResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_glist_db_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GListBLMoveResponse) GetElement() *Value {
+	if x != nil {
+		return x.Element
+	}
+	return nil
+}
+
+func (x *GListBLMoveResponse) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
 var File_lib_proto_glist_db_proto protoreflect.FileDescriptor
 
 var file_lib_proto_glist_db_proto_rawDesc = []byte{
@@ -2353,58 +2959,143 @@ var file_lib_proto_glist_db_proto_rawDesc = []byte{
 	0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
 	0x73, 0x74, 0x6f, 0x70, 0x22, 0x24, 0x0a, 0x12, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x54, 0x72,
 	0x69, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x32, 0x8b, 0x06, 0x0a, 0x0c, 0x47,
-	0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x05, 0x4c,
-	0x50, 0x75, 0x73, 0x68, 0x12, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69,
-	0x73, 0x74, 0x4c, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x47,
+	0x4c, 0x69, 0x73, 0x74, 0x4c, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x22, 0x0a, 0x05,
+	0x70, 0x69, 0x76, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x6c,
+	0x69, 0x73, 0x74, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x70, 0x69, 0x76, 0x6f, 0x74,
+	0x12, 0x26, 0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x2e, 0x0a, 0x14, 0x47, 0x4c, 0x69, 0x73,
+	0x74, 0x4c, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x8e, 0x01, 0x0a, 0x10, 0x47, 0x4c, 0x69,
+	0x73, 0x74, 0x4c, 0x50, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x26, 0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07,
+	0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x22, 0x31, 0x0a, 0x11, 0x47, 0x4c, 0x69,
+	0x73, 0x74, 0x4c, 0x50, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x71, 0x0a, 0x11,
+	0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
+	0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22,
+	0x3c, 0x0a, 0x12, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x40, 0x0a,
+	0x10, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22,
+	0x69, 0x0a, 0x11, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x22, 0x8c, 0x01, 0x0a, 0x12, 0x47,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
+	0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x59, 0x0a, 0x13, 0x47, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x26, 0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65,
+	0x64, 0x4f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65,
+	0x64, 0x4f, 0x75, 0x74, 0x32, 0x8d, 0x09, 0x0a, 0x0c, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x05, 0x4c, 0x50, 0x75, 0x73, 0x68, 0x12, 0x18,
 	0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50, 0x75, 0x73,
-	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x4c,
-	0x50, 0x75, 0x73, 0x68, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c,
-	0x69, 0x73, 0x74, 0x4c, 0x50, 0x75, 0x73, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50,
-	0x75, 0x73, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e,
-	0x0a, 0x05, 0x52, 0x50, 0x75, 0x73, 0x68, 0x12, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e,
-	0x47, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x52,
-	0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41,
-	0x0a, 0x06, 0x52, 0x50, 0x75, 0x73, 0x68, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74,
-	0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x75, 0x73, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73,
-	0x74, 0x52, 0x50, 0x75, 0x73, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x3b, 0x0a, 0x04, 0x4c, 0x50, 0x6f, 0x70, 0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73,
-	0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74,
-	0x4c, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b,
-	0x0a, 0x04, 0x52, 0x50, 0x6f, 0x70, 0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x6f,
-	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x4c,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c,
-	0x69, 0x73, 0x74, 0x4c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x52,
-	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b,
-	0x0a, 0x04, 0x4c, 0x4c, 0x65, 0x6e, 0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47,
-	0x4c, 0x69, 0x73, 0x74, 0x4c, 0x4c, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x4c, 0x65,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x4c,
-	0x52, 0x65, 0x6d, 0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73,
-	0x74, 0x4c, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67,
-	0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x52, 0x65, 0x6d, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x4c, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x12, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74,
-	0x4c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
-	0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x4c,
-	0x53, 0x65, 0x74, 0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73,
-	0x74, 0x4c, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67,
-	0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x53, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x05, 0x4c, 0x54, 0x72, 0x69,
-	0x6d, 0x12, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c,
-	0x54, 0x72, 0x69, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x6c,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74,
+	0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x4c, 0x50, 0x75, 0x73, 0x68, 0x73, 0x12,
+	0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50, 0x75,
+	0x73, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x6c, 0x69,
+	0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50, 0x75, 0x73, 0x68, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x05, 0x52, 0x50, 0x75, 0x73,
+	0x68, 0x12, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x6c,
+	0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x52, 0x50, 0x75, 0x73,
+	0x68, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x50, 0x75, 0x73, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x75, 0x73, 0x68,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x4c,
+	0x50, 0x6f, 0x70, 0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73,
+	0x74, 0x4c, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67,
+	0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50, 0x6f, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x52, 0x50, 0x6f, 0x70,
+	0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50,
+	0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73,
+	0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x4c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x6c, 0x69,
+	0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x4c, 0x4c, 0x65, 0x6e,
+	0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x4c,
+	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73,
+	0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x4c, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x4c, 0x52, 0x65, 0x6d, 0x12, 0x17, 0x2e,
+	0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x52, 0x65, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47,
+	0x4c, 0x69, 0x73, 0x74, 0x4c, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x4c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x19, 0x2e, 0x67,
+	0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e,
+	0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x4c, 0x53, 0x65, 0x74, 0x12, 0x17, 0x2e,
+	0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47,
+	0x4c, 0x69, 0x73, 0x74, 0x4c, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3e, 0x0a, 0x05, 0x4c, 0x54, 0x72, 0x69, 0x6d, 0x12, 0x18, 0x2e, 0x67, 0x6c,
 	0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x54, 0x72, 0x69, 0x6d, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x66, 0x6c, 0x79, 0x64,
-	0x62, 0x2f, 0x6c, 0x69, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x69, 0x73,
-	0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c,
+	0x69, 0x73, 0x74, 0x4c, 0x54, 0x72, 0x69, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x44, 0x0a, 0x07, 0x4c, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x12, 0x1a, 0x2e,
+	0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x49, 0x6e, 0x73, 0x65,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x6c, 0x69, 0x73,
+	0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x04, 0x4c, 0x50, 0x6f, 0x73,
+	0x12, 0x17, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50,
+	0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73,
+	0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x50, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x05, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x18,
+	0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x4d, 0x6f, 0x76,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74,
+	0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x05, 0x42, 0x4c, 0x50, 0x6f, 0x70, 0x12, 0x17,
+	0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x50, 0x6f, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e,
+	0x47, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x05, 0x42, 0x52, 0x50, 0x6f, 0x70, 0x12, 0x17, 0x2e, 0x67,
+	0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x50, 0x6f, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x41, 0x0a, 0x06, 0x42, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x6c,
+	0x69, 0x73, 0x74, 0x2e, 0x47, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x47,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x4c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x66, 0x6c, 0x79, 0x64, 0x62, 0x2f, 0x6c, 0x69,
+	0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x69, 0x73, 0x74, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2419,67 +3110,95 @@ func file_lib_proto_glist_db_proto_rawDescGZIP() []byte {
 	return file_lib_proto_glist_db_proto_rawDescData
 }
 
-var file_lib_proto_glist_db_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_lib_proto_glist_db_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_lib_proto_glist_db_proto_goTypes = []interface{}{
-	(*GListLPushRequest)(nil),   // 0: glist.GListLPushRequest
-	(*GListLPushResponse)(nil),  // 1: glist.GListLPushResponse
-	(*GListLPushsRequest)(nil),  // 2: glist.GListLPushsRequest
-	(*Value)(nil),               // 3: glist.value
-	(*GListLPushsResponse)(nil), // 4: glist.GListLPushsResponse
-	(*GListRPushRequest)(nil),   // 5: glist.GListRPushRequest
-	(*GListRPushResponse)(nil),  // 6: glist.GListRPushResponse
-	(*GListRPushsRequest)(nil),  // 7: glist.GListRPushsRequest
-	(*GListRPushsResponse)(nil), // 8: glist.GListRPushsResponse
-	(*GListLPopRequest)(nil),    // 9: glist.GListLPopRequest
-	(*GListLPopResponse)(nil),   // 10: glist.GListLPopResponse
-	(*GListRPopRequest)(nil),    // 11: glist.GListRPopRequest
-	(*GListRPopResponse)(nil),   // 12: glist.GListRPopResponse
-	(*GListLRangeRequest)(nil),  // 13: glist.GListLRangeRequest
-	(*GListLRangeResponse)(nil), // 14: glist.GListLRangeResponse
-	(*GListLLenRequest)(nil),    // 15: glist.GListLLenRequest
-	(*GListLLenResponse)(nil),   // 16: glist.GListLLenResponse
-	(*GListLRemRequest)(nil),    // 17: glist.GListLRemRequest
-	(*GListLRemResponse)(nil),   // 18: glist.GListLRemResponse
-	(*GListLIndexRequest)(nil),  // 19: glist.GListLIndexRequest
-	(*GListLIndexResponse)(nil), // 20: glist.GListLIndexResponse
-	(*GListLSetRequest)(nil),    // 21: glist.GListLSetRequest
-	(*GListLSetResponse)(nil),   // 22: glist.GListLSetResponse
-	(*GListLTrimRequest)(nil),   // 23: glist.GListLTrimRequest
-	(*GListLTrimResponse)(nil),  // 24: glist.GListLTrimResponse
+	(*GListLPushRequest)(nil),    // 0: glist.GListLPushRequest
+	(*GListLPushResponse)(nil),   // 1: glist.GListLPushResponse
+	(*GListLPushsRequest)(nil),   // 2: glist.GListLPushsRequest
+	(*Value)(nil),                // 3: glist.value
+	(*GListLPushsResponse)(nil),  // 4: glist.GListLPushsResponse
+	(*GListRPushRequest)(nil),    // 5: glist.GListRPushRequest
+	(*GListRPushResponse)(nil),   // 6: glist.GListRPushResponse
+	(*GListRPushsRequest)(nil),   // 7: glist.GListRPushsRequest
+	(*GListRPushsResponse)(nil),  // 8: glist.GListRPushsResponse
+	(*GListLPopRequest)(nil),     // 9: glist.GListLPopRequest
+	(*GListLPopResponse)(nil),    // 10: glist.GListLPopResponse
+	(*GListRPopRequest)(nil),     // 11: glist.GListRPopRequest
+	(*GListRPopResponse)(nil),    // 12: glist.GListRPopResponse
+	(*GListLRangeRequest)(nil),   // 13: glist.GListLRangeRequest
+	(*GListLRangeResponse)(nil),  // 14: glist.GListLRangeResponse
+	(*GListLLenRequest)(nil),     // 15: glist.GListLLenRequest
+	(*GListLLenResponse)(nil),    // 16: glist.GListLLenResponse
+	(*GListLRemRequest)(nil),     // 17: glist.GListLRemRequest
+	(*GListLRemResponse)(nil),    // 18: glist.GListLRemResponse
+	(*GListLIndexRequest)(nil),   // 19: glist.GListLIndexRequest
+	(*GListLIndexResponse)(nil),  // 20: glist.GListLIndexResponse
+	(*GListLSetRequest)(nil),     // 21: glist.GListLSetRequest
+	(*GListLSetResponse)(nil),    // 22: glist.GListLSetResponse
+	(*GListLTrimRequest)(nil),    // 23: glist.GListLTrimRequest
+	(*GListLTrimResponse)(nil),   // 24: glist.GListLTrimResponse
+	(*GListLInsertRequest)(nil),  // 25: glist.GListLInsertRequest
+	(*GListLInsertResponse)(nil), // 26: glist.GListLInsertResponse
+	(*GListLPosRequest)(nil),     // 27: glist.GListLPosRequest
+	(*GListLPosResponse)(nil),    // 28: glist.GListLPosResponse
+	(*GListLMoveRequest)(nil),    // 29: glist.GListLMoveRequest
+	(*GListLMoveResponse)(nil),   // 30: glist.GListLMoveResponse
+	(*GListBPopRequest)(nil),     // 31: glist.GListBPopRequest
+	(*GListBPopResponse)(nil),    // 32: glist.GListBPopResponse
+	(*GListBLMoveRequest)(nil),   // 33: glist.GListBLMoveRequest
+	(*GListBLMoveResponse)(nil),  // 34: glist.GListBLMoveResponse
 }
 var file_lib_proto_glist_db_proto_depIdxs = []int32{
 	3,  // 0: glist.GListLPushsRequest.values:type_name -> glist.value
 	3,  // 1: glist.GListRPushsRequest.values:type_name -> glist.value
 	3,  // 2: glist.GListLRangeResponse.values:type_name -> glist.value
-	0,  // 3: glist.GListService.LPush:input_type -> glist.GListLPushRequest
-	2,  // 4: glist.GListService.LPushs:input_type -> glist.GListLPushsRequest
-	5,  // 5: glist.GListService.RPush:input_type -> glist.GListRPushRequest
-	7,  // 6: glist.GListService.RPushs:input_type -> glist.GListRPushsRequest
-	9,  // 7: glist.GListService.LPop:input_type -> glist.GListLPopRequest
-	11, // 8: glist.GListService.RPop:input_type -> glist.GListRPopRequest
-	13, // 9: glist.GListService.LRange:input_type -> glist.GListLRangeRequest
-	15, // 10: glist.GListService.LLen:input_type -> glist.GListLLenRequest
-	17, // 11: glist.GListService.LRem:input_type -> glist.GListLRemRequest
-	19, // 12: glist.GListService.LIndex:input_type -> glist.GListLIndexRequest
-	21, // 13: glist.GListService.LSet:input_type -> glist.GListLSetRequest
-	23, // 14: glist.GListService.LTrim:input_type -> glist.GListLTrimRequest
-	1,  // 15: glist.GListService.LPush:output_type -> glist.GListLPushResponse
-	4,  // 16: glist.GListService.LPushs:output_type -> glist.GListLPushsResponse
-	6,  // 17: glist.GListService.RPush:output_type -> glist.GListRPushResponse
-	8,  // 18: glist.GListService.RPushs:output_type -> glist.GListRPushsResponse
-	10, // 19: glist.GListService.LPop:output_type -> glist.GListLPopResponse
-	12, // 20: glist.GListService.RPop:output_type -> glist.GListRPopResponse
-	14, // 21: glist.GListService.LRange:output_type -> glist.GListLRangeResponse
-	16, // 22: glist.GListService.LLen:output_type -> glist.GListLLenResponse
-	18, // 23: glist.GListService.LRem:output_type -> glist.GListLRemResponse
-	20, // 24: glist.GListService.LIndex:output_type -> glist.GListLIndexResponse
-	22, // 25: glist.GListService.LSet:output_type -> glist.GListLSetResponse
-	24, // 26: glist.GListService.LTrim:output_type -> glist.GListLTrimResponse
-	15, // [15:27] is the sub-list for method output_type
-	3,  // [3:15] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	3,  // 3: glist.GListLInsertRequest.pivot:type_name -> glist.value
+	3,  // 4: glist.GListLInsertRequest.element:type_name -> glist.value
+	3,  // 5: glist.GListLPosRequest.element:type_name -> glist.value
+	3,  // 6: glist.GListLMoveResponse.element:type_name -> glist.value
+	3,  // 7: glist.GListBPopResponse.element:type_name -> glist.value
+	3,  // 8: glist.GListBLMoveResponse.element:type_name -> glist.value
+	0,  // 9: glist.GListService.LPush:input_type -> glist.GListLPushRequest
+	2,  // 10: glist.GListService.LPushs:input_type -> glist.GListLPushsRequest
+	5,  // 11: glist.GListService.RPush:input_type -> glist.GListRPushRequest
+	7,  // 12: glist.GListService.RPushs:input_type -> glist.GListRPushsRequest
+	9,  // 13: glist.GListService.LPop:input_type -> glist.GListLPopRequest
+	11, // 14: glist.GListService.RPop:input_type -> glist.GListRPopRequest
+	13, // 15: glist.GListService.LRange:input_type -> glist.GListLRangeRequest
+	15, // 16: glist.GListService.LLen:input_type -> glist.GListLLenRequest
+	17, // 17: glist.GListService.LRem:input_type -> glist.GListLRemRequest
+	19, // 18: glist.GListService.LIndex:input_type -> glist.GListLIndexRequest
+	21, // 19: glist.GListService.LSet:input_type -> glist.GListLSetRequest
+	23, // 20: glist.GListService.LTrim:input_type -> glist.GListLTrimRequest
+	25, // 21: glist.GListService.LInsert:input_type -> glist.GListLInsertRequest
+	27, // 22: glist.GListService.LPos:input_type -> glist.GListLPosRequest
+	29, // 23: glist.GListService.LMove:input_type -> glist.GListLMoveRequest
+	31, // 24: glist.GListService.BLPop:input_type -> glist.GListBPopRequest
+	31, // 25: glist.GListService.BRPop:input_type -> glist.GListBPopRequest
+	33, // 26: glist.GListService.BLMove:input_type -> glist.GListBLMoveRequest
+	1,  // 27: glist.GListService.LPush:output_type -> glist.GListLPushResponse
+	4,  // 28: glist.GListService.LPushs:output_type -> glist.GListLPushsResponse
+	6,  // 29: glist.GListService.RPush:output_type -> glist.GListRPushResponse
+	8,  // 30: glist.GListService.RPushs:output_type -> glist.GListRPushsResponse
+	10, // 31: glist.GListService.LPop:output_type -> glist.GListLPopResponse
+	12, // 32: glist.GListService.RPop:output_type -> glist.GListRPopResponse
+	14, // 33: glist.GListService.LRange:output_type -> glist.GListLRangeResponse
+	16, // 34: glist.GListService.LLen:output_type -> glist.GListLLenResponse
+	18, // 35: glist.GListService.LRem:output_type -> glist.GListLRemResponse
+	20, // 36: glist.GListService.LIndex:output_type -> glist.GListLIndexResponse
+	22, // 37: glist.GListService.LSet:output_type -> glist.GListLSetResponse
+	24, // 38: glist.GListService.LTrim:output_type -> glist.GListLTrimResponse
+	26, // 39: glist.GListService.LInsert:output_type -> glist.GListLInsertResponse
+	28, // 40: glist.GListService.LPos:output_type -> glist.GListLPosResponse
+	30, // 41: glist.GListService.LMove:output_type -> glist.GListLMoveResponse
+	32, // 42: glist.GListService.BLPop:output_type -> glist.GListBPopResponse
+	32, // 43: glist.GListService.BRPop:output_type -> glist.GListBPopResponse
+	34, // 44: glist.GListService.BLMove:output_type -> glist.GListBLMoveResponse
+	27, // [27:45] is the sub-list for method output_type
+	9,  // [9:27] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_lib_proto_glist_db_proto_init() }
@@ -2788,6 +3507,126 @@ func file_lib_proto_glist_db_proto_init() {
 				return nil
 			}
 		}
+		file_lib_proto_glist_db_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListLInsertRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListLInsertResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListLPosRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListLPosResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListLMoveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListLMoveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListBPopRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListBPopResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListBLMoveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_glist_db_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GListBLMoveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_lib_proto_glist_db_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*GListLPushRequest_StringValue)(nil),
@@ -2867,7 +3706,7 @@ func file_lib_proto_glist_db_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lib_proto_glist_db_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,25 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	GListService_LPush_FullMethodName  = "/glist.GListService/LPush"
-	GListService_LPushs_FullMethodName = "/glist.GListService/LPushs"
-	GListService_RPush_FullMethodName  = "/glist.GListService/RPush"
-	GListService_RPushs_FullMethodName = "/glist.GListService/RPushs"
-	GListService_LPop_FullMethodName   = "/glist.GListService/LPop"
-	GListService_RPop_FullMethodName   = "/glist.GListService/RPop"
-	GListService_LRange_FullMethodName = "/glist.GListService/LRange"
-	GListService_LLen_FullMethodName   = "/glist.GListService/LLen"
-	GListService_LRem_FullMethodName   = "/glist.GListService/LRem"
-	GListService_LIndex_FullMethodName = "/glist.GListService/LIndex"
-	GListService_LSet_FullMethodName   = "/glist.GListService/LSet"
-	GListService_LTrim_FullMethodName  = "/glist.GListService/LTrim"
+	GListService_LPush_FullMethodName   = "/glist.GListService/LPush"
+	GListService_LPushs_FullMethodName  = "/glist.GListService/LPushs"
+	GListService_RPush_FullMethodName   = "/glist.GListService/RPush"
+	GListService_RPushs_FullMethodName  = "/glist.GListService/RPushs"
+	GListService_LPop_FullMethodName    = "/glist.GListService/LPop"
+	GListService_RPop_FullMethodName    = "/glist.GListService/RPop"
+	GListService_LRange_FullMethodName  = "/glist.GListService/LRange"
+	GListService_LLen_FullMethodName    = "/glist.GListService/LLen"
+	GListService_LRem_FullMethodName    = "/glist.GListService/LRem"
+	GListService_LIndex_FullMethodName  = "/glist.GListService/LIndex"
+	GListService_LSet_FullMethodName    = "/glist.GListService/LSet"
+	GListService_LTrim_FullMethodName   = "/glist.GListService/LTrim"
+	GListService_LInsert_FullMethodName = "/glist.GListService/LInsert"
+	GListService_LPos_FullMethodName    = "/glist.GListService/LPos"
+	GListService_LMove_FullMethodName   = "/glist.GListService/LMove"
+	GListService_BLPop_FullMethodName   = "/glist.GListService/BLPop"
+	GListService_BRPop_FullMethodName   = "/glist.GListService/BRPop"
+	GListService_BLMove_FullMethodName  = "/glist.GListService/BLMove"
 )
 
 // GListServiceClient is the client API for GListService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type GListServiceClient interface {
-	// example
 	LPush(ctx context.Context, in *GListLPushRequest, opts ...grpc.CallOption) (*GListLPushResponse, error)
 	LPushs(ctx context.Context, in *GListLPushsRequest, opts ...grpc.CallOption) (*GListLPushsResponse, error)
 	RPush(ctx context.Context, in *GListRPushRequest, opts ...grpc.CallOption) (*GListRPushResponse, error)
@@ -50,6 +55,12 @@ type GListServiceClient interface {
 	LIndex(ctx context.Context, in *GListLIndexRequest, opts ...grpc.CallOption) (*GListLIndexResponse, error)
 	LSet(ctx context.Context, in *GListLSetRequest, opts ...grpc.CallOption) (*GListLSetResponse, error)
 	LTrim(ctx context.Context, in *GListLTrimRequest, opts ...grpc.CallOption) (*GListLTrimResponse, error)
+	LInsert(ctx context.Context, in *GListLInsertRequest, opts ...grpc.CallOption) (*GListLInsertResponse, error)
+	LPos(ctx context.Context, in *GListLPosRequest, opts ...grpc.CallOption) (*GListLPosResponse, error)
+	LMove(ctx context.Context, in *GListLMoveRequest, opts ...grpc.CallOption) (*GListLMoveResponse, error)
+	BLPop(ctx context.Context, in *GListBPopRequest, opts ...grpc.CallOption) (*GListBPopResponse, error)
+	BRPop(ctx context.Context, in *GListBPopRequest, opts ...grpc.CallOption) (*GListBPopResponse, error)
+	BLMove(ctx context.Context, in *GListBLMoveRequest, opts ...grpc.CallOption) (*GListBLMoveResponse, error)
 }
 
 type gListServiceClient struct {
@@ -168,11 +179,64 @@ func (c *gListServiceClient) LTrim(ctx context.Context, in *GListLTrimRequest, o
 	return out, nil
 }
 
+func (c *gListServiceClient) LInsert(ctx context.Context, in *GListLInsertRequest, opts ...grpc.CallOption) (*GListLInsertResponse, error) {
+	out := new(GListLInsertResponse)
+	err := c.cc.Invoke(ctx, GListService_LInsert_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gListServiceClient) LPos(ctx context.Context, in *GListLPosRequest, opts ...grpc.CallOption) (*GListLPosResponse, error) {
+	out := new(GListLPosResponse)
+	err := c.cc.Invoke(ctx, GListService_LPos_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gListServiceClient) LMove(ctx context.Context, in *GListLMoveRequest, opts ...grpc.CallOption) (*GListLMoveResponse, error) {
+	out := new(GListLMoveResponse)
+	err := c.cc.Invoke(ctx, GListService_LMove_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gListServiceClient) BLPop(ctx context.Context, in *GListBPopRequest, opts ...grpc.CallOption) (*GListBPopResponse, error) {
+	out := new(GListBPopResponse)
+	err := c.cc.Invoke(ctx, GListService_BLPop_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gListServiceClient) BRPop(ctx context.Context, in *GListBPopRequest, opts ...grpc.CallOption) (*GListBPopResponse, error) {
+	out := new(GListBPopResponse)
+	err := c.cc.Invoke(ctx, GListService_BRPop_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gListServiceClient) BLMove(ctx context.Context, in *GListBLMoveRequest, opts ...grpc.CallOption) (*GListBLMoveResponse, error) {
+	out := new(GListBLMoveResponse)
+	err := c.cc.Invoke(ctx, GListService_BLMove_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GListServiceServer is the server API for GListService service.
 // All implementations must embed UnimplementedGListServiceServer
 // for forward compatibility
 type GListServiceServer interface {
-	// example
 	LPush(context.Context, *GListLPushRequest) (*GListLPushResponse, error)
 	LPushs(context.Context, *GListLPushsRequest) (*GListLPushsResponse, error)
 	RPush(context.Context, *GListRPushRequest) (*GListRPushResponse, error)
@@ -185,6 +249,12 @@ type GListServiceServer interface {
 	LIndex(context.Context, *GListLIndexRequest) (*GListLIndexResponse, error)
 	LSet(context.Context, *GListLSetRequest) (*GListLSetResponse, error)
 	LTrim(context.Context, *GListLTrimRequest) (*GListLTrimResponse, error)
+	LInsert(context.Context, *GListLInsertRequest) (*GListLInsertResponse, error)
+	LPos(context.Context, *GListLPosRequest) (*GListLPosResponse, error)
+	LMove(context.Context, *GListLMoveRequest) (*GListLMoveResponse, error)
+	BLPop(context.Context, *GListBPopRequest) (*GListBPopResponse, error)
+	BRPop(context.Context, *GListBPopRequest) (*GListBPopResponse, error)
+	BLMove(context.Context, *GListBLMoveRequest) (*GListBLMoveResponse, error)
 	mustEmbedUnimplementedGListServiceServer()
 }
 
@@ -228,6 +298,24 @@ func (UnimplementedGListServiceServer) LSet(context.Context, *GListLSetRequest)
 func (UnimplementedGListServiceServer) LTrim(context.Context, *GListLTrimRequest) (*GListLTrimResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LTrim not implemented")
 }
+func (UnimplementedGListServiceServer) LInsert(context.Context, *GListLInsertRequest) (*GListLInsertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LInsert not implemented")
+}
+func (UnimplementedGListServiceServer) LPos(context.Context, *GListLPosRequest) (*GListLPosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LPos not implemented")
+}
+func (UnimplementedGListServiceServer) LMove(context.Context, *GListLMoveRequest) (*GListLMoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LMove not implemented")
+}
+func (UnimplementedGListServiceServer) BLPop(context.Context, *GListBPopRequest) (*GListBPopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BLPop not implemented")
+}
+func (UnimplementedGListServiceServer) BRPop(context.Context, *GListBPopRequest) (*GListBPopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BRPop not implemented")
+}
+func (UnimplementedGListServiceServer) BLMove(context.Context, *GListBLMoveRequest) (*GListBLMoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BLMove not implemented")
+}
 func (UnimplementedGListServiceServer) mustEmbedUnimplementedGListServiceServer() {}
 
 // UnsafeGListServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -457,6 +545,114 @@ func _GListService_LTrim_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GListService_LInsert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GListLInsertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GListServiceServer).LInsert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GListService_LInsert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GListServiceServer).LInsert(ctx, req.(*GListLInsertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GListService_LPos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GListLPosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GListServiceServer).LPos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GListService_LPos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GListServiceServer).LPos(ctx, req.(*GListLPosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GListService_LMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GListLMoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GListServiceServer).LMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GListService_LMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GListServiceServer).LMove(ctx, req.(*GListLMoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GListService_BLPop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GListBPopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GListServiceServer).BLPop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GListService_BLPop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GListServiceServer).BLPop(ctx, req.(*GListBPopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GListService_BRPop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GListBPopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GListServiceServer).BRPop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GListService_BRPop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GListServiceServer).BRPop(ctx, req.(*GListBPopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GListService_BLMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GListBLMoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GListServiceServer).BLMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GListService_BLMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GListServiceServer).BLMove(ctx, req.(*GListBLMoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GListService_ServiceDesc is the grpc.ServiceDesc for GListService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -512,6 +708,30 @@ var GListService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LTrim",
 			Handler:    _GListService_LTrim_Handler,
 		},
+		{
+			MethodName: "LInsert",
+			Handler:    _GListService_LInsert_Handler,
+		},
+		{
+			MethodName: "LPos",
+			Handler:    _GListService_LPos_Handler,
+		},
+		{
+			MethodName: "LMove",
+			Handler:    _GListService_LMove_Handler,
+		},
+		{
+			MethodName: "BLPop",
+			Handler:    _GListService_BLPop_Handler,
+		},
+		{
+			MethodName: "BRPop",
+			Handler:    _GListService_BRPop_Handler,
+		},
+		{
+			MethodName: "BLMove",
+			Handler:    _GListService_BLMove_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lib/proto/glist/db.proto",
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.31.0
 // 	protoc        v3.14.0
 // source: lib/proto/gset/db.proto
 
@@ -969,6 +969,132 @@ func (x *EmptyResponse) GetOK() bool {
 	return false
 }
 
+type SScanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Match  string `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	Count  int64  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *SScanRequest) Reset() {
+	*x = SScanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gset_db_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SScanRequest) ProtoMessage() {}
+
+func (x *SScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gset_db_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SScanRequest.ProtoReflect.Descriptor instead.
+func (*SScanRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gset_db_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SScanRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SScanRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SScanRequest) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *SScanRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SScanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cursor  string   `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Members []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *SScanResponse) Reset() {
+	*x = SScanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gset_db_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SScanResponse) ProtoMessage() {}
+
+func (x *SScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gset_db_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SScanResponse.ProtoReflect.Descriptor instead.
+func (*SScanResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gset_db_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SScanResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SScanResponse) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
 var File_lib_proto_gset_db_proto protoreflect.FileDescriptor
 
 var file_lib_proto_gset_db_proto_rawDesc = []byte{
@@ -1031,50 +1157,64 @@ var file_lib_proto_gset_db_proto_rawDesc = []byte{
 	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65,
 	0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x1f,
 	0x0a, 0x0d, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x4f, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x4f, 0x4b, 0x32,
-	0x94, 0x05, 0x0a, 0x0b, 0x47, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x2e, 0x0a, 0x04, 0x53, 0x41, 0x64, 0x64, 0x12, 0x11, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53,
-	0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65,
-	0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x30, 0x0a, 0x05, 0x53, 0x41, 0x64, 0x64, 0x73, 0x12, 0x12, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e,
-	0x53, 0x41, 0x64, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67,
-	0x73, 0x65, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2e, 0x0a, 0x04, 0x53, 0x52, 0x65, 0x6d, 0x12, 0x11, 0x2e, 0x67, 0x73, 0x65, 0x74,
-	0x2e, 0x53, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67,
-	0x73, 0x65, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x30, 0x0a, 0x05, 0x53, 0x52, 0x65, 0x6d, 0x73, 0x12, 0x12, 0x2e, 0x67, 0x73, 0x65,
-	0x74, 0x2e, 0x53, 0x52, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
-	0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x53, 0x43, 0x61, 0x72, 0x64, 0x12, 0x12, 0x2e, 0x67,
-	0x73, 0x65, 0x74, 0x2e, 0x53, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x53, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x73, 0x12, 0x15, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e,
-	0x53, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x3c, 0x0a, 0x09, 0x53, 0x49, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x2e,
-	0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x49, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x49, 0x73,
-	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33,
-	0x0a, 0x06, 0x53, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e,
-	0x53, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
-	0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x53, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x13, 0x2e,
-	0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x49, 0x6e, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x53, 0x44, 0x69, 0x66,
-	0x66, 0x12, 0x12, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x44, 0x69,
-	0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x55,
-	0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x67, 0x73, 0x65, 0x74,
-	0x2e, 0x53, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x0e, 0x0a, 0x02, 0x4f, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x4f, 0x4b, 0x22,
+	0x64, 0x0a, 0x0c, 0x53, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x41, 0x0a, 0x0d, 0x53, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x32, 0xc6, 0x05, 0x0a, 0x0b, 0x47, 0x53, 0x65,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x53, 0x41, 0x64, 0x64,
+	0x12, 0x11, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x49, 0x6e, 0x74,
-	0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x10, 0x5a, 0x0e, 0x6c, 0x69, 0x62, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x73, 0x65, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x53, 0x41, 0x64, 0x64,
+	0x73, 0x12, 0x12, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x41, 0x64, 0x64, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x53, 0x52,
+	0x65, 0x6d, 0x12, 0x11, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x52, 0x65, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x53, 0x52,
+	0x65, 0x6d, 0x73, 0x12, 0x12, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x52, 0x65, 0x6d, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05,
+	0x53, 0x43, 0x61, 0x72, 0x64, 0x12, 0x12, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x43, 0x61,
+	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74,
+	0x2e, 0x53, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39,
+	0x0a, 0x08, 0x53, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x73, 0x65,
+	0x74, 0x2e, 0x53, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x53, 0x49, 0x73,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x49,
+	0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x49, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x53, 0x55, 0x6e, 0x69, 0x6f,
+	0x6e, 0x12, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x55,
+	0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06,
+	0x53, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x13, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x73,
+	0x65, 0x74, 0x2e, 0x53, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x30, 0x0a, 0x05, 0x53, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12, 0x2e, 0x67, 0x73, 0x65,
+	0x74, 0x2e, 0x53, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x12, 0x18, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x55, 0x6e, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67,
+	0x73, 0x65, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x12, 0x18, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e, 0x53, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x73, 0x65,
+	0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x30, 0x0a, 0x05, 0x53, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x12, 0x2e, 0x67, 0x73, 0x65, 0x74, 0x2e,
+	0x53, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67,
+	0x73, 0x65, 0x74, 0x2e, 0x53, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x10, 0x5a, 0x0e, 0x6c, 0x69, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
+	0x73, 0x65, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1089,7 +1229,7 @@ func file_lib_proto_gset_db_proto_rawDescGZIP() []byte {
 	return file_lib_proto_gset_db_proto_rawDescData
 }
 
-var file_lib_proto_gset_db_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_lib_proto_gset_db_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_lib_proto_gset_db_proto_goTypes = []interface{}{
 	(*SAddRequest)(nil),        // 0: gset.SAddRequest
 	(*SAddsRequest)(nil),       // 1: gset.SAddsRequest
@@ -1110,6 +1250,8 @@ var file_lib_proto_gset_db_proto_goTypes = []interface{}{
 	(*SUnionStoreRequest)(nil), // 16: gset.SUnionStoreRequest
 	(*SInterStoreRequest)(nil), // 17: gset.SInterStoreRequest
 	(*EmptyResponse)(nil),      // 18: gset.EmptyResponse
+	(*SScanRequest)(nil),       // 19: gset.SScanRequest
+	(*SScanResponse)(nil),      // 20: gset.SScanResponse
 }
 var file_lib_proto_gset_db_proto_depIdxs = []int32{
 	0,  // 0: gset.GSetService.SAdd:input_type -> gset.SAddRequest
@@ -1124,20 +1266,22 @@ var file_lib_proto_gset_db_proto_depIdxs = []int32{
 	14, // 9: gset.GSetService.SDiff:input_type -> gset.SDiffRequest
 	16, // 10: gset.GSetService.SUnionStore:input_type -> gset.SUnionStoreRequest
 	17, // 11: gset.GSetService.SInterStore:input_type -> gset.SInterStoreRequest
-	18, // 12: gset.GSetService.SAdd:output_type -> gset.EmptyResponse
-	18, // 13: gset.GSetService.SAdds:output_type -> gset.EmptyResponse
-	18, // 14: gset.GSetService.SRem:output_type -> gset.EmptyResponse
-	18, // 15: gset.GSetService.SRems:output_type -> gset.EmptyResponse
-	5,  // 16: gset.GSetService.SCard:output_type -> gset.SCardResponse
-	7,  // 17: gset.GSetService.SMembers:output_type -> gset.SMembersResponse
-	9,  // 18: gset.GSetService.SIsMember:output_type -> gset.SIsMemberResponse
-	11, // 19: gset.GSetService.SUnion:output_type -> gset.SUnionResponse
-	13, // 20: gset.GSetService.SInter:output_type -> gset.SInterResponse
-	15, // 21: gset.GSetService.SDiff:output_type -> gset.SDiffResponse
-	18, // 22: gset.GSetService.SUnionStore:output_type -> gset.EmptyResponse
-	18, // 23: gset.GSetService.SInterStore:output_type -> gset.EmptyResponse
-	12, // [12:24] is the sub-list for method output_type
-	0,  // [0:12] is the sub-list for method input_type
+	19, // 12: gset.GSetService.SScan:input_type -> gset.SScanRequest
+	18, // 13: gset.GSetService.SAdd:output_type -> gset.EmptyResponse
+	18, // 14: gset.GSetService.SAdds:output_type -> gset.EmptyResponse
+	18, // 15: gset.GSetService.SRem:output_type -> gset.EmptyResponse
+	18, // 16: gset.GSetService.SRems:output_type -> gset.EmptyResponse
+	5,  // 17: gset.GSetService.SCard:output_type -> gset.SCardResponse
+	7,  // 18: gset.GSetService.SMembers:output_type -> gset.SMembersResponse
+	9,  // 19: gset.GSetService.SIsMember:output_type -> gset.SIsMemberResponse
+	11, // 20: gset.GSetService.SUnion:output_type -> gset.SUnionResponse
+	13, // 21: gset.GSetService.SInter:output_type -> gset.SInterResponse
+	15, // 22: gset.GSetService.SDiff:output_type -> gset.SDiffResponse
+	18, // 23: gset.GSetService.SUnionStore:output_type -> gset.EmptyResponse
+	18, // 24: gset.GSetService.SInterStore:output_type -> gset.EmptyResponse
+	20, // 25: gset.GSetService.SScan:output_type -> gset.SScanResponse
+	13, // [13:26] is the sub-list for method output_type
+	0,  // [0:13] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -1377,6 +1521,30 @@ func file_lib_proto_gset_db_proto_init() {
 				return nil
 			}
 		}
+		file_lib_proto_gset_db_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gset_db_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SScanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1384,7 +1552,7 @@ func file_lib_proto_gset_db_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lib_proto_gset_db_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -31,6 +31,7 @@ const (
 	GSetService_SDiff_FullMethodName       = "/gset.GSetService/SDiff"
 	GSetService_SUnionStore_FullMethodName = "/gset.GSetService/SUnionStore"
 	GSetService_SInterStore_FullMethodName = "/gset.GSetService/SInterStore"
+	GSetService_SScan_FullMethodName       = "/gset.GSetService/SScan"
 )
 
 // GSetServiceClient is the client API for GSetService service.
@@ -49,6 +50,7 @@ type GSetServiceClient interface {
 	SDiff(ctx context.Context, in *SDiffRequest, opts ...grpc.CallOption) (*SDiffResponse, error)
 	SUnionStore(ctx context.Context, in *SUnionStoreRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	SInterStore(ctx context.Context, in *SInterStoreRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	SScan(ctx context.Context, in *SScanRequest, opts ...grpc.CallOption) (*SScanResponse, error)
 }
 
 type gSetServiceClient struct {
@@ -167,6 +169,15 @@ func (c *gSetServiceClient) SInterStore(ctx context.Context, in *SInterStoreRequ
 	return out, nil
 }
 
+func (c *gSetServiceClient) SScan(ctx context.Context, in *SScanRequest, opts ...grpc.CallOption) (*SScanResponse, error) {
+	out := new(SScanResponse)
+	err := c.cc.Invoke(ctx, GSetService_SScan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GSetServiceServer is the server API for GSetService service.
 // All implementations must embed UnimplementedGSetServiceServer
 // for forward compatibility
@@ -183,6 +194,7 @@ type GSetServiceServer interface {
 	SDiff(context.Context, *SDiffRequest) (*SDiffResponse, error)
 	SUnionStore(context.Context, *SUnionStoreRequest) (*EmptyResponse, error)
 	SInterStore(context.Context, *SInterStoreRequest) (*EmptyResponse, error)
+	SScan(context.Context, *SScanRequest) (*SScanResponse, error)
 	mustEmbedUnimplementedGSetServiceServer()
 }
 
@@ -226,6 +238,9 @@ func (UnimplementedGSetServiceServer) SUnionStore(context.Context, *SUnionStoreR
 func (UnimplementedGSetServiceServer) SInterStore(context.Context, *SInterStoreRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SInterStore not implemented")
 }
+func (UnimplementedGSetServiceServer) SScan(context.Context, *SScanRequest) (*SScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SScan not implemented")
+}
 func (UnimplementedGSetServiceServer) mustEmbedUnimplementedGSetServiceServer() {}
 
 // UnsafeGSetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -455,6 +470,24 @@ func _GSetService_SInterStore_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GSetService_SScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GSetServiceServer).SScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GSetService_SScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GSetServiceServer).SScan(ctx, req.(*SScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GSetService_ServiceDesc is the grpc.ServiceDesc for GSetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -510,6 +543,10 @@ var GSetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SInterStore",
 			Handler:    _GSetService_SInterStore_Handler,
 		},
+		{
+			MethodName: "SScan",
+			Handler:    _GSetService_SScan_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lib/proto/gset/db.proto",
@@ -1,15 +1,15 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.31.0
 // 	protoc        v3.6.1
 // source: lib/proto/gzset/db.proto
 
 package gzset
 
 import (
-	any1 "github.com/golang/protobuf/ptypes/any"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -26,9 +26,9 @@ type ZSetValue struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Score  int32     `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
-	Member string    `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
-	Value  *any1.Any `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Score  float64    `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Member string     `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	Value  *anypb.Any `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 }
 
 func (x *ZSetValue) Reset() {
@@ -63,7 +63,7 @@ func (*ZSetValue) Descriptor() ([]byte, []int) {
 	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *ZSetValue) GetScore() int32 {
+func (x *ZSetValue) GetScore() float64 {
 	if x != nil {
 		return x.Score
 	}
@@ -77,13 +77,100 @@ func (x *ZSetValue) GetMember() string {
 	return ""
 }
 
-func (x *ZSetValue) GetValue() *any1.Any {
+func (x *ZSetValue) GetValue() *anypb.Any {
 	if x != nil {
 		return x.Value
 	}
 	return nil
 }
 
+type ZAddFlags struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nx   bool `protobuf:"varint,1,opt,name=nx,proto3" json:"nx,omitempty"`
+	Xx   bool `protobuf:"varint,2,opt,name=xx,proto3" json:"xx,omitempty"`
+	Gt   bool `protobuf:"varint,3,opt,name=gt,proto3" json:"gt,omitempty"`
+	Lt   bool `protobuf:"varint,4,opt,name=lt,proto3" json:"lt,omitempty"`
+	Ch   bool `protobuf:"varint,5,opt,name=ch,proto3" json:"ch,omitempty"`
+	Incr bool `protobuf:"varint,6,opt,name=incr,proto3" json:"incr,omitempty"`
+}
+
+func (x *ZAddFlags) Reset() {
+	*x = ZAddFlags{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZAddFlags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZAddFlags) ProtoMessage() {}
+
+func (x *ZAddFlags) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZAddFlags.ProtoReflect.Descriptor instead.
+func (*ZAddFlags) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ZAddFlags) GetNx() bool {
+	if x != nil {
+		return x.Nx
+	}
+	return false
+}
+
+func (x *ZAddFlags) GetXx() bool {
+	if x != nil {
+		return x.Xx
+	}
+	return false
+}
+
+func (x *ZAddFlags) GetGt() bool {
+	if x != nil {
+		return x.Gt
+	}
+	return false
+}
+
+func (x *ZAddFlags) GetLt() bool {
+	if x != nil {
+		return x.Lt
+	}
+	return false
+}
+
+func (x *ZAddFlags) GetCh() bool {
+	if x != nil {
+		return x.Ch
+	}
+	return false
+}
+
+func (x *ZAddFlags) GetIncr() bool {
+	if x != nil {
+		return x.Incr
+	}
+	return false
+}
+
 type ZAddRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -91,12 +178,13 @@ type ZAddRequest struct {
 
 	Key    string     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Member *ZSetValue `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	Flags  *ZAddFlags `protobuf:"bytes,3,opt,name=flags,proto3" json:"flags,omitempty"`
 }
 
 func (x *ZAddRequest) Reset() {
 	*x = ZAddRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[1]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -109,7 +197,7 @@ func (x *ZAddRequest) String() string {
 func (*ZAddRequest) ProtoMessage() {}
 
 func (x *ZAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[1]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -122,7 +210,7 @@ func (x *ZAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZAddRequest.ProtoReflect.Descriptor instead.
 func (*ZAddRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{1}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ZAddRequest) GetKey() string {
@@ -139,19 +227,29 @@ func (x *ZAddRequest) GetMember() *ZSetValue {
 	return nil
 }
 
+func (x *ZAddRequest) GetFlags() *ZAddFlags {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
 type ZAddResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Success bool    `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Count   int32   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Score   float64 `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	Exists  bool    `protobuf:"varint,5,opt,name=exists,proto3" json:"exists,omitempty"`
 }
 
 func (x *ZAddResponse) Reset() {
 	*x = ZAddResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[2]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -164,7 +262,7 @@ func (x *ZAddResponse) String() string {
 func (*ZAddResponse) ProtoMessage() {}
 
 func (x *ZAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[2]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +275,7 @@ func (x *ZAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZAddResponse.ProtoReflect.Descriptor instead.
 func (*ZAddResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{2}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ZAddResponse) GetSuccess() bool {
@@ -194,6 +292,27 @@ func (x *ZAddResponse) GetMessage() string {
 	return ""
 }
 
+func (x *ZAddResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ZAddResponse) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ZAddResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
 type ZAddsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -201,12 +320,13 @@ type ZAddsRequest struct {
 
 	Key     string       `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Members []*ZSetValue `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Flags   *ZAddFlags   `protobuf:"bytes,3,opt,name=flags,proto3" json:"flags,omitempty"`
 }
 
 func (x *ZAddsRequest) Reset() {
 	*x = ZAddsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[3]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -219,7 +339,7 @@ func (x *ZAddsRequest) String() string {
 func (*ZAddsRequest) ProtoMessage() {}
 
 func (x *ZAddsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[3]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -232,7 +352,7 @@ func (x *ZAddsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZAddsRequest.ProtoReflect.Descriptor instead.
 func (*ZAddsRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{3}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ZAddsRequest) GetKey() string {
@@ -249,6 +369,13 @@ func (x *ZAddsRequest) GetMembers() []*ZSetValue {
 	return nil
 }
 
+func (x *ZAddsRequest) GetFlags() *ZAddFlags {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
 type ZAddsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -256,12 +383,13 @@ type ZAddsResponse struct {
 
 	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Count   int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 }
 
 func (x *ZAddsResponse) Reset() {
 	*x = ZAddsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[4]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -274,7 +402,7 @@ func (x *ZAddsResponse) String() string {
 func (*ZAddsResponse) ProtoMessage() {}
 
 func (x *ZAddsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[4]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -287,7 +415,7 @@ func (x *ZAddsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZAddsResponse.ProtoReflect.Descriptor instead.
 func (*ZAddsResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{4}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ZAddsResponse) GetSuccess() bool {
@@ -304,6 +432,13 @@ func (x *ZAddsResponse) GetMessage() string {
 	return ""
 }
 
+func (x *ZAddsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 type ZRemRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -316,7 +451,7 @@ type ZRemRequest struct {
 func (x *ZRemRequest) Reset() {
 	*x = ZRemRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[5]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -329,7 +464,7 @@ func (x *ZRemRequest) String() string {
 func (*ZRemRequest) ProtoMessage() {}
 
 func (x *ZRemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[5]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -342,7 +477,7 @@ func (x *ZRemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRemRequest.ProtoReflect.Descriptor instead.
 func (*ZRemRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{5}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ZRemRequest) GetKey() string {
@@ -371,7 +506,7 @@ type ZRemResponse struct {
 func (x *ZRemResponse) Reset() {
 	*x = ZRemResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[6]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -384,7 +519,7 @@ func (x *ZRemResponse) String() string {
 func (*ZRemResponse) ProtoMessage() {}
 
 func (x *ZRemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[6]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,7 +532,7 @@ func (x *ZRemResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRemResponse.ProtoReflect.Descriptor instead.
 func (*ZRemResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{6}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ZRemResponse) GetSuccess() bool {
@@ -426,7 +561,7 @@ type ZRemsRequest struct {
 func (x *ZRemsRequest) Reset() {
 	*x = ZRemsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[7]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -439,7 +574,7 @@ func (x *ZRemsRequest) String() string {
 func (*ZRemsRequest) ProtoMessage() {}
 
 func (x *ZRemsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[7]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -452,7 +587,7 @@ func (x *ZRemsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRemsRequest.ProtoReflect.Descriptor instead.
 func (*ZRemsRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{7}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ZRemsRequest) GetKey() string {
@@ -481,7 +616,7 @@ type ZRemsResponse struct {
 func (x *ZRemsResponse) Reset() {
 	*x = ZRemsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[8]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -494,7 +629,7 @@ func (x *ZRemsResponse) String() string {
 func (*ZRemsResponse) ProtoMessage() {}
 
 func (x *ZRemsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[8]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -507,7 +642,7 @@ func (x *ZRemsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRemsResponse.ProtoReflect.Descriptor instead.
 func (*ZRemsResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{8}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ZRemsResponse) GetSuccess() bool {
@@ -536,7 +671,7 @@ type ZScoreRequest struct {
 func (x *ZScoreRequest) Reset() {
 	*x = ZScoreRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[9]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -549,7 +684,7 @@ func (x *ZScoreRequest) String() string {
 func (*ZScoreRequest) ProtoMessage() {}
 
 func (x *ZScoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[9]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -562,7 +697,7 @@ func (x *ZScoreRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZScoreRequest.ProtoReflect.Descriptor instead.
 func (*ZScoreRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{9}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ZScoreRequest) GetKey() string {
@@ -584,14 +719,14 @@ type ZScoreResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Score  int32 `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
-	Exists bool  `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	Score  float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Exists bool    `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
 }
 
 func (x *ZScoreResponse) Reset() {
 	*x = ZScoreResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[10]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -604,7 +739,7 @@ func (x *ZScoreResponse) String() string {
 func (*ZScoreResponse) ProtoMessage() {}
 
 func (x *ZScoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[10]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,10 +752,10 @@ func (x *ZScoreResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZScoreResponse.ProtoReflect.Descriptor instead.
 func (*ZScoreResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{10}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ZScoreResponse) GetScore() int32 {
+func (x *ZScoreResponse) GetScore() float64 {
 	if x != nil {
 		return x.Score
 	}
@@ -646,7 +781,7 @@ type ZRankRequest struct {
 func (x *ZRankRequest) Reset() {
 	*x = ZRankRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[11]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -659,7 +794,7 @@ func (x *ZRankRequest) String() string {
 func (*ZRankRequest) ProtoMessage() {}
 
 func (x *ZRankRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[11]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -672,7 +807,7 @@ func (x *ZRankRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRankRequest.ProtoReflect.Descriptor instead.
 func (*ZRankRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{11}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ZRankRequest) GetKey() string {
@@ -701,7 +836,7 @@ type ZRankResponse struct {
 func (x *ZRankResponse) Reset() {
 	*x = ZRankResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[12]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -714,7 +849,7 @@ func (x *ZRankResponse) String() string {
 func (*ZRankResponse) ProtoMessage() {}
 
 func (x *ZRankResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[12]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,7 +862,7 @@ func (x *ZRankResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRankResponse.ProtoReflect.Descriptor instead.
 func (*ZRankResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{12}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ZRankResponse) GetRank() int32 {
@@ -756,7 +891,7 @@ type ZRevRankRequest struct {
 func (x *ZRevRankRequest) Reset() {
 	*x = ZRevRankRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[13]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -769,7 +904,7 @@ func (x *ZRevRankRequest) String() string {
 func (*ZRevRankRequest) ProtoMessage() {}
 
 func (x *ZRevRankRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[13]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -782,7 +917,7 @@ func (x *ZRevRankRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRevRankRequest.ProtoReflect.Descriptor instead.
 func (*ZRevRankRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{13}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ZRevRankRequest) GetKey() string {
@@ -811,7 +946,7 @@ type ZRevRankResponse struct {
 func (x *ZRevRankResponse) Reset() {
 	*x = ZRevRankResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[14]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -824,7 +959,7 @@ func (x *ZRevRankResponse) String() string {
 func (*ZRevRankResponse) ProtoMessage() {}
 
 func (x *ZRevRankResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[14]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -837,7 +972,7 @@ func (x *ZRevRankResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRevRankResponse.ProtoReflect.Descriptor instead.
 func (*ZRevRankResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{14}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ZRevRankResponse) GetRank() int32 {
@@ -867,7 +1002,7 @@ type ZRangeRequest struct {
 func (x *ZRangeRequest) Reset() {
 	*x = ZRangeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[15]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -880,7 +1015,7 @@ func (x *ZRangeRequest) String() string {
 func (*ZRangeRequest) ProtoMessage() {}
 
 func (x *ZRangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[15]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -893,7 +1028,7 @@ func (x *ZRangeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRangeRequest.ProtoReflect.Descriptor instead.
 func (*ZRangeRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{15}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ZRangeRequest) GetKey() string {
@@ -928,7 +1063,7 @@ type ZRangeResponse struct {
 func (x *ZRangeResponse) Reset() {
 	*x = ZRangeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[16]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -941,7 +1076,7 @@ func (x *ZRangeResponse) String() string {
 func (*ZRangeResponse) ProtoMessage() {}
 
 func (x *ZRangeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[16]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -954,7 +1089,7 @@ func (x *ZRangeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRangeResponse.ProtoReflect.Descriptor instead.
 func (*ZRangeResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{16}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ZRangeResponse) GetMembers() []*ZSetValue {
@@ -969,15 +1104,15 @@ type ZCountRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Min int32  `protobuf:"varint,2,opt,name=min,proto3" json:"min,omitempty"`
-	Max int32  `protobuf:"varint,3,opt,name=max,proto3" json:"max,omitempty"`
+	Key string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Min float64 `protobuf:"fixed64,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
 }
 
 func (x *ZCountRequest) Reset() {
 	*x = ZCountRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[17]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -990,7 +1125,7 @@ func (x *ZCountRequest) String() string {
 func (*ZCountRequest) ProtoMessage() {}
 
 func (x *ZCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[17]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1003,7 +1138,7 @@ func (x *ZCountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZCountRequest.ProtoReflect.Descriptor instead.
 func (*ZCountRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{17}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ZCountRequest) GetKey() string {
@@ -1013,14 +1148,14 @@ func (x *ZCountRequest) GetKey() string {
 	return ""
 }
 
-func (x *ZCountRequest) GetMin() int32 {
+func (x *ZCountRequest) GetMin() float64 {
 	if x != nil {
 		return x.Min
 	}
 	return 0
 }
 
-func (x *ZCountRequest) GetMax() int32 {
+func (x *ZCountRequest) GetMax() float64 {
 	if x != nil {
 		return x.Max
 	}
@@ -1038,7 +1173,7 @@ type ZCountResponse struct {
 func (x *ZCountResponse) Reset() {
 	*x = ZCountResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[18]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1051,7 +1186,7 @@ func (x *ZCountResponse) String() string {
 func (*ZCountResponse) ProtoMessage() {}
 
 func (x *ZCountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[18]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1064,7 +1199,7 @@ func (x *ZCountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZCountResponse.ProtoReflect.Descriptor instead.
 func (*ZCountResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{18}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ZCountResponse) GetCount() int32 {
@@ -1087,7 +1222,7 @@ type ZRevRangeRequest struct {
 func (x *ZRevRangeRequest) Reset() {
 	*x = ZRevRangeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[19]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1100,7 +1235,7 @@ func (x *ZRevRangeRequest) String() string {
 func (*ZRevRangeRequest) ProtoMessage() {}
 
 func (x *ZRevRangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[19]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1113,7 +1248,7 @@ func (x *ZRevRangeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRevRangeRequest.ProtoReflect.Descriptor instead.
 func (*ZRevRangeRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{19}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ZRevRangeRequest) GetKey() string {
@@ -1148,7 +1283,7 @@ type ZRevRangeResponse struct {
 func (x *ZRevRangeResponse) Reset() {
 	*x = ZRevRangeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[20]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1161,7 +1296,7 @@ func (x *ZRevRangeResponse) String() string {
 func (*ZRevRangeResponse) ProtoMessage() {}
 
 func (x *ZRevRangeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[20]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1174,7 +1309,7 @@ func (x *ZRevRangeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZRevRangeResponse.ProtoReflect.Descriptor instead.
 func (*ZRevRangeResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{20}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ZRevRangeResponse) GetMembers() []*ZSetValue {
@@ -1195,7 +1330,7 @@ type ZCardRequest struct {
 func (x *ZCardRequest) Reset() {
 	*x = ZCardRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[21]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1208,7 +1343,7 @@ func (x *ZCardRequest) String() string {
 func (*ZCardRequest) ProtoMessage() {}
 
 func (x *ZCardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[21]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1221,7 +1356,7 @@ func (x *ZCardRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZCardRequest.ProtoReflect.Descriptor instead.
 func (*ZCardRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{21}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ZCardRequest) GetKey() string {
@@ -1242,7 +1377,7 @@ type ZCardResponse struct {
 func (x *ZCardResponse) Reset() {
 	*x = ZCardResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[22]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1255,7 +1390,7 @@ func (x *ZCardResponse) String() string {
 func (*ZCardResponse) ProtoMessage() {}
 
 func (x *ZCardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[22]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1268,7 +1403,7 @@ func (x *ZCardResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZCardResponse.ProtoReflect.Descriptor instead.
 func (*ZCardResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{22}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ZCardResponse) GetCount() int32 {
@@ -1283,15 +1418,15 @@ type ZIncrByRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Member string `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
-	IncBy  int32  `protobuf:"varint,3,opt,name=incBy,proto3" json:"incBy,omitempty"`
+	Key    string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Member string  `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	IncBy  float64 `protobuf:"fixed64,3,opt,name=incBy,proto3" json:"incBy,omitempty"`
 }
 
 func (x *ZIncrByRequest) Reset() {
 	*x = ZIncrByRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[23]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1304,7 +1439,7 @@ func (x *ZIncrByRequest) String() string {
 func (*ZIncrByRequest) ProtoMessage() {}
 
 func (x *ZIncrByRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[23]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1317,7 +1452,7 @@ func (x *ZIncrByRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZIncrByRequest.ProtoReflect.Descriptor instead.
 func (*ZIncrByRequest) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{23}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ZIncrByRequest) GetKey() string {
@@ -1334,7 +1469,7 @@ func (x *ZIncrByRequest) GetMember() string {
 	return ""
 }
 
-func (x *ZIncrByRequest) GetIncBy() int32 {
+func (x *ZIncrByRequest) GetIncBy() float64 {
 	if x != nil {
 		return x.IncBy
 	}
@@ -1346,14 +1481,14 @@ type ZIncrByResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NewScore int32 `protobuf:"varint,1,opt,name=newScore,proto3" json:"newScore,omitempty"`
-	Exists   bool  `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	NewScore float64 `protobuf:"fixed64,1,opt,name=newScore,proto3" json:"newScore,omitempty"`
+	Exists   bool    `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
 }
 
 func (x *ZIncrByResponse) Reset() {
 	*x = ZIncrByResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lib_proto_gzset_db_proto_msgTypes[24]
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1366,7 +1501,7 @@ func (x *ZIncrByResponse) String() string {
 func (*ZIncrByResponse) ProtoMessage() {}
 
 func (x *ZIncrByResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_lib_proto_gzset_db_proto_msgTypes[24]
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1379,10 +1514,10 @@ func (x *ZIncrByResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZIncrByResponse.ProtoReflect.Descriptor instead.
 func (*ZIncrByResponse) Descriptor() ([]byte, []int) {
-	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{24}
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *ZIncrByResponse) GetNewScore() int32 {
+func (x *ZIncrByResponse) GetNewScore() float64 {
 	if x != nil {
 		return x.NewScore
 	}
@@ -1396,160 +1531,1357 @@ func (x *ZIncrByResponse) GetExists() bool {
 	return false
 }
 
-var File_lib_proto_gzset_db_proto protoreflect.FileDescriptor
+type ZScanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_lib_proto_gzset_db_proto_rawDesc = []byte{
-	0x0a, 0x18, 0x6c, 0x69, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x7a, 0x73, 0x65,
-	0x74, 0x2f, 0x64, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x67, 0x7a, 0x73, 0x65,
-	0x74, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x65, 0x0a, 0x09,
-	0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
-	0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x49, 0x0a, 0x0b, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x65,
-	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x42,
-	0x0a, 0x0c, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x4c, 0x0a, 0x0c, 0x5a, 0x41, 0x64, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53,
-	0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73,
-	0x22, 0x43, 0x0a, 0x0d, 0x5a, 0x41, 0x64, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x37, 0x0a, 0x0b, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x42,
-	0x0a, 0x0c, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x3a, 0x0a, 0x0c, 0x5a, 0x52, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x43,
-	0x0a, 0x0d, 0x5a, 0x52, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x0d, 0x5a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x3e,
-	0x0a, 0x0e, 0x5a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x38,
-	0x0a, 0x0c, 0x5a, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x3b, 0x0a, 0x0d, 0x5a, 0x52, 0x61, 0x6e,
-	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e,
-	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x0a,
-	0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65,
-	0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x3b, 0x0a, 0x0f, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e,
-	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62,
-	0x65, 0x72, 0x22, 0x3e, 0x0a, 0x10, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78,
-	0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73,
-	0x74, 0x73, 0x22, 0x49, 0x0a, 0x0d, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Match  string `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	Count  int64  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ZScanRequest) Reset() {
+	*x = ZScanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZScanRequest) ProtoMessage() {}
+
+func (x *ZScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZScanRequest.ProtoReflect.Descriptor instead.
+func (*ZScanRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ZScanRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZScanRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ZScanRequest) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *ZScanRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ZScanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cursor  string       `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Members []*ZSetValue `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *ZScanResponse) Reset() {
+	*x = ZScanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZScanResponse) ProtoMessage() {}
+
+func (x *ZScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZScanResponse.ProtoReflect.Descriptor instead.
+func (*ZScanResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ZScanResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ZScanResponse) GetMembers() []*ZSetValue {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type Limit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Count  int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *Limit) Reset() {
+	*x = Limit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Limit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limit) ProtoMessage() {}
+
+func (x *Limit) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limit.ProtoReflect.Descriptor instead.
+func (*Limit) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Limit) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Limit) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ZRangeByScoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Min   string `protobuf:"bytes,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max   string `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
+	Limit *Limit `protobuf:"bytes,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ZRangeByScoreRequest) Reset() {
+	*x = ZRangeByScoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZRangeByScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRangeByScoreRequest) ProtoMessage() {}
+
+func (x *ZRangeByScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRangeByScoreRequest.ProtoReflect.Descriptor instead.
+func (*ZRangeByScoreRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ZRangeByScoreRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZRangeByScoreRequest) GetMin() string {
+	if x != nil {
+		return x.Min
+	}
+	return ""
+}
+
+func (x *ZRangeByScoreRequest) GetMax() string {
+	if x != nil {
+		return x.Max
+	}
+	return ""
+}
+
+func (x *ZRangeByScoreRequest) GetLimit() *Limit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+type ZRangeByScoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members []*ZSetValue `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *ZRangeByScoreResponse) Reset() {
+	*x = ZRangeByScoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZRangeByScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRangeByScoreResponse) ProtoMessage() {}
+
+func (x *ZRangeByScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRangeByScoreResponse.ProtoReflect.Descriptor instead.
+func (*ZRangeByScoreResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ZRangeByScoreResponse) GetMembers() []*ZSetValue {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ZRangeByLexRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Min   string `protobuf:"bytes,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max   string `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
+	Limit *Limit `protobuf:"bytes,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ZRangeByLexRequest) Reset() {
+	*x = ZRangeByLexRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZRangeByLexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRangeByLexRequest) ProtoMessage() {}
+
+func (x *ZRangeByLexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRangeByLexRequest.ProtoReflect.Descriptor instead.
+func (*ZRangeByLexRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ZRangeByLexRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZRangeByLexRequest) GetMin() string {
+	if x != nil {
+		return x.Min
+	}
+	return ""
+}
+
+func (x *ZRangeByLexRequest) GetMax() string {
+	if x != nil {
+		return x.Max
+	}
+	return ""
+}
+
+func (x *ZRangeByLexRequest) GetLimit() *Limit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+type ZRangeByLexResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members []*ZSetValue `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *ZRangeByLexResponse) Reset() {
+	*x = ZRangeByLexResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZRangeByLexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRangeByLexResponse) ProtoMessage() {}
+
+func (x *ZRangeByLexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRangeByLexResponse.ProtoReflect.Descriptor instead.
+func (*ZRangeByLexResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ZRangeByLexResponse) GetMembers() []*ZSetValue {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ZPopRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Count int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ZPopRequest) Reset() {
+	*x = ZPopRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZPopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZPopRequest) ProtoMessage() {}
+
+func (x *ZPopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZPopRequest.ProtoReflect.Descriptor instead.
+func (*ZPopRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ZPopRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZPopRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ZPopResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members []*ZSetValue `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *ZPopResponse) Reset() {
+	*x = ZPopResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZPopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZPopResponse) ProtoMessage() {}
+
+func (x *ZPopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZPopResponse.ProtoReflect.Descriptor instead.
+func (*ZPopResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ZPopResponse) GetMembers() []*ZSetValue {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ZRemRangeByScoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Min string `protobuf:"bytes,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max string `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *ZRemRangeByScoreRequest) Reset() {
+	*x = ZRemRangeByScoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZRemRangeByScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRemRangeByScoreRequest) ProtoMessage() {}
+
+func (x *ZRemRangeByScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRemRangeByScoreRequest.ProtoReflect.Descriptor instead.
+func (*ZRemRangeByScoreRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ZRemRangeByScoreRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZRemRangeByScoreRequest) GetMin() string {
+	if x != nil {
+		return x.Min
+	}
+	return ""
+}
+
+func (x *ZRemRangeByScoreRequest) GetMax() string {
+	if x != nil {
+		return x.Max
+	}
+	return ""
+}
+
+type ZRemRangeByRankRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Start int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Stop  int64  `protobuf:"varint,3,opt,name=stop,proto3" json:"stop,omitempty"`
+}
+
+func (x *ZRemRangeByRankRequest) Reset() {
+	*x = ZRemRangeByRankRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZRemRangeByRankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRemRangeByRankRequest) ProtoMessage() {}
+
+func (x *ZRemRangeByRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRemRangeByRankRequest.ProtoReflect.Descriptor instead.
+func (*ZRemRangeByRankRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ZRemRangeByRankRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZRemRangeByRankRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ZRemRangeByRankRequest) GetStop() int64 {
+	if x != nil {
+		return x.Stop
+	}
+	return 0
+}
+
+type ZRemRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Removed int64 `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *ZRemRangeResponse) Reset() {
+	*x = ZRemRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZRemRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZRemRangeResponse) ProtoMessage() {}
+
+func (x *ZRemRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZRemRangeResponse.ProtoReflect.Descriptor instead.
+func (*ZRemRangeResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ZRemRangeResponse) GetRemoved() int64 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+type ZMScoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key     string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Members []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *ZMScoreRequest) Reset() {
+	*x = ZMScoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZMScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZMScoreRequest) ProtoMessage() {}
+
+func (x *ZMScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZMScoreRequest.ProtoReflect.Descriptor instead.
+func (*ZMScoreRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ZMScoreRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZMScoreRequest) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ZMScoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scores []float64 `protobuf:"fixed64,1,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+	Exists []bool    `protobuf:"varint,2,rep,packed,name=exists,proto3" json:"exists,omitempty"`
+}
+
+func (x *ZMScoreResponse) Reset() {
+	*x = ZMScoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZMScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZMScoreResponse) ProtoMessage() {}
+
+func (x *ZMScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZMScoreResponse.ProtoReflect.Descriptor instead.
+func (*ZMScoreResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ZMScoreResponse) GetScores() []float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *ZMScoreResponse) GetExists() []bool {
+	if x != nil {
+		return x.Exists
+	}
+	return nil
+}
+
+type ZCombineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys      []string  `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Weights   []float64 `protobuf:"fixed64,2,rep,packed,name=weights,proto3" json:"weights,omitempty"`
+	Aggregate string    `protobuf:"bytes,3,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+}
+
+func (x *ZCombineRequest) Reset() {
+	*x = ZCombineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZCombineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZCombineRequest) ProtoMessage() {}
+
+func (x *ZCombineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZCombineRequest.ProtoReflect.Descriptor instead.
+func (*ZCombineRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ZCombineRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *ZCombineRequest) GetWeights() []float64 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *ZCombineRequest) GetAggregate() string {
+	if x != nil {
+		return x.Aggregate
+	}
+	return ""
+}
+
+type ZCombineResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members []*ZSetValue `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *ZCombineResponse) Reset() {
+	*x = ZCombineResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZCombineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZCombineResponse) ProtoMessage() {}
+
+func (x *ZCombineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZCombineResponse.ProtoReflect.Descriptor instead.
+func (*ZCombineResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ZCombineResponse) GetMembers() []*ZSetValue {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ZCombineStoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Destination string    `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	Keys        []string  `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Weights     []float64 `protobuf:"fixed64,3,rep,packed,name=weights,proto3" json:"weights,omitempty"`
+	Aggregate   string    `protobuf:"bytes,4,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+}
+
+func (x *ZCombineStoreRequest) Reset() {
+	*x = ZCombineStoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZCombineStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZCombineStoreRequest) ProtoMessage() {}
+
+func (x *ZCombineStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZCombineStoreRequest.ProtoReflect.Descriptor instead.
+func (*ZCombineStoreRequest) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ZCombineStoreRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *ZCombineStoreRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *ZCombineStoreRequest) GetWeights() []float64 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *ZCombineStoreRequest) GetAggregate() string {
+	if x != nil {
+		return x.Aggregate
+	}
+	return ""
+}
+
+type ZCombineStoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ZCombineStoreResponse) Reset() {
+	*x = ZCombineStoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lib_proto_gzset_db_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZCombineStoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZCombineStoreResponse) ProtoMessage() {}
+
+func (x *ZCombineStoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lib_proto_gzset_db_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZCombineStoreResponse.ProtoReflect.Descriptor instead.
+func (*ZCombineStoreResponse) Descriptor() ([]byte, []int) {
+	return file_lib_proto_gzset_db_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ZCombineStoreResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_lib_proto_gzset_db_proto protoreflect.FileDescriptor
+
+var file_lib_proto_gzset_db_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x6c, 0x69, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x7a, 0x73, 0x65,
+	0x74, 0x2f, 0x64, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x67, 0x7a, 0x73, 0x65,
+	0x74, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x65, 0x0a, 0x09,
+	0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x6f, 0x0a, 0x09, 0x5a, 0x41, 0x64, 0x64, 0x46, 0x6c, 0x61, 0x67, 0x73,
+	0x12, 0x0e, 0x0a, 0x02, 0x6e, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6e, 0x78,
+	0x12, 0x0e, 0x0a, 0x02, 0x78, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x78, 0x78,
+	0x12, 0x0e, 0x0a, 0x02, 0x67, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x67, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6c, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x63, 0x68,
+	0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x63, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x69, 0x6e, 0x63, 0x72, 0x22, 0x71, 0x0a, 0x0b, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65,
-	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x3c, 0x0a,
-	0x0e, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x45, 0x0a, 0x0d, 0x5a,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x69, 0x6e,
-	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d,
-	0x61, 0x78, 0x22, 0x26, 0x0a, 0x0e, 0x5a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5c, 0x0a, 0x10, 0x5a, 0x52,
-	0x65, 0x76, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53,
+	0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x26, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x46, 0x6c, 0x61, 0x67, 0x73,
+	0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x0c, 0x5a, 0x41, 0x64, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73,
+	0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x22, 0x74, 0x0a, 0x0c, 0x5a, 0x41, 0x64, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x65, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x26,
+	0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52,
+	0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22, 0x59, 0x0a, 0x0d, 0x5a, 0x41, 0x64, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x37, 0x0a, 0x0b, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x42, 0x0a, 0x0c, 0x5a, 0x52,
+	0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3a,
+	0x0a, 0x0c, 0x5a, 0x52, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
 	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x18,
-	0x0a, 0x07, 0x65, 0x6e, 0x64, 0x52, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x07, 0x65, 0x6e, 0x64, 0x52, 0x61, 0x6e, 0x6b, 0x22, 0x3f, 0x0a, 0x11, 0x5a, 0x52, 0x65, 0x76,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a,
-	0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x20, 0x0a, 0x0c, 0x5a, 0x43, 0x61,
-	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x25, 0x0a, 0x0d, 0x5a,
-	0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x22, 0x50, 0x0a, 0x0e, 0x5a, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x71,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x43, 0x0a, 0x0d, 0x5a, 0x52,
+	0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x39, 0x0a, 0x0d, 0x5a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x3e, 0x0a, 0x0e, 0x5a, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x38, 0x0a, 0x0c, 0x5a, 0x52,
+	0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x22, 0x3b, 0x0a, 0x0d, 0x5a, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69,
+	0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74,
+	0x73, 0x22, 0x3b, 0x0a, 0x0f, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14,
-	0x0a, 0x05, 0x69, 0x6e, 0x63, 0x42, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69,
-	0x6e, 0x63, 0x42, 0x79, 0x22, 0x45, 0x0a, 0x0f, 0x5a, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x53, 0x63,
-	0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x53, 0x63,
-	0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x32, 0x9c, 0x05, 0x0a, 0x0c,
-	0x47, 0x5a, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2f, 0x0a, 0x04,
-	0x5a, 0x41, 0x64, 0x64, 0x12, 0x12, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x41, 0x64,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74,
-	0x2e, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a,
-	0x05, 0x5a, 0x41, 0x64, 0x64, 0x73, 0x12, 0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a,
-	0x41, 0x64, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x7a,
-	0x73, 0x65, 0x74, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2f, 0x0a, 0x04, 0x5a, 0x52, 0x65, 0x6d, 0x12, 0x12, 0x2e, 0x67, 0x7a, 0x73, 0x65,
-	0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
-	0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x5a, 0x52, 0x65, 0x6d, 0x73, 0x12, 0x13, 0x2e, 0x67, 0x7a,
-	0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x5a, 0x53, 0x63, 0x6f, 0x72, 0x65,
-	0x12, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a,
-	0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a,
-	0x05, 0x5a, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a,
-	0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x7a,
-	0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x3b, 0x0a, 0x08, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x2e,
-	0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52,
-	0x65, 0x76, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35,
-	0x0a, 0x06, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74,
-	0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
-	0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x5a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
-	0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09,
-	0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x17, 0x2e, 0x67, 0x7a, 0x73, 0x65,
-	0x74, 0x2e, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x76, 0x52,
-	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05,
-	0x5a, 0x43, 0x61, 0x72, 0x64, 0x12, 0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43,
-	0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x7a, 0x73,
-	0x65, 0x74, 0x2e, 0x5a, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x38, 0x0a, 0x07, 0x5a, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x12, 0x15, 0x2e, 0x67, 0x7a,
-	0x73, 0x65, 0x74, 0x2e, 0x5a, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x49, 0x6e, 0x63, 0x72,
-	0x42, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x11, 0x5a, 0x0f, 0x6c, 0x69,
-	0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x3e,
+	0x0a, 0x10, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x49,
+	0x0a, 0x0d, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x3c, 0x0a, 0x0e, 0x5a, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67,
+	0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x45, 0x0a, 0x0d, 0x5a, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x26,
+	0x0a, 0x0e, 0x5a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5c, 0x0a, 0x10, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e,
+	0x64, 0x52, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x64,
+	0x52, 0x61, 0x6e, 0x6b, 0x22, 0x3f, 0x0a, 0x11, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73,
+	0x65, 0x74, 0x2e, 0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x20, 0x0a, 0x0c, 0x5a, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x25, 0x0a, 0x0d, 0x5a, 0x43, 0x61, 0x72, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x50,
+	0x0a, 0x0e, 0x5a, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x63, 0x42, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x69, 0x6e, 0x63, 0x42, 0x79,
+	0x22, 0x45, 0x0a, 0x0f, 0x5a, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x0c, 0x5a, 0x53, 0x63, 0x61, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x53, 0x0a,
+	0x0d, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e,
+	0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x22, 0x35, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x70, 0x0a, 0x14, 0x5a, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x22, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x43, 0x0a, 0x15, 0x5a,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53,
+	0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73,
+	0x22, 0x6e, 0x0a, 0x12, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x4c, 0x65, 0x78, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x22, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x7a,
+	0x73, 0x65, 0x74, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x22, 0x41, 0x0a, 0x13, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x4c, 0x65, 0x78, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74,
+	0x2e, 0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x22, 0x35, 0x0a, 0x0b, 0x5a, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x3a, 0x0a, 0x0c, 0x5a, 0x50,
+	0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a,
+	0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x4f, 0x0a, 0x17, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x54, 0x0a, 0x16, 0x5a, 0x52, 0x65, 0x6d, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f,
+	0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x22, 0x2d, 0x0a,
+	0x11, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x3c, 0x0a, 0x0e,
+	0x5a, 0x4d, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x41, 0x0a, 0x0f, 0x5a, 0x4d,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x5d, 0x0a,
+	0x0f, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x6b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x22, 0x3e, 0x0a, 0x10,
+	0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x65, 0x74, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x84, 0x01, 0x0a,
+	0x14, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x07, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x22, 0x2d, 0x0a, 0x15, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x32, 0xfa, 0x0c, 0x0a, 0x0c, 0x47, 0x5a, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x5a, 0x41, 0x64, 0x64, 0x12, 0x12, 0x2e, 0x67, 0x7a,
+	0x73, 0x65, 0x74, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x5a, 0x41, 0x64, 0x64, 0x73, 0x12, 0x13, 0x2e,
+	0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x5a, 0x52, 0x65, 0x6d,
+	0x12, 0x12, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x5a, 0x52, 0x65,
+	0x6d, 0x73, 0x12, 0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e,
+	0x5a, 0x52, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a,
+	0x06, 0x5a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e,
+	0x5a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x5a, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x13, 0x2e,
+	0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x5a, 0x52, 0x65, 0x76,
+	0x52, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65,
+	0x76, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67,
+	0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06,
+	0x5a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67,
+	0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x17, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x7a, 0x73, 0x65,
+	0x74, 0x2e, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x5a, 0x43, 0x61, 0x72, 0x64, 0x12, 0x13, 0x2e, 0x67,
+	0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x61, 0x72, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x5a, 0x49, 0x6e, 0x63, 0x72,
+	0x42, 0x79, 0x12, 0x15, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x49, 0x6e, 0x63, 0x72,
+	0x42, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x7a, 0x73, 0x65,
+	0x74, 0x2e, 0x5a, 0x49, 0x6e, 0x63, 0x72, 0x42, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x32, 0x0a, 0x05, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x13, 0x2e, 0x67, 0x7a, 0x73,
+	0x65, 0x74, 0x2e, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42,
+	0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4d, 0x0a, 0x10, 0x5a, 0x52, 0x65, 0x76, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x44, 0x0a, 0x0b, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x4c, 0x65, 0x78, 0x12,
+	0x19, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79,
+	0x4c, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x7a, 0x73,
+	0x65, 0x74, 0x2e, 0x5a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x4c, 0x65, 0x78, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x5a, 0x50, 0x6f, 0x70, 0x4d, 0x69,
+	0x6e, 0x12, 0x12, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x50, 0x6f, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x50,
+	0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x5a, 0x50,
+	0x6f, 0x70, 0x4d, 0x61, 0x78, 0x12, 0x12, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x50,
+	0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x7a, 0x73, 0x65,
+	0x74, 0x2e, 0x5a, 0x50, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c,
+	0x0a, 0x10, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x1e, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0f,
+	0x5a, 0x52, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x12,
+	0x1d, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x52, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x5a, 0x4d, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x4d, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x7a, 0x73,
+	0x65, 0x74, 0x2e, 0x5a, 0x4d, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x5a, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67,
+	0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f,
+	0x6d, 0x62, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
+	0x06, 0x5a, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e,
+	0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x05, 0x5a, 0x44, 0x69, 0x66,
+	0x66, 0x12, 0x16, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x7a, 0x73, 0x65,
+	0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x5a, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x12, 0x1b, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69,
+	0x6e, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b,
+	0x5a, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x67, 0x7a,
+	0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74,
+	0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x5a, 0x44, 0x69, 0x66, 0x66, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f,
+	0x6d, 0x62, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x7a, 0x73, 0x65, 0x74, 0x2e, 0x5a, 0x43, 0x6f, 0x6d, 0x62, 0x69,
+	0x6e, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x11, 0x5a, 0x0f, 0x6c, 0x69, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x7a, 0x73,
+	0x65, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1564,70 +2896,128 @@ func file_lib_proto_gzset_db_proto_rawDescGZIP() []byte {
 	return file_lib_proto_gzset_db_proto_rawDescData
 }
 
-var file_lib_proto_gzset_db_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_lib_proto_gzset_db_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_lib_proto_gzset_db_proto_goTypes = []interface{}{
-	(*ZSetValue)(nil),         // 0: gzset.ZSetValue
-	(*ZAddRequest)(nil),       // 1: gzset.ZAddRequest
-	(*ZAddResponse)(nil),      // 2: gzset.ZAddResponse
-	(*ZAddsRequest)(nil),      // 3: gzset.ZAddsRequest
-	(*ZAddsResponse)(nil),     // 4: gzset.ZAddsResponse
-	(*ZRemRequest)(nil),       // 5: gzset.ZRemRequest
-	(*ZRemResponse)(nil),      // 6: gzset.ZRemResponse
-	(*ZRemsRequest)(nil),      // 7: gzset.ZRemsRequest
-	(*ZRemsResponse)(nil),     // 8: gzset.ZRemsResponse
-	(*ZScoreRequest)(nil),     // 9: gzset.ZScoreRequest
-	(*ZScoreResponse)(nil),    // 10: gzset.ZScoreResponse
-	(*ZRankRequest)(nil),      // 11: gzset.ZRankRequest
-	(*ZRankResponse)(nil),     // 12: gzset.ZRankResponse
-	(*ZRevRankRequest)(nil),   // 13: gzset.ZRevRankRequest
-	(*ZRevRankResponse)(nil),  // 14: gzset.ZRevRankResponse
-	(*ZRangeRequest)(nil),     // 15: gzset.ZRangeRequest
-	(*ZRangeResponse)(nil),    // 16: gzset.ZRangeResponse
-	(*ZCountRequest)(nil),     // 17: gzset.ZCountRequest
-	(*ZCountResponse)(nil),    // 18: gzset.ZCountResponse
-	(*ZRevRangeRequest)(nil),  // 19: gzset.ZRevRangeRequest
-	(*ZRevRangeResponse)(nil), // 20: gzset.ZRevRangeResponse
-	(*ZCardRequest)(nil),      // 21: gzset.ZCardRequest
-	(*ZCardResponse)(nil),     // 22: gzset.ZCardResponse
-	(*ZIncrByRequest)(nil),    // 23: gzset.ZIncrByRequest
-	(*ZIncrByResponse)(nil),   // 24: gzset.ZIncrByResponse
-	(*any1.Any)(nil),          // 25: google.protobuf.Any
+	(*ZSetValue)(nil),               // 0: gzset.ZSetValue
+	(*ZAddFlags)(nil),               // 1: gzset.ZAddFlags
+	(*ZAddRequest)(nil),             // 2: gzset.ZAddRequest
+	(*ZAddResponse)(nil),            // 3: gzset.ZAddResponse
+	(*ZAddsRequest)(nil),            // 4: gzset.ZAddsRequest
+	(*ZAddsResponse)(nil),           // 5: gzset.ZAddsResponse
+	(*ZRemRequest)(nil),             // 6: gzset.ZRemRequest
+	(*ZRemResponse)(nil),            // 7: gzset.ZRemResponse
+	(*ZRemsRequest)(nil),            // 8: gzset.ZRemsRequest
+	(*ZRemsResponse)(nil),           // 9: gzset.ZRemsResponse
+	(*ZScoreRequest)(nil),           // 10: gzset.ZScoreRequest
+	(*ZScoreResponse)(nil),          // 11: gzset.ZScoreResponse
+	(*ZRankRequest)(nil),            // 12: gzset.ZRankRequest
+	(*ZRankResponse)(nil),           // 13: gzset.ZRankResponse
+	(*ZRevRankRequest)(nil),         // 14: gzset.ZRevRankRequest
+	(*ZRevRankResponse)(nil),        // 15: gzset.ZRevRankResponse
+	(*ZRangeRequest)(nil),           // 16: gzset.ZRangeRequest
+	(*ZRangeResponse)(nil),          // 17: gzset.ZRangeResponse
+	(*ZCountRequest)(nil),           // 18: gzset.ZCountRequest
+	(*ZCountResponse)(nil),          // 19: gzset.ZCountResponse
+	(*ZRevRangeRequest)(nil),        // 20: gzset.ZRevRangeRequest
+	(*ZRevRangeResponse)(nil),       // 21: gzset.ZRevRangeResponse
+	(*ZCardRequest)(nil),            // 22: gzset.ZCardRequest
+	(*ZCardResponse)(nil),           // 23: gzset.ZCardResponse
+	(*ZIncrByRequest)(nil),          // 24: gzset.ZIncrByRequest
+	(*ZIncrByResponse)(nil),         // 25: gzset.ZIncrByResponse
+	(*ZScanRequest)(nil),            // 26: gzset.ZScanRequest
+	(*ZScanResponse)(nil),           // 27: gzset.ZScanResponse
+	(*Limit)(nil),                   // 28: gzset.Limit
+	(*ZRangeByScoreRequest)(nil),    // 29: gzset.ZRangeByScoreRequest
+	(*ZRangeByScoreResponse)(nil),   // 30: gzset.ZRangeByScoreResponse
+	(*ZRangeByLexRequest)(nil),      // 31: gzset.ZRangeByLexRequest
+	(*ZRangeByLexResponse)(nil),     // 32: gzset.ZRangeByLexResponse
+	(*ZPopRequest)(nil),             // 33: gzset.ZPopRequest
+	(*ZPopResponse)(nil),            // 34: gzset.ZPopResponse
+	(*ZRemRangeByScoreRequest)(nil), // 35: gzset.ZRemRangeByScoreRequest
+	(*ZRemRangeByRankRequest)(nil),  // 36: gzset.ZRemRangeByRankRequest
+	(*ZRemRangeResponse)(nil),       // 37: gzset.ZRemRangeResponse
+	(*ZMScoreRequest)(nil),          // 38: gzset.ZMScoreRequest
+	(*ZMScoreResponse)(nil),         // 39: gzset.ZMScoreResponse
+	(*ZCombineRequest)(nil),         // 40: gzset.ZCombineRequest
+	(*ZCombineResponse)(nil),        // 41: gzset.ZCombineResponse
+	(*ZCombineStoreRequest)(nil),    // 42: gzset.ZCombineStoreRequest
+	(*ZCombineStoreResponse)(nil),   // 43: gzset.ZCombineStoreResponse
+	(*anypb.Any)(nil),               // 44: google.protobuf.Any
 }
 var file_lib_proto_gzset_db_proto_depIdxs = []int32{
-	25, // 0: gzset.ZSetValue.value:type_name -> google.protobuf.Any
+	44, // 0: gzset.ZSetValue.value:type_name -> google.protobuf.Any
 	0,  // 1: gzset.ZAddRequest.member:type_name -> gzset.ZSetValue
-	0,  // 2: gzset.ZAddsRequest.members:type_name -> gzset.ZSetValue
-	0,  // 3: gzset.ZRangeResponse.members:type_name -> gzset.ZSetValue
-	0,  // 4: gzset.ZRevRangeResponse.members:type_name -> gzset.ZSetValue
-	1,  // 5: gzset.GZSetService.ZAdd:input_type -> gzset.ZAddRequest
-	3,  // 6: gzset.GZSetService.ZAdds:input_type -> gzset.ZAddsRequest
-	5,  // 7: gzset.GZSetService.ZRem:input_type -> gzset.ZRemRequest
-	7,  // 8: gzset.GZSetService.ZRems:input_type -> gzset.ZRemsRequest
-	9,  // 9: gzset.GZSetService.ZScore:input_type -> gzset.ZScoreRequest
-	11, // 10: gzset.GZSetService.ZRank:input_type -> gzset.ZRankRequest
-	13, // 11: gzset.GZSetService.ZRevRank:input_type -> gzset.ZRevRankRequest
-	15, // 12: gzset.GZSetService.ZRange:input_type -> gzset.ZRangeRequest
-	17, // 13: gzset.GZSetService.ZCount:input_type -> gzset.ZCountRequest
-	19, // 14: gzset.GZSetService.ZRevRange:input_type -> gzset.ZRevRangeRequest
-	21, // 15: gzset.GZSetService.ZCard:input_type -> gzset.ZCardRequest
-	23, // 16: gzset.GZSetService.ZIncrBy:input_type -> gzset.ZIncrByRequest
-	2,  // 17: gzset.GZSetService.ZAdd:output_type -> gzset.ZAddResponse
-	4,  // 18: gzset.GZSetService.ZAdds:output_type -> gzset.ZAddsResponse
-	6,  // 19: gzset.GZSetService.ZRem:output_type -> gzset.ZRemResponse
-	8,  // 20: gzset.GZSetService.ZRems:output_type -> gzset.ZRemsResponse
-	10, // 21: gzset.GZSetService.ZScore:output_type -> gzset.ZScoreResponse
-	12, // 22: gzset.GZSetService.ZRank:output_type -> gzset.ZRankResponse
-	14, // 23: gzset.GZSetService.ZRevRank:output_type -> gzset.ZRevRankResponse
-	16, // 24: gzset.GZSetService.ZRange:output_type -> gzset.ZRangeResponse
-	18, // 25: gzset.GZSetService.ZCount:output_type -> gzset.ZCountResponse
-	20, // 26: gzset.GZSetService.ZRevRange:output_type -> gzset.ZRevRangeResponse
-	22, // 27: gzset.GZSetService.ZCard:output_type -> gzset.ZCardResponse
-	24, // 28: gzset.GZSetService.ZIncrBy:output_type -> gzset.ZIncrByResponse
-	17, // [17:29] is the sub-list for method output_type
-	5,  // [5:17] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	1,  // 2: gzset.ZAddRequest.flags:type_name -> gzset.ZAddFlags
+	0,  // 3: gzset.ZAddsRequest.members:type_name -> gzset.ZSetValue
+	1,  // 4: gzset.ZAddsRequest.flags:type_name -> gzset.ZAddFlags
+	0,  // 5: gzset.ZRangeResponse.members:type_name -> gzset.ZSetValue
+	0,  // 6: gzset.ZRevRangeResponse.members:type_name -> gzset.ZSetValue
+	0,  // 7: gzset.ZScanResponse.members:type_name -> gzset.ZSetValue
+	28, // 8: gzset.ZRangeByScoreRequest.limit:type_name -> gzset.Limit
+	0,  // 9: gzset.ZRangeByScoreResponse.members:type_name -> gzset.ZSetValue
+	28, // 10: gzset.ZRangeByLexRequest.limit:type_name -> gzset.Limit
+	0,  // 11: gzset.ZRangeByLexResponse.members:type_name -> gzset.ZSetValue
+	0,  // 12: gzset.ZPopResponse.members:type_name -> gzset.ZSetValue
+	0,  // 13: gzset.ZCombineResponse.members:type_name -> gzset.ZSetValue
+	2,  // 14: gzset.GZSetService.ZAdd:input_type -> gzset.ZAddRequest
+	4,  // 15: gzset.GZSetService.ZAdds:input_type -> gzset.ZAddsRequest
+	6,  // 16: gzset.GZSetService.ZRem:input_type -> gzset.ZRemRequest
+	8,  // 17: gzset.GZSetService.ZRems:input_type -> gzset.ZRemsRequest
+	10, // 18: gzset.GZSetService.ZScore:input_type -> gzset.ZScoreRequest
+	12, // 19: gzset.GZSetService.ZRank:input_type -> gzset.ZRankRequest
+	14, // 20: gzset.GZSetService.ZRevRank:input_type -> gzset.ZRevRankRequest
+	16, // 21: gzset.GZSetService.ZRange:input_type -> gzset.ZRangeRequest
+	18, // 22: gzset.GZSetService.ZCount:input_type -> gzset.ZCountRequest
+	20, // 23: gzset.GZSetService.ZRevRange:input_type -> gzset.ZRevRangeRequest
+	22, // 24: gzset.GZSetService.ZCard:input_type -> gzset.ZCardRequest
+	24, // 25: gzset.GZSetService.ZIncrBy:input_type -> gzset.ZIncrByRequest
+	26, // 26: gzset.GZSetService.ZScan:input_type -> gzset.ZScanRequest
+	29, // 27: gzset.GZSetService.ZRangeByScore:input_type -> gzset.ZRangeByScoreRequest
+	29, // 28: gzset.GZSetService.ZRevRangeByScore:input_type -> gzset.ZRangeByScoreRequest
+	31, // 29: gzset.GZSetService.ZRangeByLex:input_type -> gzset.ZRangeByLexRequest
+	33, // 30: gzset.GZSetService.ZPopMin:input_type -> gzset.ZPopRequest
+	33, // 31: gzset.GZSetService.ZPopMax:input_type -> gzset.ZPopRequest
+	35, // 32: gzset.GZSetService.ZRemRangeByScore:input_type -> gzset.ZRemRangeByScoreRequest
+	36, // 33: gzset.GZSetService.ZRemRangeByRank:input_type -> gzset.ZRemRangeByRankRequest
+	38, // 34: gzset.GZSetService.ZMScore:input_type -> gzset.ZMScoreRequest
+	40, // 35: gzset.GZSetService.ZUnion:input_type -> gzset.ZCombineRequest
+	40, // 36: gzset.GZSetService.ZInter:input_type -> gzset.ZCombineRequest
+	40, // 37: gzset.GZSetService.ZDiff:input_type -> gzset.ZCombineRequest
+	42, // 38: gzset.GZSetService.ZUnionStore:input_type -> gzset.ZCombineStoreRequest
+	42, // 39: gzset.GZSetService.ZInterStore:input_type -> gzset.ZCombineStoreRequest
+	42, // 40: gzset.GZSetService.ZDiffStore:input_type -> gzset.ZCombineStoreRequest
+	3,  // 41: gzset.GZSetService.ZAdd:output_type -> gzset.ZAddResponse
+	5,  // 42: gzset.GZSetService.ZAdds:output_type -> gzset.ZAddsResponse
+	7,  // 43: gzset.GZSetService.ZRem:output_type -> gzset.ZRemResponse
+	9,  // 44: gzset.GZSetService.ZRems:output_type -> gzset.ZRemsResponse
+	11, // 45: gzset.GZSetService.ZScore:output_type -> gzset.ZScoreResponse
+	13, // 46: gzset.GZSetService.ZRank:output_type -> gzset.ZRankResponse
+	15, // 47: gzset.GZSetService.ZRevRank:output_type -> gzset.ZRevRankResponse
+	17, // 48: gzset.GZSetService.ZRange:output_type -> gzset.ZRangeResponse
+	19, // 49: gzset.GZSetService.ZCount:output_type -> gzset.ZCountResponse
+	21, // 50: gzset.GZSetService.ZRevRange:output_type -> gzset.ZRevRangeResponse
+	23, // 51: gzset.GZSetService.ZCard:output_type -> gzset.ZCardResponse
+	25, // 52: gzset.GZSetService.ZIncrBy:output_type -> gzset.ZIncrByResponse
+	27, // 53: gzset.GZSetService.ZScan:output_type -> gzset.ZScanResponse
+	30, // 54: gzset.GZSetService.ZRangeByScore:output_type -> gzset.ZRangeByScoreResponse
+	30, // 55: gzset.GZSetService.ZRevRangeByScore:output_type -> gzset.ZRangeByScoreResponse
+	32, // 56: gzset.GZSetService.ZRangeByLex:output_type -> gzset.ZRangeByLexResponse
+	34, // 57: gzset.GZSetService.ZPopMin:output_type -> gzset.ZPopResponse
+	34, // 58: gzset.GZSetService.ZPopMax:output_type -> gzset.ZPopResponse
+	37, // 59: gzset.GZSetService.ZRemRangeByScore:output_type -> gzset.ZRemRangeResponse
+	37, // 60: gzset.GZSetService.ZRemRangeByRank:output_type -> gzset.ZRemRangeResponse
+	39, // 61: gzset.GZSetService.ZMScore:output_type -> gzset.ZMScoreResponse
+	41, // 62: gzset.GZSetService.ZUnion:output_type -> gzset.ZCombineResponse
+	41, // 63: gzset.GZSetService.ZInter:output_type -> gzset.ZCombineResponse
+	41, // 64: gzset.GZSetService.ZDiff:output_type -> gzset.ZCombineResponse
+	43, // 65: gzset.GZSetService.ZUnionStore:output_type -> gzset.ZCombineStoreResponse
+	43, // 66: gzset.GZSetService.ZInterStore:output_type -> gzset.ZCombineStoreResponse
+	43, // 67: gzset.GZSetService.ZDiffStore:output_type -> gzset.ZCombineStoreResponse
+	41, // [41:68] is the sub-list for method output_type
+	14, // [14:41] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_lib_proto_gzset_db_proto_init() }
@@ -1649,7 +3039,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZAddRequest); i {
+			switch v := v.(*ZAddFlags); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1661,7 +3051,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZAddResponse); i {
+			switch v := v.(*ZAddRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1673,7 +3063,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZAddsRequest); i {
+			switch v := v.(*ZAddResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1685,7 +3075,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZAddsResponse); i {
+			switch v := v.(*ZAddsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1697,7 +3087,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRemRequest); i {
+			switch v := v.(*ZAddsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1709,7 +3099,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRemResponse); i {
+			switch v := v.(*ZRemRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1721,7 +3111,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRemsRequest); i {
+			switch v := v.(*ZRemResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1733,7 +3123,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRemsResponse); i {
+			switch v := v.(*ZRemsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1745,7 +3135,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZScoreRequest); i {
+			switch v := v.(*ZRemsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1757,7 +3147,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZScoreResponse); i {
+			switch v := v.(*ZScoreRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1769,7 +3159,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRankRequest); i {
+			switch v := v.(*ZScoreResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1781,7 +3171,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRankResponse); i {
+			switch v := v.(*ZRankRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1793,7 +3183,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRevRankRequest); i {
+			switch v := v.(*ZRankResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1805,7 +3195,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRevRankResponse); i {
+			switch v := v.(*ZRevRankRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1817,7 +3207,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRangeRequest); i {
+			switch v := v.(*ZRevRankResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1829,7 +3219,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRangeResponse); i {
+			switch v := v.(*ZRangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1841,7 +3231,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZCountRequest); i {
+			switch v := v.(*ZRangeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1853,7 +3243,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZCountResponse); i {
+			switch v := v.(*ZCountRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1865,7 +3255,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRevRangeRequest); i {
+			switch v := v.(*ZCountResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1877,7 +3267,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZRevRangeResponse); i {
+			switch v := v.(*ZRevRangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1889,7 +3279,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZCardRequest); i {
+			switch v := v.(*ZRevRangeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1901,7 +3291,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZCardResponse); i {
+			switch v := v.(*ZCardRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1913,7 +3303,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZIncrByRequest); i {
+			switch v := v.(*ZCardResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1925,6 +3315,18 @@ func file_lib_proto_gzset_db_proto_init() {
 			}
 		}
 		file_lib_proto_gzset_db_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZIncrByRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ZIncrByResponse); i {
 			case 0:
 				return &v.state
@@ -1936,6 +3338,222 @@ func file_lib_proto_gzset_db_proto_init() {
 				return nil
 			}
 		}
+		file_lib_proto_gzset_db_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZScanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Limit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZRangeByScoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZRangeByScoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZRangeByLexRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZRangeByLexResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZPopRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZPopResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZRemRangeByScoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZRemRangeByRankRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZRemRangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZMScoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZMScoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZCombineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZCombineResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZCombineStoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lib_proto_gzset_db_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZCombineStoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1943,7 +3561,7 @@ func file_lib_proto_gzset_db_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lib_proto_gzset_db_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.2.0
+// - protoc-gen-go-grpc v1.3.0
 // - protoc             v3.6.1
 // source: lib/proto/gzset/db.proto
 
@@ -18,6 +18,36 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
+const (
+	GZSetService_ZAdd_FullMethodName             = "/gzset.GZSetService/ZAdd"
+	GZSetService_ZAdds_FullMethodName            = "/gzset.GZSetService/ZAdds"
+	GZSetService_ZRem_FullMethodName             = "/gzset.GZSetService/ZRem"
+	GZSetService_ZRems_FullMethodName            = "/gzset.GZSetService/ZRems"
+	GZSetService_ZScore_FullMethodName           = "/gzset.GZSetService/ZScore"
+	GZSetService_ZRank_FullMethodName            = "/gzset.GZSetService/ZRank"
+	GZSetService_ZRevRank_FullMethodName         = "/gzset.GZSetService/ZRevRank"
+	GZSetService_ZRange_FullMethodName           = "/gzset.GZSetService/ZRange"
+	GZSetService_ZCount_FullMethodName           = "/gzset.GZSetService/ZCount"
+	GZSetService_ZRevRange_FullMethodName        = "/gzset.GZSetService/ZRevRange"
+	GZSetService_ZCard_FullMethodName            = "/gzset.GZSetService/ZCard"
+	GZSetService_ZIncrBy_FullMethodName          = "/gzset.GZSetService/ZIncrBy"
+	GZSetService_ZScan_FullMethodName            = "/gzset.GZSetService/ZScan"
+	GZSetService_ZRangeByScore_FullMethodName    = "/gzset.GZSetService/ZRangeByScore"
+	GZSetService_ZRevRangeByScore_FullMethodName = "/gzset.GZSetService/ZRevRangeByScore"
+	GZSetService_ZRangeByLex_FullMethodName      = "/gzset.GZSetService/ZRangeByLex"
+	GZSetService_ZPopMin_FullMethodName          = "/gzset.GZSetService/ZPopMin"
+	GZSetService_ZPopMax_FullMethodName          = "/gzset.GZSetService/ZPopMax"
+	GZSetService_ZRemRangeByScore_FullMethodName = "/gzset.GZSetService/ZRemRangeByScore"
+	GZSetService_ZRemRangeByRank_FullMethodName  = "/gzset.GZSetService/ZRemRangeByRank"
+	GZSetService_ZMScore_FullMethodName          = "/gzset.GZSetService/ZMScore"
+	GZSetService_ZUnion_FullMethodName           = "/gzset.GZSetService/ZUnion"
+	GZSetService_ZInter_FullMethodName           = "/gzset.GZSetService/ZInter"
+	GZSetService_ZDiff_FullMethodName            = "/gzset.GZSetService/ZDiff"
+	GZSetService_ZUnionStore_FullMethodName      = "/gzset.GZSetService/ZUnionStore"
+	GZSetService_ZInterStore_FullMethodName      = "/gzset.GZSetService/ZInterStore"
+	GZSetService_ZDiffStore_FullMethodName       = "/gzset.GZSetService/ZDiffStore"
+)
+
 // GZSetServiceClient is the client API for GZSetService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
@@ -34,6 +64,21 @@ type GZSetServiceClient interface {
 	ZRevRange(ctx context.Context, in *ZRevRangeRequest, opts ...grpc.CallOption) (*ZRevRangeResponse, error)
 	ZCard(ctx context.Context, in *ZCardRequest, opts ...grpc.CallOption) (*ZCardResponse, error)
 	ZIncrBy(ctx context.Context, in *ZIncrByRequest, opts ...grpc.CallOption) (*ZIncrByResponse, error)
+	ZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (*ZScanResponse, error)
+	ZRangeByScore(ctx context.Context, in *ZRangeByScoreRequest, opts ...grpc.CallOption) (*ZRangeByScoreResponse, error)
+	ZRevRangeByScore(ctx context.Context, in *ZRangeByScoreRequest, opts ...grpc.CallOption) (*ZRangeByScoreResponse, error)
+	ZRangeByLex(ctx context.Context, in *ZRangeByLexRequest, opts ...grpc.CallOption) (*ZRangeByLexResponse, error)
+	ZPopMin(ctx context.Context, in *ZPopRequest, opts ...grpc.CallOption) (*ZPopResponse, error)
+	ZPopMax(ctx context.Context, in *ZPopRequest, opts ...grpc.CallOption) (*ZPopResponse, error)
+	ZRemRangeByScore(ctx context.Context, in *ZRemRangeByScoreRequest, opts ...grpc.CallOption) (*ZRemRangeResponse, error)
+	ZRemRangeByRank(ctx context.Context, in *ZRemRangeByRankRequest, opts ...grpc.CallOption) (*ZRemRangeResponse, error)
+	ZMScore(ctx context.Context, in *ZMScoreRequest, opts ...grpc.CallOption) (*ZMScoreResponse, error)
+	ZUnion(ctx context.Context, in *ZCombineRequest, opts ...grpc.CallOption) (*ZCombineResponse, error)
+	ZInter(ctx context.Context, in *ZCombineRequest, opts ...grpc.CallOption) (*ZCombineResponse, error)
+	ZDiff(ctx context.Context, in *ZCombineRequest, opts ...grpc.CallOption) (*ZCombineResponse, error)
+	ZUnionStore(ctx context.Context, in *ZCombineStoreRequest, opts ...grpc.CallOption) (*ZCombineStoreResponse, error)
+	ZInterStore(ctx context.Context, in *ZCombineStoreRequest, opts ...grpc.CallOption) (*ZCombineStoreResponse, error)
+	ZDiffStore(ctx context.Context, in *ZCombineStoreRequest, opts ...grpc.CallOption) (*ZCombineStoreResponse, error)
 }
 
 type gZSetServiceClient struct {
@@ -46,7 +91,7 @@ func NewGZSetServiceClient(cc grpc.ClientConnInterface) GZSetServiceClient {
 
 func (c *gZSetServiceClient) ZAdd(ctx context.Context, in *ZAddRequest, opts ...grpc.CallOption) (*ZAddResponse, error) {
 	out := new(ZAddResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZAdd", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZAdd_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +100,7 @@ func (c *gZSetServiceClient) ZAdd(ctx context.Context, in *ZAddRequest, opts ...
 
 func (c *gZSetServiceClient) ZAdds(ctx context.Context, in *ZAddsRequest, opts ...grpc.CallOption) (*ZAddsResponse, error) {
 	out := new(ZAddsResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZAdds", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZAdds_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +109,7 @@ func (c *gZSetServiceClient) ZAdds(ctx context.Context, in *ZAddsRequest, opts .
 
 func (c *gZSetServiceClient) ZRem(ctx context.Context, in *ZRemRequest, opts ...grpc.CallOption) (*ZRemResponse, error) {
 	out := new(ZRemResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZRem", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZRem_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +118,7 @@ func (c *gZSetServiceClient) ZRem(ctx context.Context, in *ZRemRequest, opts ...
 
 func (c *gZSetServiceClient) ZRems(ctx context.Context, in *ZRemsRequest, opts ...grpc.CallOption) (*ZRemsResponse, error) {
 	out := new(ZRemsResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZRems", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZRems_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +127,7 @@ func (c *gZSetServiceClient) ZRems(ctx context.Context, in *ZRemsRequest, opts .
 
 func (c *gZSetServiceClient) ZScore(ctx context.Context, in *ZScoreRequest, opts ...grpc.CallOption) (*ZScoreResponse, error) {
 	out := new(ZScoreResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZScore", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZScore_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +136,7 @@ func (c *gZSetServiceClient) ZScore(ctx context.Context, in *ZScoreRequest, opts
 
 func (c *gZSetServiceClient) ZRank(ctx context.Context, in *ZRankRequest, opts ...grpc.CallOption) (*ZRankResponse, error) {
 	out := new(ZRankResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZRank", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZRank_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +145,7 @@ func (c *gZSetServiceClient) ZRank(ctx context.Context, in *ZRankRequest, opts .
 
 func (c *gZSetServiceClient) ZRevRank(ctx context.Context, in *ZRevRankRequest, opts ...grpc.CallOption) (*ZRevRankResponse, error) {
 	out := new(ZRevRankResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZRevRank", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZRevRank_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +154,7 @@ func (c *gZSetServiceClient) ZRevRank(ctx context.Context, in *ZRevRankRequest,
 
 func (c *gZSetServiceClient) ZRange(ctx context.Context, in *ZRangeRequest, opts ...grpc.CallOption) (*ZRangeResponse, error) {
 	out := new(ZRangeResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZRange", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZRange_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +163,7 @@ func (c *gZSetServiceClient) ZRange(ctx context.Context, in *ZRangeRequest, opts
 
 func (c *gZSetServiceClient) ZCount(ctx context.Context, in *ZCountRequest, opts ...grpc.CallOption) (*ZCountResponse, error) {
 	out := new(ZCountResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZCount", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZCount_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +172,7 @@ func (c *gZSetServiceClient) ZCount(ctx context.Context, in *ZCountRequest, opts
 
 func (c *gZSetServiceClient) ZRevRange(ctx context.Context, in *ZRevRangeRequest, opts ...grpc.CallOption) (*ZRevRangeResponse, error) {
 	out := new(ZRevRangeResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZRevRange", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZRevRange_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +181,7 @@ func (c *gZSetServiceClient) ZRevRange(ctx context.Context, in *ZRevRangeRequest
 
 func (c *gZSetServiceClient) ZCard(ctx context.Context, in *ZCardRequest, opts ...grpc.CallOption) (*ZCardResponse, error) {
 	out := new(ZCardResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZCard", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZCard_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +190,142 @@ func (c *gZSetServiceClient) ZCard(ctx context.Context, in *ZCardRequest, opts .
 
 func (c *gZSetServiceClient) ZIncrBy(ctx context.Context, in *ZIncrByRequest, opts ...grpc.CallOption) (*ZIncrByResponse, error) {
 	out := new(ZIncrByResponse)
-	err := c.cc.Invoke(ctx, "/gzset.GZSetService/ZIncrBy", in, out, opts...)
+	err := c.cc.Invoke(ctx, GZSetService_ZIncrBy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (*ZScanResponse, error) {
+	out := new(ZScanResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZScan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZRangeByScore(ctx context.Context, in *ZRangeByScoreRequest, opts ...grpc.CallOption) (*ZRangeByScoreResponse, error) {
+	out := new(ZRangeByScoreResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZRangeByScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZRevRangeByScore(ctx context.Context, in *ZRangeByScoreRequest, opts ...grpc.CallOption) (*ZRangeByScoreResponse, error) {
+	out := new(ZRangeByScoreResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZRevRangeByScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZRangeByLex(ctx context.Context, in *ZRangeByLexRequest, opts ...grpc.CallOption) (*ZRangeByLexResponse, error) {
+	out := new(ZRangeByLexResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZRangeByLex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZPopMin(ctx context.Context, in *ZPopRequest, opts ...grpc.CallOption) (*ZPopResponse, error) {
+	out := new(ZPopResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZPopMin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZPopMax(ctx context.Context, in *ZPopRequest, opts ...grpc.CallOption) (*ZPopResponse, error) {
+	out := new(ZPopResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZPopMax_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZRemRangeByScore(ctx context.Context, in *ZRemRangeByScoreRequest, opts ...grpc.CallOption) (*ZRemRangeResponse, error) {
+	out := new(ZRemRangeResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZRemRangeByScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZRemRangeByRank(ctx context.Context, in *ZRemRangeByRankRequest, opts ...grpc.CallOption) (*ZRemRangeResponse, error) {
+	out := new(ZRemRangeResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZRemRangeByRank_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZMScore(ctx context.Context, in *ZMScoreRequest, opts ...grpc.CallOption) (*ZMScoreResponse, error) {
+	out := new(ZMScoreResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZMScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZUnion(ctx context.Context, in *ZCombineRequest, opts ...grpc.CallOption) (*ZCombineResponse, error) {
+	out := new(ZCombineResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZUnion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZInter(ctx context.Context, in *ZCombineRequest, opts ...grpc.CallOption) (*ZCombineResponse, error) {
+	out := new(ZCombineResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZInter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZDiff(ctx context.Context, in *ZCombineRequest, opts ...grpc.CallOption) (*ZCombineResponse, error) {
+	out := new(ZCombineResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZDiff_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZUnionStore(ctx context.Context, in *ZCombineStoreRequest, opts ...grpc.CallOption) (*ZCombineStoreResponse, error) {
+	out := new(ZCombineStoreResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZUnionStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZInterStore(ctx context.Context, in *ZCombineStoreRequest, opts ...grpc.CallOption) (*ZCombineStoreResponse, error) {
+	out := new(ZCombineStoreResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZInterStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gZSetServiceClient) ZDiffStore(ctx context.Context, in *ZCombineStoreRequest, opts ...grpc.CallOption) (*ZCombineStoreResponse, error) {
+	out := new(ZCombineStoreResponse)
+	err := c.cc.Invoke(ctx, GZSetService_ZDiffStore_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +348,21 @@ type GZSetServiceServer interface {
 	ZRevRange(context.Context, *ZRevRangeRequest) (*ZRevRangeResponse, error)
 	ZCard(context.Context, *ZCardRequest) (*ZCardResponse, error)
 	ZIncrBy(context.Context, *ZIncrByRequest) (*ZIncrByResponse, error)
+	ZScan(context.Context, *ZScanRequest) (*ZScanResponse, error)
+	ZRangeByScore(context.Context, *ZRangeByScoreRequest) (*ZRangeByScoreResponse, error)
+	ZRevRangeByScore(context.Context, *ZRangeByScoreRequest) (*ZRangeByScoreResponse, error)
+	ZRangeByLex(context.Context, *ZRangeByLexRequest) (*ZRangeByLexResponse, error)
+	ZPopMin(context.Context, *ZPopRequest) (*ZPopResponse, error)
+	ZPopMax(context.Context, *ZPopRequest) (*ZPopResponse, error)
+	ZRemRangeByScore(context.Context, *ZRemRangeByScoreRequest) (*ZRemRangeResponse, error)
+	ZRemRangeByRank(context.Context, *ZRemRangeByRankRequest) (*ZRemRangeResponse, error)
+	ZMScore(context.Context, *ZMScoreRequest) (*ZMScoreResponse, error)
+	ZUnion(context.Context, *ZCombineRequest) (*ZCombineResponse, error)
+	ZInter(context.Context, *ZCombineRequest) (*ZCombineResponse, error)
+	ZDiff(context.Context, *ZCombineRequest) (*ZCombineResponse, error)
+	ZUnionStore(context.Context, *ZCombineStoreRequest) (*ZCombineStoreResponse, error)
+	ZInterStore(context.Context, *ZCombineStoreRequest) (*ZCombineStoreResponse, error)
+	ZDiffStore(context.Context, *ZCombineStoreRequest) (*ZCombineStoreResponse, error)
 	mustEmbedUnimplementedGZSetServiceServer()
 }
 
@@ -211,6 +406,51 @@ func (UnimplementedGZSetServiceServer) ZCard(context.Context, *ZCardRequest) (*Z
 func (UnimplementedGZSetServiceServer) ZIncrBy(context.Context, *ZIncrByRequest) (*ZIncrByResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ZIncrBy not implemented")
 }
+func (UnimplementedGZSetServiceServer) ZScan(context.Context, *ZScanRequest) (*ZScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZScan not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZRangeByScore(context.Context, *ZRangeByScoreRequest) (*ZRangeByScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZRangeByScore not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZRevRangeByScore(context.Context, *ZRangeByScoreRequest) (*ZRangeByScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZRevRangeByScore not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZRangeByLex(context.Context, *ZRangeByLexRequest) (*ZRangeByLexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZRangeByLex not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZPopMin(context.Context, *ZPopRequest) (*ZPopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZPopMin not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZPopMax(context.Context, *ZPopRequest) (*ZPopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZPopMax not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZRemRangeByScore(context.Context, *ZRemRangeByScoreRequest) (*ZRemRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZRemRangeByScore not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZRemRangeByRank(context.Context, *ZRemRangeByRankRequest) (*ZRemRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZRemRangeByRank not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZMScore(context.Context, *ZMScoreRequest) (*ZMScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZMScore not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZUnion(context.Context, *ZCombineRequest) (*ZCombineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZUnion not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZInter(context.Context, *ZCombineRequest) (*ZCombineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZInter not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZDiff(context.Context, *ZCombineRequest) (*ZCombineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZDiff not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZUnionStore(context.Context, *ZCombineStoreRequest) (*ZCombineStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZUnionStore not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZInterStore(context.Context, *ZCombineStoreRequest) (*ZCombineStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZInterStore not implemented")
+}
+func (UnimplementedGZSetServiceServer) ZDiffStore(context.Context, *ZCombineStoreRequest) (*ZCombineStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZDiffStore not implemented")
+}
 func (UnimplementedGZSetServiceServer) mustEmbedUnimplementedGZSetServiceServer() {}
 
 // UnsafeGZSetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -234,7 +474,7 @@ func _GZSetService_ZAdd_Handler(srv interface{}, ctx context.Context, dec func(i
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZAdd",
+		FullMethod: GZSetService_ZAdd_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZAdd(ctx, req.(*ZAddRequest))
@@ -252,7 +492,7 @@ func _GZSetService_ZAdds_Handler(srv interface{}, ctx context.Context, dec func(
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZAdds",
+		FullMethod: GZSetService_ZAdds_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZAdds(ctx, req.(*ZAddsRequest))
@@ -270,7 +510,7 @@ func _GZSetService_ZRem_Handler(srv interface{}, ctx context.Context, dec func(i
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZRem",
+		FullMethod: GZSetService_ZRem_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZRem(ctx, req.(*ZRemRequest))
@@ -288,7 +528,7 @@ func _GZSetService_ZRems_Handler(srv interface{}, ctx context.Context, dec func(
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZRems",
+		FullMethod: GZSetService_ZRems_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZRems(ctx, req.(*ZRemsRequest))
@@ -306,7 +546,7 @@ func _GZSetService_ZScore_Handler(srv interface{}, ctx context.Context, dec func
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZScore",
+		FullMethod: GZSetService_ZScore_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZScore(ctx, req.(*ZScoreRequest))
@@ -324,7 +564,7 @@ func _GZSetService_ZRank_Handler(srv interface{}, ctx context.Context, dec func(
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZRank",
+		FullMethod: GZSetService_ZRank_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZRank(ctx, req.(*ZRankRequest))
@@ -342,7 +582,7 @@ func _GZSetService_ZRevRank_Handler(srv interface{}, ctx context.Context, dec fu
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZRevRank",
+		FullMethod: GZSetService_ZRevRank_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZRevRank(ctx, req.(*ZRevRankRequest))
@@ -360,7 +600,7 @@ func _GZSetService_ZRange_Handler(srv interface{}, ctx context.Context, dec func
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZRange",
+		FullMethod: GZSetService_ZRange_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZRange(ctx, req.(*ZRangeRequest))
@@ -378,7 +618,7 @@ func _GZSetService_ZCount_Handler(srv interface{}, ctx context.Context, dec func
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZCount",
+		FullMethod: GZSetService_ZCount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZCount(ctx, req.(*ZCountRequest))
@@ -396,7 +636,7 @@ func _GZSetService_ZRevRange_Handler(srv interface{}, ctx context.Context, dec f
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZRevRange",
+		FullMethod: GZSetService_ZRevRange_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZRevRange(ctx, req.(*ZRevRangeRequest))
@@ -414,7 +654,7 @@ func _GZSetService_ZCard_Handler(srv interface{}, ctx context.Context, dec func(
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZCard",
+		FullMethod: GZSetService_ZCard_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZCard(ctx, req.(*ZCardRequest))
@@ -432,7 +672,7 @@ func _GZSetService_ZIncrBy_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/gzset.GZSetService/ZIncrBy",
+		FullMethod: GZSetService_ZIncrBy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GZSetServiceServer).ZIncrBy(ctx, req.(*ZIncrByRequest))
@@ -440,6 +680,276 @@ func _GZSetService_ZIncrBy_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GZSetService_ZScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZScan(ctx, req.(*ZScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZRangeByScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZRangeByScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZRangeByScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZRangeByScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZRangeByScore(ctx, req.(*ZRangeByScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZRevRangeByScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZRangeByScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZRevRangeByScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZRevRangeByScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZRevRangeByScore(ctx, req.(*ZRangeByScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZRangeByLex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZRangeByLexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZRangeByLex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZRangeByLex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZRangeByLex(ctx, req.(*ZRangeByLexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZPopMin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZPopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZPopMin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZPopMin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZPopMin(ctx, req.(*ZPopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZPopMax_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZPopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZPopMax(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZPopMax_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZPopMax(ctx, req.(*ZPopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZRemRangeByScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZRemRangeByScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZRemRangeByScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZRemRangeByScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZRemRangeByScore(ctx, req.(*ZRemRangeByScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZRemRangeByRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZRemRangeByRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZRemRangeByRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZRemRangeByRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZRemRangeByRank(ctx, req.(*ZRemRangeByRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZMScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZMScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZMScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZMScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZMScore(ctx, req.(*ZMScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZUnion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZCombineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZUnion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZUnion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZUnion(ctx, req.(*ZCombineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZInter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZCombineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZInter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZInter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZInter(ctx, req.(*ZCombineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZCombineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZDiff(ctx, req.(*ZCombineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZUnionStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZCombineStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZUnionStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZUnionStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZUnionStore(ctx, req.(*ZCombineStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZInterStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZCombineStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZInterStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZInterStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZInterStore(ctx, req.(*ZCombineStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GZSetService_ZDiffStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZCombineStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GZSetServiceServer).ZDiffStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GZSetService_ZDiffStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GZSetServiceServer).ZDiffStore(ctx, req.(*ZCombineStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GZSetService_ServiceDesc is the grpc.ServiceDesc for GZSetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -495,6 +1005,66 @@ var GZSetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ZIncrBy",
 			Handler:    _GZSetService_ZIncrBy_Handler,
 		},
+		{
+			MethodName: "ZScan",
+			Handler:    _GZSetService_ZScan_Handler,
+		},
+		{
+			MethodName: "ZRangeByScore",
+			Handler:    _GZSetService_ZRangeByScore_Handler,
+		},
+		{
+			MethodName: "ZRevRangeByScore",
+			Handler:    _GZSetService_ZRevRangeByScore_Handler,
+		},
+		{
+			MethodName: "ZRangeByLex",
+			Handler:    _GZSetService_ZRangeByLex_Handler,
+		},
+		{
+			MethodName: "ZPopMin",
+			Handler:    _GZSetService_ZPopMin_Handler,
+		},
+		{
+			MethodName: "ZPopMax",
+			Handler:    _GZSetService_ZPopMax_Handler,
+		},
+		{
+			MethodName: "ZRemRangeByScore",
+			Handler:    _GZSetService_ZRemRangeByScore_Handler,
+		},
+		{
+			MethodName: "ZRemRangeByRank",
+			Handler:    _GZSetService_ZRemRangeByRank_Handler,
+		},
+		{
+			MethodName: "ZMScore",
+			Handler:    _GZSetService_ZMScore_Handler,
+		},
+		{
+			MethodName: "ZUnion",
+			Handler:    _GZSetService_ZUnion_Handler,
+		},
+		{
+			MethodName: "ZInter",
+			Handler:    _GZSetService_ZInter_Handler,
+		},
+		{
+			MethodName: "ZDiff",
+			Handler:    _GZSetService_ZDiff_Handler,
+		},
+		{
+			MethodName: "ZUnionStore",
+			Handler:    _GZSetService_ZUnionStore_Handler,
+		},
+		{
+			MethodName: "ZInterStore",
+			Handler:    _GZSetService_ZInterStore_Handler,
+		},
+		{
+			MethodName: "ZDiffStore",
+			Handler:    _GZSetService_ZDiffStore_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lib/proto/gzset/db.proto",
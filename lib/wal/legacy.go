@@ -0,0 +1,96 @@
+package wal
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ByteStorage/FlyDB/db/fileio"
+)
+
+const (
+	// legacyFileName is the single file of the WAL format before segments
+	legacyFileName = "db.wal"
+
+	// legacyHeaderSize crc (4B) + size (2B) + type (1B) + log number (4B)
+	legacyHeaderSize = 4 + 2 + 1 + 4
+)
+
+// migrateLegacy moves the records of a db.wal file, written before the WAL had segments,
+// in front of the records of the WAL and removes the file.
+// The old format did not store where the key of a put ends, but the only records
+// ever written to it were deletes, whose payload is the key.
+// Hold the mutex before accessing this method
+func (w *Wal) migrateLegacy() error {
+	fileName := filepath.Join(w.options.DirPath, legacyFileName)
+	if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		return nil
+	}
+
+	// If the WAL holds records, a migration wrote them before it was interrupted
+	if len(w.segments) == 1 && w.activeSize == 0 && w.checkpoint == (Position{}) {
+		records, err := readLegacy(fileName)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			encRecord := encodeRecord(record)
+			if _, err := w.active.Write(encRecord); err != nil {
+				return err
+			}
+			w.activeSize += int64(len(encRecord))
+		}
+		if err := w.active.Sync(); err != nil {
+			return err
+		}
+	}
+	return os.Remove(fileName)
+}
+
+// readLegacy reads the records of a db.wal file.
+// +---------+-----------+-----------+----------------+--- ... ---+
+// |CRC (4B) | Size (2B) | Type (1B) | Log number (4B)| Payload   |
+// +---------+-----------+-----------+----------------+--- ... ---+
+// Size counts the log number and the payload. The CRC was taken over
+// the zeroed buffer, so it does not protect the record and is not checked.
+// The file was memory mapped, a zero size marks the end of the records.
+func readLegacy(fileName string) ([]*Record, error) {
+	file, err := fileio.NewFileIOManager(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	fileSize, err := file.Size()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	var offset int64
+	for offset+legacyHeaderSize <= fileSize {
+		header := make([]byte, legacyHeaderSize)
+		if _, err := file.Read(header, offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+		size := int64(binary.LittleEndian.Uint16(header[4:]))
+		if size == 0 {
+			break
+		}
+		if size < 4 || offset+legacyHeaderSize+size-4 > fileSize {
+			return nil, ErrCorruptedRecord
+		}
+		if header[6] != deleteType {
+			return nil, ErrUnknownRecord
+		}
+		key := make([]byte, size-4)
+		if _, err := file.Read(key, offset+legacyHeaderSize); err != nil && err != io.EOF {
+			return nil, err
+		}
+		records = append(records, &Record{Type: deleteType, Key: key})
+		offset += legacyHeaderSize + size - 4
+	}
+	return records, nil
+}
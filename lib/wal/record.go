@@ -0,0 +1,72 @@
+package wal
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+const (
+	// Record types
+	putType    = byte(1)
+	deleteType = byte(2)
+
+	// maxRecordHeaderSize crc + type + key length + value length
+	maxRecordHeaderSize = crc32.Size + 1 + binary.MaxVarintLen32*2
+)
+
+var (
+	ErrCorruptedRecord = errors.New("CorruptedRecordError : wal record is corrupted")
+	ErrUnknownRecord   = errors.New("UnknownRecordError : unknown wal record type")
+)
+
+// Record is a structure that holds information about a record from the WAL.
+type Record struct {
+	Type  byte
+	Key   []byte
+	Value []byte
+}
+
+// encodeRecord encodes a record.
+// +---------+-----------+------------+-------------+--- ... ---+--- ... ---+
+// |CRC (4B) | Type (1B) | Key size   | Value size  |    Key    |   Value   |
+// |         |           | (varint)   | (varint)    |           |           |
+// +---------+-----------+------------+-------------+--- ... ---+--- ... ---+
+// The CRC covers everything after it.
+func encodeRecord(record *Record) []byte {
+	buf := make([]byte, maxRecordHeaderSize+len(record.Key)+len(record.Value))
+	index := crc32.Size
+	buf[index] = record.Type
+	index++
+	index += binary.PutUvarint(buf[index:], uint64(len(record.Key)))
+	index += binary.PutUvarint(buf[index:], uint64(len(record.Value)))
+	index += copy(buf[index:], record.Key)
+	index += copy(buf[index:], record.Value)
+
+	binary.LittleEndian.PutUint32(buf, crc32.ChecksumIEEE(buf[crc32.Size:index]))
+	return buf[:index]
+}
+
+// decodeRecordHeader decodes the header of a record.
+// It returns the record type, the key and value sizes, and the header size,
+// or a header size of 0 if buf does not hold a complete header.
+func decodeRecordHeader(buf []byte) (crc uint32, typ byte, keySize, valueSize uint64, headerSize int) {
+	if len(buf) < crc32.Size+1 {
+		return 0, 0, 0, 0, 0
+	}
+	crc = binary.LittleEndian.Uint32(buf)
+	typ = buf[crc32.Size]
+	index := crc32.Size + 1
+
+	keySize, n := binary.Uvarint(buf[index:])
+	if n <= 0 {
+		return 0, 0, 0, 0, 0
+	}
+	index += n
+	valueSize, n = binary.Uvarint(buf[index:])
+	if n <= 0 {
+		return 0, 0, 0, 0, 0
+	}
+	index += n
+	return crc, typ, keySize, valueSize, index
+}
@@ -3,306 +3,500 @@ package wal
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash/crc32"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ByteStorage/FlyDB/db/fileio"
 )
 
 const (
-	// Record types
-	putType    = byte(1)
-	deleteType = byte(2)
+	// segmentSuffix is the suffix of WAL segment files, which are named by their id
+	segmentSuffix = ".wal"
 
-	// File names for WAL
-	walFileName = "/db.wal"
+	// checkpointFileName stores the position up to which the records are no longer needed
+	checkpointFileName = "CHECKPOINT"
+
+	// checkpointSize segment (4B) + offset (8B) + crc (4B)
+	checkpointSize = 4 + 8 + crc32.Size
 )
 
+var ErrCheckpointOutOfRange = errors.New("CheckpointOutOfRangeError : checkpoint is beyond the end of the wal")
+
+// Position identifies a place in the WAL, the offset of a record in a segment.
+type Position struct {
+	Segment uint32
+	Offset  int64
+}
+
+// Before reports whether p comes before other.
+func (p Position) Before(other Position) bool {
+	return p.Segment < other.Segment || (p.Segment == other.Segment && p.Offset < other.Offset)
+}
+
 // Wal is a write-ahead log.
+// Records are appended to the active segment file; once it reaches the configured
+// file size, a new segment is started. The owner of the WAL moves the checkpoint
+// forward once the records before it have been persisted elsewhere,
+// which deletes the segments that only hold such records.
 type Wal struct {
-	m          *fileio.MMapIO // MMapIOManager
-	logNum     uint32         // Log number
-	saveTime   int64          // Save time
-	dirPath    string         // Dir path
-	readOffset int64          // Read offset
-	filesize   int64          // File size
+	lock       *sync.Mutex
+	options    Options
+	segments   []uint32         // Ids of all segments, ascending, the last one is active
+	active     fileio.IOManager // IO manager of the active segment
+	activeSize int64            // Size of the active segment
+	checkpoint Position         // Records before the checkpoint are not replayed
+	readPos    Position         // Position of the next record returned by ReadNext
+	synced     Position         // Records before it are on disk
+	closed     chan struct{}    // Closed by Close, stops AsyncSave
+
+	// The segment that is read from, kept open while ReadNext goes through it
+	reader     fileio.IOManager
+	readerID   uint32
+	readerSize int64
 }
 
-// NewWal creates a new WAL.
+// NewWal opens the WAL in options.DirPath, creating it if it does not exist.
+// A torn record at the end of the last segment, left by a crash, is cut off.
 func NewWal(options Options) (*Wal, error) {
-	// Construct the full path to the WAL file using the given directory path and
-	// a predefined file name.
-	fileName := options.DirPath + walFileName
+	if err := os.MkdirAll(options.DirPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+	if options.FIOType == 0 {
+		options.FIOType = fileio.MmapIOType
+	}
 
-	// Check if the WAL file exists.
-	stat, err := os.Stat(fileName)
-	if err != nil {
-		// If the file doesn't exist, create the necessary directory and the file itself.
-		if os.IsNotExist(err) {
-			err := os.MkdirAll(options.DirPath, os.ModePerm)
-			if err != nil {
-				return nil, err
-			}
-			_, err = os.Create(fileName)
-			if err != nil {
-				return nil, err
-			}
-		}
-	} else {
-		// If the file exists, calculate the log number based on its size and
-		// the specified file size.
-		options.LogNum = uint32(stat.Size() / options.FileSize)
+	w := &Wal{
+		lock:    new(sync.Mutex),
+		options: options,
+		closed:  make(chan struct{}),
+	}
+	if err := w.loadCheckpoint(); err != nil {
+		return nil, err
+	}
+	if err := w.loadSegments(); err != nil {
+		return nil, err
 	}
 
-	// Create a memory-mapped I/O manager for the WAL file.
-	mapIO, err := fileio.NewMMapIOManager(fileName, options.FileSize)
+	// Open the last segment, or start the first one
+	if len(w.segments) == 0 {
+		w.segments = append(w.segments, w.checkpoint.Segment)
+	}
+	activeID := w.segments[len(w.segments)-1]
+	active, err := fileio.NewIOManager(w.segmentPath(activeID), options.FileSize, options.FIOType, options.FaultInjector)
 	if err != nil {
 		return nil, err
 	}
+	w.active = active
+	if err := w.recoverActive(); err != nil {
+		_ = active.Close()
+		return nil, err
+	}
+	if err := w.migrateLegacy(); err != nil {
+		_ = active.Close()
+		return nil, err
+	}
+	w.readPos = w.checkpoint
+	w.synced = Position{Segment: activeID, Offset: w.activeSize}
+	return w, nil
+}
 
-	// Initialize and return a new WAL instance with the provided options and created I/O manager.
-	return &Wal{
-		m:        mapIO,
-		logNum:   options.LogNum,
-		saveTime: options.SaveTime,
-		dirPath:  options.DirPath,
-		filesize: options.FileSize,
-	}, nil
+func (w *Wal) segmentPath(id uint32) string {
+	return filepath.Join(w.options.DirPath, fmt.Sprintf("%09d", id)+segmentSuffix)
 }
 
-// Put writes a record to the WAL.
-// +---------+-----------+-----------+----------------+--- ... ---+
-// |CRC (4B) | Size (2B) | Type (1B) | Log number (4B)| Payload   |
-// +---------+-----------+-----------+----------------+--- ... ---+
-// Same as above, with the addition of
-// Log number = 32bit log file number, so that we can distinguish between
-// records written by the most recent log writer vs a previous one.
-func (w *Wal) writeRecord(recordType byte, key, value []byte) error {
-	// Prepare the payload based on record type
-	var payload []byte
-	switch recordType {
-	case putType:
-		payload = append(key, value...)
-	case deleteType:
-		payload = key
-	default:
-		return errors.New("unknown record type")
+// loadSegments lists the segment files, dropping those before the checkpoint
+func (w *Wal) loadSegments() error {
+	entries, err := os.ReadDir(w.options.DirPath)
+	if err != nil {
+		return err
 	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), segmentSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), segmentSuffix), 10, 32)
+		if err != nil {
+			// Not a segment, e.g. the db.wal file of the old single file format, see migrateLegacy
+			continue
+		}
+		// Deleting it may have been interrupted by a crash
+		if uint32(id) < w.checkpoint.Segment {
+			if err := os.Remove(w.segmentPath(uint32(id))); err != nil {
+				return err
+			}
+			continue
+		}
+		w.segments = append(w.segments, uint32(id))
+	}
+	sort.Slice(w.segments, func(i, j int) bool {
+		return w.segments[i] < w.segments[j]
+	})
+	return nil
+}
 
-	size := uint16(4 + len(payload)) // 4 bytes for log number
-	buffer := make([]byte, 4+2+1+4+len(payload))
+// recoverActive finds the end of the last complete record in the active segment
+// and cuts off whatever follows it
+func (w *Wal) recoverActive() error {
+	size, err := w.active.Size()
+	if err != nil {
+		return err
+	}
+	var offset int64
+	for {
+		_, n, err := readRecord(w.active, size, offset)
+		if err != nil {
+			if err == io.EOF || err == ErrCorruptedRecord {
+				break
+			}
+			return err
+		}
+		offset += n
+	}
+	if offset < size {
+		if err := w.active.Truncate(offset); err != nil {
+			return err
+		}
+	}
+	w.activeSize = offset
+	return nil
+}
 
-	// Compute CRC
-	crc := crc32.ChecksumIEEE(buffer[4:])
-	binary.LittleEndian.PutUint32(buffer, crc)
+// readRecord reads the record at offset from a segment of the given size.
+// It returns io.EOF at the end of the segment, and ErrCorruptedRecord for
+// a record that is incomplete or fails its CRC check.
+func readRecord(segment fileio.IOManager, size, offset int64) (*Record, int64, error) {
+	if offset >= size {
+		return nil, 0, io.EOF
+	}
+	headerBytes := int64(maxRecordHeaderSize)
+	if offset+headerBytes > size {
+		headerBytes = size - offset
+	}
+	headerBuf := make([]byte, headerBytes)
+	if _, err := segment.Read(headerBuf, offset); err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	crc, typ, keySize, valueSize, headerSize := decodeRecordHeader(headerBuf)
+	// Zeroes past the last record, e.g. the unused part of a mapped file
+	if crc == 0 && typ == 0 {
+		return nil, 0, io.EOF
+	}
+	if headerSize == 0 {
+		return nil, 0, ErrCorruptedRecord
+	}
 
-	// Write size
-	binary.LittleEndian.PutUint16(buffer[4:], size)
+	recordSize := int64(headerSize) + int64(keySize) + int64(valueSize)
+	if offset+recordSize > size {
+		return nil, 0, ErrCorruptedRecord
+	}
+	buf := make([]byte, recordSize)
+	if _, err := segment.Read(buf, offset); err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	if crc32.ChecksumIEEE(buf[crc32.Size:]) != crc {
+		return nil, 0, ErrCorruptedRecord
+	}
 
-	// Write type
-	buffer[4+2] = recordType
+	kv := buf[headerSize:]
+	record := &Record{
+		Type:  typ,
+		Key:   kv[:keySize],
+		Value: kv[keySize:],
+	}
+	if valueSize == 0 {
+		record.Value = nil
+	}
+	return record, recordSize, nil
+}
 
-	// Write log number
-	binary.LittleEndian.PutUint32(buffer[4+2+1:], w.logNum)
+// Write appends a record to the WAL, starting a new segment if the active one is full.
+func (w *Wal) Write(record *Record) error {
+	encRecord := encodeRecord(record)
 
-	// Write payload
-	copy(buffer[4+2+1+4:], payload)
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.activeSize > 0 && w.activeSize+int64(len(encRecord)) > w.options.FileSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := w.active.Write(encRecord); err != nil {
+		// Do not leave a partial record in front of the next one
+		_ = w.active.Truncate(w.activeSize)
+		return err
+	}
+	w.activeSize += int64(len(encRecord))
+	return nil
+}
 
-	_, err := w.m.Write(buffer)
-	return err
+// rotate closes the active segment and starts the next one
+// Hold the mutex before accessing this method
+func (w *Wal) rotate() error {
+	if err := w.active.Sync(); err != nil {
+		return err
+	}
+	if err := w.active.Close(); err != nil {
+		return err
+	}
+	id := w.segments[len(w.segments)-1] + 1
+	active, err := fileio.NewIOManager(w.segmentPath(id), w.options.FileSize, w.options.FIOType, w.options.FaultInjector)
+	if err != nil {
+		return err
+	}
+	w.segments = append(w.segments, id)
+	w.active = active
+	w.activeSize = 0
+	return nil
 }
 
 // Put writes a record to the WAL.
 func (w *Wal) Put(key []byte, value []byte) error {
-	return w.writeRecord(putType, key, value)
+	return w.Write(&Record{Type: putType, Key: key, Value: value})
 }
 
 // Delete writes a delete record to the WAL.
 func (w *Wal) Delete(key []byte) error {
-	return w.writeRecord(deleteType, key, nil)
+	return w.Write(&Record{Type: deleteType, Key: key})
 }
 
-// Record is a structure that holds information about a record from the WAL.
-type Record struct {
-	Type  byte
-	Key   []byte
-	Value []byte
+// Position returns the position the next record will be written at.
+// Every record written so far comes before it.
+func (w *Wal) Position() Position {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return Position{Segment: w.segments[len(w.segments)-1], Offset: w.activeSize}
 }
 
-// InitReading Initializes the WAL reading position to the start of the file.
-func (w *Wal) InitReading() {
-	w.readOffset = 0
-}
+// Checkpoint records that the records before pos are no longer needed:
+// they are not replayed any more, and the segments that only hold such records are deleted.
+func (w *Wal) Checkpoint(pos Position) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
 
-// ReadNext reads the next operation from the WAL.
-func (w *Wal) ReadNext() (*Record, error) {
-	buffer := make([]byte, 4+2+1+4) // Buffer size to read headers
+	end := Position{Segment: w.segments[len(w.segments)-1], Offset: w.activeSize}
+	if end.Before(pos) {
+		return ErrCheckpointOutOfRange
+	}
+	if !w.checkpoint.Before(pos) {
+		return nil
+	}
+	if err := w.saveCheckpoint(pos); err != nil {
+		return err
+	}
+	w.checkpoint = pos
 
-	//TODO: has bug here, get the buffer is [0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]
-	_, err := w.m.Read(buffer, w.readOffset)
-	if err == io.EOF {
-		return nil, io.EOF
+	// Delete the segments before the checkpoint
+	if w.reader != nil && w.readerID < pos.Segment {
+		w.closeReader()
 	}
-	if err != nil {
-		return nil, err
+	var kept []uint32
+	for _, id := range w.segments {
+		if id >= pos.Segment {
+			kept = append(kept, id)
+			continue
+		}
+		if err := os.Remove(w.segmentPath(id)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
 	}
+	w.segments = kept
+	return nil
+}
 
-	// Move readOffset
-	w.readOffset += int64(len(buffer))
+// saveCheckpoint writes the checkpoint file, replacing it atomically
+func (w *Wal) saveCheckpoint(pos Position) error {
+	buf := make([]byte, checkpointSize)
+	binary.LittleEndian.PutUint32(buf, pos.Segment)
+	binary.LittleEndian.PutUint64(buf[4:], uint64(pos.Offset))
+	binary.LittleEndian.PutUint32(buf[12:], crc32.ChecksumIEEE(buf[:12]))
 
-	// Verify CRC
-	expectedCRC := binary.LittleEndian.Uint32(buffer)
-	if crc32.ChecksumIEEE(buffer[4:]) != expectedCRC {
-		return nil, errors.New("corrupted record found")
+	fileName := filepath.Join(w.options.DirPath, checkpointFileName)
+	tmpName := fileName + ".tmp"
+	if err := os.WriteFile(tmpName, buf, fileio.DataFilePerm); err != nil {
+		return err
 	}
+	return os.Rename(tmpName, fileName)
+}
 
-	// Get record size and type
-	size := binary.LittleEndian.Uint16(buffer[4:])
-	recordType := buffer[4+2]
-
-	// Read the payload
-	payload := make([]byte, size-4) // Subtract 4 for log number
-	_, err = w.m.Read(payload, w.readOffset)
+// loadCheckpoint reads the checkpoint file, if there is one
+func (w *Wal) loadCheckpoint() error {
+	buf, err := os.ReadFile(filepath.Join(w.options.DirPath, checkpointFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if len(buf) != checkpointSize || crc32.ChecksumIEEE(buf[:12]) != binary.LittleEndian.Uint32(buf[12:]) {
+		return ErrCorruptedRecord
+	}
+	w.checkpoint = Position{
+		Segment: binary.LittleEndian.Uint32(buf),
+		Offset:  int64(binary.LittleEndian.Uint64(buf[4:])),
 	}
+	return nil
+}
 
-	// Move readOffset again
-	w.readOffset += int64(len(payload))
+// InitReading Initializes the WAL reading position to the checkpoint.
+func (w *Wal) InitReading() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.readPos = w.checkpoint
+}
 
-	// Parse based on record type
-	switch recordType {
-	case putType:
-		return &Record{Type: putType, Key: payload[:len(payload)-len(buffer)], Value: payload[len(payload)-len(buffer):]}, nil
-	case deleteType:
-		return &Record{Type: deleteType, Key: payload, Value: nil}, nil
-	default:
-		return nil, errors.New("unknown record type")
-	}
+// ReadPosition returns the position of the record the next ReadNext starts at.
+func (w *Wal) ReadPosition() Position {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.readPos
 }
 
-func (w *Wal) Compact() error {
-	// Create a map to track the latest put operations and a set to track deleted keys
-	latestPuts := make(map[string][]byte)
-	deletedKeys := make(map[string]bool)
+// ReadNext reads the next record from the WAL, it returns io.EOF after the last one.
+func (w *Wal) ReadNext() (*Record, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
 
-	w.InitReading()
 	for {
-		record, err := w.ReadNext()
-		if err == io.EOF {
-			break
+		activeID := w.segments[len(w.segments)-1]
+		if w.readPos.Segment > activeID {
+			return nil, io.EOF
 		}
-		if err != nil {
-			return err
+		// The checkpoint may sit at the very end of a deleted segment
+		if w.readPos.Segment < w.segments[0] {
+			w.readPos = Position{Segment: w.segments[0]}
 		}
-		if record.Type == putType {
-			latestPuts[string(record.Key)] = record.Value
-			// If the key was previously deleted, ensure it's removed from the deletedKeys set
-			delete(deletedKeys, string(record.Key))
-		} else if record.Type == deleteType {
-			delete(latestPuts, string(record.Key))
-			deletedKeys[string(record.Key)] = true
-		}
-	}
-
-	// Step 2: Create a temporary WAL for writing compressed records
-	tmpWALPath := w.dirPath + "/db.tmp.wal"
-	tmpWAL, err := fileio.NewMMapIOManager(tmpWALPath, w.filesize) // assuming w.m provides FileSize method
-	if err != nil {
-		return err
-	}
-	defer tmpWAL.Close()
 
-	// Step 3: Write records to temporary WAL
-	for key, value := range latestPuts {
-		// Skip the key if it was deleted
-		if _, deleted := deletedKeys[key]; deleted {
+		var record *Record
+		var n int64
+		var err error
+		if w.readPos.Segment == activeID {
+			record, n, err = readRecord(w.active, w.activeSize, w.readPos.Offset)
+		} else {
+			record, n, err = w.readSegment(w.readPos)
+		}
+		if err == io.EOF {
+			if w.readPos.Segment == activeID {
+				w.closeReader()
+				return nil, io.EOF
+			}
+			// Continue with the next segment
+			w.readPos = Position{Segment: w.readPos.Segment + 1}
 			continue
 		}
-		// TODO: Consider adding the log number, if necessary.
-		err = w.writeToSpecificWAL(tmpWAL, putType, []byte(key), value)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		w.readPos.Offset += n
+		return record, nil
 	}
+}
 
-	// Rename files to replace the old WAL with the compacted one
-	err = os.Rename(tmpWALPath, w.dirPath+walFileName)
-	if err != nil {
-		return err
+// readSegment reads the record at pos from a segment that is no longer written.
+// The segment stays open for the records after it.
+// Hold the mutex before accessing this method
+func (w *Wal) readSegment(pos Position) (*Record, int64, error) {
+	if w.reader == nil || w.readerID != pos.Segment {
+		w.closeReader()
+		segment, err := fileio.NewFileIOManager(w.segmentPath(pos.Segment))
+		if err != nil {
+			return nil, 0, err
+		}
+		size, err := segment.Size()
+		if err != nil {
+			_ = segment.Close()
+			return nil, 0, err
+		}
+		w.reader, w.readerID, w.readerSize = segment, pos.Segment, size
 	}
+	return readRecord(w.reader, w.readerSize, pos.Offset)
+}
 
-	// Reinitialize mmap with the compacted file
-	w.m, err = fileio.NewMMapIOManager(w.dirPath+walFileName, w.filesize)
-	if err != nil {
-		return err
+// closeReader closes the segment that was read from
+// Hold the mutex before accessing this method
+func (w *Wal) closeReader() {
+	if w.reader != nil {
+		_ = w.reader.Close()
+		w.reader = nil
 	}
+}
 
-	return nil
+// Save flushes the WAL to disk.
+func (w *Wal) Save() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.sync()
 }
 
-func (w *Wal) writeToSpecificWAL(targetWAL *fileio.MMapIO, recordType byte, key, value []byte) error {
-	// Prepare the payload based on record type
-	var payload []byte
-	switch recordType {
-	case putType:
-		payload = append(key, value...)
-	case deleteType:
-		payload = key
-	default:
-		return errors.New("unknown record type")
+// SyncTo makes sure the records before pos are on disk.
+// Writers waiting for a sync share it: whoever syncs first covers everything
+// written up to then, and the others return without syncing again.
+func (w *Wal) SyncTo(pos Position) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.synced.Before(pos) {
+		return nil
 	}
-
-	size := uint16(4 + len(payload)) // 4 bytes for log number
-	buffer := make([]byte, 4+2+1+4+len(payload))
-
-	// Compute CRC
-	crc := crc32.ChecksumIEEE(buffer[4:])
-	binary.LittleEndian.PutUint32(buffer, crc)
-
-	// Write size
-	binary.LittleEndian.PutUint16(buffer[4:], size)
-
-	// Write type
-	buffer[4+2] = recordType
-
-	// Write log number
-	binary.LittleEndian.PutUint32(buffer[4+2+1:], w.logNum)
-
-	// Write payload
-	copy(buffer[4+2+1+4:], payload)
-
-	_, err := targetWAL.Write(buffer)
-	return err
+	return w.sync()
 }
 
-// Save flushes the WAL to disk.
-func (w *Wal) Save() error {
-	return w.m.Sync()
+// sync flushes the active segment to disk
+// Hold the mutex before accessing this method
+func (w *Wal) sync() error {
+	if err := w.active.Sync(); err != nil {
+		return err
+	}
+	w.synced = Position{Segment: w.segments[len(w.segments)-1], Offset: w.activeSize}
+	return nil
 }
 
 // Close closes the WAL.
 func (w *Wal) Close() error {
-	return w.m.Close()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	select {
+	case <-w.closed:
+		return nil
+	default:
+		close(w.closed)
+	}
+	w.closeReader()
+	if err := w.active.Sync(); err != nil {
+		return err
+	}
+	return w.active.Close()
 }
 
+// Clean closes the WAL and removes its directory.
 func (w *Wal) Clean() error {
-	err := w.m.Close()
+	err := w.Close()
 	if err != nil {
 		return err
 	}
-	return os.RemoveAll(w.dirPath)
+	return os.RemoveAll(w.options.DirPath)
 }
 
-// AsyncSave periodically flushes the WAL to disk.
+// AsyncSave periodically flushes the WAL to disk until it is closed.
 func (w *Wal) AsyncSave() {
-	for range time.Tick(time.Duration(w.saveTime)) {
-		err := w.Save()
-		if err != nil {
-			// TODO how to fix this error?
-			continue
+	if w.options.SaveTime <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(w.options.SaveTime))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.closed:
+			return
+		case <-ticker.C:
+			// A failed save is retried on the next tick
+			_ = w.Save()
 		}
 	}
 }
@@ -1,12 +1,15 @@
 package wal
 
+import "github.com/ByteStorage/FlyDB/db/fileio"
+
 // Options encapsulates configuration settings for the Write-Ahead Logging (WAL)
 // mechanism in a database.
 type Options struct {
 	// DirPath specifies the directory path where Write-Ahead Logging (WAL) files will be stored.
 	DirPath string
 
-	// FileSize determines the maximum size of individual WAL files.
+	// FileSize determines the maximum size of individual WAL segment files,
+	// a new segment is started once the active one would grow beyond it.
 	FileSize int64
 
 	// SaveTime defines the interval at which WAL data should be persisted from memory to disk.
@@ -14,5 +17,12 @@ type Options struct {
 
 	// LogNum specifies the number of WAL logs to retain, influencing performance and
 	// recovery behavior.
+	// Deprecated: segments are numbered by the WAL and deleted through checkpoints.
 	LogNum uint32
+
+	// FIOType selects the IO manager of the WAL file, memory mapping if unset.
+	FIOType int8
+
+	// FaultInjector programs the faults of the WAL files if FIOType is FaultIOType, for testing.
+	FaultInjector *fileio.FaultInjector
 }
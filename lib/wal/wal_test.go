@@ -1,8 +1,14 @@
 package wal
 
 import (
+	"encoding/binary"
+	"github.com/ByteStorage/FlyDB/db/fileio"
 	"github.com/ByteStorage/FlyDB/lib/randkv"
 	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -26,3 +32,205 @@ func TestWal_Put(t *testing.T) {
 	end := time.Now()
 	t.Log("put time: ", end.Sub(start).String())
 }
+
+func TestWal_Rotate(t *testing.T) {
+	opt := Options{
+		DirPath:  filepath.Join(os.TempDir(), "flydb-wal-rotate"),
+		FileSize: 4 * 1024,
+		FIOType:  fileio.FileIOType,
+	}
+	_ = os.RemoveAll(opt.DirPath)
+	w, err := NewWal(opt)
+	assert.Nil(t, err)
+	defer w.Clean()
+
+	for n := 0; n < 1000; n++ {
+		assert.Nil(t, w.Put(randkv.GetTestKey(n), randkv.GetTestKey(n)))
+	}
+	assert.Nil(t, w.Delete(randkv.GetTestKey(0)))
+	assert.Greater(t, len(w.segments), 1)
+
+	// Replay after reopening
+	assert.Nil(t, w.Close())
+	w, err = NewWal(opt)
+	assert.Nil(t, err)
+	w.InitReading()
+	for n := 0; n < 1000; n++ {
+		record, err := w.ReadNext()
+		assert.Nil(t, err)
+		assert.Equal(t, putType, record.Type)
+		assert.Equal(t, randkv.GetTestKey(n), record.Key)
+		assert.Equal(t, randkv.GetTestKey(n), record.Value)
+	}
+	record, err := w.ReadNext()
+	assert.Nil(t, err)
+	assert.Equal(t, deleteType, record.Type)
+	assert.Nil(t, record.Value)
+	_, err = w.ReadNext()
+	assert.Equal(t, io.EOF, err)
+	// The segments read from are closed at the end
+	assert.Nil(t, w.reader)
+}
+
+func TestWal_Checkpoint(t *testing.T) {
+	opt := Options{
+		DirPath:  filepath.Join(os.TempDir(), "flydb-wal-checkpoint"),
+		FileSize: 4 * 1024,
+		FIOType:  fileio.FileIOType,
+	}
+	_ = os.RemoveAll(opt.DirPath)
+	w, err := NewWal(opt)
+	assert.Nil(t, err)
+	defer w.Clean()
+
+	for n := 0; n < 500; n++ {
+		assert.Nil(t, w.Put(randkv.GetTestKey(n), nil))
+	}
+	pos := w.Position()
+	for n := 500; n < 600; n++ {
+		assert.Nil(t, w.Put(randkv.GetTestKey(n), nil))
+	}
+	assert.Nil(t, w.Checkpoint(pos))
+	assert.Equal(t, pos.Segment, w.segments[0])
+	_, err = os.Stat(w.segmentPath(pos.Segment - 1))
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, ErrCheckpointOutOfRange, w.Checkpoint(Position{Segment: pos.Segment + 100}))
+
+	// Only the records after the checkpoint are replayed, also after reopening
+	assert.Nil(t, w.Close())
+	w, err = NewWal(opt)
+	assert.Nil(t, err)
+	w.InitReading()
+	for n := 500; n < 600; n++ {
+		record, err := w.ReadNext()
+		assert.Nil(t, err)
+		assert.Equal(t, randkv.GetTestKey(n), record.Key)
+	}
+	_, err = w.ReadNext()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestWal_TornTail(t *testing.T) {
+	opt := Options{
+		DirPath:  filepath.Join(os.TempDir(), "flydb-wal-torn"),
+		FileSize: 1024 * 1024,
+		FIOType:  fileio.FileIOType,
+	}
+	_ = os.RemoveAll(opt.DirPath)
+	w, err := NewWal(opt)
+	assert.Nil(t, err)
+	defer w.Clean()
+
+	assert.Nil(t, w.Put([]byte("a"), []byte("1")))
+	assert.Nil(t, w.Put([]byte("b"), []byte("2")))
+	end := w.Position()
+	assert.Nil(t, w.Close())
+
+	// Simulate a crash in the middle of a record
+	torn := encodeRecord(&Record{Type: putType, Key: []byte("c"), Value: []byte("3")})
+	file, err := os.OpenFile(w.segmentPath(end.Segment), os.O_WRONLY|os.O_APPEND, 0)
+	assert.Nil(t, err)
+	_, err = file.Write(torn[:len(torn)-1])
+	assert.Nil(t, err)
+	assert.Nil(t, file.Close())
+
+	w, err = NewWal(opt)
+	assert.Nil(t, err)
+	assert.Equal(t, end, w.Position())
+	assert.Nil(t, w.Put([]byte("d"), []byte("4")))
+
+	w.InitReading()
+	var keys []string
+	for {
+		record, err := w.ReadNext()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		keys = append(keys, string(record.Key))
+	}
+	assert.Equal(t, []string{"a", "b", "d"}, keys)
+}
+
+func TestWal_CorruptedRecord(t *testing.T) {
+	record := &Record{Type: putType, Key: []byte("key"), Value: []byte("value")}
+	buf := encodeRecord(record)
+	segment, err := fileio.NewMemIOManager("segment")
+	assert.Nil(t, err)
+	_, err = segment.Write(buf)
+	assert.Nil(t, err)
+
+	decoded, n, err := readRecord(segment, int64(len(buf)), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(buf)), n)
+	assert.Equal(t, record, decoded)
+
+	// Flip a bit of the value
+	buf[len(buf)-1] ^= 1
+	segment, err = fileio.NewMemIOManager("segment")
+	assert.Nil(t, err)
+	_, err = segment.Write(buf)
+	assert.Nil(t, err)
+	_, _, err = readRecord(segment, int64(len(buf)), 0)
+	assert.Equal(t, ErrCorruptedRecord, err)
+}
+
+// writeLegacyRecord appends a record the way the WAL wrote db.wal before it had segments
+func writeLegacyRecord(t *testing.T, file *os.File, typ byte, payload []byte) {
+	buf := make([]byte, legacyHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf, crc32.ChecksumIEEE(buf[4:]))
+	binary.LittleEndian.PutUint16(buf[4:], uint16(4+len(payload)))
+	buf[6] = typ
+	copy(buf[legacyHeaderSize:], payload)
+	_, err := file.Write(buf)
+	assert.Nil(t, err)
+}
+
+func TestWal_MigrateLegacy(t *testing.T) {
+	opt := Options{
+		DirPath:  filepath.Join(os.TempDir(), "flydb-wal-legacy"),
+		FileSize: 4 * 1024,
+		FIOType:  fileio.FileIOType,
+	}
+	_ = os.RemoveAll(opt.DirPath)
+	assert.Nil(t, os.MkdirAll(opt.DirPath, os.ModePerm))
+	legacy, err := os.Create(filepath.Join(opt.DirPath, legacyFileName))
+	assert.Nil(t, err)
+	for n := 0; n < 10; n++ {
+		writeLegacyRecord(t, legacy, deleteType, randkv.GetTestKey(n))
+	}
+	// the rest of the mapped file was zeroes
+	_, err = legacy.Write(make([]byte, 1024))
+	assert.Nil(t, err)
+	assert.Nil(t, legacy.Close())
+
+	// The deletes come before the records written afterwards
+	w, err := NewWal(opt)
+	assert.Nil(t, err)
+	defer w.Clean()
+	_, err = os.Stat(filepath.Join(opt.DirPath, legacyFileName))
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, w.Put([]byte("key"), []byte("value")))
+	w.InitReading()
+	for n := 0; n < 10; n++ {
+		record, err := w.ReadNext()
+		assert.Nil(t, err)
+		assert.Equal(t, deleteType, record.Type)
+		assert.Equal(t, randkv.GetTestKey(n), record.Key)
+	}
+	record, err := w.ReadNext()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key"), record.Key)
+	_, err = w.ReadNext()
+	assert.Equal(t, io.EOF, err)
+
+	// A put cannot be told apart into its key and value
+	opt.DirPath = filepath.Join(opt.DirPath, "put")
+	assert.Nil(t, os.MkdirAll(opt.DirPath, os.ModePerm))
+	legacy, err = os.Create(filepath.Join(opt.DirPath, legacyFileName))
+	assert.Nil(t, err)
+	writeLegacyRecord(t, legacy, putType, []byte("keyvalue"))
+	assert.Nil(t, legacy.Close())
+	_, err = NewWal(opt)
+	assert.Equal(t, ErrUnknownRecord, err)
+}
@@ -5,9 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
-	"github.com/ByteStorage/FlyDB/db/engine"
 	_const "github.com/ByteStorage/FlyDB/lib/const"
-	"regexp"
 	"strconv"
 	"time"
 )
@@ -25,18 +23,23 @@ type HashMetadata struct {
 const maxHashMetaSize = 1 + binary.MaxVarintLen64*6
 
 type HashStructure struct {
-	db            *engine.DB
+	ks            *Keyspace
 	hashValueType string
 	HashFieldType string
 }
 
 // NewHashStructure Returns a new NewHashStructure
 func NewHashStructure(options config.Options) (*HashStructure, error) {
-	db, err := engine.NewDB(options)
+	ks, err := NewKeyspace(options)
 	if err != nil {
 		return nil, err
 	}
-	return &HashStructure{db: db}, nil
+	return NewHashStructureWithKeyspace(ks), nil
+}
+
+// NewHashStructureWithKeyspace returns a HashStructure that stores its hashes in a shared keyspace
+func NewHashStructureWithKeyspace(ks *Keyspace) *HashStructure {
+	return &HashStructure{ks: ks}
 }
 
 // HSet sets the string value of a hash field in the HashStructure.
@@ -61,6 +64,12 @@ func NewHashStructure(options config.Options) (*HashStructure, error) {
 // - The function uses a write batch to efficiently commit changes to the database.
 // - It returns a boolean indicating whether the field was newly created or updated.
 func (hs *HashStructure) HSet(key string, field, value interface{}) (bool, error) {
+	defer hs.ks.lockKeys(key)()
+	return hs.hSet(key, field, value)
+}
+
+// hSet is HSet with the lock of the key held
+func (hs *HashStructure) hSet(key string, field, value interface{}) (bool, error) {
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -108,13 +117,13 @@ func (hs *HashStructure) HSet(key string, field, value interface{}) (bool, error
 	var exist = true
 
 	// Get the field from the database
-	_, err = hs.db.Get(hfBuf)
+	_, err = hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		exist = false
 	}
 
 	// new a write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
 	// If the field is not found, increase the counter
 	if !exist {
@@ -126,7 +135,7 @@ func (hs *HashStructure) HSet(key string, field, value interface{}) (bool, error
 	_ = batch.Put(hfBuf, v)
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return false, err
 	}
@@ -158,7 +167,10 @@ func (hs *HashStructure) HSet(key string, field, value interface{}) (bool, error
 // - Returns the value corresponding to the field and any possible error.
 func (hs *HashStructure) HGet(key string, field interface{}) (interface{}, error) {
 	// check the key ttl
-	ttl, _ := hs.TTL(key)
+	ttl, err := hs.TTL(key)
+	if err == ErrWrongType {
+		return nil, err
+	}
 	if ttl == -1 {
 		return nil, _const.ErrKeyIsExpired
 	}
@@ -199,7 +211,7 @@ func (hs *HashStructure) HGet(key string, field interface{}) (interface{}, error
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	value, err := hs.db.Get(hfBuf)
+	value, err := hs.ks.db.Get(hfBuf)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +242,10 @@ func (hs *HashStructure) HGet(key string, field interface{}) (interface{}, error
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HMGet(key string, field ...interface{}) ([]interface{}, error) {
 	// check the key ttl
-	ttl, _ := hs.TTL(key)
+	ttl, err := hs.TTL(key)
+	if err == ErrWrongType {
+		return nil, err
+	}
 	if ttl == -1 {
 		return nil, _const.ErrKeyIsExpired
 	}
@@ -273,7 +288,7 @@ func (hs *HashStructure) HMGet(key string, field ...interface{}) ([]interface{},
 		hfBuf := hf.encodeHashField()
 
 		// Get the field from the database
-		value, err := hs.db.Get(hfBuf)
+		value, err := hs.ks.db.Get(hfBuf)
 		if err != nil {
 			return nil, err
 		}
@@ -306,6 +321,8 @@ func (hs *HashStructure) HMGet(key string, field ...interface{}) ([]interface{},
 //	bool: True if the field was deleted successfully, false otherwise.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HDel(key string, field interface{}) (bool, error) {
+	defer hs.ks.lockKeys(key)()
+
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -342,13 +359,13 @@ func (hs *HashStructure) HDel(key string, field interface{}) (bool, error) {
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	_, err = hs.db.Get(hfBuf)
+	_, err = hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return false, nil
 	}
 
 	// Create a new write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
 	// Delete the field from the database
 	_ = batch.Delete(hfBuf)
@@ -360,7 +377,7 @@ func (hs *HashStructure) HDel(key string, field interface{}) (bool, error) {
 	_ = batch.Put(k, hashMeta.encodeHashMeta())
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return false, err
 	}
@@ -381,6 +398,8 @@ func (hs *HashStructure) HDel(key string, field interface{}) (bool, error) {
 //	bool: True if the hash was deleted successfully, false otherwise.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HDelAll(key string) (bool, error) {
+	defer hs.ks.lockKeys(key)()
+
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -401,13 +420,15 @@ func (hs *HashStructure) HDelAll(key string) (bool, error) {
 	}
 
 	// Create a new write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
-	// Delete the field from the database
-	_ = batch.Delete(k)
+	// Delete the hash metadata and the fields from the database
+	if err = hs.ks.deleteValue(batch, key); err != nil {
+		return false, err
+	}
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return false, err
 	}
@@ -429,6 +450,8 @@ func (hs *HashStructure) HDelAll(key string) (bool, error) {
 //	bool: True if the timeout was set successfully, false otherwise.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HExpire(key string, ttl int64) (bool, error) {
+	defer hs.ks.lockKeys(key)()
+
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -449,14 +472,15 @@ func (hs *HashStructure) HExpire(key string, ttl int64) (bool, error) {
 	}
 
 	// Create a new write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
 	// Put the updated hash metadata to the database
 	hashMeta.expire = time.Now().Add(time.Duration(ttl) * time.Second).UnixNano()
 	_ = batch.Put(k, hashMeta.encodeHashMeta())
+	hs.ks.indexExpire(batch, k, hashMeta.expire)
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return false, err
 	}
@@ -514,7 +538,7 @@ func (hs *HashStructure) HExists(key string, field interface{}) (bool, error) {
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	_, err = hs.db.Get(hfBuf)
+	_, err = hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return false, nil
 	}
@@ -571,6 +595,8 @@ func (hs *HashStructure) HLen(key string) (int, error) {
 //	bool: True if the update was successful, false otherwise.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HUpdate(key string, field, value interface{}) (bool, error) {
+	defer hs.ks.lockKeys(key)()
+
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -612,19 +638,19 @@ func (hs *HashStructure) HUpdate(key string, field, value interface{}) (bool, er
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	_, err = hs.db.Get(hfBuf)
+	_, err = hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return false, nil
 	}
 
 	// Create a new write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
 	// Put the field to the database
 	_ = batch.Put(hfBuf, v)
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return false, err
 	}
@@ -647,6 +673,8 @@ func (hs *HashStructure) HUpdate(key string, field, value interface{}) (bool, er
 //	int64: The updated value of the field after increment.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HIncrBy(key string, field interface{}, increment int64) (int64, error) {
+	defer hs.ks.lockKeys(key)()
+
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -683,7 +711,7 @@ func (hs *HashStructure) HIncrBy(key string, field interface{}, increment int64)
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	value, err := hs.db.Get(hfBuf)
+	value, err := hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return 0, nil
 	}
@@ -701,13 +729,13 @@ func (hs *HashStructure) HIncrBy(key string, field interface{}, increment int64)
 	value = []byte(strconv.FormatInt(val, 10))
 
 	// Create a new write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
 	// Put the field to the database
 	_ = batch.Put(hfBuf, value)
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return 0, err
 	}
@@ -730,6 +758,8 @@ func (hs *HashStructure) HIncrBy(key string, field interface{}, increment int64)
 //	float64: The updated value of the field after increment.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HIncrByFloat(key string, field interface{}, increment float64) (float64, error) {
+	defer hs.ks.lockKeys(key)()
+
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -766,7 +796,7 @@ func (hs *HashStructure) HIncrByFloat(key string, field interface{}, increment f
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	value, err := hs.db.Get(hfBuf)
+	value, err := hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return 0, nil
 	}
@@ -784,13 +814,13 @@ func (hs *HashStructure) HIncrByFloat(key string, field interface{}, increment f
 	value = []byte(strconv.FormatFloat(val, 'f', -1, 64))
 
 	// Create a new write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
 	// Put the field to the database
 	_ = batch.Put(hfBuf, value)
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return 0, err
 	}
@@ -813,6 +843,8 @@ func (hs *HashStructure) HIncrByFloat(key string, field interface{}, increment f
 //	int64: The updated value of the field after decrement.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HDecrBy(key string, field interface{}, decrement int64) (int64, error) {
+	defer hs.ks.lockKeys(key)()
+
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -849,7 +881,7 @@ func (hs *HashStructure) HDecrBy(key string, field interface{}, decrement int64)
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	value, err := hs.db.Get(hfBuf)
+	value, err := hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return 0, nil
 	}
@@ -867,13 +899,13 @@ func (hs *HashStructure) HDecrBy(key string, field interface{}, decrement int64)
 	value = []byte(strconv.FormatInt(val, 10))
 
 	// Create a new write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
 	// Put the field to the database
 	_ = batch.Put(hfBuf, value)
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return 0, err
 	}
@@ -930,7 +962,7 @@ func (hs *HashStructure) HStrLen(key string, field interface{}) (int, error) {
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	value, err := hs.db.Get(hfBuf)
+	value, err := hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return 0, nil
 	}
@@ -953,6 +985,8 @@ func (hs *HashStructure) HStrLen(key string, field interface{}) (int, error) {
 //	bool: True if the move was successful, false otherwise.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HMove(source, destination string, field interface{}) (bool, error) {
+	defer hs.ks.lockKeys(source, destination)()
+
 	// Convert the parameters to bytes
 	f, err, _ := interfaceToBytes(field)
 	if err != nil {
@@ -1007,13 +1041,13 @@ func (hs *HashStructure) HMove(source, destination string, field interface{}) (b
 	destinationHfBuf := destinationHf.encodeHashField()
 
 	// Get the field from the database
-	value, err := hs.db.Get(destinationHfBuf)
+	value, err := hs.ks.db.Get(destinationHfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return false, nil
 	}
 
 	// Create a new write batch
-	batch := hs.db.NewWriteBatch(config.DefaultWriteBatchOptions)
+	batch := hs.ks.newBatch()
 
 	// Delete the field from the source
 	_ = batch.Delete(hfBuf)
@@ -1022,7 +1056,7 @@ func (hs *HashStructure) HMove(source, destination string, field interface{}) (b
 	_ = batch.Put(hfBuf, value)
 
 	// Commit the write batch
-	err = batch.Commit()
+	err = hs.ks.commit(batch)
 	if err != nil {
 		return false, err
 	}
@@ -1045,6 +1079,8 @@ func (hs *HashStructure) HMove(source, destination string, field interface{}) (b
 //	bool: True if the field was set, false otherwise.
 //	error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) HSetNX(key string, field, value interface{}) (bool, error) {
+	defer hs.ks.lockKeys(key)()
+
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
@@ -1082,9 +1118,9 @@ func (hs *HashStructure) HSetNX(key string, field, value interface{}) (bool, err
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	_, err = hs.db.Get(hfBuf)
+	_, err = hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
-		_, err := hs.HSet(key, field, value)
+		_, err := hs.hSet(key, field, value)
 		if err != nil {
 			return false, err
 		}
@@ -1144,7 +1180,7 @@ func (hs *HashStructure) HTypes(key string, field interface{}) (string, error) {
 	hfBuf := hf.encodeHashField()
 
 	// Get the field from the database
-	_, err = hs.db.Get(hfBuf)
+	_, err = hs.ks.db.Get(hfBuf)
 	if err != nil && err == _const.ErrKeyNotFound {
 		return "", _const.ErrKeyNotFound
 	} else {
@@ -1160,40 +1196,54 @@ func (hs *HashStructure) HTypes(key string, field interface{}) (string, error) {
 // []string: A list of field names in the hash.
 // error: An error if occurred during the operation, or nil on success.
 func (hs *HashStructure) Keys(regx string) ([]string, error) {
-	toRegexp := convertToRegexp(regx)
-	compile, err := regexp.Compile(toRegexp)
+	return hs.ks.keys(regx, Hash)
+}
+
+// Scan returns a page of the keys holding a hash that match a pattern, see Keyspace.Scan
+func (hs *HashStructure) Scan(cursor, match string, count int) (string, []string, error) {
+	return hs.ks.Scan(cursor, match, count, typeNames[Hash])
+}
+
+// HScan returns a page of the fields of a hash that match a pattern, each followed by its value.
+// count bounds how many fields the call looks at. It returns the cursor of the next page,
+// which is ScanCursorStart once every field was visited.
+func (hs *HashStructure) HScan(key string, cursor, match string, count int) (string, []string, error) {
+	if err := checkKey(key); err != nil {
+		return "", nil, err
+	}
+	next, compile, count, err := scanArgs(cursor, match, count)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
-	// Get all the keys from the database
-	byteKeys := hs.db.GetListKeys()
-
-	// Create a new slice of strings
-	keys := make([]string, 0)
-
-	for _, key := range byteKeys {
-		if compile.MatchString(string(key)) {
-			// Check if the key has the identifier suffix
-			if !keysIdentify(key) {
-				fields := hs.GetFields(string(key))
-				ok := true
-				for _, field := range fields {
-					_, err := hs.HGet(string(key), field)
-					if err != nil {
-						ok = false
-						break
-					}
-				}
-				if !ok {
-					continue
-				}
-				keys = append(keys, string(key))
-			}
-		}
 
+	// Find the hash metadata, fields of older versions of the hash are skipped
+	hashMeta, err := hs.findHashMeta(key, Hash)
+	if err != nil {
+		return "", nil, err
+	}
+	pairs := make([]string, 0)
+	if hashMeta.counter == 0 || isExpired(hashMeta.expire) {
+		return ScanCursorStart, pairs, nil
 	}
 
-	return keys, nil
+	it := hs.ks.db.NewIterator(config.IteratorOptions{Prefix: hashFieldPrefix(key), Start: next})
+	defer it.Close()
+	for examined := 0; examined < count && it.Valid(); it.Next() {
+		examined++
+		hf, err := decodeHashField(it.Key())
+		if err != nil || hf.version != hashMeta.version || !compile.Match(hf.field) {
+			continue
+		}
+		value, err := it.Value()
+		if err != nil {
+			return "", nil, err
+		}
+		pairs = append(pairs, string(hf.field), string(value))
+	}
+	if !it.Valid() {
+		return ScanCursorStart, pairs, nil
+	}
+	return encodeCursor(it.Key()), pairs, nil
 }
 
 // GetFields returns a list of all field names in the hash stored at the specified key.
@@ -1208,19 +1258,19 @@ func (hs *HashStructure) Keys(regx string) ([]string, error) {
 //
 // []string: A list of field names in the hash.
 func (hs *HashStructure) GetFields(key string) []string {
-	// Get all the keys from the database
-	byte_keys := hs.db.GetListKeys()
-
 	// Create a new slice of strings
 	fields := make([]string, 0)
 
-	for _, k := range byte_keys {
-		// Check if the key has the identifier suffix
-		if keysIdentify(k) {
-			hf, _ := decodeHashField(k)
-			if string(hf.key) == key {
-				fields = append(fields, string(hf.field))
-			}
+	// Find the hash metadata, fields of older versions of the hash are skipped
+	hashMeta, err := hs.findHashMeta(key, Hash)
+	if err != nil {
+		return fields
+	}
+
+	for _, k := range hs.ks.hashFieldKeys(key) {
+		hf, err := decodeHashField(k)
+		if err == nil && hf.version == hashMeta.version {
+			fields = append(fields, string(hf.field))
 		}
 	}
 
@@ -1255,19 +1305,6 @@ func (hs *HashStructure) HGetAllFieldAndValue(key string) (map[string]interface{
 	return filedAndValue, nil
 }
 
-// keysIdentify checks if the key has the identifier suffix
-//
-// Parameters:
-//
-//	key: The key to check.
-//
-// Returns:
-//
-//	bool: True if the key has the identifier suffix, false otherwise.
-func keysIdentify(key []byte) bool {
-	return bytes.HasSuffix(key, []byte("notk"))
-}
-
 // TTL returns the time-to-live (TTL) of a key in the hash.
 // It takes a string key 'k' and returns the remaining TTL in seconds and any possible error.
 //
@@ -1360,11 +1397,14 @@ func (hs *HashStructure) Size(key string) (string, error) {
 
 // findHashMeta finds the hash metadata by the given key.
 func (hs *HashStructure) findHashMeta(key string, dataType DataStructure) (*HashMetadata, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
 	// Convert the parameters to bytes
 	k := stringToBytesWithKey(key)
 
 	// Find the hash metadata by the given key
-	meta, err := hs.db.Get(k)
+	meta, err := hs.ks.db.Get(k)
 	if err != nil && err != _const.ErrKeyNotFound {
 		return nil, err
 	}
@@ -1374,15 +1414,18 @@ func (hs *HashStructure) findHashMeta(key string, dataType DataStructure) (*Hash
 	// If the hash metadata is not found, create a new one
 	if err == _const.ErrKeyNotFound {
 		exist = false
+	} else if meta[0] != dataType {
+		// A value of another type keeps the key, unless it expired or is empty
+		if err = hs.ks.checkType(key, dataType); err != nil {
+			return nil, err
+		}
+		exist = false
 	} else {
 		// Decode the hash metadata
-		hashMeta = decodeHashMeta(meta)
-
-		// Check the data type
-		if hashMeta.dataType != dataType {
-			return nil, ErrInvalidType
+		hashMeta, err = decodeHashMeta(meta)
+		if err != nil {
+			return nil, err
 		}
-
 	}
 
 	// If the hash metadata is not found, create a new one
@@ -1402,12 +1445,11 @@ func (hs *HashStructure) findHashMeta(key string, dataType DataStructure) (*Hash
 }
 
 func (hs *HashStructure) Stop() error {
-	err := hs.db.Close()
-	return err
+	return hs.ks.Close()
 }
 
 func (hs *HashStructure) Clean() {
-	hs.db.Clean()
+	hs.ks.db.Clean()
 }
 
 type HashField struct {
@@ -1416,101 +1458,77 @@ type HashField struct {
 	version int64
 }
 
+// hashFieldKeyPrefix starts the keys of the fields of every hash
+var hashFieldKeyPrefix = []byte("\x00hf")
+
 // encodeHashField encodes a HashField and returns the byte array and length.
-// +-------------+------------+------------+------------+
-// |    key      |  field     |  version   | identifier |
-// +-------------+------------+------------+------------+
-// |  variable   |  variable  |  8 bytes   |   4 byte   |
-// +-------------+------------+------------+------------+
+// +----------+------------+------------+------------+------------+------------+
+// |  prefix  |  key size  |    key     | field size |   field    |  version   |
+// +----------+------------+------------+------------+------------+------------+
+// | "\x00hf" |   4 bytes  |  variable  |   4 bytes  |  variable  |  8 bytes   |
+// +----------+------------+------------+------------+------------+------------+
 func (hf *HashField) encodeHashField() []byte {
-	keyLen := int32(len(hf.key))
-	fieldLen := int32(len(hf.field))
-
-	// Prepare a buffer to hold the encoded data
-	buf := new(bytes.Buffer)
-
-	// Write the key length, key, field length, field, and version to the buffer
-	if err := binary.Write(buf, binary.BigEndian, keyLen); err != nil {
-		return nil
-	}
-	if _, err := buf.Write(hf.key); err != nil {
-		return nil
-	}
-	if err := binary.Write(buf, binary.BigEndian, fieldLen); err != nil {
-		return nil
-	}
-	if _, err := buf.Write(hf.field); err != nil {
-		return nil
-	}
-	if err := binary.Write(buf, binary.BigEndian, hf.version); err != nil {
-		return nil
-	}
-
-	// Write the identifier
-	if err := binary.Write(buf, binary.BigEndian, []byte("notk")); err != nil {
-		return nil
-	}
-
-	return buf.Bytes()
+	buf := make([]byte, len(hashFieldKeyPrefix)+4+len(hf.key)+4+len(hf.field)+8)
+	offset := copy(buf, hashFieldKeyPrefix)
+	binary.BigEndian.PutUint32(buf[offset:], uint32(len(hf.key)))
+	offset += 4 + copy(buf[offset+4:], hf.key)
+	binary.BigEndian.PutUint32(buf[offset:], uint32(len(hf.field)))
+	offset += 4 + copy(buf[offset+4:], hf.field)
+	binary.BigEndian.PutUint64(buf[offset:], uint64(hf.version))
+	return buf
+}
 
+// hashFieldPrefix returns the prefix the fields of a hash share, whatever their version
+func hashFieldPrefix(key string) []byte {
+	prefix := make([]byte, len(hashFieldKeyPrefix)+4+len(key))
+	offset := copy(prefix, hashFieldKeyPrefix)
+	binary.BigEndian.PutUint32(prefix[offset:], uint32(len(key)))
+	copy(prefix[offset+4:], key)
+	return prefix
 }
 
 // decodeHashField decodes the HashField from a byte buffer.
 func decodeHashField(data []byte) (*HashField, error) {
-	hf := &HashField{}
-
-	// Create a buffer to hold the data
-	buf := bytes.NewBuffer(data)
-
-	var keyLen int32
-	if err := binary.Read(buf, binary.BigEndian, &keyLen); err != nil {
-		return nil, err
-	}
-
-	hf.key = make([]byte, keyLen)
-	if _, err := buf.Read(hf.key); err != nil {
-		return nil, err
+	if !bytes.HasPrefix(data, hashFieldKeyPrefix) {
+		return nil, ErrInvalidValue
 	}
-
-	var fieldLen int32
-	if err := binary.Read(buf, binary.BigEndian, &fieldLen); err != nil {
-		return nil, err
+	rest := data[len(hashFieldKeyPrefix):]
+	if len(rest) < 4 {
+		return nil, ErrInvalidValue
 	}
-
-	hf.field = make([]byte, fieldLen)
-	if _, err := buf.Read(hf.field); err != nil {
-		return nil, err
+	keyLen := int(binary.BigEndian.Uint32(rest))
+	if keyLen > len(rest)-4-4-8 {
+		return nil, ErrInvalidValue
 	}
-
-	if err := binary.Read(buf, binary.BigEndian, &hf.version); err != nil {
-		return nil, err
+	key, rest := rest[4:4+keyLen], rest[4+keyLen:]
+	fieldLen := int(binary.BigEndian.Uint32(rest))
+	if fieldLen != len(rest)-4-8 {
+		return nil, ErrInvalidValue
 	}
+	field, rest := rest[4:4+fieldLen], rest[4+fieldLen:]
 
 	return &HashField{
-		key:     hf.key,
-		field:   hf.field,
-		version: hf.version,
+		key:     append([]byte(nil), key...),
+		field:   append([]byte(nil), field...),
+		version: int64(binary.BigEndian.Uint64(rest)),
 	}, nil
-
 }
 
 // EncodeHashMeta encodes a HashMetadata and returns the byte array and length.
-// +-------------+------------+------------+------------+---------+----------+----------+---------+---------+
-// |  data type  |  data size |   expire   |  version   | counter | created  | updated  |  field  |  value  |
-// +-------------+------------+------------+------------+---------+----------+----------+---------+---------+
-// |  1 byte     |  variable  |  variable  |  variable  | variable| variable | variable | variable| variable|
-// +-------------+------------+------------+------------+---------+----------+----------+---------+---------+
+// +-------------+------------+------------+------------+---------+----------+----------+
+// |  data type  |   expire   |  data size |  version   | counter | created  | updated  |
+// +-------------+------------+------------+------------+---------+----------+----------+
+// |  1 byte     |  variable  |  variable  |  variable  | variable| variable | variable |
+// +-------------+------------+------------+------------+---------+----------+----------+
+// The data type and the expiry time are the header every value of the keyspace starts with.
 func (meta *HashMetadata) encodeHashMeta() []byte {
 	buf := make([]byte, maxHashMetaSize)
 
-	// Store the data type at the first byte
-	buf[0] = meta.dataType
-
-	var offset = 1
+	// Store the header first
+	var offset = copy(buf, encodeHeader(meta.dataType, meta.expire))
 
-	// Store the lengths of data size, expire, version, counter, createdTime and lastUpdatedTime
+	// Store the lengths of data size, version, counter, createdTime and lastUpdatedTime
 	offset += binary.PutVarint(buf[offset:], meta.dataSize)
-	offset += binary.PutVarint(buf[offset:], meta.expire)
 	offset += binary.PutVarint(buf[offset:], meta.version)
 	offset += binary.PutVarint(buf[offset:], meta.counter)
 	offset += binary.PutVarint(buf[offset:], meta.createdTime)
@@ -1519,28 +1537,38 @@ func (meta *HashMetadata) encodeHashMeta() []byte {
 }
 
 // DecodeHashMeta decodes the HashMetadata from a byte buffer.
-func decodeHashMeta(buf []byte) *HashMetadata {
+func decodeHashMeta(buf []byte) (*HashMetadata, error) {
+	dataType, expire, n, err := decodeHeader(buf) // Decode data type and expire
+	if err != nil {
+		return nil, err
+	}
+	meta, err := decodeHashMetaPayload(buf[n:])
+	if err != nil {
+		return nil, err
+	}
+	meta.dataType = dataType
+	meta.expire = expire
+	return meta, nil
+}
+
+// decodeHashMetaPayload decodes the HashMetadata that follows the header.
+func decodeHashMetaPayload(buf []byte) (*HashMetadata, error) {
+	var fields [5]int64
 	var offset = 0
-	dataType := buf[offset] // Decode data type
-	offset++
-	dataSize, n := binary.Varint(buf[offset:]) // Decode data size
-	offset += n
-	expire, n := binary.Varint(buf[offset:]) // Decode expire
-	offset += n
-	version, n := binary.Varint(buf[offset:]) // Decode version
-	offset += n
-	counter, n := binary.Varint(buf[offset:]) // Decode counter
-	offset += n
-	createdTime, n := binary.Varint(buf[offset:]) // Decode createdTime
-	offset += n
-	lastUpdatedTime, _ := binary.Varint(buf[offset:]) // Decode lastUpdatedTime
-	return &HashMetadata{
-		dataType:        dataType,
-		dataSize:        dataSize,
-		expire:          expire,
-		version:         version,
-		counter:         counter,
-		createdTime:     createdTime,
-		lastUpdatedTime: lastUpdatedTime,
+	// Decode data size, version, counter, createdTime and lastUpdatedTime
+	for i := range fields {
+		value, n := binary.Varint(buf[offset:])
+		if n <= 0 {
+			return nil, ErrInvalidValue
+		}
+		fields[i] = value
+		offset += n
 	}
+	return &HashMetadata{
+		dataSize:        fields[0],
+		version:         fields[1],
+		counter:         fields[2],
+		createdTime:     fields[3],
+		lastUpdatedTime: fields[4],
+	}, nil
 }
@@ -14,13 +14,14 @@ func initHashDB() (*HashStructure, *config.Options) {
 	opts := config.DefaultOptions
 	dir, _ := os.MkdirTemp("", "TestHashStructure")
 	opts.DirPath = dir
-	hash, _ := NewHashStructure(opts)
+	ks, _ := NewKeyspaceWithSweepOptions(opts, config.ExpireSweepOptions{})
+	hash := NewHashStructureWithKeyspace(ks)
 	return hash, &opts
 }
 
 func TestHashStructure_HGet(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), randkv.RandomValue(10))
 	assert.Nil(t, err)
@@ -57,7 +58,7 @@ func TestHashStructure_HGet(t *testing.T) {
 
 func TestHashStructure_HMGet(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), randkv.RandomValue(10))
 	assert.Nil(t, err)
@@ -84,7 +85,7 @@ func TestHashStructure_HMGet(t *testing.T) {
 
 func TestHashStructure_HDel(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok, err := hash.HDel("1", []byte("field1"))
 	assert.Nil(t, err)
@@ -114,7 +115,7 @@ func TestHashStructure_HDel(t *testing.T) {
 
 func TestHashStructure_HExists(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), randkv.RandomValue(100))
 	assert.Nil(t, err)
@@ -132,7 +133,7 @@ func TestHashStructure_HExists(t *testing.T) {
 
 func TestHashStructure_HLen(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), randkv.RandomValue(100))
 	assert.Nil(t, err)
@@ -153,7 +154,7 @@ func TestHashStructure_HLen(t *testing.T) {
 
 func TestHashStructure_HUpdate(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), randkv.RandomValue(100))
 	assert.Nil(t, err)
@@ -186,7 +187,7 @@ func TestHashStructure_HUpdate(t *testing.T) {
 
 func TestHashStructure_HIncrBy(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), []byte("10"))
 	assert.Nil(t, err)
@@ -220,7 +221,7 @@ func TestHashStructure_HIncrBy(t *testing.T) {
 
 func TestHashStructure_HIncrByFloat(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), []byte("10"))
 	assert.Nil(t, err)
@@ -254,7 +255,7 @@ func TestHashStructure_HIncrByFloat(t *testing.T) {
 
 func TestHashStructure_HDecrBy(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), []byte("10"))
 	assert.Nil(t, err)
@@ -288,7 +289,7 @@ func TestHashStructure_HDecrBy(t *testing.T) {
 
 func TestHashStructure_HStrLen(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), []byte("1000"))
 	assert.Nil(t, err)
@@ -322,7 +323,7 @@ func TestHashStructure_HStrLen(t *testing.T) {
 
 func TestHashStructure_HMove(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), []byte("111-1000"))
 	assert.Nil(t, err)
@@ -380,7 +381,7 @@ func TestHashStructure_HMove(t *testing.T) {
 
 func TestHashStructure_HSetNX(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSetNX("1", []byte("field1"), []byte("1000"))
 	assert.Nil(t, err)
@@ -410,7 +411,7 @@ func TestHashStructure_HSetNX(t *testing.T) {
 
 func TestHashStructure_HTypes(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", []byte("field1"), []byte("1000"))
 	assert.Nil(t, err)
@@ -443,7 +444,7 @@ func TestHashStructure_HTypes(t *testing.T) {
 
 func TestHashStructure_TTL(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", "field1", "123123")
 	assert.Nil(t, err)
@@ -473,7 +474,7 @@ func TestHashStructure_TTL(t *testing.T) {
 
 func TestHashStructure_Size(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", "field1", "11111")
 	assert.Nil(t, err)
@@ -491,7 +492,7 @@ func TestHashStructure_Size(t *testing.T) {
 
 func TestHashStructure_Keys(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("qqqqqq", "!qqq!1", "11111")
 	assert.Nil(t, err)
@@ -521,7 +522,7 @@ func TestHashStructure_Keys(t *testing.T) {
 
 func TestHashStructure_GetFields(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("qqqqqq", "!qqq!1", "11111")
 	assert.Nil(t, err)
@@ -573,7 +574,7 @@ func TestHashStructure_GetFields(t *testing.T) {
 
 func TestHashStructure_HGetAllFieldAndValue(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("qqqqqq", "!qqq!1", "11111")
 	assert.Nil(t, err)
@@ -611,7 +612,7 @@ func TestHashStructure_HGetAllFieldAndValue(t *testing.T) {
 
 func TestHashStructure_HDelAll(t *testing.T) {
 	hash, _ := initHashDB()
-	defer hash.db.Clean()
+	defer hash.ks.db.Clean()
 
 	ok1, err := hash.HSet("1", "field1", "111111")
 	assert.Nil(t, err)
@@ -0,0 +1,585 @@
+package structure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/encoding"
+)
+
+var (
+	// ErrWrongType is returned if a key holds a value of another data structure
+	ErrWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	// ErrReservedKey is returned if a key starts with internalKeyPrefix
+	ErrReservedKey = errors.New("Wrong value: keys starting with a zero byte are reserved")
+)
+
+// internalKeyPrefix starts every key the data structures keep for themselves: the fields of
+// a hash, the members of a set or a sorted set, the chunks of a list and the entries of the expiry index.
+// No key of the data structures can start with it, see checkKey.
+const internalKeyPrefix byte = 0
+
+// Keyspace is the engine shared by the data structures.
+// Every value in it starts with the same header, so that the type and
+// the expiry time of a key can be read without knowing its data structure:
+// +----------+------------+------------+
+// |   type   |   expire   |  payload   |
+// +----------+------------+------------+
+// |  1 byte  |  variable  |  variable  |
+// +----------+------------+------------+
+// The fields of a hash are stored under keys of their own, starting with internalKeyPrefix.
+// A sweeper deletes the keys whose time to live ran out in the background, see sweeper.go.
+type Keyspace struct {
+	commits   uint64 // Batches committed, read and written atomically, first for its alignment
+	db        *engine.DB
+	closeOnce sync.Once
+	closeErr  error
+
+	syncWrites    bool                       // Whether the batches of the structures are synced on commit
+	keyLocks      [keyLockStripes]sync.Mutex // Held from the read to the commit of a write to a key, see lockKeys
+	sweepOnce     sync.Once
+	sweepStop     chan struct{}
+	sweepDone     chan struct{}
+	sweepIdle     bool   // Whether the last sweep found the expiry index empty
+	sweepIdleAt   uint64 // commits when the last sweep read the expiry index
+	listenersLock sync.Mutex
+	listeners     []chan<- ExpiredEvent
+	blockLock     sync.Mutex               // Held while the blocked pops are served, see list_blocking.go
+	blocked       map[string][]*listWaiter // The pops blocked on each list, in order of arrival
+}
+
+// keyLockStripes is the number of locks the keys are spread over
+const keyLockStripes = 256
+
+// NewKeyspace opens the engine that the data structures share, with config.DefaultExpireSweepOptions:
+// expired keys are deleted when they are read, not in the background
+func NewKeyspace(options config.Options) (*Keyspace, error) {
+	return NewKeyspaceWithSweepOptions(options, config.DefaultExpireSweepOptions)
+}
+
+// NewKeyspaceWithSweepOptions opens the engine that the data structures share,
+// sweepOptions configure how fast expired keys are deleted in the background
+func NewKeyspaceWithSweepOptions(options config.Options, sweepOptions config.ExpireSweepOptions) (*Keyspace, error) {
+	db, err := engine.NewDB(options)
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keyspace{
+		db:         db,
+		syncWrites: options.SyncWrite,
+		sweepStop:  make(chan struct{}),
+		sweepDone:  make(chan struct{}),
+		blocked:    make(map[string][]*listWaiter),
+	}
+	ks.startSweeper(sweepOptions)
+	return ks, nil
+}
+
+// Close stops the sweeper and closes the engine, the structures sharing it may all call it
+func (ks *Keyspace) Close() error {
+	ks.closeOnce.Do(func() {
+		ks.stopSweeper()
+		ks.closeErr = ks.db.Close()
+	})
+	return ks.closeErr
+}
+
+func (ks *Keyspace) Clean() {
+	ks.stopSweeper()
+	ks.db.Clean()
+}
+
+// lockKeys locks the keys for a write, from the read of their values to the commit of the batch
+// that changes them, and returns the function that unlocks them. The keys share a fixed number of locks,
+// which are taken in order, so that writes locking several keys do not deadlock each other.
+// The locks are not reentrant: the helpers a locked write calls, such as checkType, deleteValue
+// or commit, expect the lock to be held and do not take it.
+func (ks *Keyspace) lockKeys(keys ...string) func() {
+	stripes := make([]int, 0, len(keys))
+	for _, key := range keys {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(key))
+		stripes = append(stripes, int(h.Sum32()%keyLockStripes))
+	}
+	sort.Ints(stripes)
+	locked := stripes[:0]
+	for i, stripe := range stripes {
+		if i > 0 && stripe == stripes[i-1] {
+			continue
+		}
+		ks.keyLocks[stripe].Lock()
+		locked = append(locked, stripe)
+	}
+	return func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			ks.keyLocks[locked[i]].Unlock()
+		}
+	}
+}
+
+// typeNames are the names TYPE returns for the data structures
+var typeNames = map[DataStructure]string{
+	String: "string",
+	Hash:   "hash",
+	List:   "list",
+	Set:    "set",
+	ZSet:   "zset",
+}
+
+// Type returns the type of the value of a key, or "none" if the key does not exist
+func (ks *Keyspace) Type(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	typ, _, _, err := ks.lookup(key)
+	if err == _const.ErrKeyNotFound {
+		return "none", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return typeNames[typ], nil
+}
+
+// Del deletes keys of any type, it returns how many of them existed
+func (ks *Keyspace) Del(keys ...string) (int, error) {
+	deleted := 0
+	for _, key := range keys {
+		if err := checkKey(key); err != nil {
+			return deleted, err
+		}
+		existed, err := ks.del(key)
+		if err != nil {
+			return deleted, err
+		}
+		if existed {
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// del deletes a key of any type with its lock taken, and reports whether it existed.
+// Expired and empty values are removed as well.
+func (ks *Keyspace) del(key string) (bool, error) {
+	defer ks.lockKeys(key)()
+	return ks.delLocked(key)
+}
+
+// delLocked is del with the lock of the key held
+func (ks *Keyspace) delLocked(key string) (bool, error) {
+	_, _, _, err := ks.lookup(key)
+	if err != nil && err != _const.ErrKeyNotFound {
+		return false, err
+	}
+	existed := err == nil
+	batch := ks.newBatch()
+	if err := ks.deleteValue(batch, key); err != nil {
+		return false, err
+	}
+	if err := ks.commit(batch); err != nil {
+		return false, err
+	}
+	return existed, nil
+}
+
+// Exists returns how many of the keys exist, a key given twice is counted twice
+func (ks *Keyspace) Exists(keys ...string) (int, error) {
+	count := 0
+	for _, key := range keys {
+		if err := checkKey(key); err != nil {
+			return count, err
+		}
+		_, _, _, err := ks.lookup(key)
+		if err == _const.ErrKeyNotFound {
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Rename moves the value of key to newKey, the value newKey held is deleted whatever its type.
+// The expiry time of the key moves with it.
+func (ks *Keyspace) Rename(key, newKey string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if err := checkKey(newKey); err != nil {
+		return err
+	}
+	typ, err := ks.rename(key, newKey)
+	if err != nil {
+		return err
+	}
+	if typ == List {
+		ks.wakeListWaiter(newKey)
+	}
+	return nil
+}
+
+// rename is Rename with the locks of both keys held, it returns the type of the value it moved
+func (ks *Keyspace) rename(key, newKey string) (DataStructure, error) {
+	defer ks.lockKeys(key, newKey)()
+	typ, expire, value, err := ks.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if key == newKey {
+		return typ, nil
+	}
+
+	batch := ks.newBatch()
+	if err := ks.deleteValue(batch, newKey); err != nil {
+		return 0, err
+	}
+	if typ == ZSet {
+		// a sorted set written in one value is migrated first, so that its members move with it
+		if _, err := NewZSetStructureWithKeyspace(ks).getZSetMeta([]byte(key)); err != nil {
+			return 0, err
+		}
+		if value, err = ks.db.Get([]byte(key)); err != nil {
+			return 0, err
+		}
+		oldPrefix, newPrefix := zsetPrefix([]byte(key)), zsetPrefix([]byte(newKey))
+		for _, memberKey := range ks.zsetKeys(key) {
+			memberValue, err := ks.db.Get(memberKey)
+			if err != nil {
+				return 0, err
+			}
+			_ = batch.Delete(memberKey)
+			_ = batch.Put(append(append([]byte(nil), newPrefix...), memberKey[len(oldPrefix):]...), memberValue)
+		}
+	}
+	if typ == Set {
+		// a set written in one value is migrated first, so that its members move with it
+		if _, err := NewSetStructureWithKeyspace(ks).getSetMeta([]byte(key)); err != nil {
+			return 0, err
+		}
+		if value, err = ks.db.Get([]byte(key)); err != nil {
+			return 0, err
+		}
+		oldPrefix, newPrefix := setPrefix([]byte(key)), setPrefix([]byte(newKey))
+		for _, memberKey := range ks.setKeys(key) {
+			_ = batch.Delete(memberKey)
+			_ = batch.Put(append(append([]byte(nil), newPrefix...), memberKey[len(oldPrefix):]...), nil)
+		}
+	}
+	if typ == List {
+		// a list written in one value is migrated first, so that its chunks move with it
+		if _, err := NewListStructureWithKeyspace(ks).getListMeta(key); err != nil {
+			return 0, err
+		}
+		if value, err = ks.db.Get([]byte(key)); err != nil {
+			return 0, err
+		}
+		oldPrefix, newPrefix := listChunkKeyPrefix([]byte(key)), listChunkKeyPrefix([]byte(newKey))
+		for _, chunkKey := range ks.listChunkKeys(key) {
+			chunkValue, err := ks.db.Get(chunkKey)
+			if err != nil {
+				return 0, err
+			}
+			_ = batch.Delete(chunkKey)
+			_ = batch.Put(append(append([]byte(nil), newPrefix...), chunkKey[len(oldPrefix):]...), chunkValue)
+		}
+	}
+	if typ == Hash {
+		// the fields keep the version of the hash, only their key changes
+		for _, fieldKey := range ks.hashFieldKeys(key) {
+			hf, err := decodeHashField(fieldKey)
+			if err != nil {
+				return 0, err
+			}
+			fieldValue, err := ks.db.Get(fieldKey)
+			if err != nil {
+				return 0, err
+			}
+			_ = batch.Delete(fieldKey)
+			hf.key = []byte(newKey)
+			_ = batch.Put(hf.encodeHashField(), fieldValue)
+		}
+	}
+	_ = batch.Delete([]byte(key))
+	_ = batch.Put([]byte(newKey), value)
+	ks.indexExpire(batch, []byte(newKey), expire)
+	return typ, ks.commit(batch)
+}
+
+// Expire sets the time to live of a key in seconds, a ttl <= 0 deletes the key
+func (ks *Keyspace) Expire(key string, ttl int64) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	defer ks.lockKeys(key)()
+	if _, _, _, err := ks.lookup(key); err != nil {
+		return err
+	}
+	if ttl <= 0 {
+		_, err := ks.delLocked(key)
+		return err
+	}
+	return ks.setExpire(key, time.Now().Add(time.Duration(ttl)*time.Second).UnixNano())
+}
+
+// Persist removes the expiry time of a key
+func (ks *Keyspace) Persist(key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	defer ks.lockKeys(key)()
+	if _, _, _, err := ks.lookup(key); err != nil {
+		return err
+	}
+	return ks.setExpire(key, 0)
+}
+
+// TTL returns the seconds a key has left to live, or -1 if it does not expire
+func (ks *Keyspace) TTL(key string) (int64, error) {
+	if err := checkKey(key); err != nil {
+		return -1, err
+	}
+	_, expire, _, err := ks.lookup(key)
+	if err != nil {
+		return -1, err
+	}
+	if expire == 0 {
+		return -1, nil
+	}
+	remaining := time.Duration(expire - time.Now().UnixNano())
+	return int64(remaining.Round(time.Second) / time.Second), nil
+}
+
+// lookup returns the type, the expiry time and the raw value of a key.
+// A key whose value expired, or whose hash, list, set or zset is empty, does not exist.
+func (ks *Keyspace) lookup(key string) (DataStructure, int64, []byte, error) {
+	if err := checkKey(key); err != nil {
+		return 0, 0, nil, err
+	}
+	value, err := ks.db.Get([]byte(key))
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	typ, expire, n, err := decodeHeader(value)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if isExpired(expire) {
+		return 0, 0, nil, _const.ErrKeyNotFound
+	}
+	empty, err := isEmptyValue(typ, value[n:])
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if empty {
+		return 0, 0, nil, _const.ErrKeyNotFound
+	}
+	return typ, expire, value, nil
+}
+
+// checkType returns ErrWrongType if key holds a live value of another type than typ.
+// A value of another type that expired or is empty is deleted, so that typ can take the key.
+func (ks *Keyspace) checkType(key string, typ DataStructure) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	value, err := ks.db.Get([]byte(key))
+	if err == _const.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	current, _, _, err := decodeHeader(value)
+	if err != nil {
+		return err
+	}
+	if current == typ {
+		return nil
+	}
+	if _, _, _, err := ks.lookup(key); err != _const.ErrKeyNotFound {
+		if err != nil {
+			return err
+		}
+		return ErrWrongType
+	}
+	batch := ks.newBatch()
+	if err := ks.deleteValue(batch, key); err != nil {
+		return err
+	}
+	return ks.commit(batch)
+}
+
+// deleteValue adds the deletes of the value of a key, and of the fields of a hash, to a batch
+func (ks *Keyspace) deleteValue(batch *engine.WriteBatch, key string) error {
+	value, err := ks.db.Get([]byte(key))
+	if err == _const.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(value) > 0 && value[0] == Hash {
+		for _, fieldKey := range ks.hashFieldKeys(key) {
+			_ = batch.Delete(fieldKey)
+		}
+	}
+	if len(value) > 0 && value[0] == Set {
+		for _, memberKey := range ks.setKeys(key) {
+			_ = batch.Delete(memberKey)
+		}
+	}
+	if len(value) > 0 && value[0] == ZSet {
+		for _, memberKey := range ks.zsetKeys(key) {
+			_ = batch.Delete(memberKey)
+		}
+	}
+	if len(value) > 0 && value[0] == List {
+		for _, chunkKey := range ks.listChunkKeys(key) {
+			_ = batch.Delete(chunkKey)
+		}
+	}
+	return batch.Delete([]byte(key))
+}
+
+// setExpire rewrites the header of the value of a key with another expiry time, the key must be locked
+func (ks *Keyspace) setExpire(key string, expire int64) error {
+	value, err := ks.db.Get([]byte(key))
+	if err != nil {
+		return err
+	}
+	typ, _, n, err := decodeHeader(value)
+	if err != nil {
+		return err
+	}
+	return ks.putValue([]byte(key), append(encodeHeader(typ, expire), value[n:]...))
+}
+
+// hashFieldKeys returns the keys of the fields of a hash, of every version
+func (ks *Keyspace) hashFieldKeys(key string) [][]byte {
+	it := ks.db.NewIterator(config.IteratorOptions{Prefix: hashFieldPrefix(key)})
+	defer it.Close()
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// keys returns the live keys of a type that match a pattern
+func (ks *Keyspace) keys(pattern string, typ DataStructure) ([]string, error) {
+	compile, err := regexp.Compile(convertToRegexp(pattern))
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, key := range ks.db.GetListKeys() {
+		if isInternalKey(key) || !compile.MatchString(string(key)) {
+			continue
+		}
+		current, _, _, err := ks.lookup(string(key))
+		if err != nil || current != typ {
+			continue
+		}
+		keys = append(keys, string(key))
+	}
+	return keys, nil
+}
+
+// encodeHeader returns the header of a value
+func encodeHeader(typ DataStructure, expire int64) []byte {
+	buf := make([]byte, 1+binary.MaxVarintLen64)
+	buf[0] = typ
+	n := binary.PutVarint(buf[1:], expire)
+	return buf[:1+n]
+}
+
+// decodeHeader returns the type and the expiry time of a value, and the size of its header
+func decodeHeader(value []byte) (DataStructure, int64, int, error) {
+	if len(value) < 2 {
+		return 0, 0, 0, ErrInvalidValue
+	}
+	expire, n := binary.Varint(value[1:])
+	if n <= 0 {
+		return 0, 0, 0, ErrInvalidValue
+	}
+	return value[0], expire, 1 + n, nil
+}
+
+// decodeValue returns the expiry time and the payload of a value of type typ
+func decodeValue(value []byte, typ DataStructure) (int64, []byte, error) {
+	current, expire, n, err := decodeHeader(value)
+	if err != nil {
+		return 0, nil, err
+	}
+	if current != typ {
+		return 0, nil, ErrWrongType
+	}
+	if isExpired(expire) {
+		return 0, nil, _const.ErrKeyIsExpired
+	}
+	return expire, value[n:], nil
+}
+
+// isExpired reports whether an expiry time has passed, 0 never expires
+func isExpired(expire int64) bool {
+	return expire != 0 && expire < time.Now().UnixNano()
+}
+
+// isEmptyValue reports whether the payload of a hash, list, set or zset holds no element
+func isEmptyValue(typ DataStructure, payload []byte) (bool, error) {
+	switch typ {
+	case Hash:
+		meta, err := decodeHashMetaPayload(payload)
+		if err != nil {
+			return false, err
+		}
+		return meta.counter == 0, nil
+	case List:
+		if !isLegacyList(payload) {
+			meta, err := decodeListMetaPayload(0, payload)
+			if err != nil {
+				return false, err
+			}
+			return meta.length == 0, nil
+		}
+		var lst list
+		if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&lst); err != nil {
+			return false, err
+		}
+		return lst.Length == 0, nil
+	case Set:
+		if !isLegacySet(payload) {
+			card, err := decodeSetMetaPayload(payload)
+			return card == 0, err
+		}
+		var fs FSets
+		if err := encoding.NewMessagePackDecoder(payload).Decode(&fs); err != nil {
+			return false, err
+		}
+		return len(fs) == 0, nil
+	case ZSet:
+		if !isLegacyZSet(payload) {
+			card, err := decodeZSetMetaPayload(payload)
+			return card == 0, err
+		}
+		var zSet FZSet
+		if err := zSet.FromBytes(payload); err != nil {
+			return false, err
+		}
+		return zSet.size == 0, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,230 @@
+package structure
+
+import (
+	"github.com/ByteStorage/FlyDB/config"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+func initKeyspace() *Keyspace {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "TestKeyspace")
+	opts.DirPath = dir
+	ks, _ := NewKeyspace(opts)
+	return ks
+}
+
+func TestKeyspace_Type(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+	list := NewListStructureWithKeyspace(ks)
+	set := NewSetStructureWithKeyspace(ks)
+	zset := NewZSetStructureWithKeyspace(ks)
+
+	assert.Nil(t, str.Set("string", "value", 0))
+	_, err := hash.HSet("hash", "field", "value")
+	assert.Nil(t, err)
+	assert.Nil(t, list.LPush("list", "value", 0))
+	assert.Nil(t, set.SAdd("set", "member", 0))
+	assert.Nil(t, zset.ZAdd("zset", 1, "member", ""))
+
+	for key, want := range map[string]string{
+		"string":  "string",
+		"hash":    "hash",
+		"list":    "list",
+		"set":     "set",
+		"zset":    "zset",
+		"missing": "none",
+	} {
+		typ, err := ks.Type(key)
+		assert.Nil(t, err)
+		assert.Equal(t, want, typ)
+	}
+
+	// An empty list does not exist
+	_, err = list.LPop("list")
+	assert.Nil(t, err)
+	typ, err := ks.Type("list")
+	assert.Nil(t, err)
+	assert.Equal(t, "none", typ)
+}
+
+func TestKeyspace_WrongType(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+	list := NewListStructureWithKeyspace(ks)
+	set := NewSetStructureWithKeyspace(ks)
+	zset := NewZSetStructureWithKeyspace(ks)
+
+	assert.Nil(t, str.Set("key", "value", 0))
+	_, err := hash.HSet("key", "field", "value")
+	assert.Equal(t, ErrWrongType, err)
+	assert.Equal(t, ErrWrongType, list.LPush("key", "value", 0))
+	assert.Equal(t, ErrWrongType, set.SAdd("key", "member", 0))
+	assert.ErrorIs(t, zset.ZAdd("key", 1, "member", ""), ErrWrongType)
+
+	assert.Nil(t, set.SAdd("set", "member", 0))
+	assert.Equal(t, ErrWrongType, str.Set("set", "value", 0))
+	_, err = str.Get("set")
+	assert.Equal(t, ErrWrongType, err)
+
+	// A key whose value expired can take another type
+	assert.Nil(t, str.Set("expired", "value", 1))
+	time.Sleep(1100 * time.Millisecond)
+	_, err = hash.HSet("expired", "field", "value")
+	assert.Nil(t, err)
+	value, err := hash.HGet("expired", "field")
+	assert.Nil(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestKeyspace_ReservedKeys(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+	list := NewListStructureWithKeyspace(ks)
+	set := NewSetStructureWithKeyspace(ks)
+	zset := NewZSetStructureWithKeyspace(ks)
+
+	_, err := hash.HSet("hash", "field", "value")
+	assert.Nil(t, err)
+	meta, err := hash.findHashMeta("hash", Hash)
+	assert.Nil(t, err)
+	fieldKey := string((&HashField{key: []byte("hash"), field: []byte("field"), version: meta.version}).encodeHashField())
+
+	// A key that looks like an internal key is rejected instead of overwriting it
+	assert.Equal(t, ErrReservedKey, str.Set(fieldKey, "other", 0))
+	assert.Equal(t, ErrReservedKey, str.Del(fieldKey))
+	_, err = str.Get(fieldKey)
+	assert.Equal(t, ErrReservedKey, err)
+	_, err = hash.HSet("\x00zs", "field", "value")
+	assert.Equal(t, ErrReservedKey, err)
+	assert.Equal(t, ErrReservedKey, list.LPush("\x00ls", "value", 0))
+	assert.Equal(t, ErrReservedKey, set.SAdd("\x00exp", "member", 0))
+	assert.ErrorIs(t, zset.ZAdd("\x00", 1, "member", ""), ErrReservedKey)
+	_, err = ks.Type(fieldKey)
+	assert.Equal(t, ErrReservedKey, err)
+
+	value, err := hash.HGet("hash", "field")
+	assert.Nil(t, err)
+	assert.Equal(t, "value", value)
+
+	// A key ending like the fields of a hash used to is an ordinary key
+	assert.Nil(t, str.Set("keynotk", "value", 0))
+	keys, err := str.Keys("*")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"keynotk"}, keys)
+	keys = scanAll(t, func(cursor string) (string, []string, error) {
+		return ks.Scan(cursor, "*", 10, "")
+	})
+	assert.Equal(t, []string{"hash", "keynotk"}, keys)
+}
+
+func TestKeyspace_DelExists(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+
+	assert.Nil(t, str.Set("string", "value", 0))
+	_, err := hash.HSet("hash", "field1", "value")
+	assert.Nil(t, err)
+	_, err = hash.HSet("hash", "field2", "value")
+	assert.Nil(t, err)
+
+	count, err := ks.Exists("string", "hash", "string", "missing")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	deleted, err := ks.Del("string", "hash", "missing")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, deleted)
+
+	count, err = ks.Exists("string", "hash")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	// The fields of the hash are deleted with it
+	assert.Empty(t, ks.hashFieldKeys("hash"))
+	_, err = ks.Del("")
+	assert.Equal(t, _const.ErrKeyIsEmpty, err)
+}
+
+func TestKeyspace_Rename(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+
+	_, err := hash.HSet("hash", "field", "value")
+	assert.Nil(t, err)
+	assert.Nil(t, str.Set("string", "value", 0))
+
+	// The hash replaces the string, with its fields
+	assert.Nil(t, ks.Rename("hash", "string"))
+	value, err := hash.HGet("string", "field")
+	assert.Nil(t, err)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, []string{"field"}, hash.GetFields("string"))
+
+	typ, err := ks.Type("hash")
+	assert.Nil(t, err)
+	assert.Equal(t, "none", typ)
+	assert.Empty(t, ks.hashFieldKeys("hash"))
+
+	assert.Equal(t, _const.ErrKeyNotFound, ks.Rename("missing", "other"))
+}
+
+func TestKeyspace_ExpireTTL(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	set := NewSetStructureWithKeyspace(ks)
+	zset := NewZSetStructureWithKeyspace(ks)
+
+	assert.Nil(t, str.Set("string", "value", 0))
+	assert.Nil(t, set.SAdd("set", "member", 0))
+	assert.Nil(t, zset.ZAdd("zset", 1, "member", ""))
+
+	ttl, err := ks.TTL("set")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), ttl)
+
+	assert.Nil(t, ks.Expire("set", 10))
+	ttl, err = ks.TTL("set")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), ttl)
+
+	// The expiry time of a sorted set survives a write
+	assert.Nil(t, ks.Expire("zset", 1))
+	assert.Nil(t, zset.ZAdd("zset", 2, "other", ""))
+	time.Sleep(1100 * time.Millisecond)
+	count, err := ks.Exists("zset")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	assert.Nil(t, ks.Persist("set"))
+	ttl, err = ks.TTL("set")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), ttl)
+
+	// A ttl that is not positive deletes the key
+	assert.Nil(t, ks.Expire("string", 0))
+	_, err = ks.TTL("string")
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+	assert.Equal(t, _const.ErrKeyNotFound, ks.Expire("missing", 10))
+}
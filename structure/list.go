@@ -5,23 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"regexp"
+	"strings"
 	"time"
 
-	"encoding/gob"
-
 	"github.com/ByteStorage/FlyDB/config"
-	"github.com/ByteStorage/FlyDB/db/engine"
-	_const "github.com/ByteStorage/FlyDB/lib/const"
 )
 
-// ListStructure Due to the complexity of each operation is at least O(n)
-// So we can directly use slice to implement the list at the bottom level
-// If the implementation of the db is improved later, we need to switch to a linked list
+// ListStructure stores every list in chunks of values under their own keys, see list_chunks.go
+// Pushes and pops at either end only write the chunk at that end,
+// and an index is found without reading the chunks before it
 type ListStructure struct {
-	db *engine.DB
+	ks *Keyspace
 }
 
+// listNode and list are how lists were stored before chunks, as one gob value, see migrateList
 type listNode struct {
 	Value interface{}
 	Next  *listNode
@@ -37,56 +34,36 @@ type list struct {
 // or the database cannot be created
 // The database will be created if it does not exist
 func NewListStructure(options config.Options) (*ListStructure, error) {
-	db, err := engine.NewDB(options)
+	ks, err := NewKeyspace(options)
 	if err != nil {
 		return nil, err
 	}
-	return &ListStructure{db: db}, nil
+	return NewListStructureWithKeyspace(ks), nil
+}
+
+// NewListStructureWithKeyspace returns a ListStructure that stores its lists in a shared keyspace
+func NewListStructureWithKeyspace(ks *Keyspace) *ListStructure {
+	return &ListStructure{ks: ks}
 }
 
 // LPush adds a value to the left of the list corresponding to the key
 // If the key does not exist, it will create the key
 func (l *ListStructure) LPush(key string, value interface{}, ttl int64) error {
-	// Get the list
-	lst, _, err := l.getListFromDB(key, true)
-	if err != nil {
-		return err
-	}
-	var expirationTime time.Duration
-	newNode := &listNode{
-		Value: value,
-		Next:  lst.Head,
-	}
-	lst.Head = newNode
-	lst.Length++
-	expirationTime = time.Duration(ttl) * time.Second
-	return l.setListToDB(key, lst, expirationTime)
+	return l.push(key, ttl, ListLeft, value)
 }
 
+// LPushs adds values to the left of the list corresponding to the key, the first value ends up first
+// If the key does not exist, it will create the key
 func (l *ListStructure) LPushs(key string, ttl int64, values ...interface{}) error {
 	// Check if values are valid
 	if len(values) == 0 {
 		return ErrInvalidArgs
 	}
-
-	// Get the list
-	lst, _, err := l.getListFromDB(key, true)
-	if err != nil {
-		return err
-	}
-	var expirationTime time.Duration
-	for i := len(values) - 1; i >= 0; i-- {
-		newNode := &listNode{
-			Value: values[i],
-			Next:  lst.Head,
-		}
-		lst.Head = newNode
-		lst.Length++
+	reversed := make([]interface{}, len(values))
+	for i, value := range values {
+		reversed[len(values)-1-i] = value
 	}
-	expirationTime = time.Duration(ttl) * time.Second
-
-	// Store to db
-	return l.setListToDB(key, lst, expirationTime)
+	return l.push(key, ttl, ListLeft, reversed...)
 }
 
 // RPush adds a value to the right of the list corresponding to the key
@@ -96,32 +73,7 @@ func (l *ListStructure) RPush(key string, value interface{}, ttl int64) error {
 	if value == nil {
 		return ErrInvalidValue
 	}
-
-	// Get the list
-	lst, _, err := l.getListFromDB(key, true)
-	if err != nil {
-		return err
-	}
-	var expirationTime time.Duration
-	// Append the new data to the end
-	newNode := &listNode{
-		Value: value,
-		Next:  nil,
-	}
-	if lst.Length == 0 {
-		lst.Head = newNode
-	} else {
-		// Find the last node
-		lastNode := lst.Head
-		for lastNode.Next != nil {
-			lastNode = lastNode.Next
-		}
-		lastNode.Next = newNode
-	}
-	lst.Length++
-	expirationTime = time.Duration(ttl) * time.Second
-	// Store to db
-	return l.setListToDB(key, lst, expirationTime)
+	return l.push(key, ttl, ListRight, value)
 }
 
 // RPushs appends one or more values to the right side of a list associated with a key.
@@ -131,94 +83,64 @@ func (l *ListStructure) RPushs(key string, ttl int64, values ...interface{}) err
 	if len(values) == 0 {
 		return ErrInvalidArgs
 	}
+	return l.push(key, ttl, ListRight, values...)
+}
 
-	// Get the list
-	lst, _, err := l.getListFromDB(key, true)
-	if err != nil {
+// push adds values one by one to an end of a list, and makes the list expire ttl seconds from now,
+// never if ttl is 0. The pops blocked on the list are woken once the key is unlocked.
+func (l *ListStructure) push(key string, ttl int64, end ListEnd, values ...interface{}) error {
+	if err := l.pushLocked(key, ttl, end, values...); err != nil {
 		return err
 	}
-	var expirationTime time.Duration
-	// Find the last node
-	var lastNode *listNode
-	if lst.Length == 0 {
-		lastNode = nil
-	} else {
-		lastNode = lst.Head
-		for lastNode.Next != nil {
-			lastNode = lastNode.Next
-		}
-	}
+	l.ks.wakeListWaiter(key)
+	return nil
+}
 
+// pushLocked is push holding the lock of the key, without waking the blocked pops
+func (l *ListStructure) pushLocked(key string, ttl int64, end ListEnd, values ...interface{}) error {
+	defer l.ks.lockKeys(key)()
+	batch := l.ks.newBatch()
+	meta, err := l.getOrCreateListMeta(batch, key)
+	if err != nil {
+		return err
+	}
+	c := l.newListChunks(key, meta)
 	for _, value := range values {
-		newNode := &listNode{
-			Value: value,
-			Next:  nil,
-		}
-		if lastNode == nil {
-			lst.Head = newNode
-		} else {
-			lastNode.Next = newNode
+		if err := c.push(end, value); err != nil {
+			return err
 		}
-		lastNode = newNode
-		lst.Length++
 	}
-	expirationTime = time.Duration(ttl) * time.Second
-	// Store to db
-	return l.setListToDB(key, lst, expirationTime)
+	meta.expire = expireAfter(ttl)
+	return c.commit(batch)
 }
 
 // LPop returns and removes the leftmost value of a list associated with a key.
 // If the key does not exist, an error is returned.
 // If the list is empty, an error is returned.
 func (l *ListStructure) LPop(key string) (interface{}, error) {
-	// Get the list
-	lst, _, err := l.getListFromDB(key, false)
-	if err != nil {
-		return nil, err
-	}
-	// Return error if the list is empty
-	if lst.Length == 0 {
-		return nil, ErrListEmpty
-	}
-
-	popValue := lst.Head.Value
-	lst.Head = lst.Head.Next
-	lst.Length--
-
-	// Store in the database
-	return popValue, l.setListToDB(key, lst, 0)
+	return l.pop(key, ListLeft)
 }
 
 // RPop returns and removes the rightmost value of a list associated with a key.
 // If the key does not exist, an error is returned.
 // If the list is empty, an error is returned.
 func (l *ListStructure) RPop(key string) (interface{}, error) {
-	// Get the list
-	lst, _, err := l.getListFromDB(key, false)
+	return l.pop(key, ListRight)
+}
+
+// pop removes and returns the value at an end of a list, only the chunk holding it is written
+func (l *ListStructure) pop(key string, end ListEnd) (interface{}, error) {
+	defer l.ks.lockKeys(key)()
+	meta, err := l.getListMeta(key)
 	if err != nil {
 		return nil, err
 	}
-	// Return error if the list is empty
-	if lst.Length == 0 {
-		return nil, ErrListEmpty
-	} else if lst.Length == 1 {
-		popValue := lst.Head.Value
-		lst.Head = nil
-		lst.Length = 0
-		return popValue, l.setListToDB(key, lst, 0)
-	}
-
-	// Find the new tail
-	newTail := lst.Head
-	for i := 0; i < lst.Length-2; i++ {
-		newTail = newTail.Next
+	c := l.newListChunks(key, meta)
+	value, err := c.pop(end)
+	if err != nil {
+		return nil, err
 	}
-	popValue := newTail.Next.Value
-	newTail.Next = nil
-	lst.Length--
-
-	// Store in the database
-	return popValue, l.setListToDB(key, lst, 0)
+	return value, c.commit(l.ks.newBatch())
 }
 
 // LRange returns a range of elements from a list associated with a key.
@@ -227,51 +149,43 @@ func (l *ListStructure) RPop(key string) (interface{}, error) {
 // If the list is empty, an error is returned.
 // Negative indices can be used, where -1 represents the last element of the list,
 // -2 represents the second last element, and so on.
+// Only the chunks holding the range are read.
 func (l *ListStructure) LRange(key string, start int, stop int) ([]interface{}, error) {
+	defer l.ks.lockKeys(key)()
 	// Get the list
-	lst, _, err := l.getListFromDB(key, false)
+	meta, err := l.getListMeta(key)
 	if err != nil {
 		return nil, err
 	}
 
 	// Return error if the list is empty
-	if lst.Length == 0 {
+	if meta.length == 0 {
 		return nil, ErrListEmpty
 	}
 
 	// Calculate the correct indices
-	start = (start%lst.Length + lst.Length) % lst.Length
-	stop = (stop%lst.Length + lst.Length) % lst.Length
+	start = (start%meta.length + meta.length) % meta.length
+	stop = (stop%meta.length + meta.length) % meta.length
 
 	// Return empty if the range length is less than 1
 	if stop < start {
 		return nil, nil
 	}
 
-	nowNode := lst.Head
-
-	for i := 0; i < start; i++ {
-		nowNode = nowNode.Next
-	}
-	result := make([]interface{}, 0, stop-start+1)
-	for i := start; i <= stop; i++ {
-		result = append(result, nowNode.Value)
-		nowNode = nowNode.Next
-	}
-
-	return result, nil
+	return l.newListChunks(key, meta).rangeValues(start, stop)
 }
 
 // LLen returns the size of a list associated with a key.
 // If the key does not exist, an error is returned.
 func (l *ListStructure) LLen(key string) (int, error) {
+	defer l.ks.lockKeys(key)()
 	// Get the list
-	lst, _, err := l.getListFromDB(key, false)
+	meta, err := l.getListMeta(key)
 	if err != nil {
 		return 0, err
 	}
 
-	return lst.Length, nil
+	return meta.length, nil
 }
 
 // LRem removes elements from a list associated with a key based on the count and value parameters.
@@ -281,77 +195,87 @@ func (l *ListStructure) LLen(key string) (int, error) {
 // count = 0: Remove all occurrences of the value from the list.
 // If the key does not exist, an error is returned.
 func (l *ListStructure) LRem(key string, count int, value interface{}) error {
+	defer l.ks.lockKeys(key)()
 	// Get the list
-	lst, _, err := l.getListFromDB(key, false)
+	meta, err := l.getListMeta(key)
+	if err != nil || meta.length == 0 {
+		return err
+	}
+	c := l.newListChunks(key, meta)
+	values, err := c.rangeValues(0, meta.length-1)
 	if err != nil {
 		return err
 	}
-	var expirationTime time.Duration
-	// Process different counts
-	if count != 0 {
-		prev, curr := lst.Head, lst.Head
-		for i := 0; (count > 0 && i < count || count < 0 && lst.Length+i > -count) && curr != nil; {
-			if l.valueEqual(curr.Value, value) {
-				if curr == lst.Head {
-					lst.Head = curr.Next
-				} else {
-					prev.Next = curr.Next
-				}
-				i++
-				lst.Length--
-			} else {
-				prev = curr
-			}
-			curr = curr.Next
+
+	// Mark the values to remove
+	limit := count
+	if limit < 0 {
+		limit = -count
+	}
+	removed := make([]bool, len(values))
+	first, n := len(values), 0
+	for i := range values {
+		if limit != 0 && n == limit {
+			break
 		}
-	} else {
-		prev, curr := lst.Head, lst.Head
-		for curr != nil {
-			if l.valueEqual(curr.Value, value) {
-				if curr == lst.Head {
-					lst.Head = curr.Next
-				} else {
-					prev.Next = curr.Next
-				}
-				lst.Length--
-			} else {
-				prev = curr
+		index := i
+		if count < 0 {
+			index = len(values) - 1 - i
+		}
+		if l.valueEqual(values[index], value) {
+			removed[index] = true
+			n++
+			if index < first {
+				first = index
 			}
-			curr = curr.Next
 		}
 	}
+	if n == 0 {
+		return nil
+	}
 
-	// Store to db
-	return l.setListToDB(key, lst, expirationTime)
+	// Rewrite the chunks from the one holding the first removed value
+	seq, _ := meta.locate(first)
+	kept := make([]interface{}, 0, len(values)-n)
+	for i := meta.chunkStart(seq); i < len(values); i++ {
+		if !removed[i] {
+			kept = append(kept, values[i])
+		}
+	}
+	c.rewrite(seq, kept)
+	return c.commit(l.ks.newBatch())
 }
 
 // LSet sets the value of an element in a list associated with a key based on the index.
 // If the index is out of range, an error is returned.
 // If the list is empty, an error is returned.
+// The list expires ttl seconds from now if ttl is positive, otherwise its expiration is kept.
 func (l *ListStructure) LSet(key string, index int, value interface{}, ttl int64) error {
+	defer l.ks.lockKeys(key)()
 	// Get the list
-	lst, _, err := l.getListFromDB(key, false)
+	meta, err := l.getListMeta(key)
 	if err != nil {
 		return err
 	}
-	var expirationTime time.Duration
 	// Check if the index is out of range
-	if index < 0 || index >= lst.Length {
+	if index < 0 || index >= meta.length {
 		return ErrIndexOutOfRange
 	}
 
-	nowNode := lst.Head
-
-	for i := 0; i < index; i++ {
-		nowNode = nowNode.Next
+	c := l.newListChunks(key, meta)
+	seq, offset := meta.locate(index)
+	values, err := c.get(seq)
+	if err != nil {
+		return err
 	}
-
-	nowNode.Value = value
+	values = append([]interface{}(nil), values...)
+	values[offset] = value
+	c.set(seq, values)
 	if ttl > 0 {
-		expirationTime = time.Duration(ttl) * time.Second
+		meta.expire = expireAfter(ttl)
 	}
 	// Store in the database
-	return l.setListToDB(key, lst, expirationTime)
+	return c.commit(l.ks.newBatch())
 }
 
 // LTrim retains a range of elements in a list associated with a key.
@@ -361,47 +285,55 @@ func (l *ListStructure) LSet(key string, index int, value interface{}, ttl int64
 // Negative indices can be used, where -1 represents the last element of the list,
 // -2 represents the second last element, and so on.
 func (l *ListStructure) LTrim(key string, start int, stop int) error {
+	defer l.ks.lockKeys(key)()
 	// Get the list
-	lst, _, err := l.getListFromDB(key, false)
+	meta, err := l.getListMeta(key)
 	if err != nil {
 		return err
 	}
-	if lst.Length == 0 {
+	if meta.length == 0 {
 		return ErrListEmpty
 	}
 
 	// Calculate the correct indices
-	start = (start%lst.Length + lst.Length) % lst.Length
-	stop = (stop%lst.Length + lst.Length) % lst.Length
+	start = (start%meta.length + meta.length) % meta.length
+	stop = (stop%meta.length + meta.length) % meta.length
 
+	c := l.newListChunks(key, meta)
 	if start > stop {
-		lst = &list{
-			Head:   nil,
-			Length: 0,
-		}
-	} else {
-		// Find the new head
-		newHead := lst.Head
-		for i := 0; i < start; i++ {
-			newHead = newHead.Next
-		}
+		c.reset()
+		return c.commit(l.ks.newBatch())
+	}
 
-		// Find the new tail
-		newTail := newHead
-		for i := start; i < stop; i++ {
-			newTail = newTail.Next
+	// Delete the chunks out of the range, and cut the ones at its ends
+	startSeq, startOffset := meta.locate(start)
+	stopSeq, stopOffset := meta.locate(stop)
+	for seq := meta.head; seq < startSeq; seq++ {
+		c.set(seq, nil)
+	}
+	for seq := stopSeq + 1; seq <= meta.tail; seq++ {
+		c.set(seq, nil)
+	}
+	first, err := c.get(startSeq)
+	if err != nil {
+		return err
+	}
+	if startSeq == stopSeq {
+		c.set(startSeq, first[startOffset:stopOffset+1])
+	} else {
+		last, err := c.get(stopSeq)
+		if err != nil {
+			return err
 		}
-
-		// Disconnect the new tail from the rest of the list
-		newTail.Next = nil
-
-		// Update the list
-		lst.Head = newHead
-		lst.Length = stop - start + 1
+		c.set(startSeq, first[startOffset:])
+		c.set(stopSeq, last[:stopOffset+1])
 	}
+	meta.head, meta.tail = startSeq, stopSeq
+	meta.length = stop - start + 1
+	meta.headSize = len(c.values[startSeq])
 
 	// Store in the database
-	return l.setListToDB(key, lst, 0)
+	return c.commit(l.ks.newBatch())
 }
 
 // LIndex returns the value of an element in a list associated with a key based on the index.
@@ -410,116 +342,197 @@ func (l *ListStructure) LTrim(key string, start int, stop int) error {
 // Negative indices can be used, where -1 represents the last element of the list,
 // -2 represents the second last element, and so on.
 func (l *ListStructure) LIndex(key string, index int) (interface{}, error) {
+	defer l.ks.lockKeys(key)()
 	// Get the list
-	lst, _, err := l.getListFromDB(key, false)
+	meta, err := l.getListMeta(key)
 	if err != nil {
 		return nil, err
 	}
 
 	// Return error if the list is empty
-	if lst.Length == 0 {
+	if meta.length == 0 {
 		return nil, ErrListEmpty
 	}
 
 	// Calculate the correct index
-	index = (index%lst.Length + lst.Length) % lst.Length
-
-	nowNode := lst.Head
+	index = (index%meta.length + meta.length) % meta.length
 
-	for i := 0; i < index; i++ {
-		nowNode = nowNode.Next
+	seq, offset := meta.locate(index)
+	values, err := l.newListChunks(key, meta).get(seq)
+	if err != nil {
+		return nil, err
 	}
+	return values[offset], nil
+}
 
-	return nowNode.Value, nil
+// LInsert inserts a value before or after the first occurrence of pivot in a list associated with a key,
+// and returns the length of the list, or -1 if pivot is not in the list.
+// If the key does not exist, an error is returned.
+func (l *ListStructure) LInsert(key string, before bool, pivot interface{}, value interface{}) (int, error) {
+	defer l.ks.lockKeys(key)()
+	meta, err := l.getListMeta(key)
+	if err != nil {
+		return 0, err
+	}
+	c := l.newListChunks(key, meta)
+	for seq := meta.head; meta.length > 0 && seq <= meta.tail; seq++ {
+		values, err := c.get(seq)
+		if err != nil {
+			return 0, err
+		}
+		for offset, v := range values {
+			if !l.valueEqual(v, pivot) {
+				continue
+			}
+			if !before {
+				offset++
+			}
+			if err := c.insert(seq, offset, value); err != nil {
+				return 0, err
+			}
+			return meta.length, c.commit(l.ks.newBatch())
+		}
+	}
+	return -1, nil
 }
 
-// Keys returns all the keys of the list structure.
-func (l *ListStructure) Keys(regx string) ([]string, error) {
-	toRegexp := convertToRegexp(regx)
-	compile, err := regexp.Compile(toRegexp)
+// LPos returns the indexes of the occurrences of a value in a list associated with a key.
+// rank is the occurrence to start from, 1 for the first one, and negative ranks search from the end of the list:
+// -1 is the last occurrence. At most count indexes are returned, all of them if count is 0.
+// Only the first maxLen values searched are compared, all of them if maxLen is 0.
+// If the key does not exist, an error is returned.
+func (l *ListStructure) LPos(key string, value interface{}, rank, count, maxLen int) ([]int, error) {
+	if rank == 0 || count < 0 || maxLen < 0 {
+		return nil, ErrInvalidArgs
+	}
+	defer l.ks.lockKeys(key)()
+	meta, err := l.getListMeta(key)
 	if err != nil {
 		return nil, err
 	}
-	var keys []string
-	byteKeys := l.db.GetListKeys()
-	for _, key := range byteKeys {
-		// match prefix and key
-		if compile.MatchString(string(key)) {
-			// check if deleted
-			db, _, err := l.getListFromDB(string(key), true)
-			if err != nil {
-				continue
+	if maxLen == 0 || maxLen > meta.length {
+		maxLen = meta.length
+	}
+	reverse := rank < 0
+	if reverse {
+		rank = -rank
+	}
+
+	c := l.newListChunks(key, meta)
+	result := make([]int, 0)
+	for searched := 0; searched < maxLen; {
+		index := searched
+		if reverse {
+			index = meta.length - 1 - searched
+		}
+		seq, offset := meta.locate(index)
+		values, err := c.get(seq)
+		if err != nil {
+			return nil, err
+		}
+		// Compare the values of the chunk in the direction of the search
+		for ; offset >= 0 && offset < len(values) && searched < maxLen; searched++ {
+			if l.valueEqual(values[offset], value) {
+				if rank--; rank <= 0 {
+					result = append(result, meta.chunkStart(seq)+offset)
+					if len(result) == count {
+						return result, nil
+					}
+				}
 			}
-			if db.Length == 0 {
-				continue
+			if reverse {
+				offset--
+			} else {
+				offset++
 			}
-			keys = append(keys, string(key))
 		}
 	}
-	return keys, nil
+	return result, nil
 }
 
-// RPOPLPUSH removes the last element from one list and pushes it to another list.
+// LMove removes the value at one end of the source list and pushes it to an end of the destination list,
+// in one write, and returns it. source and destination may be the same list, which rotates it.
+// If the source key does not exist, an error is returned.
 // If the source list is empty, an error is returned.
-// If the destination list is empty, it is created.
-// Atomicity is not guaranteed.
-func (l *ListStructure) RPOPLPUSH(source string, destination string, ttl int64) error {
-	// Get the source list
-	lst1, _, err := l.getListFromDB(source, false)
+// If the destination list does not exist, it is created.
+func (l *ListStructure) LMove(source, destination string, from, to ListEnd) (interface{}, error) {
+	value, err := l.move(source, destination, from, to, 0)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	l.ks.wakeListWaiter(destination)
+	return value, nil
+}
 
-	// Get the destination list
-	lst2, _, err := l.getListFromDB(destination, true)
+// move is LMove, making both lists expire ttl seconds from now if ttl is positive.
+// It does not wake the pops blocked on destination.
+func (l *ListStructure) move(source, destination string, from, to ListEnd, ttl int64) (interface{}, error) {
+	defer l.ks.lockKeys(source, destination)()
+	meta, err := l.getListMeta(source)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	var expirationTime time.Duration
-	// Return error if the source list is empty
-	if lst1.Length == 0 {
-		return ErrListEmpty
+	src := l.newListChunks(source, meta)
+	batch := l.ks.newBatch()
+	dst := src
+	if destination != source {
+		dstMeta, err := l.getOrCreateListMeta(batch, destination)
+		if err != nil {
+			return nil, err
+		}
+		dst = l.newListChunks(destination, dstMeta)
 	}
 
-	// Find the last node of the source list
-	lastNode := lst1.Head
-	prevNode := lst1.Head
-	for lastNode.Next != nil {
-		prevNode = lastNode
-		lastNode = lastNode.Next
+	value, err := src.pop(from)
+	if err != nil {
+		return nil, err
 	}
-
-	// Remove the last node from the source list
-	if lst1.Length == 1 {
-		lst1.Head = nil
-	} else {
-		prevNode.Next = nil
+	if err := dst.push(to, value); err != nil {
+		return nil, err
 	}
-	lst1.Length--
-
-	// Add the last node to the head of the destination list
-	lastNode.Next = lst2.Head
-	lst2.Head = lastNode
-	lst2.Length++
 	if ttl > 0 {
-		expirationTime = time.Duration(ttl) * time.Second
+		src.meta.expire = expireAfter(ttl)
+		dst.meta.expire = src.meta.expire
 	}
-	// Store in the database
-	err = l.setListToDB(source, lst1, expirationTime)
-	if err != nil {
+	if dst != src {
+		if err := src.write(batch); err != nil {
+			return nil, err
+		}
+	}
+	return value, dst.commit(batch)
+}
+
+// Keys returns all the keys of the list structure.
+func (l *ListStructure) Keys(regx string) ([]string, error) {
+	return l.ks.keys(regx, List)
+}
+
+// Scan returns a page of the keys holding a list that match a pattern, see Keyspace.Scan
+func (l *ListStructure) Scan(cursor, match string, count int) (string, []string, error) {
+	return l.ks.Scan(cursor, match, count, typeNames[List])
+}
+
+// RPOPLPUSH removes the last element from one list and pushes it to another list.
+// If the source list is empty, an error is returned.
+// If the destination list is empty, it is created.
+// Both lists are written at once.
+func (l *ListStructure) RPOPLPUSH(source string, destination string, ttl int64) error {
+	if _, err := l.move(source, destination, ListRight, ListLeft, ttl); err != nil {
 		return err
 	}
-	return l.setListToDB(destination, lst2, expirationTime)
+	l.ks.wakeListWaiter(destination)
+	return nil
 }
 
 func (l *ListStructure) TTL(k string) (int64, error) {
-	_, expire, err := l.getListFromDB(k, false)
+	defer l.ks.lockKeys(k)()
+	meta, err := l.getListMeta(k)
 	if err != nil {
 		return -1, err
 	}
 
 	now := time.Now().UnixNano() / int64(time.Second)
-	expire = expire / int64(time.Second)
+	expire := meta.expire / int64(time.Second)
 
 	remainingTTL := expire - now
 
@@ -531,6 +544,33 @@ func (l *ListStructure) TTL(k string) (int64, error) {
 	return remainingTTL, nil
 }
 
+// ListEnd is one of the two ends of a list
+type ListEnd int
+
+const (
+	ListLeft ListEnd = iota
+	ListRight
+)
+
+// ParseListEnd parses LEFT or RIGHT, in any case
+func ParseListEnd(s string) (ListEnd, error) {
+	switch strings.ToUpper(s) {
+	case "LEFT":
+		return ListLeft, nil
+	case "RIGHT":
+		return ListRight, nil
+	}
+	return 0, ErrInvalidArgs
+}
+
+// expireAfter returns the expiration time of a value living ttl seconds from now, 0 if ttl is 0
+func expireAfter(ttl int64) int64 {
+	if ttl == 0 {
+		return 0
+	}
+	return time.Now().Add(time.Duration(ttl) * time.Second).UnixNano()
+}
+
 var (
 	// ErrListEmpty is returned if the list is empty.
 	ErrListEmpty = errors.New("Wrong operation: list is empty")
@@ -570,166 +610,8 @@ func (l *ListStructure) valueEqual(value1 interface{}, value2 interface{}) bool
 	return false
 }
 
-// getListFromDB retrieves data from the database. When isKeyCanNotExist is true, it returns an empty slice if the key doesn't exist instead of an error.
-func (l *ListStructure) getListFromDB(key string, isKeyCanNotExist bool) (*list, int64, error) {
-	if isKeyCanNotExist {
-		// Get data corresponding to the key from the database
-		dbData, err := l.db.Get([]byte(key))
-
-		// Since the key might not exist, we need to handle ErrKeyNotFound separately as it is a valid case
-		if err != nil && err != _const.ErrKeyNotFound {
-			return nil, 0, err
-		}
-
-		// Deserialize the data into a DecodedList
-		decodedList, err := l.decodeList(dbData)
-		if err != nil {
-			if len(dbData) != 0 {
-				return nil, 0, err
-			} else {
-				decodedList = &DecodedList{List: &list{nil, 0}, Expiration: 0}
-			}
-		}
-		return decodedList.List, decodedList.Expiration, nil
-	} else {
-		// Get data corresponding to the key from the database
-		dbData, err := l.db.Get([]byte(key))
-		if err != nil {
-			return nil, 0, err
-		}
-
-		// Deserialize the data into a DecodedList
-		decodedList, err := l.decodeList(dbData)
-		if err != nil {
-			return nil, 0, err
-		}
-
-		return decodedList.List, decodedList.Expiration, nil
-	}
-}
-
-// setListToDB stores the data into the database.
-func (l *ListStructure) setListToDB(key string, lst *list, ttl time.Duration) error {
-	// Serialize into binary array
-	encValue, err := l.encodeList(lst, ttl)
-	if err != nil {
-		return err
-	}
-	// Store in the database
-	return l.db.Put([]byte(key), encValue)
-}
-
-// encodeList encodes the value
-// format: [type][data]
-type ExpiredItem struct {
-	Data       []byte
-	Expiration int64
-}
-
-func (l *ListStructure) encodeList(lst *list, ttl time.Duration) ([]byte, error) {
-	// Register the list type for gob
-	gob.Register(&list{})
-
-	// Create a bytes.Buffer and a new gob.Encoder for the list's data
-	dataBuffer := new(bytes.Buffer)
-	dataEnc := gob.NewEncoder(dataBuffer)
-
-	// Encode the list's data
-	err := dataEnc.Encode(lst)
-	if err != nil {
-		return nil, err
-	}
-	var expire int64 = 0
-
-	// Calculate the expiration time by adding ttl to the current time,
-	// convert it to nanoseconds, and store it in the expire variable.
-	if ttl != 0 {
-		expire = time.Now().Add(ttl).UnixNano()
-	}
-	// Create an ExpiredItem with the encoded data and expiration time
-	expiredItem := ExpiredItem{
-		Data:       dataBuffer.Bytes(),
-		Expiration: expire, // Set expiration time
-	}
-
-	// Create a bytes.Buffer and a new gob.Encoder for the ExpiredItem
-	itemBuffer := new(bytes.Buffer)
-	itemEnc := gob.NewEncoder(itemBuffer)
-
-	// Encode the ExpiredItem
-	err = itemEnc.Encode(&expiredItem)
-	if err != nil {
-		return nil, err
-	}
-
-	// Return the encoded bytes, prefixed with the type
-	return append([]byte{List}, itemBuffer.Bytes()...), nil
-}
-
-type DecodedList struct {
-	List       *list
-	Expiration int64
-}
-
-// decodeList decodes the value
-func (l *ListStructure) decodeList(value []byte) (*DecodedList, error) {
-	// Check the length of the value
-	if len(value) < 1 {
-		return nil, ErrInvalidValue
-	}
-
-	// Check the type of the value
-	if value[0] != List {
-		return nil, ErrInvalidType
-	}
-
-	// Create a bytes.Buffer from the value (excluding the first byte)
-	buffer := bytes.NewBuffer(value[1:])
-
-	// Create a new gob.Decoder
-	dec := gob.NewDecoder(buffer)
-
-	// Create a new ExpiredItem to hold the decoded value and expiration time
-	var expiredItem ExpiredItem
-
-	// Decode the ExpiredItem
-	err := dec.Decode(&expiredItem)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create a bytes.Buffer from the ExpiredItem's data
-	dataBuffer := bytes.NewBuffer(expiredItem.Data)
-
-	// Create a new gob.Decoder for the data
-	dataDec := gob.NewDecoder(dataBuffer)
-
-	// Create a new list to hold the decoded data
-	var lst list
-
-	// Decode the data into the list
-	err = dataDec.Decode(&lst)
-	if err != nil {
-		return nil, err
-	}
-
-	expiration := expiredItem.Expiration
-
-	if expiration != 0 && expiration < time.Now().UnixNano() {
-		return nil, _const.ErrKeyIsExpired
-	}
-
-	decodedList := &DecodedList{
-		List:       &lst,
-		Expiration: expiration, // Calculate remaining time
-	}
-
-	return decodedList, nil
-}
-
 func (s *ListStructure) Stop() error {
-	err := s.db.Close()
-	return err
+	return s.ks.Close()
 }
 
 func (l *ListStructure) Size(key string) (string, error) {
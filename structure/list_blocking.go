@@ -0,0 +1,181 @@
+package structure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+)
+
+// ErrListTimeout is returned if a blocking pop found no value before its timeout
+var ErrListTimeout = errors.New("Wrong operation: timed out waiting for a list")
+
+// listWaiter is a blocking pop waiting for values to be pushed to its lists.
+// It is queued on each of its lists, and only the waiter at the front of the queue
+// of a list takes values from it, so that the waiters are served in order of arrival.
+type listWaiter struct {
+	keys  []string
+	ready chan struct{}
+}
+
+// BLPop removes and returns the leftmost value of the first non-empty list of keys, and the key of that list.
+// If all the lists are empty or do not exist, it waits for a push to one of them, at most timeout,
+// forever if timeout is 0, and returns ErrListTimeout when it is over.
+// The pops waiting on a list are served in the order they arrived.
+// It returns the error of ctx if ctx is done first.
+func (l *ListStructure) BLPop(ctx context.Context, timeout time.Duration, keys ...string) (string, interface{}, error) {
+	return l.blockingPop(ctx, timeout, ListLeft, keys)
+}
+
+// BRPop removes and returns the rightmost value of the first non-empty list of keys, and the key of that list.
+// It waits like BLPop.
+func (l *ListStructure) BRPop(ctx context.Context, timeout time.Duration, keys ...string) (string, interface{}, error) {
+	return l.blockingPop(ctx, timeout, ListRight, keys)
+}
+
+// BLMove is LMove waiting like BLPop if the source list is empty or does not exist
+func (l *ListStructure) BLMove(ctx context.Context, source, destination string, from, to ListEnd, timeout time.Duration) (interface{}, error) {
+	var value interface{}
+	err := l.block(ctx, []string{source}, timeout, func(string) (bool, error) {
+		v, err := l.move(source, destination, from, to, 0)
+		if isNoListValue(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		value = v
+		l.ks.wakeListWaiterLocked(destination)
+		return true, nil
+	})
+	return value, err
+}
+
+func (l *ListStructure) blockingPop(ctx context.Context, timeout time.Duration, end ListEnd, keys []string) (string, interface{}, error) {
+	var (
+		popped string
+		value  interface{}
+	)
+	err := l.block(ctx, keys, timeout, func(key string) (bool, error) {
+		v, err := l.pop(key, end)
+		if isNoListValue(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		popped, value = key, v
+		return true, nil
+	})
+	return popped, value, err
+}
+
+// isNoListValue reports whether err is returned by a pop from a list that is empty or does not exist
+func isNoListValue(err error) bool {
+	return errors.Is(err, ErrListEmpty) || errors.Is(err, _const.ErrKeyNotFound) || errors.Is(err, _const.ErrKeyIsExpired)
+}
+
+// block calls take with the keys until it takes a value, and waits for pushes to the lists of keys in between.
+// take reports whether it took a value from the list of a key, it is called with the block lock held
+// and locks the keys it writes itself. The block lock is always taken before the lock of a key, never after,
+// which is why a push wakes the waiters once it unlocked its key.
+func (l *ListStructure) block(ctx context.Context, keys []string, timeout time.Duration, take func(key string) (bool, error)) error {
+	if len(keys) == 0 || timeout < 0 {
+		return ErrInvalidArgs
+	}
+	for _, key := range keys {
+		if err := checkKey(key); err != nil {
+			return err
+		}
+	}
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	ks := l.ks
+	w := &listWaiter{keys: keys, ready: make(chan struct{}, 1)}
+	ks.blockLock.Lock()
+	taken, err := ks.serveListWaiter(w, take)
+	if taken || err != nil {
+		ks.blockLock.Unlock()
+		return err
+	}
+	for _, key := range keys {
+		ks.blocked[key] = append(ks.blocked[key], w)
+	}
+	ks.blockLock.Unlock()
+	defer ks.removeListWaiter(w)
+
+	for {
+		select {
+		case <-w.ready:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-expired:
+			return ErrListTimeout
+		}
+		ks.blockLock.Lock()
+		taken, err := ks.serveListWaiter(w, take)
+		ks.blockLock.Unlock()
+		if taken || err != nil {
+			return err
+		}
+	}
+}
+
+// serveListWaiter calls take with the keys of w in order, skipping the lists other waiters are ahead on,
+// until it takes a value. The block lock must be held.
+func (ks *Keyspace) serveListWaiter(w *listWaiter, take func(key string) (bool, error)) (bool, error) {
+	for _, key := range w.keys {
+		if queue := ks.blocked[key]; len(queue) > 0 && queue[0] != w {
+			continue
+		}
+		if taken, err := take(key); taken || err != nil {
+			return taken, err
+		}
+	}
+	return false, nil
+}
+
+// removeListWaiter removes w from the queues of its lists, and wakes the waiters now at their front
+// in case values are left for them
+func (ks *Keyspace) removeListWaiter(w *listWaiter) {
+	ks.blockLock.Lock()
+	defer ks.blockLock.Unlock()
+	for _, key := range w.keys {
+		queue := ks.blocked[key]
+		for i := range queue {
+			if queue[i] == w {
+				queue = append(queue[:i:i], queue[i+1:]...)
+				break
+			}
+		}
+		if len(queue) == 0 {
+			delete(ks.blocked, key)
+			continue
+		}
+		ks.blocked[key] = queue
+		ks.wakeListWaiterLocked(key)
+	}
+}
+
+// wakeListWaiter wakes the first waiter blocked on the list of key, after a push to it
+func (ks *Keyspace) wakeListWaiter(key string) {
+	ks.blockLock.Lock()
+	defer ks.blockLock.Unlock()
+	ks.wakeListWaiterLocked(key)
+}
+
+// wakeListWaiterLocked is wakeListWaiter with the block lock held
+func (ks *Keyspace) wakeListWaiterLocked(key string) {
+	if queue := ks.blocked[key]; len(queue) > 0 {
+		select {
+		case queue[0].ready <- struct{}{}:
+		default:
+		}
+	}
+}
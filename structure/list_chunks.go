@@ -0,0 +1,560 @@
+package structure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+)
+
+// A list is stored in chunks of up to listChunkSize values. The value of its key is the metadata:
+// +----------+------------+------------+------------+------------+------------+-------------+
+// |   type   |   expire   |   format   |   length   |    head    |    tail    |  head size  |
+// +----------+------------+------------+------------+------------+------------+-------------+
+// |  1 byte  |  variable  |   1 byte   |  variable  |  variable  |  variable  |  variable   |
+// +----------+------------+------------+------------+------------+------------+-------------+
+// head and tail are the sequence numbers of the first and the last chunk, the chunks in between
+// have the numbers in between and are full, so that the chunk of an index is found without reading
+// any chunk. Only the head and the tail chunk may hold fewer values. Every chunk has its own key,
+// holding the gob encoding of its values:
+// +----------+------------+------------+------------+
+// |  prefix  |  key size  |    key     |  sequence  |
+// +----------+------------+------------+------------+
+// | "\x00ls" |   4 bytes  |  variable  |  8 bytes   |
+// +----------+------------+------------+------------+
+// Lists written before, as one gob value, are migrated when they are first used.
+const (
+	// listMetaFormat starts the payload of a list stored in chunks,
+	// the gob encoding of a list stored in one value never starts with it.
+	listMetaFormat byte = 1
+
+	// listChunkSize is the number of values of a full chunk
+	listChunkSize = 128
+)
+
+var listChunkPrefix = []byte("\x00ls")
+
+// listMeta is the metadata of a list
+type listMeta struct {
+	expire   int64
+	length   int
+	head     int64
+	tail     int64
+	headSize int
+}
+
+func (m *listMeta) encode() []byte {
+	buf := encodeHeader(List, m.expire)
+	buf = append(buf, listMetaFormat)
+	varint := make([]byte, binary.MaxVarintLen64)
+	for _, v := range []int64{int64(m.length), m.head, m.tail, int64(m.headSize)} {
+		n := binary.PutVarint(varint, v)
+		buf = append(buf, varint[:n]...)
+	}
+	return buf
+}
+
+// decodeListMetaPayload returns the metadata stored in the payload of a list
+func decodeListMetaPayload(expire int64, payload []byte) (*listMeta, error) {
+	if isLegacyList(payload) {
+		return nil, ErrInvalidValue
+	}
+	var fields [4]int64
+	offset := 1
+	for i := range fields {
+		v, n := binary.Varint(payload[offset:])
+		if n <= 0 {
+			return nil, ErrInvalidValue
+		}
+		fields[i] = v
+		offset += n
+	}
+	return &listMeta{
+		expire:   expire,
+		length:   int(fields[0]),
+		head:     fields[1],
+		tail:     fields[2],
+		headSize: int(fields[3]),
+	}, nil
+}
+
+// isLegacyList reports whether the payload of a list holds the whole list as one gob value
+func isLegacyList(payload []byte) bool {
+	return len(payload) == 0 || payload[0] != listMetaFormat
+}
+
+// chunks returns the number of chunks of the list
+func (m *listMeta) chunks() int {
+	if m.length == 0 {
+		return 0
+	}
+	return int(m.tail-m.head) + 1
+}
+
+// chunkSize returns the number of values of the chunk with sequence number seq
+func (m *listMeta) chunkSize(seq int64) int {
+	switch {
+	case m.head == m.tail:
+		return m.length
+	case seq == m.head:
+		return m.headSize
+	case seq == m.tail:
+		return m.length - m.headSize - (m.chunks()-2)*listChunkSize
+	}
+	return listChunkSize
+}
+
+// chunkStart returns the index of the first value of the chunk with sequence number seq
+func (m *listMeta) chunkStart(seq int64) int {
+	if seq == m.head {
+		return 0
+	}
+	return m.headSize + int(seq-m.head-1)*listChunkSize
+}
+
+// locate returns the sequence number of the chunk holding the value at index, and its offset in the chunk
+func (m *listMeta) locate(index int) (int64, int) {
+	if index < m.headSize {
+		return m.head, index
+	}
+	index -= m.headSize
+	return m.head + 1 + int64(index/listChunkSize), index % listChunkSize
+}
+
+// listChunkKey returns the key of a chunk of a list
+func listChunkKey(key []byte, seq int64) []byte {
+	chunkKey := listChunkKeyPrefix(key)
+	var buf [8]byte
+	// the sign bit is flipped so that the sequence numbers sort like the keys
+	binary.BigEndian.PutUint64(buf[:], uint64(seq)^1<<63)
+	return append(chunkKey, buf[:]...)
+}
+
+// listChunkKeyPrefix returns the prefix of the keys of the chunks of a list
+func listChunkKeyPrefix(key []byte) []byte {
+	prefix := make([]byte, len(listChunkPrefix)+4, len(listChunkPrefix)+4+len(key)+8)
+	n := copy(prefix, listChunkPrefix)
+	binary.BigEndian.PutUint32(prefix[n:], uint32(len(key)))
+	return append(prefix, key...)
+}
+
+// decodeListChunkKey returns the key of the list a chunk belongs to and the sequence number
+// of the chunk, ok is false if k is not the key of a chunk
+func decodeListChunkKey(k []byte) (key []byte, seq int64, ok bool) {
+	headerLen := len(listChunkPrefix) + 4
+	if !bytes.HasPrefix(k, listChunkPrefix) || len(k) < headerLen+8 {
+		return nil, 0, false
+	}
+	keyLen := int(binary.BigEndian.Uint32(k[len(listChunkPrefix):]))
+	if headerLen+keyLen+8 != len(k) {
+		return nil, 0, false
+	}
+	seq = int64(binary.BigEndian.Uint64(k[headerLen+keyLen:]) ^ 1<<63)
+	return k[headerLen : headerLen+keyLen], seq, true
+}
+
+// listChunkKeys returns the keys of the chunks of a list
+func (ks *Keyspace) listChunkKeys(key string) [][]byte {
+	prefix := listChunkKeyPrefix([]byte(key))
+	it := ks.db.NewIterator(config.IteratorOptions{Start: prefix, End: prefixEnd(prefix)})
+	defer it.Close()
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		if _, _, ok := decodeListChunkKey(it.Key()); ok {
+			keys = append(keys, append([]byte(nil), it.Key()...))
+		}
+	}
+	return keys
+}
+
+func encodeListChunk(values []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(values); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeListChunk(data []byte) ([]interface{}, error) {
+	var values []interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// listChunks holds the chunks of a list that an operation reads and writes,
+// the writes are kept until commit
+type listChunks struct {
+	l      *ListStructure
+	key    []byte
+	meta   *listMeta
+	values map[int64][]interface{}
+	dirty  map[int64]bool
+}
+
+func (l *ListStructure) newListChunks(key string, meta *listMeta) *listChunks {
+	return &listChunks{
+		l:      l,
+		key:    []byte(key),
+		meta:   meta,
+		values: make(map[int64][]interface{}),
+		dirty:  make(map[int64]bool),
+	}
+}
+
+// get returns the values of a chunk, which the caller must not modify
+func (c *listChunks) get(seq int64) ([]interface{}, error) {
+	if values, ok := c.values[seq]; ok {
+		return values, nil
+	}
+	data, err := c.l.ks.db.Get(listChunkKey(c.key, seq))
+	if err != nil {
+		return nil, err
+	}
+	values, err := decodeListChunk(data)
+	if err != nil {
+		return nil, err
+	}
+	c.values[seq] = values
+	return values, nil
+}
+
+// set replaces the values of a chunk, a chunk without values is deleted
+func (c *listChunks) set(seq int64, values []interface{}) {
+	c.values[seq] = values
+	c.dirty[seq] = true
+}
+
+// reset empties the list, the chunks it had are deleted
+func (c *listChunks) reset() {
+	for seq := c.meta.head; c.meta.length > 0 && seq <= c.meta.tail; seq++ {
+		c.set(seq, nil)
+	}
+	c.meta.length, c.meta.head, c.meta.tail, c.meta.headSize = 0, 0, 0, 0
+}
+
+// pushHead adds a value before the first value of the list
+func (c *listChunks) pushHead(value interface{}) error {
+	m := c.meta
+	switch {
+	case m.length == 0:
+		c.set(m.head, []interface{}{value})
+	case m.headSize < listChunkSize:
+		values, err := c.get(m.head)
+		if err != nil {
+			return err
+		}
+		c.set(m.head, append([]interface{}{value}, values...))
+	default:
+		m.head--
+		m.headSize = 0
+		c.set(m.head, []interface{}{value})
+	}
+	m.headSize++
+	m.length++
+	return nil
+}
+
+// pushTail adds a value after the last value of the list
+func (c *listChunks) pushTail(value interface{}) error {
+	m := c.meta
+	if m.length == 0 {
+		return c.pushHead(value)
+	}
+	if m.chunkSize(m.tail) < listChunkSize {
+		values, err := c.get(m.tail)
+		if err != nil {
+			return err
+		}
+		c.set(m.tail, append(append(make([]interface{}, 0, len(values)+1), values...), value))
+		if m.head == m.tail {
+			m.headSize++
+		}
+	} else {
+		m.tail++
+		c.set(m.tail, []interface{}{value})
+	}
+	m.length++
+	return nil
+}
+
+// popHead removes and returns the first value of the list
+func (c *listChunks) popHead() (interface{}, error) {
+	m := c.meta
+	if m.length == 0 {
+		return nil, ErrListEmpty
+	}
+	values, err := c.get(m.head)
+	if err != nil {
+		return nil, err
+	}
+	value := values[0]
+	c.set(m.head, values[1:])
+	m.length--
+	m.headSize--
+	if m.length == 0 {
+		c.reset()
+	} else if m.headSize == 0 {
+		m.head++
+		m.headSize = listChunkSize
+		if m.head == m.tail {
+			m.headSize = m.length
+		}
+	}
+	return value, nil
+}
+
+// popTail removes and returns the last value of the list
+func (c *listChunks) popTail() (interface{}, error) {
+	m := c.meta
+	if m.length == 0 {
+		return nil, ErrListEmpty
+	}
+	tailSize := m.chunkSize(m.tail)
+	values, err := c.get(m.tail)
+	if err != nil {
+		return nil, err
+	}
+	value := values[len(values)-1]
+	c.set(m.tail, values[:len(values)-1])
+	if m.head == m.tail {
+		m.headSize--
+	}
+	m.length--
+	if m.length == 0 {
+		c.reset()
+	} else if tailSize == 1 {
+		m.tail--
+	}
+	return value, nil
+}
+
+// push adds a value at an end of the list
+func (c *listChunks) push(end ListEnd, value interface{}) error {
+	if end == ListLeft {
+		return c.pushHead(value)
+	}
+	return c.pushTail(value)
+}
+
+// pop removes and returns the value at an end of the list
+func (c *listChunks) pop(end ListEnd) (interface{}, error) {
+	if end == ListLeft {
+		return c.popHead()
+	}
+	return c.popTail()
+}
+
+// insert adds a value at an offset of the chunk with sequence number seq. Only that chunk is written
+// if it is the head or the tail chunk and is not full, otherwise the chunks from it to the tail are.
+func (c *listChunks) insert(seq int64, offset int, value interface{}) error {
+	m := c.meta
+	values, err := c.get(seq)
+	if err != nil {
+		return err
+	}
+	if (seq == m.head || seq == m.tail) && len(values) < listChunkSize {
+		inserted := make([]interface{}, 0, len(values)+1)
+		inserted = append(append(append(inserted, values[:offset]...), value), values[offset:]...)
+		c.set(seq, inserted)
+		if seq == m.head {
+			m.headSize++
+		}
+		m.length++
+		return nil
+	}
+	start := m.chunkStart(seq)
+	rest, err := c.rangeValues(start, m.length-1)
+	if err != nil {
+		return err
+	}
+	inserted := make([]interface{}, 0, len(rest)+1)
+	inserted = append(append(append(inserted, rest[:offset]...), value), rest[offset:]...)
+	c.rewrite(seq, inserted)
+	return nil
+}
+
+// rangeValues returns the values from index start to index stop, inclusive
+func (c *listChunks) rangeValues(start, stop int) ([]interface{}, error) {
+	result := make([]interface{}, 0, stop-start+1)
+	startSeq, offset := c.meta.locate(start)
+	stopSeq, _ := c.meta.locate(stop)
+	for seq := startSeq; seq <= stopSeq; seq++ {
+		values, err := c.get(seq)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values[offset:] {
+			if len(result) == stop-start+1 {
+				break
+			}
+			result = append(result, value)
+		}
+		offset = 0
+	}
+	return result, nil
+}
+
+// rewrite replaces the values from the chunk with sequence number seq to the end of the list
+func (c *listChunks) rewrite(seq int64, values []interface{}) {
+	m := c.meta
+	before := m.chunkStart(seq)
+	oldTail := m.tail
+	if before+len(values) == 0 {
+		c.reset()
+		return
+	}
+	next := seq
+	for i := 0; i < len(values); i += listChunkSize {
+		end := i + listChunkSize
+		if end > len(values) {
+			end = len(values)
+		}
+		c.set(next, append([]interface{}(nil), values[i:end]...))
+		next++
+	}
+	m.tail = next - 1
+	if len(values) == 0 {
+		m.tail = seq - 1
+	}
+	for stale := m.tail + 1; stale <= oldTail; stale++ {
+		c.set(stale, nil)
+	}
+	m.length = before + len(values)
+	if seq == m.head {
+		m.headSize = m.length
+		if m.headSize > listChunkSize {
+			m.headSize = listChunkSize
+		}
+	}
+}
+
+// commit writes the chunks that changed and the metadata of the list with the rest of batch
+func (c *listChunks) commit(batch *engine.WriteBatch) error {
+	if err := c.write(batch); err != nil {
+		return err
+	}
+	return c.l.ks.commit(batch)
+}
+
+// write adds the writes of the chunks that changed and of the metadata of the list to batch
+func (c *listChunks) write(batch *engine.WriteBatch) error {
+	for seq := range c.dirty {
+		values := c.values[seq]
+		if len(values) == 0 {
+			_ = batch.Delete(listChunkKey(c.key, seq))
+			continue
+		}
+		data, err := encodeListChunk(values)
+		if err != nil {
+			return err
+		}
+		_ = batch.Put(listChunkKey(c.key, seq), data)
+	}
+	_ = batch.Put(c.key, c.meta.encode())
+	c.l.ks.indexExpire(batch, c.key, c.meta.expire)
+	return nil
+}
+
+// getListMeta returns the metadata of a list. A list stored in one value is migrated first,
+// so the key must be locked even to read it.
+func (l *ListStructure) getListMeta(key string) (*listMeta, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	value, err := l.ks.db.Get([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	expire, payload, err := decodeValue(value, List)
+	if err != nil {
+		return nil, err
+	}
+	if isLegacyList(payload) {
+		return l.migrateList(key, expire, payload)
+	}
+	return decodeListMetaPayload(expire, payload)
+}
+
+// getOrCreateListMeta returns the metadata of a list to write to. A list that does not exist
+// or expired is empty, the deletes of the chunks it had are added to batch.
+func (l *ListStructure) getOrCreateListMeta(batch *engine.WriteBatch, key string) (*listMeta, error) {
+	if err := l.ks.checkType(key, List); err != nil {
+		return nil, err
+	}
+	meta, err := l.getListMeta(key)
+	if errors.Is(err, _const.ErrKeyIsExpired) {
+		if err := l.ks.deleteValue(batch, key); err != nil {
+			return nil, err
+		}
+		return &listMeta{}, nil
+	}
+	if errors.Is(err, _const.ErrKeyNotFound) {
+		return &listMeta{}, nil
+	}
+	return meta, err
+}
+
+// migrateList rewrites a list stored in one value in chunks and returns its metadata
+func (l *ListStructure) migrateList(key string, expire int64, payload []byte) (*listMeta, error) {
+	var lst list
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&lst); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, 0, lst.Length)
+	for node := lst.Head; node != nil; node = node.Next {
+		values = append(values, node.Value)
+	}
+	c := l.newListChunks(key, &listMeta{expire: expire})
+	c.rewrite(0, values)
+	if err := c.commit(l.ks.newBatch()); err != nil {
+		return nil, err
+	}
+	return c.meta, nil
+}
+
+// Migrate rewrites every list that is still stored in one value in chunks.
+// Lists are also migrated when they are first used, Migrate spares that cost
+// to the first command. It returns how many lists it rewrote.
+func (l *ListStructure) Migrate() (int, error) {
+	keys, err := l.ks.keys("*", List)
+	if err != nil {
+		return 0, err
+	}
+	migrated := 0
+	for _, key := range keys {
+		ok, err := l.migrateKey(key)
+		if err != nil {
+			return migrated, err
+		}
+		if ok {
+			migrated++
+		}
+	}
+	return migrated, nil
+}
+
+// migrateKey migrates a list if it is still stored in one value, and reports whether it did
+func (l *ListStructure) migrateKey(key string) (bool, error) {
+	defer l.ks.lockKeys(key)()
+	value, err := l.ks.db.Get([]byte(key))
+	if err == _const.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	expire, payload, err := decodeValue(value, List)
+	if err != nil || !isLegacyList(payload) {
+		return false, nil
+	}
+	if _, err := l.migrateList(key, expire, payload); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -1,6 +1,12 @@
 package structure
 
 import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"sync"
+
 	_const "github.com/ByteStorage/FlyDB/lib/const"
 	"github.com/ByteStorage/FlyDB/lib/randkv"
 	"os"
@@ -17,13 +23,14 @@ func initList() (*ListStructure, *config.Options) {
 	opts := config.DefaultOptions
 	dir, _ := os.MkdirTemp("", "TestListStructure")
 	opts.DirPath = dir
-	list, _ := NewListStructure(opts)
+	ks, _ := NewKeyspaceWithSweepOptions(opts, config.ExpireSweepOptions{})
+	list := NewListStructureWithKeyspace(ks)
 	return list, &opts
 }
 
 func TestListStructure_TTL(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	err := list.LPush("2", "123123", 2)
 	assert.Nil(t, err)
@@ -52,7 +59,7 @@ func TestListStructure_TTL(t *testing.T) {
 
 func TestListStructure_Size(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	err = list.LPush("2", "123123", 0)
 	assert.Nil(t, err)
@@ -67,7 +74,7 @@ func TestListStructure_Size(t *testing.T) {
 
 func TestListStructure_LPush(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LPush function when the key exists
 	listErr = list.LPush("key", "w", 10)
@@ -80,7 +87,7 @@ func TestListStructure_LPush(t *testing.T) {
 
 func TestListStructure_LPushs(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LPushs function when the key exists
 	listErr = list.LPushs(string(randkv.GetTestKey(1)), 0, randkv.RandomValue(100), randkv.RandomValue(100))
@@ -93,7 +100,7 @@ func TestListStructure_LPushs(t *testing.T) {
 
 func TestListStructure_RPush(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test RPush function when the key exists
 	listErr = list.RPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -106,7 +113,7 @@ func TestListStructure_RPush(t *testing.T) {
 
 func TestListStructure_RPushs(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test RPushs function when the key exists
 	listErr = list.RPushs(string(randkv.GetTestKey(1)), 0, randkv.RandomValue(100), randkv.RandomValue(100))
@@ -119,7 +126,7 @@ func TestListStructure_RPushs(t *testing.T) {
 
 func TestListStructure_LPop(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LPop function when the key exists
 	listErr = list.LPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -144,7 +151,7 @@ func TestListStructure_LPop(t *testing.T) {
 
 func TestListStructure_RPop(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test RPop function when the key exists
 	listErr = list.RPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -168,7 +175,7 @@ func TestListStructure_RPop(t *testing.T) {
 
 func TestListStructure_LRange(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LRange function when the key exists
 	listErr = list.LPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -192,7 +199,7 @@ func TestListStructure_LRange(t *testing.T) {
 
 func TestListStructure_LLen(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LLen function when the key exists
 	listErr = list.LPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -208,7 +215,7 @@ func TestListStructure_LLen(t *testing.T) {
 
 func TestListStructure_LRem(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LRem function when the key exists
 	listErr = list.LPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -223,7 +230,7 @@ func TestListStructure_LRem(t *testing.T) {
 
 func TestListStructure_LSet(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LSet function when the key exists
 	listErr = list.LPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -246,7 +253,7 @@ func TestListStructure_LSet(t *testing.T) {
 
 func TestListStructure_LTrim(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LTrim function when the key exists
 	listErr = list.LPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -269,7 +276,7 @@ func TestListStructure_LTrim(t *testing.T) {
 
 func TestListStructure_LIndex(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test LIndex function when the key exists
 	listErr = list.LPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -293,7 +300,7 @@ func TestListStructure_LIndex(t *testing.T) {
 
 func TestListStructure_RPOPLPUSH(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Test RPOPLPUSH function when the source list exists
 	listErr = list.RPush(string(randkv.GetTestKey(1)), randkv.RandomValue(100), 0)
@@ -316,7 +323,7 @@ func TestListStructure_RPOPLPUSH(t *testing.T) {
 
 func TestListStructure_Integration(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	// Create a key and use LPush to add some values
 	key := string(randkv.GetTestKey(1))
@@ -377,7 +384,7 @@ func TestListStructure_Integration(t *testing.T) {
 
 func TestListStructure_Keys(t *testing.T) {
 	list, _ := initList()
-	defer list.db.Clean()
+	defer list.ks.db.Clean()
 
 	listErr = list.LPush("111", randkv.RandomValue(100), 0)
 	assert.Nil(t, listErr)
@@ -408,3 +415,478 @@ func TestListStructure_Keys(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, len(keys), 3)
 }
+
+// listModel returns the values of a list, checking its metadata against the chunks stored
+func listModel(t *testing.T, list *ListStructure, key string) []interface{} {
+	meta, err := list.getListMeta(key)
+	assert.Nil(t, err)
+	keys := list.ks.listChunkKeys(key)
+	assert.Equal(t, meta.chunks(), len(keys))
+	if meta.length == 0 {
+		return nil
+	}
+	values, err := list.LRange(key, 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, meta.length, len(values))
+	return values
+}
+
+func TestListStructure_Chunks(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+
+	// Push at both ends across several chunks
+	var expected []interface{}
+	for i := 0; i < 3*listChunkSize; i++ {
+		assert.Nil(t, list.RPush("l", i, 0))
+		expected = append(expected, i)
+	}
+	for i := -1; i >= -listChunkSize-10; i-- {
+		assert.Nil(t, list.LPush("l", i, 0))
+		expected = append([]interface{}{i}, expected...)
+	}
+	assert.Equal(t, expected, listModel(t, list, "l"))
+	meta, err := list.getListMeta("l")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, meta.chunks())
+	assert.Equal(t, 10, meta.headSize)
+
+	// Seek to any index
+	for _, index := range []int{0, 9, 10, listChunkSize + 10, len(expected) - 1, -1, -listChunkSize} {
+		value, err := list.LIndex("l", index)
+		assert.Nil(t, err)
+		assert.Equal(t, expected[(index+len(expected))%len(expected)], value)
+	}
+	values, err := list.LRange("l", 5, 2*listChunkSize)
+	assert.Nil(t, err)
+	assert.Equal(t, expected[5:2*listChunkSize+1], values)
+
+	// Pop at both ends until chunks are emptied
+	for i := 0; i < 20; i++ {
+		value, err := list.LPop("l")
+		assert.Nil(t, err)
+		assert.Equal(t, expected[0], value)
+		expected = expected[1:]
+		value, err = list.RPop("l")
+		assert.Nil(t, err)
+		assert.Equal(t, expected[len(expected)-1], value)
+		expected = expected[:len(expected)-1]
+	}
+	assert.Equal(t, expected, listModel(t, list, "l"))
+
+	// Set, remove and trim in the middle
+	assert.Nil(t, list.LSet("l", 200, "set", 0))
+	expected[200] = "set"
+	assert.Nil(t, list.LRem("l", 0, 100))
+	for i, value := range expected {
+		if value == 100 {
+			expected = append(expected[:i:i], expected[i+1:]...)
+			break
+		}
+	}
+	assert.Equal(t, expected, listModel(t, list, "l"))
+	assert.Nil(t, list.LTrim("l", 3, 2*listChunkSize+7))
+	expected = expected[3 : 2*listChunkSize+8]
+	assert.Equal(t, expected, listModel(t, list, "l"))
+
+	// Pop everything, the chunks are deleted
+	for range expected {
+		_, err := list.RPop("l")
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, listModel(t, list, "l"))
+	_, err = list.LPop("l")
+	assert.Equal(t, ErrListEmpty, err)
+
+	// Deleting a list deletes its chunks
+	assert.Nil(t, list.RPushs("d", 0, expected[:listChunkSize+1]...))
+	assert.Equal(t, 2, len(list.ks.listChunkKeys("d")))
+	deleted, err := list.ks.Del("d")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Empty(t, list.ks.listChunkKeys("d"))
+}
+
+func TestListStructure_Concurrent(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+
+	// Push at both ends and pop from several goroutines at once
+	const pushers, pushes = 4, 2 * listChunkSize
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		popped   []interface{}
+		done     = make(chan struct{})
+		popperWg sync.WaitGroup
+	)
+	for p := 0; p < pushers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < pushes; i++ {
+				value := fmt.Sprintf("%d-%d", p, i)
+				if i%2 == 0 {
+					assert.Nil(t, list.RPush("l", value, 0))
+				} else {
+					assert.Nil(t, list.LPush("l", value, 0))
+				}
+			}
+		}(p)
+	}
+	for p := 0; p < 2; p++ {
+		popperWg.Add(1)
+		go func() {
+			defer popperWg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				value, err := list.LPop("l")
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				popped = append(popped, value)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	popperWg.Wait()
+
+	// The metadata still matches the chunks, and every value is either popped once or left in the list
+	values := append(popped, listModel(t, list, "l")...)
+	assert.Equal(t, pushers*pushes, len(values))
+	seen := make(map[interface{}]bool)
+	for _, value := range values {
+		assert.False(t, seen[value], value)
+		seen[value] = true
+	}
+}
+
+func TestListStructure_LRemCount(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+
+	assert.Nil(t, list.RPushs("l", 0, "a", "b", "a", "c", "a"))
+	assert.Nil(t, list.LRem("l", -2, "a"))
+	values, err := list.LRange("l", 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, values)
+
+	assert.Nil(t, list.LRem("l", 1, "a"))
+	values, err = list.LRange("l", 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"b", "c"}, values)
+}
+
+func TestListStructure_LInsert(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+
+	_, err := list.LInsert("l", true, "a", "b")
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+
+	assert.Nil(t, list.RPushs("l", 0, "a", "c"))
+	n, err := list.LInsert("l", true, "c", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, n)
+	n, err = list.LInsert("l", false, "c", "d")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+	n, err = list.LInsert("l", false, "x", "y")
+	assert.Nil(t, err)
+	assert.Equal(t, -1, n)
+	values, err := list.LRange("l", 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "c", "d"}, values)
+
+	// Inserting in a full chunk shifts the following chunks
+	var expected []interface{}
+	for i := 0; i < 3*listChunkSize; i++ {
+		expected = append(expected, i)
+	}
+	assert.Nil(t, list.RPushs("f", 0, expected...))
+	n, err = list.LInsert("f", false, 5, "x")
+	assert.Nil(t, err)
+	assert.Equal(t, len(expected)+1, n)
+	expected = append(expected[:6:6], append([]interface{}{"x"}, expected[6:]...)...)
+	assert.Equal(t, expected, listModel(t, list, "f"))
+	meta, err := list.getListMeta("f")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, meta.chunks())
+}
+
+func TestListStructure_LPos(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+
+	values := make([]interface{}, 0, 2*listChunkSize)
+	for i := 0; i < 2*listChunkSize; i++ {
+		values = append(values, i%3)
+	}
+	assert.Nil(t, list.RPushs("l", 0, values...))
+
+	positions, err := list.LPos("l", 1, 1, 1, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, positions)
+	positions, err = list.LPos("l", 1, 2, 3, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4, 7, 10}, positions)
+	positions, err = list.LPos("l", 0, -1, 2, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{255, 252}, positions)
+	positions, err = list.LPos("l", 2, 1, 0, 6)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 5}, positions)
+	positions, err = list.LPos("l", 2, 1, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 85, len(positions))
+	positions, err = list.LPos("l", 3, 1, 0, 0)
+	assert.Nil(t, err)
+	assert.Empty(t, positions)
+
+	_, err = list.LPos("l", 1, 0, 1, 0)
+	assert.Equal(t, ErrInvalidArgs, err)
+	_, err = list.LPos("missing", 1, 1, 1, 0)
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+}
+
+func TestListStructure_LMove(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+
+	assert.Nil(t, list.RPushs("src", 0, "a", "b", "c"))
+	value, err := list.LMove("src", "dst", ListLeft, ListRight)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", value)
+	value, err = list.LMove("src", "dst", ListRight, ListLeft)
+	assert.Nil(t, err)
+	assert.Equal(t, "c", value)
+	values, err := list.LRange("dst", 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"c", "a"}, values)
+
+	// Moving within a list rotates it
+	value, err = list.LMove("dst", "dst", ListLeft, ListRight)
+	assert.Nil(t, err)
+	assert.Equal(t, "c", value)
+	values, err = list.LRange("dst", 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "c"}, values)
+
+	_, err = list.LMove("src", "src", ListLeft, ListLeft)
+	assert.Nil(t, err)
+	_, err = list.LPop("src")
+	assert.Nil(t, err)
+	_, err = list.LMove("src", "dst", ListLeft, ListLeft)
+	assert.Equal(t, ErrListEmpty, err)
+	_, err = list.LMove("missing", "dst", ListLeft, ListLeft)
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+
+	end, err := ParseListEnd("right")
+	assert.Nil(t, err)
+	assert.Equal(t, ListRight, end)
+	_, err = ParseListEnd("middle")
+	assert.Equal(t, ErrInvalidArgs, err)
+}
+
+func TestListStructure_Migrate(t *testing.T) {
+	ls, _ := initList()
+	defer ls.ks.Clean()
+
+	// Lists written as one gob value, as they were before
+	for _, key := range []string{"lazy", "explicit"} {
+		legacy := &list{Length: 2, Head: &listNode{Value: "a", Next: &listNode{Value: []byte("b")}}}
+		var buf bytes.Buffer
+		assert.Nil(t, gob.NewEncoder(&buf).Encode(legacy))
+		assert.Nil(t, ls.ks.db.Put([]byte(key), append(encodeHeader(List, 0), buf.Bytes()...)))
+	}
+
+	// A list is migrated when it is first used
+	n, err := ls.LLen("lazy")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 1, len(ls.ks.listChunkKeys("lazy")))
+
+	migrated, err := ls.Migrate()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, migrated)
+	migrated, err = ls.Migrate()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, migrated)
+
+	values, err := ls.LRange("explicit", 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", []byte("b")}, values)
+
+	// Renaming a list moves its chunks
+	assert.Nil(t, ls.ks.Rename("explicit", "renamed"))
+	assert.Empty(t, ls.ks.listChunkKeys("explicit"))
+	values, err = ls.LRange("renamed", 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", []byte("b")}, values)
+}
+
+// waitBlocked waits until n pops are blocked on the list of key
+func waitBlocked(t *testing.T, list *ListStructure, key string, n int) {
+	assert.Eventually(t, func() bool {
+		list.ks.blockLock.Lock()
+		defer list.ks.blockLock.Unlock()
+		return len(list.ks.blocked[key]) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestListStructure_BLPop(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+	ctx := context.Background()
+
+	// A value is popped right away from the first list that has one
+	assert.Nil(t, list.RPushs("b", 0, "b1", "b2"))
+	key, value, err := list.BLPop(ctx, time.Second, "a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", key)
+	assert.Equal(t, "b1", value)
+	key, value, err = list.BRPop(ctx, time.Second, "a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", key)
+	assert.Equal(t, "b2", value)
+
+	// Empty lists wait until the timeout or until ctx is done
+	_, _, err = list.BLPop(ctx, 20*time.Millisecond, "a", "b")
+	assert.Equal(t, ErrListTimeout, err)
+	cancelCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		waitBlocked(t, list, "a", 1)
+		cancel()
+	}()
+	_, _, err = list.BLPop(cancelCtx, 0, "a")
+	assert.Equal(t, context.Canceled, err)
+	waitBlocked(t, list, "a", 0)
+
+	// A push wakes the pops in the order they blocked
+	results := make([]chan interface{}, 3)
+	for i := range results {
+		results[i] = make(chan interface{}, 1)
+		go func(result chan interface{}) {
+			_, value, err := list.BLPop(ctx, 5*time.Second, "a", "c")
+			assert.Nil(t, err)
+			result <- value
+		}(results[i])
+		waitBlocked(t, list, "a", i+1)
+	}
+	assert.Nil(t, list.RPushs("a", 0, 0, 1))
+	assert.Nil(t, list.LPush("c", 2, 0))
+	for i, result := range results {
+		select {
+		case value := <-result:
+			assert.Equal(t, i, value)
+		case <-time.After(5 * time.Second):
+			t.Fatal("blocked pop not woken")
+		}
+	}
+	waitBlocked(t, list, "a", 0)
+	waitBlocked(t, list, "c", 0)
+
+	_, _, err = list.BLPop(ctx, time.Second)
+	assert.Equal(t, ErrInvalidArgs, err)
+	_, _, err = list.BLPop(ctx, -time.Second, "a")
+	assert.Equal(t, ErrInvalidArgs, err)
+	assert.Nil(t, NewStringStructureWithKeyspace(list.ks).Set("s", "v", 0))
+	_, _, err = list.BLPop(ctx, time.Second, "s")
+	assert.Equal(t, ErrWrongType, err)
+}
+
+func TestListStructure_BLMove(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+	ctx := context.Background()
+
+	// A move to a list wakes the pops blocked on it
+	moved := make(chan interface{}, 1)
+	popped := make(chan interface{}, 1)
+	go func() {
+		value, err := list.BLMove(ctx, "src", "dst", ListLeft, ListRight, 5*time.Second)
+		assert.Nil(t, err)
+		moved <- value
+	}()
+	waitBlocked(t, list, "src", 1)
+	go func() {
+		_, value, err := list.BLPop(ctx, 5*time.Second, "dst")
+		assert.Nil(t, err)
+		popped <- value
+	}()
+	waitBlocked(t, list, "dst", 1)
+
+	assert.Nil(t, list.RPush("src", "v", 0))
+	for _, result := range []chan interface{}{moved, popped} {
+		select {
+		case value := <-result:
+			assert.Equal(t, "v", value)
+		case <-time.After(5 * time.Second):
+			t.Fatal("blocked move not woken")
+		}
+	}
+	_, err := list.BLMove(ctx, "src", "dst", ListLeft, ListRight, 20*time.Millisecond)
+	assert.Equal(t, ErrListTimeout, err)
+}
+
+func TestListStructure_BLPopConcurrent(t *testing.T) {
+	list, _ := initList()
+	defer list.ks.Clean()
+
+	// Producers push jobs while consumers wait for them with blocking pops
+	const producers, jobs = 4, 500
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan interface{}, producers*jobs)
+	var consumers sync.WaitGroup
+	for c := 0; c < 2; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				_, value, err := list.BLPop(ctx, 0, "jobs")
+				if err != nil {
+					assert.Equal(t, context.Canceled, err)
+					return
+				}
+				received <- value
+			}
+		}()
+	}
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < jobs; i++ {
+				assert.Nil(t, list.RPush("jobs", fmt.Sprintf("%d-%d", p, i), 0))
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	// Every job is delivered exactly once
+	seen := make(map[interface{}]bool)
+	for len(seen) < producers*jobs {
+		select {
+		case value := <-received:
+			assert.False(t, seen[value], value)
+			seen[value] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%d jobs were lost", producers*jobs-len(seen))
+		}
+	}
+	cancel()
+	consumers.Wait()
+	assert.Empty(t, received)
+	n, err := list.LLen("jobs")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+}
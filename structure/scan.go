@@ -0,0 +1,106 @@
+package structure
+
+import (
+	"encoding/base64"
+	"errors"
+	"regexp"
+
+	"github.com/ByteStorage/FlyDB/config"
+)
+
+const (
+	// ScanCursorStart starts a scan, a scan is complete when it returns this cursor again
+	ScanCursorStart = "0"
+
+	// DefaultScanCount is how many entries a scan looks at per call if no count is given
+	DefaultScanCount = 10
+)
+
+// ErrInvalidCursor is returned if a cursor was not returned by a scan
+var ErrInvalidCursor = errors.New("Wrong value: invalid cursor")
+
+// A scan is stateless: its cursor is the first key, field or member of the next page.
+// Entries are visited in order, so an entry that is present for the whole scan is
+// returned exactly once, and entries added or removed during the scan may or may not be.
+
+// encodeCursor returns the cursor that continues a scan at next
+func encodeCursor(next []byte) string {
+	return base64.RawURLEncoding.EncodeToString(next)
+}
+
+// decodeCursor returns where a scan continues, nil at the start of the scan
+func decodeCursor(cursor string) ([]byte, error) {
+	if cursor == "" || cursor == ScanCursorStart {
+		return nil, nil
+	}
+	next, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(next) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return next, nil
+}
+
+// scanArgs checks the arguments every scan takes
+func scanArgs(cursor, match string, count int) ([]byte, *regexp.Regexp, int, error) {
+	next, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if match == "" {
+		match = "*"
+	}
+	compile, err := regexp.Compile(convertToRegexp(match))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if count <= 0 {
+		count = DefaultScanCount
+	}
+	return next, compile, count, nil
+}
+
+// Scan returns a page of the keys that match a pattern and, unless typ is empty, hold a value of that type.
+// count bounds how many keys the call looks at, so a page may hold fewer keys, or none, before the scan completes.
+// It returns the cursor of the next page, which is ScanCursorStart once the scan is complete.
+func (ks *Keyspace) Scan(cursor, match string, count int, typ string) (string, []string, error) {
+	next, compile, count, err := scanArgs(cursor, match, count)
+	if err != nil {
+		return "", nil, err
+	}
+	var want DataStructure
+	if typ != "" {
+		for dataType, name := range typeNames {
+			if name == typ {
+				want = dataType
+			}
+		}
+		if want == 0 {
+			return "", nil, ErrInvalidArgs
+		}
+	}
+
+	// The internal keys all sort before the first key of the data structures
+	if len(next) == 0 || next[0] == internalKeyPrefix {
+		next = []byte{internalKeyPrefix + 1}
+	}
+	it := ks.db.NewIterator(config.IteratorOptions{Start: next})
+	defer it.Close()
+
+	keys := make([]string, 0)
+	for examined := 0; examined < count && it.Valid(); it.Next() {
+		examined++
+		key := it.Key()
+		if !compile.Match(key) {
+			continue
+		}
+		current, _, _, err := ks.lookup(string(key))
+		if err != nil || (want != 0 && current != want) {
+			continue
+		}
+		keys = append(keys, string(key))
+	}
+	if !it.Valid() {
+		return ScanCursorStart, keys, nil
+	}
+	return encodeCursor(it.Key()), keys, nil
+}
@@ -0,0 +1,186 @@
+package structure
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestKeyspace_Scan(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+	for i := 0; i < 25; i++ {
+		assert.Nil(t, str.Set(fmt.Sprintf("string%02d", i), "value", 0))
+	}
+	for i := 0; i < 5; i++ {
+		_, err := hash.HSet(fmt.Sprintf("hash%d", i), "field", "value")
+		assert.Nil(t, err)
+	}
+
+	// Every key is returned once, in pages of at most count keys
+	seen := make(map[string]int)
+	cursor := ScanCursorStart
+	for {
+		next, keys, err := ks.Scan(cursor, "", 7, "")
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(keys), 7)
+		for _, key := range keys {
+			seen[key]++
+		}
+		cursor = next
+		if cursor == ScanCursorStart {
+			break
+		}
+	}
+	assert.Equal(t, 30, len(seen))
+	for key, n := range seen {
+		assert.Equal(t, 1, n, key)
+	}
+
+	// The pattern and the type filter the keys
+	keys := scanAll(t, func(cursor string) (string, []string, error) {
+		return ks.Scan(cursor, "string1*", 4, "string")
+	})
+	assert.Equal(t, 10, len(keys))
+	keys = scanAll(t, func(cursor string) (string, []string, error) {
+		return hash.Scan(cursor, "*", 3)
+	})
+	assert.Equal(t, []string{"hash0", "hash1", "hash2", "hash3", "hash4"}, keys)
+
+	_, _, err := ks.Scan(ScanCursorStart, "", 10, "stream")
+	assert.Equal(t, ErrInvalidArgs, err)
+	_, _, err = ks.Scan("not a cursor!", "", 10, "")
+	assert.Equal(t, ErrInvalidCursor, err)
+}
+
+func TestKeyspace_ScanSkipsInternalKeys(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+	zset := NewZSetStructureWithKeyspace(ks)
+	for i := 0; i < 100; i++ {
+		_, err := hash.HSet("hash", fmt.Sprintf("field%03d", i), "value")
+		assert.Nil(t, err)
+		assert.Nil(t, zset.ZAdd("zset", float64(i), fmt.Sprintf("member%03d", i), ""))
+	}
+	assert.Nil(t, str.Set("string", "value", 3600))
+
+	// The fields, members and expiry entries sort before the keys but do not count toward count
+	next, keys, err := ks.Scan(ScanCursorStart, "", 3, "")
+	assert.Nil(t, err)
+	assert.Equal(t, ScanCursorStart, next)
+	assert.Equal(t, []string{"hash", "string", "zset"}, keys)
+}
+
+func TestStructure_MemberScan(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	hash := NewHashStructureWithKeyspace(ks)
+	set := NewSetStructureWithKeyspace(ks)
+	zset := NewZSetStructureWithKeyspace(ks)
+	for i := 0; i < 12; i++ {
+		_, err := hash.HSet("hash", fmt.Sprintf("field%02d", i), fmt.Sprintf("value%d", i))
+		assert.Nil(t, err)
+		assert.Nil(t, set.SAdd("set", fmt.Sprintf("member%02d", i), 0))
+		assert.Nil(t, zset.ZAdd("zset", float64(12-i), fmt.Sprintf("member%02d", i), ""))
+	}
+
+	pairs := scanAll(t, func(cursor string) (string, []string, error) {
+		return hash.HScan("hash", cursor, "field0*", 5)
+	})
+	assert.Equal(t, 20, len(pairs))
+	assert.Equal(t, "field00", pairs[0])
+	assert.Equal(t, "value0", pairs[1])
+
+	members := scanAll(t, func(cursor string) (string, []string, error) {
+		return set.SScan("set", cursor, "", 5)
+	})
+	assert.Equal(t, 12, len(members))
+	assert.True(t, sort.StringsAreSorted(members))
+
+	var scores []float64
+	cursor := ScanCursorStart
+	for {
+		next, values, err := zset.ZScan("zset", cursor, "member1*", 4)
+		assert.Nil(t, err)
+		for _, value := range values {
+			scores = append(scores, value.score)
+		}
+		if cursor = next; cursor == ScanCursorStart {
+			break
+		}
+	}
+	assert.Equal(t, []float64{2, 1}, scores)
+
+	// A missing key has no members
+	cursor, members, err := set.SScan("missing", ScanCursorStart, "", 5)
+	assert.Nil(t, err)
+	assert.Equal(t, ScanCursorStart, cursor)
+	assert.Empty(t, members)
+}
+
+func TestKeyspace_ScanPageCost(t *testing.T) {
+	ks := initKeyspace()
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+	set := NewSetStructureWithKeyspace(ks)
+	grow := func(n int) {
+		members := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			assert.Nil(t, str.Set(fmt.Sprintf("string%06d", i), "value", 0))
+			_, err := hash.HSet("hash", fmt.Sprintf("field%06d", i), "value")
+			assert.Nil(t, err)
+			members = append(members, fmt.Sprintf("member%06d", i))
+		}
+		assert.Nil(t, set.SAdds("set", 0, members...))
+	}
+	// pageAllocs returns the allocations of a page after the first 500 entries of each scan
+	pageAllocs := func() []float64 {
+		scans := []func(cursor string, count int) (string, []string, error){
+			func(cursor string, count int) (string, []string, error) { return ks.Scan(cursor, "", count, "") },
+			func(cursor string, count int) (string, []string, error) { return hash.HScan("hash", cursor, "", count) },
+			func(cursor string, count int) (string, []string, error) { return set.SScan("set", cursor, "", count) },
+		}
+		allocs := make([]float64, len(scans))
+		for i, scan := range scans {
+			cursor, _, err := scan(ScanCursorStart, 500)
+			assert.Nil(t, err)
+			allocs[i] = testing.AllocsPerRun(10, func() {
+				_, _, err := scan(cursor, 10)
+				assert.Nil(t, err)
+			})
+		}
+		return allocs
+	}
+
+	grow(1000)
+	small := pageAllocs()
+	grow(20000)
+	large := pageAllocs()
+	for i := range small {
+		assert.Less(t, large[i], small[i]*1.5, "scan %d: %v allocations in a keyspace of 1000 keys, %v in one of 20000", i, small[i], large[i])
+	}
+}
+
+// scanAll runs a scan to completion
+func scanAll(t *testing.T, scan func(cursor string) (string, []string, error)) []string {
+	var all []string
+	cursor := ScanCursorStart
+	for {
+		next, page, err := scan(cursor)
+		assert.Nil(t, err)
+		all = append(all, page...)
+		if cursor = next; cursor == ScanCursorStart {
+			return all
+		}
+	}
+}
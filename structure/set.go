@@ -4,15 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
-	"github.com/ByteStorage/FlyDB/db/engine"
 	_const "github.com/ByteStorage/FlyDB/lib/const"
-	"github.com/ByteStorage/FlyDB/lib/encoding"
-	"regexp"
 	"time"
 )
 
 type SetStructure struct {
-	db *engine.DB
+	ks *Keyspace
 }
 
 var (
@@ -24,11 +21,16 @@ var (
 type FSets map[string]struct{}
 
 func NewSetStructure(options config.Options) (*SetStructure, error) {
-	db, err := engine.NewDB(options)
+	ks, err := NewKeyspace(options)
 	if err != nil {
 		return nil, err
 	}
-	return &SetStructure{db: db}, nil
+	return NewSetStructureWithKeyspace(ks), nil
+}
+
+// NewSetStructureWithKeyspace returns a SetStructure that stores its sets in a shared keyspace
+func NewSetStructureWithKeyspace(ks *Keyspace) *SetStructure {
+	return &SetStructure{ks: ks}
 }
 
 // SAdd adds a member to the set stored at key.
@@ -60,14 +62,40 @@ Internal logic:
 All the methods like 'getZSetFromDB', 'setZSetToDB', and 'add' handle the lower-level logic associated with database interaction and set manipulation.
 */
 func (s *SetStructure) SAdds(key string, ttl int64, members ...string) error {
-	fs, err := s.checkAndGetSet(key, true)
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if s.ks.db == nil {
+		return ErrSetNotInitialized
+	}
+	defer s.ks.lockKeys(key)()
+	batch := s.ks.newBatch()
+	meta, err := s.getOrCreateSetMeta(batch, key)
 	if err != nil {
 		return err
 	}
-	var expirationTime time.Duration
-	fs.add(members...)
-	expirationTime = time.Duration(ttl) * time.Second
-	return s.setSetToDB(stringToBytesWithKey(key), fs, expirationTime)
+	keyBytes := stringToBytesWithKey(key)
+	added := make(FSets, len(members))
+	for _, member := range members {
+		if added.exists(member) {
+			continue
+		}
+		added.add(member)
+		// The members of a set that expired are deleted in batch, it has none left
+		if meta.card > 0 {
+			exists, err := s.hasMember(keyBytes, member)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+		}
+		_ = batch.Put(setMemberKey(keyBytes, member), nil)
+		meta.card++
+	}
+	meta.expire = expireAfter(ttl)
+	return s.commitSet(batch, keyBytes, meta)
 }
 
 // SRem removes a member from a set
@@ -76,26 +104,49 @@ func (s *SetStructure) SRem(key, member string) error {
 }
 
 // SRems removes multiple members from a set
+// If one of the members does not belong to the set, none is removed.
 func (s *SetStructure) SRems(key string, ttl int64, members ...string) error {
-	fs, err := s.checkAndGetSet(key, false)
-	if err != nil {
+	if err := checkKey(key); err != nil {
 		return err
 	}
-	var expirationTime time.Duration
-	if err = fs.remove(members...); err != nil {
+	defer s.ks.lockKeys(key)()
+	keyBytes := stringToBytesWithKey(key)
+	meta, err := s.getSetMeta(keyBytes)
+	if err != nil {
 		return err
 	}
-	expirationTime = time.Duration(ttl) * time.Second
-	return s.setSetToDB(stringToBytesWithKey(key), fs, expirationTime)
+	batch := s.ks.newBatch()
+	removed := make(FSets, len(members))
+	for _, member := range members {
+		if removed.exists(member) {
+			continue
+		}
+		exists, err := s.hasMember(keyBytes, member)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrMemberNotFound
+		}
+		removed.add(member)
+		_ = batch.Delete(setMemberKey(keyBytes, member))
+	}
+	meta.card -= len(removed)
+	meta.expire = expireAfter(ttl)
+	return s.commitSet(batch, keyBytes, meta)
 }
 
 // SCard gets the cardinality (size) of a set
 func (s *SetStructure) SCard(key string) (int, error) {
-	fs, err := s.checkAndGetSet(key, false)
+	if err := checkKey(key); err != nil {
+		return -1, err
+	}
+	defer s.ks.lockKeys(key)()
+	meta, err := s.getSetMeta(stringToBytesWithKey(key))
 	if err != nil {
 		return -1, err
 	}
-	return len(*fs), nil
+	return meta.card, nil
 }
 
 // SMembers gets all members of a set identified by the provided key.
@@ -103,7 +154,7 @@ func (s *SetStructure) SCard(key string) (int, error) {
 // the function will return an error.
 // It returns a slice of strings which are the members of the set.
 func (s *SetStructure) SMembers(key string) ([]string, error) {
-	fs, err := s.checkAndGetSet(key, false)
+	fs, err := s.checkAndGetSet(key)
 	if err != nil {
 		return nil, err
 	}
@@ -119,12 +170,15 @@ func (s *SetStructure) SMembers(key string) ([]string, error) {
 
 // SIsMember checks if a member exists in a set
 func (s *SetStructure) SIsMember(key, member string) (bool, error) {
-	fs, err := s.checkAndGetSet(key, false)
-	if err != nil {
+	if err := checkKey(key); err != nil {
 		return false, err
 	}
-
-	return fs.exists(member), nil
+	defer s.ks.lockKeys(key)()
+	keyBytes := stringToBytesWithKey(key)
+	if _, err := s.getSetMeta(keyBytes); err != nil {
+		return false, err
+	}
+	return s.hasMember(keyBytes, member)
 }
 
 // SUnion gets the union of multiple sets
@@ -136,7 +190,7 @@ func (s *SetStructure) SUnion(keys ...string) ([]string, error) {
 	mem := make(map[string]struct{})
 	var members []string
 	for _, key := range keys {
-		fs, err := s.checkAndGetSet(key, false)
+		fs, err := s.checkAndGetSet(key)
 		if err != nil {
 			return nil, err
 		}
@@ -161,7 +215,7 @@ func (s *SetStructure) SInter(keys ...string) ([]string, error) {
 	}
 	// All elements of 'first' are stored in 'mem' map because it's checked
 	// against each of the subsequent sets.
-	first, err := s.checkAndGetSet(keys[0], false)
+	first, err := s.checkAndGetSet(keys[0])
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +228,7 @@ func (s *SetStructure) SInter(keys ...string) ([]string, error) {
 	var members []string
 	// For each other key, we get its set and its members.
 	for _, key := range keys[1:] {
-		fs, err := s.checkAndGetSet(key, false)
+		fs, err := s.checkAndGetSet(key)
 		if err != nil {
 			return nil, err
 		}
@@ -199,7 +253,7 @@ func (s *SetStructure) SDiff(keys ...string) ([]string, error) {
 		return nil, nil
 	}
 
-	first, err := s.checkAndGetSet(keys[0], false)
+	first, err := s.checkAndGetSet(keys[0])
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +267,7 @@ func (s *SetStructure) SDiff(keys ...string) ([]string, error) {
 	var diffMembers []string
 	// If member is in the first set and also in another set, remove it.
 	for _, key := range keys[1:] {
-		fs, err := s.checkAndGetSet(key, false)
+		fs, err := s.checkAndGetSet(key)
 		if err != nil {
 			return nil, err
 		}
@@ -232,23 +286,53 @@ func (s *SetStructure) SDiff(keys ...string) ([]string, error) {
 
 // Keys returns all the keys of the set structure
 func (s *SetStructure) Keys(regx string) ([]string, error) {
-	toRegexp := convertToRegexp(regx)
-	compile, err := regexp.Compile(toRegexp)
+	return s.ks.keys(regx, Set)
+}
+
+// Scan returns a page of the keys holding a set that match a pattern, see Keyspace.Scan
+func (s *SetStructure) Scan(cursor, match string, count int) (string, []string, error) {
+	return s.ks.Scan(cursor, match, count, typeNames[Set])
+}
+
+// SScan returns a page of the members of a set that match a pattern, in the order of the members.
+// count bounds how many members the call looks at. It returns the cursor of the next page,
+// which is ScanCursorStart once every member was visited.
+func (s *SetStructure) SScan(key string, cursor, match string, count int) (string, []string, error) {
+	if err := checkKey(key); err != nil {
+		return "", nil, err
+	}
+	next, compile, count, err := scanArgs(cursor, match, count)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
-	var keys []string
-	byteKeys := s.db.GetListKeys()
-	for _, key := range byteKeys {
-		if compile.MatchString(string(key)) {
-			// check if deleted
-			if !s.exists(string(key)) {
-				continue
-			}
-			keys = append(keys, string(key))
+	defer s.ks.lockKeys(key)()
+	keyBytes := stringToBytesWithKey(key)
+	meta, err := s.getSetMeta(keyBytes)
+	if err == _const.ErrKeyNotFound || err == _const.ErrKeyIsExpired {
+		return ScanCursorStart, []string{}, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	page := make([]string, 0)
+	if meta.card == 0 {
+		return ScanCursorStart, page, nil
+	}
+
+	prefix := setPrefix(keyBytes)
+	it := s.ks.db.NewIterator(config.IteratorOptions{Start: append(prefix, next...), End: prefixEnd(prefix)})
+	defer it.Close()
+	for examined := 0; examined < count && it.Valid(); it.Next() {
+		examined++
+		member := it.Key()[len(prefix):]
+		if compile.Match(member) {
+			page = append(page, string(member))
 		}
 	}
-	return keys, nil
+	if !it.Valid() {
+		return ScanCursorStart, page, nil
+	}
+	return encodeCursor(it.Key()[len(prefix):]), page, nil
 }
 
 // SUnionStore calculates and stores the union of multiple sets
@@ -303,14 +387,14 @@ func (s *SetStructure) SInterStore(destination string, keys ...string) error {
 	return s.SAdds(destination, 0, inter...)
 }
 
-func (s *SetStructure) checkAndGetSet(key string, createIfNotExist bool) (*FSets, error) {
+func (s *SetStructure) checkAndGetSet(key string) (*FSets, error) {
 	// Check if value is empty
 	if err := checkKey(key); err != nil {
 		return nil, err
 	}
 	keyBytes := stringToBytesWithKey(key)
-	// Get the list
-	set, _, err := s.getSetFromDB(keyBytes, createIfNotExist)
+	// Get the set
+	set, _, err := s.getSetFromDB(keyBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -370,61 +454,20 @@ func (s *FSets) remove(member ...string) error {
 	return nil
 }
 
-// GetSetFromDB retrieves a set from database given a key. If createIfNotExist is true,
-// a new set will be created if the key is not found. It returns the file sets and any write error encountered.
-func (s *SetStructure) getSetFromDB(key []byte, createIfNotExist bool) (*FSets, int64, error) {
-	if s.db == nil {
-		return nil, 0, ErrSetNotInitialized
-	}
-	var zSetValueWithTTL FSetWithTTL
-	dbData, err := s.db.Get(key)
-
-	// If key is not found, return nil for both; otherwise return the error.
-	if err != nil {
-		if errors.Is(err, _const.ErrKeyNotFound) && createIfNotExist {
-			return &FSets{}, 0, nil
-		}
+// getSetFromDB retrieves a set and its expiration time from database given a key.
+func (s *SetStructure) getSetFromDB(key []byte) (*FSets, int64, error) {
+	if err := checkKey(string(key)); err != nil {
 		return nil, 0, err
-	} else {
-		err = encoding.NewMessagePackDecoder(dbData).Decode(&zSetValueWithTTL) // Decode the value along with TTL
-		if err != nil {
-			return nil, 0, err
-		}
-
-		expiration := zSetValueWithTTL.TTL
-		if expiration != 0 && expiration < time.Now().UnixNano() {
-			return nil, -1, _const.ErrKeyIsExpired
-		}
-
-		return zSetValueWithTTL.ZSet, zSetValueWithTTL.TTL, nil // Return the zSetValue and TTL
 	}
-}
-
-type FSetWithTTL struct {
-	ZSet *FSets
-	TTL  int64
-}
-
-// setSetToDB
-func (s *SetStructure) setSetToDB(key []byte, zSetValue *FSets, ttl time.Duration) error {
-	// create a new zSetValueWithTTL struct
-	var expire int64 = 0
-
-	if ttl != 0 {
-		expire = time.Now().Add(ttl).UnixNano()
-	}
-
-	valueWithTTL := &FSetWithTTL{
-		ZSet: zSetValue,
-		TTL:  expire,
+	defer s.ks.lockKeys(string(key))()
+	meta, err := s.getSetMeta(key)
+	if err == _const.ErrKeyIsExpired {
+		return nil, -1, err
 	}
-
-	val := encoding.NewMessagePackEncoder()
-	err := val.Encode(valueWithTTL) // Encode the value along with TTL
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
-	return s.db.Put(key, val.Bytes())
+	return s.members(key), meta.expire, nil // Return the set and its expiration time
 }
 
 func (s *SetStructure) exists(key string, member ...string) bool {
@@ -433,7 +476,7 @@ func (s *SetStructure) exists(key string, member ...string) bool {
 	}
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, _, err := s.getSetFromDB(keyBytes, false)
+	zSet, _, err := s.getSetFromDB(keyBytes)
 
 	if err != nil {
 		return false
@@ -450,16 +493,19 @@ func (s *FSets) exists(member ...string) bool {
 }
 
 func (s *SetStructure) Stop() error {
-	err := s.db.Close()
-	return err
+	return s.ks.Close()
 }
 
 func (s *SetStructure) TTL(k string) (int64, error) {
-	keyBytes := stringToBytesWithKey(k)
-	_, expire, err := s.getSetFromDB(keyBytes, false)
+	if err := checkKey(k); err != nil {
+		return -1, err
+	}
+	defer s.ks.lockKeys(k)()
+	meta, err := s.getSetMeta(stringToBytesWithKey(k))
 	if err != nil {
 		return -1, err
 	}
+	expire := meta.expire
 
 	now := time.Now().UnixNano() / int64(time.Second)
 	expire = expire / int64(time.Second)
@@ -512,10 +558,13 @@ func (s *SetStructure) Size(key string) (string, error) {
 }
 
 func (s *SetStructure) SDel(key string) error {
-	byteKey := stringToBytesWithKey(key)
-	err := s.db.Delete(byteKey)
-	if err != nil {
+	if err := checkKey(key); err != nil {
 		return err
 	}
-	return nil
+	defer s.ks.lockKeys(key)()
+	batch := s.ks.newBatch()
+	if err := s.ks.deleteValue(batch, key); err != nil {
+		return err
+	}
+	return s.ks.commit(batch)
 }
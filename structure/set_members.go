@@ -0,0 +1,217 @@
+package structure
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/encoding"
+)
+
+// A set is stored member by member. The value of its key is the metadata:
+// +----------+------------+------------+------------+
+// |   type   |   expire   |   format   |    card    |
+// +----------+------------+------------+------------+
+// |  1 byte  |  variable  |   1 byte   |  variable  |
+// +----------+------------+------------+------------+
+// Every member has a key of its own, with an empty value, so that the members
+// are read in order through an iterator:
+// +----------+------------+------------+------------+
+// |  prefix  |  key size  |    key     |   member   |
+// +----------+------------+------------+------------+
+// | "\x00st" |   4 bytes  |  variable  |  variable  |
+// +----------+------------+------------+------------+
+// Sets written before, as one msgpack value, are migrated when they are first used.
+
+// setMetaFormat starts the payload of a set stored member by member,
+// the msgpack map of a set stored in one value never starts with it.
+const setMetaFormat byte = 1
+
+var setKeyPrefix = []byte("\x00st")
+
+// setMeta is the metadata of a set
+type setMeta struct {
+	expire int64
+	card   int
+}
+
+func (m *setMeta) encode() []byte {
+	buf := encodeHeader(Set, m.expire)
+	buf = append(buf, setMetaFormat)
+	card := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(card, int64(m.card))
+	return append(buf, card[:n]...)
+}
+
+// decodeSetMetaPayload returns the cardinality stored in the payload of a set
+func decodeSetMetaPayload(payload []byte) (int, error) {
+	if isLegacySet(payload) {
+		return 0, ErrInvalidValue
+	}
+	card, n := binary.Varint(payload[1:])
+	if n <= 0 {
+		return 0, ErrInvalidValue
+	}
+	return int(card), nil
+}
+
+// isLegacySet reports whether the payload of a set holds the whole set as one msgpack value
+func isLegacySet(payload []byte) bool {
+	return len(payload) == 0 || payload[0] != setMetaFormat
+}
+
+// setPrefix returns the prefix of the member keys of a set
+func setPrefix(key []byte) []byte {
+	prefix := make([]byte, len(setKeyPrefix)+4, len(setKeyPrefix)+4+len(key))
+	n := copy(prefix, setKeyPrefix)
+	binary.BigEndian.PutUint32(prefix[n:], uint32(len(key)))
+	return append(prefix, key...)
+}
+
+// setMemberKey returns the key of a member of a set
+func setMemberKey(key []byte, member string) []byte {
+	return append(setPrefix(key), member...)
+}
+
+// setKeys returns the member keys of a set
+func (ks *Keyspace) setKeys(key string) [][]byte {
+	prefix := setPrefix([]byte(key))
+	it := ks.db.NewIterator(config.IteratorOptions{Start: prefix, End: prefixEnd(prefix)})
+	defer it.Close()
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, append([]byte(nil), it.Key()...))
+	}
+	return keys
+}
+
+// getSetMeta returns the metadata of a set. A set stored in one value is migrated first,
+// so the key must be locked even to read it.
+func (s *SetStructure) getSetMeta(key []byte) (*setMeta, error) {
+	if s.ks.db == nil {
+		return nil, ErrSetNotInitialized
+	}
+	value, err := s.ks.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	expire, payload, err := decodeValue(value, Set)
+	if err != nil {
+		return nil, err
+	}
+	if isLegacySet(payload) {
+		return s.migrateSet(key, expire, payload)
+	}
+	card, err := decodeSetMetaPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &setMeta{expire: expire, card: card}, nil
+}
+
+// getOrCreateSetMeta returns the metadata of a set to write to. A set that does not
+// exist or expired is empty, the deletes of the members it had are added to batch.
+func (s *SetStructure) getOrCreateSetMeta(batch *engine.WriteBatch, key string) (*setMeta, error) {
+	if err := s.ks.checkType(key, Set); err != nil {
+		return nil, err
+	}
+	meta, err := s.getSetMeta(stringToBytesWithKey(key))
+	if errors.Is(err, _const.ErrKeyIsExpired) {
+		if err := s.ks.deleteValue(batch, key); err != nil {
+			return nil, err
+		}
+		return &setMeta{}, nil
+	}
+	if errors.Is(err, _const.ErrKeyNotFound) {
+		return &setMeta{}, nil
+	}
+	return meta, err
+}
+
+// hasMember reports whether a member belongs to a set
+func (s *SetStructure) hasMember(key []byte, member string) (bool, error) {
+	_, err := s.ks.db.Get(setMemberKey(key, member))
+	if err == _const.ErrKeyNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// members returns the members of a set
+func (s *SetStructure) members(key []byte) *FSets {
+	prefix := setPrefix(key)
+	it := s.ks.db.NewIterator(config.IteratorOptions{Start: prefix, End: prefixEnd(prefix)})
+	defer it.Close()
+	fs := FSets{}
+	for ; it.Valid(); it.Next() {
+		fs[string(it.Key()[len(prefix):])] = struct{}{}
+	}
+	return &fs
+}
+
+// commitSet writes the metadata of a set with the rest of batch
+func (s *SetStructure) commitSet(batch *engine.WriteBatch, key []byte, meta *setMeta) error {
+	_ = batch.Put(key, meta.encode())
+	s.ks.indexExpire(batch, key, meta.expire)
+	return s.ks.commit(batch)
+}
+
+// migrateSet rewrites a set stored in one value member by member and returns its metadata
+func (s *SetStructure) migrateSet(key []byte, expire int64, payload []byte) (*setMeta, error) {
+	fs := FSets{}
+	if err := encoding.NewMessagePackDecoder(payload).Decode(&fs); err != nil {
+		return nil, err
+	}
+	batch := s.ks.newBatch()
+	for member := range fs {
+		_ = batch.Put(setMemberKey(key, member), nil)
+	}
+	meta := &setMeta{expire: expire, card: len(fs)}
+	if err := s.commitSet(batch, key, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Migrate rewrites every set that is still stored in one value member by member.
+// Sets are also migrated when they are first used, Migrate spares that cost
+// to the first command. It returns how many sets it rewrote.
+func (s *SetStructure) Migrate() (int, error) {
+	keys, err := s.ks.keys("*", Set)
+	if err != nil {
+		return 0, err
+	}
+	migrated := 0
+	for _, key := range keys {
+		ok, err := s.migrateKey(key)
+		if err != nil {
+			return migrated, err
+		}
+		if ok {
+			migrated++
+		}
+	}
+	return migrated, nil
+}
+
+// migrateKey migrates a set if it is still stored in one value, and reports whether it did
+func (s *SetStructure) migrateKey(key string) (bool, error) {
+	defer s.ks.lockKeys(key)()
+	value, err := s.ks.db.Get([]byte(key))
+	if err == _const.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	expire, payload, err := decodeValue(value, Set)
+	if err != nil || !isLegacySet(payload) {
+		return false, nil
+	}
+	if _, err := s.migrateSet([]byte(key), expire, payload); err != nil {
+		return false, err
+	}
+	return true, nil
+}
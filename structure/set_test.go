@@ -5,6 +5,7 @@ import (
 	"github.com/ByteStorage/FlyDB/config"
 	"github.com/ByteStorage/FlyDB/db/engine"
 	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/encoding"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"reflect"
@@ -17,13 +18,14 @@ func initTestSetDb() (*SetStructure, *config.Options) {
 	opts := config.DefaultOptions
 	dir, _ := os.MkdirTemp("", "TestSetStructure")
 	opts.DirPath = dir
-	str, _ := NewSetStructure(opts)
+	ks, _ := NewKeyspaceWithSweepOptions(opts, config.ExpireSweepOptions{})
+	str := NewSetStructureWithKeyspace(ks)
 	return str, &opts
 }
 
 func TestSetStructure_TTL(t *testing.T) {
 	set, _ := initTestSetDb()
-	defer set.db.Clean()
+	defer set.ks.db.Clean()
 
 	//err := set.SAdd("1", "123123", 0)
 	//assert.Nil(t, err)
@@ -57,7 +59,7 @@ func TestSetStructure_TTL(t *testing.T) {
 
 func TestSetStructure_Size(t *testing.T) {
 	set, _ := initTestSetDb()
-	defer set.db.Clean()
+	defer set.ks.db.Clean()
 
 	err = set.SAdd("2", "123123", 0)
 	assert.Nil(t, err)
@@ -72,7 +74,7 @@ func TestSetStructure_Size(t *testing.T) {
 
 func TestSetStructure_SDel(t *testing.T) {
 	set, _ := initTestSetDb()
-	defer set.db.Clean()
+	defer set.ks.db.Clean()
 
 	err = set.SAdd("2", "123123", 0)
 	assert.Nil(t, err)
@@ -130,7 +132,7 @@ func TestSAdd(t *testing.T) {
 		{
 			name: "test add db not init",
 			setup: func(s *SetStructure) {
-				s.db = nil
+				s.ks.db = nil
 			},
 			key:         "destination",
 			membersAdd:  []string{"key1", "key2"},
@@ -963,7 +965,7 @@ func TestNewSetStructure(t *testing.T) {
 	opts.DirPath = dir
 	setDB, _ = NewSetStructure(opts)
 	assert.NotNil(t, setDB)
-	assert.IsType(t, &engine.DB{}, setDB.db)
+	assert.IsType(t, &engine.DB{}, setDB.ks.db)
 }
 
 func TestSetStructure_exists(t *testing.T) {
@@ -1142,7 +1144,7 @@ func TestFSets_remove(t *testing.T) {
 
 func TestSetStructure_Keys(t *testing.T) {
 	set, _ := initTestSetDb()
-	defer set.db.Clean()
+	defer set.ks.db.Clean()
 
 	err = set.SAdd("testKey11", "non1", 0)
 	assert.Nil(t, err)
@@ -1177,3 +1179,69 @@ func TestSetStructure_Keys(t *testing.T) {
 	assert.Equal(t, 0, len(keys))
 
 }
+
+func TestSetStructure_Migrate(t *testing.T) {
+	set, _ := initTestSetDb()
+	defer set.ks.Clean()
+
+	// Sets written as one msgpack value, as they were before
+	for _, key := range []string{"lazy", "explicit"} {
+		enc := encoding.NewMessagePackEncoder()
+		assert.Nil(t, enc.Encode(&FSets{"a": {}, "b": {}, "c": {}}))
+		assert.Nil(t, set.ks.db.Put([]byte(key), append(encodeHeader(Set, 0), enc.Bytes()...)))
+	}
+	typ, err := set.ks.Type("lazy")
+	assert.Nil(t, err)
+	assert.Equal(t, "set", typ)
+
+	// A set is migrated when it is first used
+	ok, err := set.SIsMember("lazy", "b")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, len(set.ks.setKeys("lazy")))
+
+	migrated, err := set.Migrate()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, migrated)
+	migrated, err = set.Migrate()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, migrated)
+
+	card, err := set.SCard("explicit")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, card)
+}
+
+func TestSetStructure_MemberKeys(t *testing.T) {
+	set, _ := initTestSetDb()
+	defer set.ks.Clean()
+
+	assert.Nil(t, set.SAdds("set", 0, "a", "b", "b", "c"))
+	card, err := set.SCard("set")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, card)
+
+	// Removing a member that is missing removes none
+	assert.Equal(t, ErrMemberNotFound, set.SRems("set", 0, "a", "missing"))
+	assert.Nil(t, set.SRems("set", 0, "a", "a"))
+	card, err = set.SCard("set")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, card)
+
+	// The members move with the set and are deleted with it
+	assert.Nil(t, set.ks.Rename("set", "renamed"))
+	assert.Empty(t, set.ks.setKeys("set"))
+	members, err := set.SMembers("renamed")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"b", "c"}, members)
+	assert.Nil(t, set.SDel("renamed"))
+	assert.Empty(t, set.ks.setKeys("renamed"))
+
+	// A set that expired starts empty
+	assert.Nil(t, set.SAdds("expired", 1, "a", "b"))
+	time.Sleep(1100 * time.Millisecond)
+	assert.Nil(t, set.SAdds("expired", 0, "b"))
+	members, err = set.SMembers("expired")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b"}, members)
+}
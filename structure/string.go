@@ -5,16 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
-	"github.com/ByteStorage/FlyDB/db/engine"
 	_const "github.com/ByteStorage/FlyDB/lib/const"
-	"regexp"
 	"strconv"
 	"time"
 )
 
 // StringStructure is a structure that stores string data
 type StringStructure struct {
-	db        *engine.DB
+	ks        *Keyspace
 	valueType string
 }
 
@@ -23,11 +21,16 @@ type StringStructure struct {
 // or the database cannot be created
 // The database will be created if it does not exist
 func NewStringStructure(options config.Options) (*StringStructure, error) {
-	db, err := engine.NewDB(options)
+	ks, err := NewKeyspace(options)
 	if err != nil {
 		return nil, err
 	}
-	return &StringStructure{db: db}, nil
+	return NewStringStructureWithKeyspace(ks), nil
+}
+
+// NewStringStructureWithKeyspace returns a StringStructure that stores its strings in a shared keyspace
+func NewStringStructureWithKeyspace(ks *Keyspace) *StringStructure {
+	return &StringStructure{ks: ks}
 }
 
 // stringToBytesWithKey converts a string to a byte slice
@@ -46,6 +49,12 @@ func integerToDuration(ttl int64) time.Duration {
 // If the key is not expired, it will be updated
 // func (s *StringStructure) Set(key, value []byte, ttl time.Duration) error {
 func (s *StringStructure) Set(k string, v interface{}, ttl int64) error {
+	defer s.ks.lockKeys(k)()
+	return s.set(k, v, ttl)
+}
+
+// set is Set with the lock of the key held
+func (s *StringStructure) set(k string, v interface{}, ttl int64) error {
 	key := stringToBytesWithKey(k)
 	value, err, valueType := interfaceToBytes(v)
 
@@ -60,6 +69,11 @@ func (s *StringStructure) Set(k string, v interface{}, ttl int64) error {
 		return nil
 	}
 
+	// A key holding another data structure is not overwritten
+	if err = s.ks.checkType(k, String); err != nil {
+		return err
+	}
+
 	// Encode the value
 	encValue, err := encodeStringValue(value, integerToDuration(ttl))
 	if err != nil {
@@ -67,7 +81,7 @@ func (s *StringStructure) Set(k string, v interface{}, ttl int64) error {
 	}
 
 	// Set the value
-	return s.db.Put(key, encValue)
+	return s.ks.putValue(key, encValue)
 }
 
 // Get gets the value of a key
@@ -76,10 +90,13 @@ func (s *StringStructure) Set(k string, v interface{}, ttl int64) error {
 // If the key is expired, it will be deleted and return nil
 // If the key is not expired, it will be updated and return the value
 func (s *StringStructure) Get(k string) (interface{}, error) {
+	if err := checkKey(k); err != nil {
+		return nil, err
+	}
 	key := stringToBytesWithKey(k)
 
 	// Get the value
-	value, err := s.db.Get(key)
+	value, err := s.ks.db.Get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -105,9 +122,13 @@ func (s *StringStructure) Get(k string) (interface{}, error) {
 // If the key is expired, it will be deleted and return nil
 // If the key is not expired, it will be updated and return nil
 func (s *StringStructure) Del(k string) error {
+	if err := checkKey(k); err != nil {
+		return err
+	}
+	defer s.ks.lockKeys(k)()
 	key := stringToBytesWithKey(k)
 	// Delete the value
-	return s.db.Delete(key)
+	return s.ks.db.Delete(key)
 }
 
 // Type returns the type of a key
@@ -116,9 +137,12 @@ func (s *StringStructure) Del(k string) error {
 // If the key is expired, it will be deleted and return ""
 // If the key is not expired, it will be updated and return "string"
 func (s *StringStructure) Type(k string) (string, error) {
+	if err := checkKey(k); err != nil {
+		return "", err
+	}
 	key := stringToBytesWithKey(k)
 	// Get the value
-	value, err := s.db.Get(key)
+	value, err := s.ks.db.Get(key)
 	if err != nil {
 		return "", err
 	}
@@ -139,9 +163,12 @@ func (s *StringStructure) Type(k string) (string, error) {
 // If the key is expired, it will be deleted and return 0
 // If the key is not expired, it will be updated and return the length of the value
 func (s *StringStructure) StrLen(k string) (int, error) {
+	if err := checkKey(k); err != nil {
+		return 0, err
+	}
 	key := stringToBytesWithKey(k)
 	// Get the value
-	value, err := s.db.Get(key)
+	value, err := s.ks.db.Get(key)
 	if err != nil {
 		return 0, err
 	}
@@ -158,6 +185,8 @@ func (s *StringStructure) StrLen(k string) (int, error) {
 
 // GetSet sets the value of a key and returns its old value
 func (s *StringStructure) GetSet(key string, value interface{}, ttl int64) (interface{}, error) {
+	defer s.ks.lockKeys(key)()
+
 	// Get the old value
 	oldValue, err := s.Get(key)
 	if err != nil {
@@ -165,7 +194,7 @@ func (s *StringStructure) GetSet(key string, value interface{}, ttl int64) (inte
 	}
 
 	// Set the value
-	err = s.Set(key, value, ttl)
+	err = s.set(key, value, ttl)
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +205,8 @@ func (s *StringStructure) GetSet(key string, value interface{}, ttl int64) (inte
 
 // Append appends a value to the value of a key
 func (s *StringStructure) Append(key string, v interface{}, ttl int64) error {
+	defer s.ks.lockKeys(key)()
+
 	// Get the old value
 	oldValue, err := s.Get(key)
 	if err != nil {
@@ -194,11 +225,13 @@ func (s *StringStructure) Append(key string, v interface{}, ttl int64) error {
 	newValue := append(oldValueType, value...)
 
 	// Set the value
-	return s.Set(key, string(newValue), ttl)
+	return s.set(key, string(newValue), ttl)
 }
 
 // Incr increments the integer value of a key by 1
 func (s *StringStructure) Incr(key string, ttl int64) error {
+	defer s.ks.lockKeys(key)()
+
 	// Get the old value
 	oldValue, err := s.Get(key)
 	if err != nil {
@@ -217,11 +250,13 @@ func (s *StringStructure) Incr(key string, ttl int64) error {
 	newValue := strconv.Itoa(newIntValue)
 
 	// Set the value
-	return s.Set(key, newValue, ttl)
+	return s.set(key, newValue, ttl)
 }
 
 // IncrBy increments the integer value of a key by the given amount
 func (s *StringStructure) IncrBy(key string, amount int, ttl int64) error {
+	defer s.ks.lockKeys(key)()
+
 	// Get the old value
 	oldValue, err := s.Get(key)
 	if err != nil {
@@ -238,11 +273,13 @@ func (s *StringStructure) IncrBy(key string, amount int, ttl int64) error {
 
 	newValue := strconv.Itoa(newIntValue)
 
-	return s.Set(key, newValue, ttl)
+	return s.set(key, newValue, ttl)
 }
 
 // IncrByFloat increments the float value of a key by the given amount
 func (s *StringStructure) IncrByFloat(key string, amount float64, ttl int64) error {
+	defer s.ks.lockKeys(key)()
+
 	// Get the old value
 	oldValue, err := s.Get(key)
 	if err != nil {
@@ -262,11 +299,13 @@ func (s *StringStructure) IncrByFloat(key string, amount float64, ttl int64) err
 	newValue := strconv.FormatFloat(newFloatValue, 'f', -1, 64)
 
 	// Set the value
-	return s.Set(key, newValue, ttl)
+	return s.set(key, newValue, ttl)
 }
 
 // Decr decrements the integer value of a key by 1
 func (s *StringStructure) Decr(key string, ttl int64) error {
+	defer s.ks.lockKeys(key)()
+
 	// Get the old value
 	oldValue, err := s.Get(key)
 	if err != nil {
@@ -285,11 +324,13 @@ func (s *StringStructure) Decr(key string, ttl int64) error {
 	newValue := strconv.Itoa(newIntValue)
 
 	// Set the value
-	return s.Set(key, newValue, ttl)
+	return s.set(key, newValue, ttl)
 }
 
 // DecrBy decrements the integer value of a key by the given amount
 func (s *StringStructure) DecrBy(key string, amount int, ttl int64) error {
+	defer s.ks.lockKeys(key)()
+
 	// Get the old value
 	oldValue, err := s.Get(key)
 	if err != nil {
@@ -308,30 +349,17 @@ func (s *StringStructure) DecrBy(key string, amount int, ttl int64) error {
 	newValue := strconv.Itoa(newIntValue)
 
 	// Set the value
-	return s.Set(key, newValue, ttl)
+	return s.set(key, newValue, ttl)
 }
 
 // Keys returns all keys matching pattern
 func (s *StringStructure) Keys(regx string) ([]string, error) {
-	toRegexp := convertToRegexp(regx)
-	compile, err := regexp.Compile(toRegexp)
-	if err != nil {
-		return nil, err
-	}
-	var keys []string
-	byteKeys := s.db.GetListKeys()
-	for _, key := range byteKeys {
-		if compile.MatchString(string(key)) {
-			// check if key is expired
-			_, err := s.Get(string(key))
-			if err != nil {
-				continue
-			}
-			keys = append(keys, string(key))
-		}
+	return s.ks.keys(regx, String)
+}
 
-	}
-	return keys, nil
+// Scan returns a page of the keys holding a string that match a pattern, see Keyspace.Scan
+func (s *StringStructure) Scan(cursor, match string, count int) (string, []string, error) {
+	return s.ks.Scan(cursor, match, count, typeNames[String])
 }
 
 // Exists checks if a key exists
@@ -350,6 +378,8 @@ func (s *StringStructure) Exists(key string) (bool, error) {
 
 // Expire sets the expiration time of a key
 func (s *StringStructure) Expire(key string, ttl int64) error {
+	defer s.ks.lockKeys(key)()
+
 	// Get the value
 	oldValue, err := s.Get(key)
 	if err != nil {
@@ -357,11 +387,13 @@ func (s *StringStructure) Expire(key string, ttl int64) error {
 	}
 
 	// Set the value
-	return s.Set(key, oldValue, ttl)
+	return s.set(key, oldValue, ttl)
 }
 
 // Persist removes the expiration time of a key
 func (s *StringStructure) Persist(key string) error {
+	defer s.ks.lockKeys(key)()
+
 	// Get the value
 	value, err := s.Get(key)
 	if err != nil {
@@ -369,15 +401,18 @@ func (s *StringStructure) Persist(key string) error {
 	}
 
 	// Set the value
-	return s.Set(key, value, 0)
+	return s.set(key, value, 0)
 }
 
 // TTL returns the time to live of a key
 func (s *StringStructure) TTL(key string) (int64, error) {
+	if err := checkKey(key); err != nil {
+		return -1, err
+	}
 	k := stringToBytesWithKey(key)
 
 	// Get the value
-	value, err := s.db.Get(k)
+	value, err := s.ks.db.Get(k)
 	if err != nil {
 		return -1, err
 	}
@@ -484,13 +519,19 @@ func (s *StringStructure) MSetNX(pairs ...interface{}) (bool, error) {
 		return false, errors.New("Wrong number of arguments")
 	}
 
-	// Check if any of the specified keys already exist
+	// Lock all the keys, so that none of them is set between the check and the writes
+	keys := make([]string, 0, len(pairs)/2)
 	for i := 0; i < len(pairs); i += 2 {
 		key, ok := pairs[i].(string)
 		if !ok {
 			return false, errors.New("Invalid key")
 		}
+		keys = append(keys, key)
+	}
+	defer s.ks.lockKeys(keys...)()
 
+	// Check if any of the specified keys already exist
+	for _, key := range keys {
 		exists, err := s.Exists(key)
 		if err != nil {
 			return false, err
@@ -517,7 +558,7 @@ func (s *StringStructure) MSetNX(pairs ...interface{}) (bool, error) {
 
 	// Set each key-value pair in the map
 	for key, value := range data {
-		if err := s.Set(key, value, 0); err != nil {
+		if err := s.set(key, value, 0); err != nil {
 			return false, err
 		}
 	}
@@ -584,46 +625,19 @@ var (
 // expire: 8 bytes
 // value: n bytes
 func decodeStringValue(value []byte) ([]byte, int64, error) {
-	// Check the length of the value
-	if len(value) < 1 {
-		return nil, -1, ErrInvalidValue
-	}
-
-	// Check the type of the value
-	if value[0] != String {
-		return nil, -1, ErrInvalidType
-	}
-
-	// Use the variable bufIndex to keep track of the current index position in value,
-	// starting from 1 to indicate the number of bytes read so far.
-	var bufIndex = 1
-
-	// Decode the expiration time expire from the sub-slice of byte slice value
-	// starting from the current index position bufIndex.
-	expire, n := binary.Varint(value[bufIndex:])
-
-	// Check the number of bytes read
-	if n <= 0 {
-		return nil, -1, ErrInvalidValue
-	}
-
-	// Update the current index position bufIndex by adding the number of bytes read n.
-	bufIndex += n
-
-	// Check the expiration time expire
-	if expire != 0 && expire < time.Now().UnixNano() {
-		return nil, -1, _const.ErrKeyIsExpired
+	expire, payload, err := decodeValue(value, String)
+	if err != nil {
+		return nil, -1, err
 	}
 
 	// Return the original value value
-	return value[bufIndex:], expire, nil
+	return payload, expire, nil
 }
 
 func (s *StringStructure) Stop() error {
-	err := s.db.Close()
-	return err
+	return s.ks.Close()
 }
 
 func (s *StringStructure) Clean() {
-	s.db.Clean()
+	s.ks.db.Clean()
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/ByteStorage/FlyDB/lib/randkv"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"sync"
 	"testing"
 	"time"
 )
@@ -16,13 +17,15 @@ func initdb() (*StringStructure, *config.Options) {
 	opts := config.DefaultOptions
 	dir, _ := os.MkdirTemp("", "TestStringStructure_Get")
 	opts.DirPath = dir
-	str, _ := NewStringStructure(opts)
+	// The sweeper is off, so that expired keys stay until they are read
+	ks, _ := NewKeyspaceWithSweepOptions(opts, config.ExpireSweepOptions{})
+	str := NewStringStructureWithKeyspace(ks)
 	return str, &opts
 }
 
 func TestStringStructure_Get(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", randkv.RandomValue(100), 0)
 	assert.Nil(t, err)
@@ -45,7 +48,7 @@ func TestStringStructure_Get(t *testing.T) {
 
 func TestStringStructure_Del(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", randkv.RandomValue(100), 0)
 	assert.Nil(t, err)
@@ -59,7 +62,7 @@ func TestStringStructure_Del(t *testing.T) {
 
 func TestStringStructure_Type(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", randkv.RandomValue(100), 0)
 	assert.Nil(t, err)
@@ -73,7 +76,7 @@ func TestStringStructure_Type(t *testing.T) {
 
 func TestStringStructure_StrLen(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", randkv.RandomValue(100), 0)
 	assert.Nil(t, err)
@@ -85,7 +88,7 @@ func TestStringStructure_StrLen(t *testing.T) {
 
 func TestStringStructure_GetSet(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", randkv.RandomValue(100), 0)
 	assert.Nil(t, err)
@@ -99,7 +102,7 @@ func TestStringStructure_GetSet(t *testing.T) {
 
 func TestStringStructure_Append(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", "msg", 0)
 	assert.Nil(t, err)
@@ -113,7 +116,7 @@ func TestStringStructure_Append(t *testing.T) {
 
 func TestStringStructure_Incr(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", "1", 0)
 	assert.Nil(t, err)
@@ -144,9 +147,32 @@ func TestStringStructure_Incr(t *testing.T) {
 	assert.Equal(t, v4, "2")
 }
 
+func TestStringStructure_IncrConcurrent(t *testing.T) {
+	str, _ := initdb()
+	defer str.ks.Clean()
+
+	assert.Nil(t, str.Set("counter", "0", 0))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Nil(t, str.Incr("counter", 0))
+			}
+		}()
+	}
+	wg.Wait()
+
+	// No increment is lost between the read and the write of another one
+	value, err := str.Get("counter")
+	assert.Nil(t, err)
+	assert.Equal(t, "800", value)
+}
+
 func TestStringStructure_IncrBy(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", 1, 0)
 	assert.Nil(t, err)
@@ -183,7 +209,7 @@ func TestStringStructure_IncrBy(t *testing.T) {
 
 func TestStringStructure_IncrByFloat(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", "1", 0)
 	assert.Nil(t, err)
@@ -217,7 +243,7 @@ func TestStringStructure_IncrByFloat(t *testing.T) {
 
 func TestStringStructure_Decr(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", "1", 0)
 	assert.Nil(t, err)
@@ -250,7 +276,7 @@ func TestStringStructure_Decr(t *testing.T) {
 
 func TestStringStructure_DecrBy(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", 1, 0)
 	assert.Nil(t, err)
@@ -284,7 +310,7 @@ func TestStringStructure_DecrBy(t *testing.T) {
 
 func TestStringStructure_Exists(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", []byte("1"), 0)
 	assert.Nil(t, err)
@@ -300,7 +326,7 @@ func TestStringStructure_Exists(t *testing.T) {
 
 func TestStringStructure_Expire(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", []byte("1"), 0)
 	assert.Nil(t, err)
@@ -333,7 +359,7 @@ func TestStringStructure_Expire(t *testing.T) {
 
 func TestStringStructure_Persist(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", []byte("1"), 0)
 	assert.Nil(t, err)
@@ -353,7 +379,7 @@ func TestStringStructure_Persist(t *testing.T) {
 
 func TestStringStructure_MGet(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err := str.Set("key1", "value1", 0)
 	assert.Nil(t, err)
@@ -373,7 +399,7 @@ func TestStringStructure_MGet(t *testing.T) {
 
 func TestStringStructure_MSet(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err := str.MSet("key1", "value1", "key2", "value2", "key3", "value3")
 	assert.Nil(t, err)
@@ -393,7 +419,7 @@ func TestStringStructure_MSet(t *testing.T) {
 
 func TestStringStructure_MSetNX(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	// Test case: All keys and values are new, should return true
 	success, err := str.MSetNX("key1", "value1", "key2", "value2", "key3", "value3")
@@ -423,7 +449,7 @@ func TestStringStructure_MSetNX(t *testing.T) {
 
 func TestStringStructure_Keys(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("11", randkv.RandomValue(100), 1)
 	assert.Nil(t, err)
@@ -461,7 +487,7 @@ func TestStringStructure_Keys(t *testing.T) {
 
 func TestStringStructure_TTL(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", []byte("1"), 2)
 	assert.Nil(t, err)
@@ -491,7 +517,7 @@ func TestStringStructure_TTL(t *testing.T) {
 
 func TestStringStructure_Size(t *testing.T) {
 	str, _ := initdb()
-	defer str.db.Clean()
+	defer str.ks.db.Clean()
 
 	err = str.Set("1", []byte("1"), 0)
 	assert.Nil(t, err)
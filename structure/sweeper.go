@@ -0,0 +1,216 @@
+package structure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"go.uber.org/zap"
+)
+
+// The expiry index holds an entry for every value written with an expiry time,
+// ordered by that time, so that the sweeper finds the keys that are due without
+// reading the others:
+// +----------+------------+------------+------------+------------+
+// |  prefix  |  deadline  |  key size  |    key     |   suffix   |
+// +----------+------------+------------+------------+------------+
+// | "\x00exp"|   8 bytes  |   4 bytes  |  variable  |   "expk"   |
+// +----------+------------+------------+------------+------------+
+// An entry is not removed when its key is deleted or gets another expiry time,
+// the sweeper drops such entries once their deadline passes.
+var (
+	expireIndexPrefix = []byte("\x00exp")
+	expireIndexSuffix = []byte("expk")
+)
+
+// ExpiredEvent reports a key that the sweeper deleted because its time to live ran out
+type ExpiredEvent struct {
+	Key    string
+	Type   string
+	Expire time.Time
+}
+
+// NotifyExpired registers ch to receive an ExpiredEvent for every key the sweeper deletes.
+// The sweeper does not wait for ch, the events it cannot send right away are dropped.
+func (ks *Keyspace) NotifyExpired(ch chan<- ExpiredEvent) {
+	ks.listenersLock.Lock()
+	defer ks.listenersLock.Unlock()
+	ks.listeners = append(ks.listeners, ch)
+}
+
+// startSweeper deletes expired keys in the background until stopSweeper is called
+func (ks *Keyspace) startSweeper(options config.ExpireSweepOptions) {
+	if options.Interval <= 0 || options.MaxKeysPerSweep <= 0 {
+		close(ks.sweepDone)
+		return
+	}
+	go func() {
+		defer close(ks.sweepDone)
+		ticker := time.NewTicker(options.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ks.sweepStop:
+				return
+			case <-ticker.C:
+				ks.sweep(options.MaxKeysPerSweep)
+			}
+		}
+	}()
+}
+
+// stopSweeper stops the sweeper and waits for the sweep in progress to finish
+func (ks *Keyspace) stopSweeper() {
+	ks.sweepOnce.Do(func() {
+		close(ks.sweepStop)
+	})
+	<-ks.sweepDone
+}
+
+// sweep deletes the keys whose deadline passed, looking at no more than limit entries
+// of the expiry index. It returns how many keys it deleted. An entry that fails is logged
+// and skipped, so that it does not hold back the entries after it.
+// The expiry index is not read again while nothing was committed since a sweep found it empty.
+func (ks *Keyspace) sweep(limit int) int {
+	// loaded before the index is read, a commit the read may miss changes it
+	commits := atomic.LoadUint64(&ks.commits)
+	if ks.sweepIdle && ks.sweepIdleAt == commits {
+		return 0
+	}
+	now := time.Now().UnixNano()
+	var due [][]byte
+	it := ks.db.NewIterator(config.IteratorOptions{Prefix: expireIndexPrefix})
+	ks.sweepIdle, ks.sweepIdleAt = !it.Valid(), commits
+	for ; it.Valid() && len(due) < limit; it.Next() {
+		deadline, _, ok := decodeExpireIndexKey(it.Key())
+		if !ok {
+			continue
+		}
+		if deadline > now {
+			break
+		}
+		due = append(due, append([]byte(nil), it.Key()...))
+	}
+	it.Close()
+
+	deleted := 0
+	for _, indexKey := range due {
+		event, err := ks.expireEntry(indexKey)
+		if err != nil {
+			_, key, _ := decodeExpireIndexKey(indexKey)
+			zap.L().Warn("sweep expired key", zap.ByteString("key", key), zap.Error(err))
+			continue
+		}
+		if event != nil {
+			deleted++
+			ks.emitExpired(*event)
+		}
+	}
+	return deleted
+}
+
+// expireEntry removes an entry of the expiry index whose deadline passed, and deletes its key
+// if the key still has that deadline. It returns the event to emit if the key was deleted.
+func (ks *Keyspace) expireEntry(indexKey []byte) (*ExpiredEvent, error) {
+	deadline, key, _ := decodeExpireIndexKey(indexKey)
+
+	// writes to the key wait, so that a value written after the check is not deleted
+	defer ks.lockKeys(string(key))()
+
+	batch := ks.newBatch()
+	_ = batch.Delete(indexKey)
+	var event *ExpiredEvent
+	value, err := ks.db.Get(key)
+	if err != nil && err != _const.ErrKeyNotFound {
+		return nil, err
+	}
+	if err == nil {
+		typ, expire, _, err := decodeHeader(value)
+		if err == nil && expire == deadline {
+			if err := ks.deleteValue(batch, string(key)); err != nil {
+				return nil, err
+			}
+			event = &ExpiredEvent{Key: string(key), Type: typeNames[typ], Expire: time.Unix(0, deadline)}
+		}
+	}
+	if err := ks.commit(batch); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// emitExpired sends an event to the listeners that are ready for it
+func (ks *Keyspace) emitExpired(event ExpiredEvent) {
+	ks.listenersLock.Lock()
+	defer ks.listenersLock.Unlock()
+	for _, ch := range ks.listeners {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// putValue writes the value of a key, and its entry in the expiry index if it expires.
+// The key must be locked.
+func (ks *Keyspace) putValue(key, value []byte) error {
+	batch := ks.newBatch()
+	_ = batch.Put(key, value)
+	if _, expire, _, err := decodeHeader(value); err == nil {
+		ks.indexExpire(batch, key, expire)
+	}
+	return ks.commit(batch)
+}
+
+// indexExpire adds the entry of a key to the expiry index, a key that does not expire has none
+func (ks *Keyspace) indexExpire(batch *engine.WriteBatch, key []byte, expire int64) {
+	if expire != 0 {
+		_ = batch.Put(encodeExpireIndexKey(expire, key), nil)
+	}
+}
+
+// commit commits a batch that writes the values of the structures.
+// The keys it writes must be locked since their values were read, see lockKeys.
+func (ks *Keyspace) commit(batch *engine.WriteBatch) error {
+	if err := batch.Commit(); err != nil {
+		return err
+	}
+	atomic.AddUint64(&ks.commits, 1)
+	return nil
+}
+
+// encodeExpireIndexKey returns the key of the entry of the expiry index for a key and its deadline
+func encodeExpireIndexKey(deadline int64, key []byte) []byte {
+	buf := make([]byte, len(expireIndexPrefix)+8+4, len(expireIndexPrefix)+8+4+len(key)+len(expireIndexSuffix))
+	n := copy(buf, expireIndexPrefix)
+	binary.BigEndian.PutUint64(buf[n:], uint64(deadline))
+	binary.BigEndian.PutUint32(buf[n+8:], uint32(len(key)))
+	buf = append(buf, key...)
+	return append(buf, expireIndexSuffix...)
+}
+
+// decodeExpireIndexKey returns the deadline and the key of an entry of the expiry index,
+// ok is false if indexKey is not such an entry
+func decodeExpireIndexKey(indexKey []byte) (deadline int64, key []byte, ok bool) {
+	headerLen := len(expireIndexPrefix) + 8 + 4
+	if !bytes.HasPrefix(indexKey, expireIndexPrefix) || !bytes.HasSuffix(indexKey, expireIndexSuffix) ||
+		len(indexKey) < headerLen+len(expireIndexSuffix) {
+		return 0, nil, false
+	}
+	keyLen := int(binary.BigEndian.Uint32(indexKey[headerLen-4:]))
+	if headerLen+keyLen+len(expireIndexSuffix) != len(indexKey) {
+		return 0, nil, false
+	}
+	deadline = int64(binary.BigEndian.Uint64(indexKey[len(expireIndexPrefix):]))
+	return deadline, indexKey[headerLen : headerLen+keyLen], true
+}
+
+// isInternalKey reports whether a key of the engine holds a hash field, a member of a set or a sorted set,
+// a chunk of a list or an entry of the expiry index, rather than the value of a key of the data structures
+func isInternalKey(key []byte) bool {
+	return len(key) > 0 && key[0] == internalKeyPrefix
+}
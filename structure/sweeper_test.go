@@ -0,0 +1,174 @@
+package structure
+
+import (
+	"fmt"
+
+	"github.com/ByteStorage/FlyDB/config"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+func initKeyspaceWithSweepOptions(sweepOptions config.ExpireSweepOptions) *Keyspace {
+	opts := config.DefaultOptions
+	dir, _ := os.MkdirTemp("", "TestSweeper")
+	opts.DirPath = dir
+	ks, _ := NewKeyspaceWithSweepOptions(opts, sweepOptions)
+	return ks
+}
+
+func TestKeyspace_Sweep(t *testing.T) {
+	// The sweeper only runs when the test calls sweep
+	ks := initKeyspaceWithSweepOptions(config.ExpireSweepOptions{})
+	defer ks.Clean()
+
+	str := NewStringStructureWithKeyspace(ks)
+	hash := NewHashStructureWithKeyspace(ks)
+	set := NewSetStructureWithKeyspace(ks)
+
+	for _, key := range []string{"a", "b", "c"} {
+		assert.Nil(t, str.Set(key, "value", 1))
+	}
+	assert.Nil(t, str.Set("forever", "value", 0))
+	assert.Nil(t, str.Set("persisted", "value", 1))
+	assert.Nil(t, ks.Persist("persisted"))
+	assert.Nil(t, str.Set("rewritten", "value", 1))
+	assert.Nil(t, str.Set("rewritten", "value", 100))
+	_, err := hash.HSet("hash", "field", "value")
+	assert.Nil(t, err)
+	_, err = hash.HExpire("hash", 1)
+	assert.Nil(t, err)
+	assert.Nil(t, set.SAdd("set", "member", 1))
+	assert.Nil(t, ks.Rename("set", "renamed"))
+
+	// Nothing is due yet
+	assert.Equal(t, 0, ks.sweep(100))
+
+	time.Sleep(1100 * time.Millisecond)
+
+	// The limit bounds the entries of the index one sweep looks at
+	assert.Equal(t, 2, ks.sweep(2))
+	assert.Equal(t, 3, ks.sweep(100))
+
+	// The expired keys are gone from the engine, not only hidden
+	for _, key := range []string{"a", "b", "c", "hash", "renamed"} {
+		_, err := ks.db.Get([]byte(key))
+		assert.Equal(t, _const.ErrKeyNotFound, err, key)
+	}
+	assert.Empty(t, ks.hashFieldKeys("hash"))
+
+	// The keys whose expiry time changed are kept, and so is their entry if they still expire
+	for _, key := range []string{"forever", "persisted", "rewritten"} {
+		value, err := str.Get(key)
+		assert.Nil(t, err)
+		assert.Equal(t, "value", value)
+	}
+	it := ks.db.NewIterator(config.IteratorOptions{Prefix: expireIndexPrefix})
+	defer it.Close()
+	var indexed []string
+	for ; it.Valid(); it.Next() {
+		_, key, ok := decodeExpireIndexKey(it.Key())
+		assert.True(t, ok)
+		indexed = append(indexed, string(key))
+	}
+	assert.Equal(t, []string{"rewritten"}, indexed)
+
+	// The index does not show up in the keys of the structures
+	keys := scanAll(t, func(cursor string) (string, []string, error) {
+		return ks.Scan(cursor, "*", 10, "")
+	})
+	assert.ElementsMatch(t, []string{"forever", "persisted", "rewritten"}, keys)
+}
+
+func TestKeyspace_SweepIdle(t *testing.T) {
+	ks := initKeyspaceWithSweepOptions(config.ExpireSweepOptions{})
+	defer ks.Clean()
+	str := NewStringStructureWithKeyspace(ks)
+
+	assert.Equal(t, 0, ks.sweep(100))
+	assert.True(t, ks.sweepIdle)
+
+	// An entry written around the keyspace is not seen while nothing is committed through it
+	deadline := time.Now().Add(-time.Second).UnixNano()
+	assert.Nil(t, ks.db.Put([]byte("due"), encodeHeader(String, deadline)))
+	assert.Nil(t, ks.db.Put(encodeExpireIndexKey(deadline, []byte("due")), nil))
+	assert.Equal(t, 0, ks.sweep(100))
+
+	// Any commit makes the next sweep read the expiry index again
+	assert.Nil(t, str.Set("forever", "value", 0))
+	assert.Equal(t, 1, ks.sweep(100))
+	assert.False(t, ks.sweepIdle)
+	assert.Equal(t, 0, ks.sweep(100))
+	assert.True(t, ks.sweepIdle)
+}
+
+func TestKeyspace_SweepLargeValues(t *testing.T) {
+	ks := initKeyspaceWithSweepOptions(config.ExpireSweepOptions{})
+	defer ks.Clean()
+
+	// Sorted sets and lists with more keys than config.DefaultWriteBatchOptions allows in a batch
+	zset := NewZSetStructureWithKeyspace(ks)
+	list := NewListStructureWithKeyspace(ks)
+	members := int(config.DefaultWriteBatchOptions.MaxBatchNum)/2 + 1000
+	values := make([]ZSetValue, members)
+	for i := range values {
+		values[i] = NewZSetValue(float64(i), fmt.Sprintf("member-%d", i), "")
+	}
+	assert.Nil(t, zset.ZAdds("zset", values...))
+	assert.Nil(t, ks.Expire("zset", 1))
+	chunks := make([]interface{}, int(config.DefaultWriteBatchOptions.MaxBatchNum+1)*listChunkSize)
+	for i := range chunks {
+		chunks[i] = i
+	}
+	assert.Nil(t, list.RPushs("list", 1, chunks...))
+	assert.Nil(t, NewStringStructureWithKeyspace(ks).Set("string", "value", 2))
+
+	time.Sleep(1100 * time.Millisecond)
+	assert.Equal(t, 2, ks.sweep(100))
+	assert.Empty(t, ks.zsetKeys("zset"))
+	assert.Empty(t, ks.listChunkKeys("list"))
+
+	// The keys due later are swept too
+	time.Sleep(time.Second)
+	assert.Equal(t, 1, ks.sweep(100))
+}
+
+func TestKeyspace_SweeperEvents(t *testing.T) {
+	ks := initKeyspaceWithSweepOptions(config.ExpireSweepOptions{
+		Interval:        10 * time.Millisecond,
+		MaxKeysPerSweep: 10,
+	})
+	defer ks.Clean()
+
+	events := make(chan ExpiredEvent, 10)
+	ks.NotifyExpired(events)
+
+	list := NewListStructureWithKeyspace(ks)
+	assert.Nil(t, list.LPush("list", "value", 1))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "list", event.Key)
+		assert.Equal(t, "list", event.Type)
+		assert.False(t, event.Expire.After(time.Now()))
+	case <-time.After(3 * time.Second):
+		t.Fatal("the sweeper did not delete the expired key")
+	}
+	_, err := ks.db.Get([]byte("list"))
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+}
+
+func TestExpireIndexKey(t *testing.T) {
+	indexKey := encodeExpireIndexKey(42, []byte("key"))
+	deadline, key, ok := decodeExpireIndexKey(indexKey)
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), deadline)
+	assert.Equal(t, []byte("key"), key)
+	assert.True(t, isInternalKey(indexKey))
+
+	_, _, ok = decodeExpireIndexKey([]byte("\x00expkey expk"))
+	assert.False(t, ok)
+	assert.False(t, isInternalKey([]byte("key")))
+}
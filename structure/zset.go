@@ -4,11 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
-	"github.com/ByteStorage/FlyDB/db/engine"
 	_const "github.com/ByteStorage/FlyDB/lib/const"
 	"github.com/ByteStorage/FlyDB/lib/encoding"
 	"math"
 	"math/rand"
+	"reflect"
 	"time"
 )
 
@@ -23,9 +23,11 @@ ZSet or Sorted Set structure is borrowed from Redis' implementation, the Redis i
 utilizes a SkipList and a dictionary.
 */
 
-// ZSetStructure is a structure for ZSet or SortedSet
+// ZSetStructure is a structure for ZSet or SortedSet.
+// Its sorted sets are stored member by member, see zset_members.go,
+// FZSet holds a whole sorted set in memory.
 type ZSetStructure struct {
-	db *engine.DB
+	ks *Keyspace
 }
 
 // FZSet represents a specific data structure in the database, which is key to handling sorted sets (ZSets).
@@ -55,6 +57,10 @@ type FZSet struct {
 	// skipList field is a pointer to an object of type SkipList,
 	// codified with the tag "skip_list".
 	skipList *SkipList `codec:"skip_list"`
+
+	// expire field is the expiry time of the sorted set, it is
+	// stored in the header of the value rather than codified.
+	expire int64
 }
 
 // SkipList represents a skip list data structure, an ordered list with a hierarchical
@@ -106,12 +112,12 @@ type SkipListNode struct {
 
 // ZSetValue is a struct used in the SkipList data structure. In the context of Redis Sorted Set (ZSet) implementation,
 // it represents a single node value in the skip list. A ZSetValue has three members:
-// - 'score' which is an integer representing the score of the node. Nodes in a skip list are ordered by this score in ascending order.
+// - 'score' which is a float representing the score of the node. Nodes in a skip list are ordered by this score in ascending order.
 // - 'member' which is a string defining the key of the node. For nodes with equal scores, order is determined with lexicographical comparison of keys.
 // - 'value' which is an interface{}, meaning it can hold any data type. This represents the actual value of the node in the skip list.
 type ZSetValue struct {
 	// Score is typically used for sorting purposes. Nodes with higher scores will be placed higher in the skip list.
-	score int
+	score float64
 
 	// member represents the unique identifier for each node.
 	member string
@@ -120,6 +126,11 @@ type ZSetValue struct {
 	value interface{}
 }
 
+// NewZSetValue returns the value of a member of a sorted set, to add it with ZAdds or ZAddWithOptions
+func NewZSetValue(score float64, member string, value interface{}) ZSetValue {
+	return ZSetValue{score: score, member: member, value: value}
+}
+
 // randomLevel is a function that generates a probabilistic level for a node in a SkipList data structure.
 // The goal is to diversify the level distribution and contribute to achieving an ideal skiplist performance.
 // Function has no parameters.
@@ -155,11 +166,16 @@ func randomLevel() int {
 
 // NewZSetStructure Returns a new ZSetStructure
 func NewZSetStructure(options config.Options) (*ZSetStructure, error) {
-	db, err := engine.NewDB(options)
+	ks, err := NewKeyspace(options)
 	if err != nil {
 		return nil, err
 	}
-	return &ZSetStructure{db: db}, nil
+	return NewZSetStructureWithKeyspace(ks), nil
+}
+
+// NewZSetStructureWithKeyspace returns a ZSetStructure that stores its sorted sets in a shared keyspace
+func NewZSetStructureWithKeyspace(ks *Keyspace) *ZSetStructure {
+	return &ZSetStructure{ks: ks}
 }
 
 // newZSetNodes is a function that creates a new FZSet object and returns a pointer to it.
@@ -189,7 +205,7 @@ func newSkipList() *SkipList {
 // It returns a pointer to a SkipListNode. This function is responsible for creating a new SkipListNode with provided level, score,
 // key, and value. After creating the node, it initializes every level of the node with an empty SkipListLevel object.
 // In the context of a skip list data structure, this function serves as a helper function for creating new nodes to be inserted to the list.
-func newSkipListNode(level int, score int, key string, value interface{}) *SkipListNode {
+func newSkipListNode(level int, score float64, key string, value interface{}) *SkipListNode {
 	// Create a new SkipListNode with specified score, key, value and a slice of
 	// SkipListLevel with length equal to specified level
 	node := &SkipListNode{
@@ -208,7 +224,7 @@ func newSkipListNode(level int, score int, key string, value interface{}) *SkipL
 // newSkipListNodeValue is a function that constructs and returns a new ZSetValue.
 // It takes a score (int), a key (string), and a value (interface{}) as parameters.
 // These parameters serve as the initial state of the ZSetValue upon its creation.
-func newSkipListNodeValue(score int, member string, value interface{}) *ZSetValue {
+func newSkipListNodeValue(score float64, member string, value interface{}) *ZSetValue {
 	// Create a new instance of a ZSetValue with the provided score, key, and value.
 	node := &ZSetValue{
 		score:  score,
@@ -224,7 +240,7 @@ func newSkipListNodeValue(score int, member string, value interface{}) *ZSetValu
 // the score (int), key (string) and a value (interface{}), and returns a pointer to the ZSetValue struct. The method
 // organizes nodes in the list based on the score in ascending order. If two nodes have the same score, they will be arranged
 // based on the key value. The method also assigns span values to the levels in the skip list.
-func (sl *SkipList) insert(score int, key string, value interface{}) *ZSetValue {
+func (sl *SkipList) insert(score float64, key string, value interface{}) *ZSetValue {
 	update := make([]*SkipListNode, SKIPLIST_MAX_LEVEL)
 	rank := make([]int, SKIPLIST_MAX_LEVEL)
 	node := sl.head
@@ -296,7 +312,7 @@ func (sl *SkipList) insert(score int, key string, value interface{}) *ZSetValue
 //
 //	score: the score of the node to delete.
 //	key: the key of the node to delete.
-func (sl *SkipList) delete(score int, member string) {
+func (sl *SkipList) delete(score float64, member string) {
 
 	// update: an array of pointers to SkipListNodes; holds the nodes that will have their next pointers updated.
 	update := make([]*SkipListNode, SKIPLIST_MAX_LEVEL)
@@ -403,7 +419,7 @@ func (sl *SkipList) deleteNode(node *SkipListNode, updates []*SkipListNode) {
 //
 // Return:
 // Returns the rank of the element in the SkipList if it's found, otherwise returns 0.
-func (sl *SkipList) getRank(score int, key string) int {
+func (sl *SkipList) getRank(score float64, key string) int {
 	var rank int
 	h := sl.head // Start at the head node of the SkipList
 
@@ -478,12 +494,12 @@ func (sl *SkipList) getNodeByRank(rank int) *SkipListNode {
 // Parameters:
 //
 //	key:    a string that represents the key of the sorted set.
-//	score:  an integer value that determines the order of the added element in the sorted set.
+//	score:  a float value that determines the order of the added element in the sorted set.
 //	member: a string used for identifying the added value within the sorted set.
 //	value:  the actual value to be stored within the sorted set.
 //
 // If the key is an empty string, an error will be returned
-func (zs *ZSetStructure) ZAdd(key string, score int, member string, value string) error {
+func (zs *ZSetStructure) ZAdd(key string, score float64, member string, value string) error {
 	return zs.ZAdds(key, []ZSetValue{{score: score, member: member, value: value}}...)
 }
 
@@ -494,39 +510,72 @@ func (zs *ZSetStructure) ZAdd(key string, score int, member string, value string
 //
 //	values:    ...ZSetValue multiple values of ZSetValue.
 func (zs *ZSetStructure) ZAdds(key string, vals ...ZSetValue) error {
+	_, err := zs.ZAddWithOptions(key, ZAddOptions{}, vals...)
+	return err
+}
+
+// Keys returns all keys of the ZSetStructure.
+//
+// Returns:
+//
+//	[]string: all keys of the ZSetStructure.
+func (zs *ZSetStructure) Keys() ([]string, error) {
+	return zs.ks.keys("*", ZSet)
+}
+
+// Scan returns a page of the keys holding a sorted set that match a pattern, see Keyspace.Scan
+func (zs *ZSetStructure) Scan(cursor, match string, count int) (string, []string, error) {
+	return zs.ks.Scan(cursor, match, count, typeNames[ZSet])
+}
+
+// ZScan returns a page of the members of a sorted set that match a pattern, in the order
+// of the members rather than of their scores, so that a member whose score changes during
+// the scan is still returned. count bounds how many members the call looks at.
+// It returns the cursor of the next page, which is ScanCursorStart once every member was visited.
+func (zs *ZSetStructure) ZScan(key string, cursor, match string, count int) (string, []ZSetValue, error) {
 	if err := checkKey(key); err != nil {
-		return err
+		return "", nil, err
 	}
-	zSet, err := zs.getOrCreateZSet(key)
+	defer zs.ks.lockKeys(key)()
+	next, compile, count, err := scanArgs(cursor, match, count)
 	if err != nil {
-		return fmt.Errorf("failed to get or create ZSet from DB with key '%v': %w", key, err)
+		return "", nil, err
+	}
+	keyBytes := stringToBytesWithKey(key)
+	_, err = zs.getZSetMeta(keyBytes)
+	if errors.Is(err, _const.ErrKeyNotFound) || errors.Is(err, _const.ErrKeyIsExpired) {
+		return ScanCursorStart, []ZSetValue{}, nil
+	}
+	if err != nil {
+		return "", nil, err
 	}
 
-	for _, val := range vals {
-		// if values didn't change, do nothing
-		if zs.valuesDidntChange(zSet, val.score, val.member, val.value) {
+	prefix := append(zsetPrefix(keyBytes), zsetMemberKind)
+	it := zs.ks.db.NewIterator(config.IteratorOptions{Start: append(prefix, next...), End: prefixEnd(prefix)})
+	defer it.Close()
+
+	values := make([]ZSetValue, 0)
+	for examined := 0; examined < count && it.Valid(); it.Next() {
+		examined++
+		_, _, member, _ := decodeZSetKey(it.Key())
+		if !compile.Match(member) {
 			continue
 		}
-		if err := zs.updateZSet(zSet, key, val.score, val.member, val.value); err != nil {
-			return fmt.Errorf("failed to set ZSet to DB with key '%v': %w", key, err)
+		data, err := it.Value()
+		if err != nil {
+			return "", nil, err
+		}
+		var v ZSetValue
+		if err := v.UnmarshalBinary(data); err != nil {
+			return "", nil, err
 		}
+		values = append(values, v)
 	}
-
-	return zs.setZSetToDB(stringToBytesWithKey(key), zSet)
-}
-
-// Keys returns all keys of the ZSetStructure.
-//
-// Returns:
-//
-//	[]string: all keys of the ZSetStructure.
-func (zs *ZSetStructure) Keys() ([]string, error) {
-	var keys []string
-	byte_keys := zs.db.GetListKeys()
-	for _, key := range byte_keys {
-		keys = append(keys, string(key))
+	if !it.Valid() {
+		return ScanCursorStart, values, nil
 	}
-	return keys, nil
+	_, _, member, _ := decodeZSetKey(it.Key())
+	return encodeCursor(member), values, nil
 }
 
 // exists checks if a given member with a specific score exists in a ZSet. It
@@ -546,18 +595,18 @@ func (zs *ZSetStructure) Keys() ([]string, error) {
 //
 // exists in the ZSet or not. Returns false if the ZSet does not exist or if
 // the key is invalid.
-func (zs *ZSetStructure) exists(key string, score int, member string) bool {
+func (zs *ZSetStructure) exists(key string, score float64, member string) bool {
 	if err := checkKey(key); err != nil {
 		return false
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, err := zs.getZSetFromDB(keyBytes)
-
-	if err != nil {
+	if _, err := zs.getZSetMeta(keyBytes); err != nil {
 		return false
 	}
-	return zSet.exists(score, member)
+	v, err := zs.getMember(keyBytes, member)
+	return err == nil && v.score == score
 }
 
 /*
@@ -576,19 +625,7 @@ the ZSet in the database. If any point of this operation fails,
 the function will return the corresponding error.
 */
 func (zs *ZSetStructure) ZRem(key string, member string) error {
-	if err := checkKey(key); err != nil {
-		return err
-	}
-	keyBytes := stringToBytesWithKey(key)
-
-	zSet, err := zs.getZSetFromDB(keyBytes)
-	if err != nil {
-		return err
-	}
-	if err = zSet.RemoveNode(member); err != nil {
-		return err
-	}
-	return zs.setZSetToDB(keyBytes, zSet)
+	return zs.ZRems(key, member)
 }
 
 // ZRems method removes one or more specified members from the sorted set that's stored under the provided key.
@@ -603,39 +640,47 @@ func (zs *ZSetStructure) ZRems(key string, member ...string) error {
 	if err := checkKey(key); err != nil {
 		return err
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, err := zs.getZSetFromDB(keyBytes)
-
+	meta, err := zs.getZSetMeta(keyBytes)
 	if err != nil {
-		return fmt.Errorf("failed to get or create ZSet from DB with key '%v': %w", key, err)
+		return err
 	}
+	// Nothing is removed if one of the members does not exist
+	batch := zs.ks.newBatch()
+	removed := make(map[string]bool)
 	for _, s := range member {
-		if err = zSet.RemoveNode(s); err != nil {
+		v, err := zs.getMember(keyBytes, s)
+		if err != nil {
 			return err
 		}
+		if removed[s] {
+			return _const.ErrKeyNotFound
+		}
+		removed[s] = true
+		zs.deleteMember(batch, keyBytes, v)
+		meta.card--
 	}
-	return zs.setZSetToDB(keyBytes, zSet)
+	return zs.commitZSet(batch, keyBytes, meta)
 }
 
 // ZScore method retrieves the score associated with the member in a sorted set stored at the key
-func (zs *ZSetStructure) ZScore(key string, member string) (int, error) {
+func (zs *ZSetStructure) ZScore(key string, member string) (float64, error) {
 	if err := checkKey(key); err != nil {
 		return 0, err
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, err := zs.getZSetFromDB(keyBytes)
-	if err != nil {
+	if _, err := zs.getZSetMeta(keyBytes); err != nil {
 		return 0, err
 	}
-	// if the member in the sorted set is found, return the score associated with it
-	if v, ok := zSet.dict[member]; ok {
-		return v.score, nil
+	v, err := zs.getMember(keyBytes, member)
+	if err != nil {
+		return 0, err
 	}
-
-	// if the member doesn't exist in the set, return score of zero and an error
-	return 0, _const.ErrKeyNotFound
+	return v.score, nil
 }
 
 /*
@@ -670,18 +715,30 @@ func (zs *ZSetStructure) ZRank(key string, member string) (int, error) {
 	if err := checkKey(key); err != nil {
 		return 0, err
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, err := zs.getZSetFromDB(keyBytes)
-	if err != nil {
+	if _, err := zs.getZSetMeta(keyBytes); err != nil {
 		return 0, fmt.Errorf("failed to get or create ZSet from DB with key '%v': %w", key, err)
 	}
-	if v, ok := zSet.dict[member]; ok {
-		return zSet.skipList.getRank(v.score, member), nil
+	v, err := zs.getMember(keyBytes, member)
+	if err != nil {
+		return 0, err
 	}
+	return zs.rank(keyBytes, v)
+}
 
-	// rank zero means no rank found
-	return 0, _const.ErrKeyNotFound
+// rank returns the 1-based position of a member of a sorted set in order of score
+func (zs *ZSetStructure) rank(key []byte, v *ZSetValue) (int, error) {
+	// The range ends right after the score key of the member
+	end := append(zsetScoreKey(key, v.score, v.member), 0)
+	it := zs.scoreIterator(key, nil, end, false)
+	defer it.Close()
+	rank := 0
+	for ; it.Valid(); it.Next() {
+		rank++
+	}
+	return rank, nil
 }
 
 // ZRevRank calculates the reverse rank of a member in a ZSet (Sorted Set) associated with a given key.
@@ -706,19 +763,22 @@ func (zs *ZSetStructure) ZRevRank(key string, member string) (int, error) {
 	if err := checkKey(key); err != nil {
 		return 0, err
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, err := zs.getZSetFromDB(keyBytes)
+	meta, err := zs.getZSetMeta(keyBytes)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get or create ZSet from DB with key '%v': %w", key, err)
 	}
-	if v, ok := zSet.dict[member]; ok {
-		rank := zSet.skipList.getRank(v.score, member)
-		return (zSet.size) - rank + 1, nil
+	v, err := zs.getMember(keyBytes, member)
+	if err != nil {
+		return 0, err
 	}
-
-	// rank zero means no rank found
-	return 0, _const.ErrKeyNotFound
+	rank, err := zs.rank(keyBytes, v)
+	if err != nil {
+		return 0, err
+	}
+	return meta.card - rank + 1, nil
 }
 
 // ZRange retrieves a specific range of elements from a sorted set (ZSet) denoted by a specific key.
@@ -752,19 +812,32 @@ func (zs *ZSetStructure) ZRevRank(key string, member string) (int, error) {
 //
 // This method is part of the ZSetStructure type.
 func (zs *ZSetStructure) ZRange(key string, start int, end int) ([]ZSetValue, error) {
+	return zs.zRange(key, start, end, false)
+}
+
+// zRange returns the members of a sorted set from rank start up to rank end, exclusive.
+// An end past the last member stops before it.
+func (zs *ZSetStructure) zRange(key string, start int, end int, reverse bool) ([]ZSetValue, error) {
 	if err := checkKey(key); err != nil {
 		return nil, err
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, err := zs.getZSetFromDB(keyBytes)
+	meta, err := zs.getZSetMeta(keyBytes)
 	if err != nil {
 		return nil, err
 	}
-	r := zSet.skipList.getRange(start, end, false)
-
-	// rank zero means no rank found
-	return r, nil
+	if end > meta.card {
+		end = meta.card - 1
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start > end || end <= 0 {
+		return nil, nil
+	}
+	return zs.rangeByRank(keyBytes, start, end, reverse)
 }
 
 // ZCount traverses through the elements of the ZSetStructure based on the given key.
@@ -783,40 +856,25 @@ func (zs *ZSetStructure) ZRange(key string, start int, end int) ([]ZSetValue, er
 // Returns:
 //  1. int: The total count of elements based on the score range.
 //  2. error: Errors that occurred during execution, if any.
-func (zs *ZSetStructure) ZCount(key string, min int, max int) (count int, err error) {
+func (zs *ZSetStructure) ZCount(key string, min float64, max float64) (count int, err error) {
 	if err = checkKey(key); err != nil {
 		return 0, err
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
-	zSet, err := zs.getZSetFromDB(keyBytes)
-	if err != nil {
+	if _, err = zs.getZSetMeta(keyBytes); err != nil {
 		return 0, err
 	}
 	if min > max {
 		return 0, ErrInvalidArgs
 	}
-	min, max, err = zs.adjustMinMax(zSet, min, max)
-	if err != nil {
-		return 0, err
-	}
-	x := zSet.skipList.head
-	// Node traversal loop. We keep moving to the next node at current level
-	// as long as the score of the next node's value is less than 'min'.
-	for i := zSet.skipList.level - 1; i >= 0; i-- {
-		for x.level[i].next != nil && x.level[i].next.value.score < min {
-			x = x.level[i].next
-		}
-	}
-
-	x = x.level[0].next
-	// Score range check loop. We traverse nodes and increment 'count'
-	// as long as node value's score is in the range ['min', 'max']
-	for x != nil {
-		if x.value.score > max {
-			break
-		}
+	prefix := append(zsetPrefix(keyBytes), zsetScoreKind)
+	start := append(prefix, encodeScore(min)...)
+	end := prefixEnd(append(prefix, encodeScore(max)...))
+	it := zs.scoreIterator(keyBytes, start, end, false)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
 		count++
-		x = x.level[0].next
 	}
 	return count, nil
 }
@@ -832,19 +890,7 @@ func (zs *ZSetStructure) ZCount(key string, min int, max int) (count int, err er
 //   - Error if an issue occurs, such as when the key is empty or ZSet retrieval fails
 //     error
 func (zs *ZSetStructure) ZRevRange(key string, startRank int, endRank int) ([]ZSetValue, error) {
-	if err := checkKey(key); err != nil {
-		return nil, err
-	}
-	keyBytes := stringToBytesWithKey(key)
-
-	zSet, err := zs.getZSetFromDB(keyBytes)
-	if err != nil {
-		return nil, err
-	}
-	r := zSet.skipList.getRange(startRank, endRank, true)
-
-	// rank zero means no rank found
-	return r, nil
+	return zs.zRange(key, startRank, endRank, true)
 }
 
 // The ZCard function returns the size of the dictionary of the sorted set stored at key in the database.
@@ -853,14 +899,14 @@ func (zs *ZSetStructure) ZCard(key string) (int, error) {
 	if err := checkKey(key); err != nil {
 		return 0, err
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, err := zs.getZSetFromDB(keyBytes)
+	meta, err := zs.getZSetMeta(keyBytes)
 	if err != nil {
 		return 0, err
 	}
-	// get the size of the dictionary
-	return zSet.size, nil
+	return meta.card, nil
 }
 
 // ZIncrBy increases the score of an existing member in a sorted set stored at specified key by
@@ -878,54 +924,33 @@ func (zs *ZSetStructure) ZCard(key string) (int, error) {
 // if it's unable to fetch or create ZSet from DB,
 // if there's an issue with node insertion,
 // if unable to set ZSet to DB post increment operation
-func (zs *ZSetStructure) ZIncrBy(key string, member string, incBy int) error {
+func (zs *ZSetStructure) ZIncrBy(key string, member string, incBy float64) error {
 	if err := checkKey(key); err != nil {
 		return err
 	}
+	defer zs.ks.lockKeys(key)()
 	keyBytes := stringToBytesWithKey(key)
 
-	zSet, err := zs.getZSetFromDB(keyBytes)
+	meta, err := zs.getZSetMeta(keyBytes)
 	if err != nil {
 		return fmt.Errorf("failed to get or create ZSet from DB with key '%v': %w", key, err)
 	}
-
-	if v, ok := zSet.dict[member]; ok {
-		if err = zSet.InsertNode(v.score+incBy, member, v.value); err != nil {
-			return err
-		}
-		if err = zs.setZSetToDB(keyBytes, zSet); err != nil {
-			return err
-		}
-		return zs.setZSetToDB(keyBytes, zSet)
+	old, err := zs.getMember(keyBytes, member)
+	if err != nil {
+		return err
 	}
 
-	return _const.ErrKeyNotFound
-}
-
-// getOrCreateZSet attempts to retrieve a sorted set by a key, or creates a new one if it doesn't exist.
-func (zs *ZSetStructure) getOrCreateZSet(key string) (*FZSet, error) {
-	keyBytes := stringToBytesWithKey(key)
-	zSet, err := zs.getZSetFromDB(keyBytes)
-	// if key is not in the DB, create it.
-	if errors.Is(err, _const.ErrKeyNotFound) {
-		return newZSetNodes(), nil
+	batch := zs.ks.newBatch()
+	v := &ZSetValue{score: old.score + incBy, member: member, value: old.value}
+	if err := zs.putMember(batch, keyBytes, old, v); err != nil {
+		return err
 	}
-
-	return zSet, err
+	return zs.commitZSet(batch, keyBytes, meta)
 }
 
 // valuesDidntChange checks if the data of a specific member in a sorted set remained the same.
-func (zs *ZSetStructure) valuesDidntChange(zSet *FZSet, score int, member string, value interface{}) bool {
-	if v, ok := zSet.dict[member]; ok {
-		return v.score == score && v.member == member && v.value == value
-	}
-
-	return false
-}
-
-// updateZSet updates or inserts a member in a sorted set and saves the change in storage.
-func (zs *ZSetStructure) updateZSet(zSet *FZSet, key string, score int, member string, value interface{}) error {
-	return zSet.InsertNode(score, member, value)
+func (zs *ZSetStructure) valuesDidntChange(v *ZSetValue, score float64, member string, value interface{}) bool {
+	return v.score == score && v.member == member && reflect.DeepEqual(v.value, value)
 }
 
 // InsertNode is a method on the FZSet structure. It inserts a new node
@@ -942,7 +967,7 @@ func (zs *ZSetStructure) updateZSet(zSet *FZSet, key string, score int, member s
 // If the key doesn't exist in the dictionary, it adds the new key, value and score
 // to the dictionary, increments the size of the dictionary by 1, and also adds
 // the node to the skip list.
-func (fzs *FZSet) InsertNode(score int, member string, value interface{}) error {
+func (fzs *FZSet) InsertNode(score float64, member string, value interface{}) error {
 	// Instantiate dictionary if it's not already
 	if fzs.dict == nil {
 		fzs.dict = make(map[string]*ZSetValue)
@@ -970,14 +995,14 @@ func (fzs *FZSet) InsertNode(score int, member string, value interface{}) error
 	// Returns nil as no specific error condition is checked in this function
 	return nil
 }
-func (zs *ZSetStructure) adjustMinMax(zSet *FZSet, min int, max int) (adjustedMin int, adjustedMax int, err error) {
+func (zs *ZSetStructure) adjustMinMax(zSet *FZSet, min float64, max float64) (adjustedMin float64, adjustedMax float64, err error) {
 	if min > max {
 		return min, max, ErrInvalidArgs
 	}
 	minScore, maxScore := zSet.getMinMaxScore()
 	return zSet.max(min, minScore), zSet.min(max, maxScore), nil
 }
-func (fzs *FZSet) getMinMaxScore() (minScore int, maxScore int) {
+func (fzs *FZSet) getMinMaxScore() (minScore float64, maxScore float64) {
 	if fzs == nil || fzs.skipList == nil || fzs.skipList.head == nil || len(fzs.skipList.head.level) < 1 || fzs.skipList.head.level[0].next == nil || fzs.skipList.tail == nil {
 		return 0, 0
 	}
@@ -988,14 +1013,14 @@ func (fzs *FZSet) getMinMaxScore() (minScore int, maxScore int) {
 	return fzs.skipList.head.level[0].next.value.score,
 		fzs.skipList.tail.value.score
 }
-func (fzs *FZSet) min(a, b int) int {
+func (fzs *FZSet) min(a, b float64) float64 {
 	if a < b {
 		return a
 	}
 	return b
 }
 
-func (fzs *FZSet) max(a, b int) int {
+func (fzs *FZSet) max(a, b float64) float64 {
 	if a > b {
 		return a
 	}
@@ -1036,7 +1061,7 @@ func (fzs *FZSet) RemoveNode(member string) error {
 	return nil
 }
 
-func (fzs *FZSet) exists(score int, member string) bool {
+func (fzs *FZSet) exists(score float64, member string) bool {
 	v, ok := fzs.dict[member]
 
 	return ok && v.score == score
@@ -1060,65 +1085,58 @@ func (fzs *FZSet) FromBytes(b []byte) error {
 	return encoding.NewMessagePackDecoder(b).Decode(fzs)
 }
 
-// getZSetFromDB fetches and deserializes FZSet from the database.
+// getZSetFromDB reads every member of a sorted set into an FZSet.
 //
 // Returns a pointer to the FZSet and error, if any.
-// If the key doesn't exist, both the pointer and the error will be nil.
-// In case of deserialization errors, returns nil and the error.
+// If the key doesn't exist, the error is ErrKeyNotFound.
 func (zs *ZSetStructure) getZSetFromDB(key []byte) (*FZSet, error) {
-	dbData, err := zs.db.Get(key)
-
-	// If key is not found, return nil for both; otherwise return the error.
+	meta, err := zs.getZSetMeta(key)
 	if err != nil {
-
-		return nil, err
-	}
-	dec := encoding.NewMessagePackDecoder(dbData)
-	// Deserialize the data.
-	var zSetValue FZSet
-	if err = dec.Decode(&zSetValue); err != nil {
 		return nil, err
 	}
+	zSet := newZSetNodes()
+	zSet.expire = meta.expire
 
-	// return a pointer to the deserialized FZSet, nil for the error
-	return &zSetValue, nil
+	prefix := append(zsetPrefix(key), zsetMemberKind)
+	it := zs.ks.db.NewIterator(config.IteratorOptions{Start: prefix, End: prefixEnd(prefix)})
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		data, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+		var v ZSetValue
+		if err := v.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		if err := zSet.InsertNode(v.score, v.member, v.value); err != nil {
+			return nil, err
+		}
+	}
+	return zSet, nil
 }
 
 // checkKey function that accepts a string parameter key
-// and returns error if key is empty.
+// and returns error if key is empty or reserved for internal keys.
 //
 // # It returns nil otherwise
 //
 // Parameters:
 //
-//	key : A string that is checked if empty
+//	key : A string that is checked
 //
 // Returns:
 //
-//	error : _const.ErrKeyIsEmpty if key is empty, nil otherwise
+//	error : _const.ErrKeyIsEmpty if key is empty, ErrReservedKey if key starts
+//	with internalKeyPrefix, nil otherwise
 func checkKey(key string) error {
 	if len(key) == 0 {
 		return _const.ErrKeyIsEmpty
 	}
-	return nil
-}
-
-// setZSetToDB writes a FZSet object to the database.
-//
-//	parameters:
-//	key: This is a byte slice that is used as a key in the database.
-//	zSetValue: This is a pointer to a FZSet object that needs to be stored in the database.
-//
-// The function serializes the FZSet object into MessagePack format. If an error occurs
-// either during serialization or when writing to the database, that specific error is returned.
-// If the process is successful, it returns nil.
-func (zs *ZSetStructure) setZSetToDB(key []byte, zSetValue *FZSet) error {
-	val := encoding.NewMessagePackEncoder()
-	err := val.Encode(zSetValue)
-	if err != nil {
-		return err
+	if key[0] == internalKeyPrefix {
+		return ErrReservedKey
 	}
-	return zs.db.Put(key, val.Bytes())
+	return nil
 }
 
 // UnmarshalBinary de-serializes the given byte slice into FZSet instance
@@ -1208,9 +1226,23 @@ func (p *ZSetValue) UnmarshalBinary(data []byte) (err error) {
 	if err = dec.Decode(&p.member); err != nil {
 		return
 	}
-	if err = dec.Decode(&p.score); err != nil {
+	// Scores were integers before they became floats
+	var score interface{}
+	if err = dec.Decode(&score); err != nil {
 		return err
 	}
+	switch s := score.(type) {
+	case float64:
+		p.score = s
+	case float32:
+		p.score = float64(s)
+	case int64:
+		p.score = float64(s)
+	case uint64:
+		p.score = float64(s)
+	default:
+		return ErrInvalidValue
+	}
 	if err = dec.Decode(&p.value); err != nil {
 		return
 	}
@@ -1249,6 +1281,5 @@ func (d *ZSetValue) MarshalBinary() (_ []byte, err error) {
 }
 
 func (d *ZSetStructure) Stop() error {
-	err := d.db.Close()
-	return err
+	return d.ks.Close()
 }
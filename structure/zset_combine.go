@@ -0,0 +1,269 @@
+package structure
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ByteStorage/FlyDB/config"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+)
+
+// Aggregate is how ZUnion and ZInter combine the scores a member has in several sorted sets
+type Aggregate int
+
+const (
+	AggregateSum Aggregate = iota
+	AggregateMin
+	AggregateMax
+)
+
+// ParseAggregate parses SUM, MIN or MAX, in any case, an empty string is SUM
+func ParseAggregate(s string) (Aggregate, error) {
+	switch strings.ToUpper(s) {
+	case "", "SUM":
+		return AggregateSum, nil
+	case "MIN":
+		return AggregateMin, nil
+	case "MAX":
+		return AggregateMax, nil
+	}
+	return 0, ErrInvalidArgs
+}
+
+// apply combines two scores of a member, the sum of infinities of opposite signs is 0
+func (a Aggregate) apply(x, y float64) float64 {
+	switch a {
+	case AggregateMin:
+		return math.Min(x, y)
+	case AggregateMax:
+		return math.Max(x, y)
+	}
+	if sum := x + y; !math.IsNaN(sum) {
+		return sum
+	}
+	return 0
+}
+
+// ZCombineOptions are the options of ZUnion and ZInter
+type ZCombineOptions struct {
+	// Weights multiply the scores of each sorted set, there is one per key. nil weighs every sorted set 1.
+	Weights   []float64
+	Aggregate Aggregate
+}
+
+// weight returns the score of a member of the i-th sorted set multiplied by its weight
+func (o ZCombineOptions) weight(i int, score float64) float64 {
+	if o.Weights == nil {
+		return score
+	}
+	if weighted := score * o.Weights[i]; !math.IsNaN(weighted) {
+		return weighted
+	}
+	return 0
+}
+
+// ZUnion returns the members of any of the sorted sets of keys, in order of score.
+// The score of a member is the aggregate of its weighted scores, its value the one of the first sorted set
+// holding it. A key that does not exist is an empty sorted set.
+func (zs *ZSetStructure) ZUnion(keys []string, opts ZCombineOptions) ([]ZSetValue, error) {
+	defer zs.ks.lockKeys(keys...)()
+	return zs.union(keys, opts)
+}
+
+// union is ZUnion with the locks of the keys held
+func (zs *ZSetStructure) union(keys []string, opts ZCombineOptions) ([]ZSetValue, error) {
+	sets, err := zs.loadAll(keys, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*ZSetValue)
+	for i, set := range sets {
+		for _, v := range set {
+			score := opts.weight(i, v.score)
+			if r, ok := result[v.member]; ok {
+				r.score = opts.Aggregate.apply(r.score, score)
+				continue
+			}
+			result[v.member] = &ZSetValue{score: score, member: v.member, value: v.value}
+		}
+	}
+	return sortZSetValues(result), nil
+}
+
+// ZInter returns the members of all the sorted sets of keys, in order of score, see ZUnion
+func (zs *ZSetStructure) ZInter(keys []string, opts ZCombineOptions) ([]ZSetValue, error) {
+	defer zs.ks.lockKeys(keys...)()
+	return zs.inter(keys, opts)
+}
+
+// inter is ZInter with the locks of the keys held
+func (zs *ZSetStructure) inter(keys []string, opts ZCombineOptions) ([]ZSetValue, error) {
+	sets, err := zs.loadAll(keys, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*ZSetValue)
+	for _, v := range sets[0] {
+		result[v.member] = &ZSetValue{score: opts.weight(0, v.score), member: v.member, value: v.value}
+	}
+	for i, set := range sets[1:] {
+		next := make(map[string]*ZSetValue)
+		for _, v := range set {
+			if r, ok := result[v.member]; ok {
+				r.score = opts.Aggregate.apply(r.score, opts.weight(i+1, v.score))
+				next[v.member] = r
+			}
+		}
+		result = next
+	}
+	return sortZSetValues(result), nil
+}
+
+// ZDiff returns the members of the first sorted set of keys that are in none of the others, in order of score
+func (zs *ZSetStructure) ZDiff(keys ...string) ([]ZSetValue, error) {
+	defer zs.ks.lockKeys(keys...)()
+	return zs.diff(keys)
+}
+
+// diff is ZDiff with the locks of the keys held
+func (zs *ZSetStructure) diff(keys []string) ([]ZSetValue, error) {
+	sets, err := zs.loadAll(keys, ZCombineOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*ZSetValue)
+	for i := range sets[0] {
+		result[sets[0][i].member] = &sets[0][i]
+	}
+	for _, set := range sets[1:] {
+		for _, v := range set {
+			delete(result, v.member)
+		}
+	}
+	return sortZSetValues(result), nil
+}
+
+// ZUnionStore writes the union of the sorted sets of keys to destination, see ZUnion.
+// It returns the number of members of destination.
+func (zs *ZSetStructure) ZUnionStore(destination string, keys []string, opts ZCombineOptions) (int, error) {
+	defer zs.ks.lockKeys(append([]string{destination}, keys...)...)()
+	values, err := zs.union(keys, opts)
+	if err != nil {
+		return 0, err
+	}
+	return zs.store(destination, values)
+}
+
+// ZInterStore writes the intersection of the sorted sets of keys to destination, see ZInter.
+// It returns the number of members of destination.
+func (zs *ZSetStructure) ZInterStore(destination string, keys []string, opts ZCombineOptions) (int, error) {
+	defer zs.ks.lockKeys(append([]string{destination}, keys...)...)()
+	values, err := zs.inter(keys, opts)
+	if err != nil {
+		return 0, err
+	}
+	return zs.store(destination, values)
+}
+
+// ZDiffStore writes the difference of the sorted sets of keys to destination, see ZDiff.
+// It returns the number of members of destination.
+func (zs *ZSetStructure) ZDiffStore(destination string, keys ...string) (int, error) {
+	defer zs.ks.lockKeys(append([]string{destination}, keys...)...)()
+	values, err := zs.diff(keys)
+	if err != nil {
+		return 0, err
+	}
+	return zs.store(destination, values)
+}
+
+// loadAll returns the members of the sorted sets of keys
+func (zs *ZSetStructure) loadAll(keys []string, opts ZCombineOptions) ([][]ZSetValue, error) {
+	if len(keys) == 0 || (opts.Weights != nil && len(opts.Weights) != len(keys)) {
+		return nil, ErrInvalidArgs
+	}
+	sets := make([][]ZSetValue, len(keys))
+	for i, key := range keys {
+		set, err := zs.load(key)
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	return sets, nil
+}
+
+// load returns the members of a sorted set in the order of the members,
+// none if the key does not exist or expired
+func (zs *ZSetStructure) load(key string) ([]ZSetValue, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	keyBytes := stringToBytesWithKey(key)
+
+	_, err := zs.getZSetMeta(keyBytes)
+	if errors.Is(err, _const.ErrKeyNotFound) || errors.Is(err, _const.ErrKeyIsExpired) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefix := append(zsetPrefix(keyBytes), zsetMemberKind)
+	it := zs.ks.db.NewIterator(config.IteratorOptions{Start: prefix, End: prefixEnd(prefix)})
+	defer it.Close()
+
+	var values []ZSetValue
+	for ; it.Valid(); it.Next() {
+		data, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+		var v ZSetValue
+		if err := v.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// store replaces whatever destination holds with a sorted set of values in one batch,
+// destination is deleted if values is empty. It returns the number of values.
+func (zs *ZSetStructure) store(destination string, values []ZSetValue) (int, error) {
+	if err := checkKey(destination); err != nil {
+		return 0, err
+	}
+	keyBytes := stringToBytesWithKey(destination)
+	batch := zs.ks.newBatch()
+	if err := zs.ks.deleteValue(batch, destination); err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, zs.ks.commit(batch)
+	}
+	for i := range values {
+		if err := zs.putMember(batch, keyBytes, nil, &values[i]); err != nil {
+			return 0, err
+		}
+	}
+	if err := zs.commitZSet(batch, keyBytes, &zsetMeta{card: len(values)}); err != nil {
+		return 0, err
+	}
+	return len(values), nil
+}
+
+// sortZSetValues returns the values of a map in order of score, then of member
+func sortZSetValues(m map[string]*ZSetValue) []ZSetValue {
+	values := make([]ZSetValue, 0, len(m))
+	for _, v := range m {
+		values = append(values, *v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].score != values[j].score {
+			return values[i].score < values[j].score
+		}
+		return values[i].member < values[j].member
+	})
+	return values
+}
@@ -0,0 +1,341 @@
+package structure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+)
+
+// A sorted set is stored member by member. The value of its key is the metadata:
+// +----------+------------+------------+------------+
+// |   type   |   expire   |   format   |    card    |
+// +----------+------------+------------+------------+
+// |  1 byte  |  variable  |   1 byte   |  variable  |
+// +----------+------------+------------+------------+
+// Every member has two keys of its own, next to each other in the engine:
+// the member key, holding the encoded ZSetValue, and the score key, ordered
+// by score and then by member, so that ranges are read through an iterator:
+// +----------+------------+------------+--------+---------------------+
+// |  prefix  |  key size  |    key     |  kind  |        rest         |
+// +----------+------------+------------+--------+---------------------+
+// | "\x00zs" |   4 bytes  |  variable  |  'm'   |       member        |
+// | "\x00zs" |   4 bytes  |  variable  |  's'   |   score, member     |
+// +----------+------------+------------+--------+---------------------+
+// Sorted sets written before, as one msgpack value, are migrated when they are first used.
+const (
+	// zsetMetaFormat starts the payload of a sorted set stored member by member,
+	// the msgpack string of a sorted set stored in one value never starts with it.
+	zsetMetaFormat byte = 1
+
+	zsetMemberKind byte = 'm'
+	zsetScoreKind  byte = 's'
+)
+
+var zsetKeyPrefix = []byte("\x00zs")
+
+// zsetMeta is the metadata of a sorted set
+type zsetMeta struct {
+	expire int64
+	card   int
+}
+
+func (m *zsetMeta) encode() []byte {
+	buf := encodeHeader(ZSet, m.expire)
+	buf = append(buf, zsetMetaFormat)
+	card := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(card, int64(m.card))
+	return append(buf, card[:n]...)
+}
+
+// decodeZSetMetaPayload returns the cardinality stored in the payload of a sorted set
+func decodeZSetMetaPayload(payload []byte) (int, error) {
+	if isLegacyZSet(payload) {
+		return 0, ErrInvalidValue
+	}
+	card, n := binary.Varint(payload[1:])
+	if n <= 0 {
+		return 0, ErrInvalidValue
+	}
+	return int(card), nil
+}
+
+// isLegacyZSet reports whether the payload of a sorted set holds the whole set as one msgpack value
+func isLegacyZSet(payload []byte) bool {
+	return len(payload) == 0 || payload[0] != zsetMetaFormat
+}
+
+// zsetPrefix returns the prefix of the member and score keys of a sorted set
+func zsetPrefix(key []byte) []byte {
+	prefix := make([]byte, len(zsetKeyPrefix)+4, len(zsetKeyPrefix)+4+len(key)+1)
+	n := copy(prefix, zsetKeyPrefix)
+	binary.BigEndian.PutUint32(prefix[n:], uint32(len(key)))
+	return append(prefix, key...)
+}
+
+// zsetMemberKey returns the key holding a member of a sorted set
+func zsetMemberKey(key []byte, member string) []byte {
+	return append(append(zsetPrefix(key), zsetMemberKind), member...)
+}
+
+// zsetScoreKey returns the key ordering a member of a sorted set by its score
+func zsetScoreKey(key []byte, score float64, member string) []byte {
+	return append(append(append(zsetPrefix(key), zsetScoreKind), encodeScore(score)...), member...)
+}
+
+// encodeScore encodes a score so that the encodings sort like the scores
+func encodeScore(score float64) []byte {
+	if score == 0 {
+		// -0 and 0 are the same score
+		score = 0
+	}
+	bits := math.Float64bits(score)
+	if score >= 0 {
+		bits ^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, bits)
+	return buf
+}
+
+// decodeScore reverses encodeScore
+func decodeScore(buf []byte) float64 {
+	bits := binary.BigEndian.Uint64(buf)
+	if bits&(1<<63) != 0 {
+		bits ^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+	return math.Float64frombits(bits)
+}
+
+// decodeZSetKey returns the key of the sorted set a member or score key belongs to,
+// the kind of the key and what follows it, ok is false if k is not such a key
+func decodeZSetKey(k []byte) (key []byte, kind byte, rest []byte, ok bool) {
+	headerLen := len(zsetKeyPrefix) + 4
+	if !bytes.HasPrefix(k, zsetKeyPrefix) || len(k) < headerLen+1 {
+		return nil, 0, nil, false
+	}
+	keyLen := int(binary.BigEndian.Uint32(k[len(zsetKeyPrefix):]))
+	if keyLen > len(k)-headerLen-1 {
+		return nil, 0, nil, false
+	}
+	key, kind, rest = k[headerLen:headerLen+keyLen], k[headerLen+keyLen], k[headerLen+keyLen+1:]
+	switch {
+	case kind == zsetMemberKind:
+		return key, kind, rest, true
+	case kind == zsetScoreKind && len(rest) >= 8:
+		return key, kind, rest, true
+	}
+	return nil, 0, nil, false
+}
+
+// prefixEnd returns the smallest key greater than every key starting with prefix
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte(nil), prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// zsetKeys returns the member and score keys of a sorted set
+func (ks *Keyspace) zsetKeys(key string) [][]byte {
+	prefix := zsetPrefix([]byte(key))
+	it := ks.db.NewIterator(config.IteratorOptions{Start: prefix, End: prefixEnd(prefix)})
+	defer it.Close()
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, append([]byte(nil), it.Key()...))
+	}
+	return keys
+}
+
+// newBatch returns a batch for the writes of a data structure, which may hold any number of keys
+func (ks *Keyspace) newBatch() *engine.WriteBatch {
+	return ks.db.NewWriteBatch(config.WriteBatchOptions{
+		MaxBatchNum: math.MaxUint32,
+		SyncWrites:  ks.syncWrites,
+	})
+}
+
+// getZSetMeta returns the metadata of a sorted set. A sorted set stored in one value is migrated first,
+// so the key must be locked even to read it.
+func (zs *ZSetStructure) getZSetMeta(key []byte) (*zsetMeta, error) {
+	value, err := zs.ks.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	expire, payload, err := decodeValue(value, ZSet)
+	if err != nil {
+		return nil, err
+	}
+	if isLegacyZSet(payload) {
+		return zs.migrateZSet(key, expire, payload)
+	}
+	card, err := decodeZSetMetaPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &zsetMeta{expire: expire, card: card}, nil
+}
+
+// getOrCreateZSetMeta returns the metadata of a sorted set to write to. A sorted set that
+// does not exist or expired is empty, the deletes of the members it had are added to batch.
+func (zs *ZSetStructure) getOrCreateZSetMeta(batch *engine.WriteBatch, key string) (*zsetMeta, error) {
+	if err := zs.ks.checkType(key, ZSet); err != nil {
+		return nil, err
+	}
+	meta, err := zs.getZSetMeta(stringToBytesWithKey(key))
+	if errors.Is(err, _const.ErrKeyIsExpired) {
+		if err := zs.ks.deleteValue(batch, key); err != nil {
+			return nil, err
+		}
+		return &zsetMeta{}, nil
+	}
+	if errors.Is(err, _const.ErrKeyNotFound) {
+		return &zsetMeta{}, nil
+	}
+	return meta, err
+}
+
+// getMember returns a member of a sorted set, or ErrKeyNotFound
+func (zs *ZSetStructure) getMember(key []byte, member string) (*ZSetValue, error) {
+	data, err := zs.ks.db.Get(zsetMemberKey(key, member))
+	if err != nil {
+		return nil, err
+	}
+	v := &ZSetValue{}
+	if err := v.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// putMember adds the writes of a member to batch, old is the member it replaces or nil
+func (zs *ZSetStructure) putMember(batch *engine.WriteBatch, key []byte, old *ZSetValue, v *ZSetValue) error {
+	data, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if old != nil && old.score != v.score {
+		_ = batch.Delete(zsetScoreKey(key, old.score, old.member))
+	}
+	_ = batch.Put(zsetMemberKey(key, v.member), data)
+	return batch.Put(zsetScoreKey(key, v.score, v.member), nil)
+}
+
+// deleteMember adds the deletes of a member to batch
+func (zs *ZSetStructure) deleteMember(batch *engine.WriteBatch, key []byte, v *ZSetValue) {
+	_ = batch.Delete(zsetMemberKey(key, v.member))
+	_ = batch.Delete(zsetScoreKey(key, v.score, v.member))
+}
+
+// commitZSet writes the metadata of a sorted set with the rest of batch
+func (zs *ZSetStructure) commitZSet(batch *engine.WriteBatch, key []byte, meta *zsetMeta) error {
+	_ = batch.Put(key, meta.encode())
+	zs.ks.indexExpire(batch, key, meta.expire)
+	return zs.ks.commit(batch)
+}
+
+// scoreIterator returns an iterator over the score keys of a sorted set from start,
+// the end of the range is exclusive. nil bounds are those of the whole sorted set.
+func (zs *ZSetStructure) scoreIterator(key []byte, start, end []byte, reverse bool) *engine.Iterator {
+	prefix := append(zsetPrefix(key), zsetScoreKind)
+	if start == nil {
+		start = prefix
+	}
+	if end == nil {
+		end = prefixEnd(prefix)
+	}
+	return zs.ks.db.NewIterator(config.IteratorOptions{Start: start, End: end, Reverse: reverse})
+}
+
+// rangeByRank returns the members from rank start up to rank end, exclusive, in order of score,
+// or from the highest score if reverse is true
+func (zs *ZSetStructure) rangeByRank(key []byte, start, end int, reverse bool) ([]ZSetValue, error) {
+	it := zs.scoreIterator(key, nil, nil, reverse)
+	defer it.Close()
+	var values []ZSetValue
+	for rank := 0; it.Valid() && rank < end; it.Next() {
+		if rank++; rank <= start {
+			continue
+		}
+		_, _, rest, _ := decodeZSetKey(it.Key())
+		v, err := zs.getMember(key, string(rest[8:]))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, *v)
+	}
+	return values, nil
+}
+
+// migrateZSet rewrites a sorted set stored in one value member by member and returns its metadata
+func (zs *ZSetStructure) migrateZSet(key []byte, expire int64, payload []byte) (*zsetMeta, error) {
+	var zSet FZSet
+	if err := zSet.FromBytes(payload); err != nil {
+		return nil, err
+	}
+	batch := zs.ks.newBatch()
+	for _, v := range zSet.dict {
+		if err := zs.putMember(batch, key, nil, v); err != nil {
+			return nil, err
+		}
+	}
+	meta := &zsetMeta{expire: expire, card: len(zSet.dict)}
+	if err := zs.commitZSet(batch, key, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Migrate rewrites every sorted set that is still stored in one value member by member.
+// Sorted sets are also migrated when they are first used, Migrate spares that cost
+// to the first command. It returns how many sorted sets it rewrote.
+func (zs *ZSetStructure) Migrate() (int, error) {
+	keys, err := zs.ks.keys("*", ZSet)
+	if err != nil {
+		return 0, err
+	}
+	migrated := 0
+	for _, key := range keys {
+		ok, err := zs.migrateKey(key)
+		if err != nil {
+			return migrated, err
+		}
+		if ok {
+			migrated++
+		}
+	}
+	return migrated, nil
+}
+
+// migrateKey migrates a sorted set if it is still stored in one value, and reports whether it did
+func (zs *ZSetStructure) migrateKey(key string) (bool, error) {
+	defer zs.ks.lockKeys(key)()
+	value, err := zs.ks.db.Get([]byte(key))
+	if err == _const.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	expire, payload, err := decodeValue(value, ZSet)
+	if err != nil || !isLegacyZSet(payload) {
+		return false, nil
+	}
+	if _, err := zs.migrateZSet([]byte(key), expire, payload); err != nil {
+		return false, err
+	}
+	return true, nil
+}
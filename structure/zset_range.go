@@ -0,0 +1,455 @@
+package structure
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ByteStorage/FlyDB/config"
+	"github.com/ByteStorage/FlyDB/db/engine"
+	_const "github.com/ByteStorage/FlyDB/lib/const"
+)
+
+// ScoreBound is one end of a range of scores
+type ScoreBound struct {
+	Score     float64
+	Exclusive bool
+}
+
+// ParseScoreBound parses a bound of a range of scores the way Redis writes them:
+// a score, a score preceded by "(" to exclude it, or "-inf" and "+inf".
+func ParseScoreBound(s string) (ScoreBound, error) {
+	bound := ScoreBound{}
+	if strings.HasPrefix(s, "(") {
+		bound.Exclusive = true
+		s = s[1:]
+	}
+	score, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(score) {
+		return ScoreBound{}, ErrInvalidArgs
+	}
+	bound.Score = score
+	return bound, nil
+}
+
+// LexBound is one end of a range of members
+type LexBound struct {
+	Member    string
+	Exclusive bool
+	// Inf is -1 for "-", below every member, and 1 for "+", above every member
+	Inf int
+}
+
+// ParseLexBound parses a bound of a range of members the way Redis writes them:
+// a member preceded by "[" to include it or "(" to exclude it, or "-" and "+".
+func ParseLexBound(s string) (LexBound, error) {
+	switch {
+	case s == "-":
+		return LexBound{Inf: -1}, nil
+	case s == "+":
+		return LexBound{Inf: 1}, nil
+	case strings.HasPrefix(s, "["):
+		return LexBound{Member: s[1:]}, nil
+	case strings.HasPrefix(s, "("):
+		return LexBound{Member: s[1:], Exclusive: true}, nil
+	}
+	return LexBound{}, ErrInvalidArgs
+}
+
+// ZAddOptions are the flags of ZAddWithOptions and ZAddIncr, named after those of the Redis ZADD command
+type ZAddOptions struct {
+	// NX only adds new members, XX only updates existing members
+	NX, XX bool
+	// GT only updates a member if its new score is greater, LT if it is lower
+	GT, LT bool
+	// CH counts the members that changed as well as the members that were added
+	CH bool
+}
+
+func (o ZAddOptions) validate() error {
+	if (o.NX && o.XX) || (o.GT && o.LT) || (o.NX && (o.GT || o.LT)) {
+		return ErrInvalidArgs
+	}
+	return nil
+}
+
+// skip reports whether the flags keep a member from being written, old is nil for a new member
+func (o ZAddOptions) skip(old *ZSetValue, score float64) bool {
+	if old == nil {
+		return o.XX
+	}
+	return o.NX || (o.GT && score <= old.score) || (o.LT && score >= old.score)
+}
+
+// ZAddWithOptions adds or updates members of a sorted set as allowed by opts.
+// It returns the number of members it added, or of members it added or changed with CH.
+func (zs *ZSetStructure) ZAddWithOptions(key string, opts ZAddOptions, vals ...ZSetValue) (int, error) {
+	if err := checkKey(key); err != nil {
+		return 0, err
+	}
+	defer zs.ks.lockKeys(key)()
+	if err := opts.validate(); err != nil {
+		return 0, err
+	}
+	for _, val := range vals {
+		if math.IsNaN(val.score) {
+			return 0, ErrInvalidArgs
+		}
+	}
+	keyBytes := stringToBytesWithKey(key)
+	batch := zs.ks.newBatch()
+	meta, err := zs.getOrCreateZSetMeta(batch, key)
+	if err != nil {
+		return 0, err
+	}
+
+	// written holds the members this call wrote, a member may be given twice
+	written := make(map[string]*ZSetValue)
+	added, changed := make(map[string]bool), make(map[string]bool)
+	for i := range vals {
+		val := &vals[i]
+		old, ok := written[val.member]
+		if !ok {
+			if old, err = zs.lookupMember(keyBytes, val.member); err != nil {
+				return 0, err
+			}
+		}
+		if opts.skip(old, val.score) {
+			continue
+		}
+		if old == nil {
+			meta.card++
+			added[val.member] = true
+		} else if zs.valuesDidntChange(old, val.score, val.member, val.value) {
+			continue
+		} else if !added[val.member] {
+			changed[val.member] = true
+		}
+		if err := zs.putMember(batch, keyBytes, old, val); err != nil {
+			return 0, err
+		}
+		written[val.member] = val
+	}
+
+	if err := zs.commitZSet(batch, keyBytes, meta); err != nil {
+		return 0, err
+	}
+	if opts.CH {
+		return len(added) + len(changed), nil
+	}
+	return len(added), nil
+}
+
+// ZAddIncr adds incr to the score of a member of a sorted set, as the INCR flag of the Redis
+// ZADD command does, a member that does not exist is added with incr as its score.
+// It returns the new score, ok is false if opts kept the member from being written.
+func (zs *ZSetStructure) ZAddIncr(key string, opts ZAddOptions, incr float64, member string, value string) (score float64, ok bool, err error) {
+	if err := checkKey(key); err != nil {
+		return 0, false, err
+	}
+	defer zs.ks.lockKeys(key)()
+	if err := opts.validate(); err != nil {
+		return 0, false, err
+	}
+	keyBytes := stringToBytesWithKey(key)
+	batch := zs.ks.newBatch()
+	meta, err := zs.getOrCreateZSetMeta(batch, key)
+	if err != nil {
+		return 0, false, err
+	}
+	old, err := zs.lookupMember(keyBytes, member)
+	if err != nil {
+		return 0, false, err
+	}
+	score = incr
+	if old != nil {
+		score += old.score
+	}
+	if math.IsNaN(score) {
+		return 0, false, ErrInvalidArgs
+	}
+	if opts.skip(old, score) {
+		return 0, false, nil
+	}
+	if old == nil {
+		meta.card++
+	}
+	if err := zs.putMember(batch, keyBytes, old, &ZSetValue{score: score, member: member, value: value}); err != nil {
+		return 0, false, err
+	}
+	if err := zs.commitZSet(batch, keyBytes, meta); err != nil {
+		return 0, false, err
+	}
+	return score, true, nil
+}
+
+// ZMScore returns the scores of members of a sorted set, nil for a member that does not exist
+func (zs *ZSetStructure) ZMScore(key string, members ...string) ([]*float64, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	defer zs.ks.lockKeys(key)()
+	keyBytes := stringToBytesWithKey(key)
+
+	if _, err := zs.getZSetMeta(keyBytes); err != nil {
+		return nil, err
+	}
+	scores := make([]*float64, len(members))
+	for i, member := range members {
+		v, err := zs.lookupMember(keyBytes, member)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			scores[i] = &v.score
+		}
+	}
+	return scores, nil
+}
+
+// ZRangeByScore returns the members of a sorted set whose score is between min and max,
+// from the lowest score. It skips the first offset members and returns at most count
+// members, all of them if count is negative.
+func (zs *ZSetStructure) ZRangeByScore(key string, min, max ScoreBound, offset, count int) ([]ZSetValue, error) {
+	return zs.rangeByScore(key, min, max, offset, count, false)
+}
+
+// ZRevRangeByScore returns the members of a sorted set whose score is between max and min,
+// from the highest score, see ZRangeByScore.
+func (zs *ZSetStructure) ZRevRangeByScore(key string, max, min ScoreBound, offset, count int) ([]ZSetValue, error) {
+	return zs.rangeByScore(key, min, max, offset, count, true)
+}
+
+func (zs *ZSetStructure) rangeByScore(key string, min, max ScoreBound, offset, count int, reverse bool) ([]ZSetValue, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	defer zs.ks.lockKeys(key)()
+	keyBytes := stringToBytesWithKey(key)
+
+	if _, err := zs.getZSetMeta(keyBytes); err != nil {
+		return nil, err
+	}
+	start, end := scoreRange(keyBytes, min, max)
+	it := zs.scoreIterator(keyBytes, start, end, reverse)
+	defer it.Close()
+	return zs.collect(keyBytes, it, offset, count)
+}
+
+// ZRangeByLex returns the members of a sorted set between min and max in the order of the
+// members, as the Redis ZRANGEBYLEX command does for members that all have the same score.
+// It skips the first offset members and returns at most count members, all of them if count is negative.
+func (zs *ZSetStructure) ZRangeByLex(key string, min, max LexBound, offset, count int) ([]ZSetValue, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	defer zs.ks.lockKeys(key)()
+	keyBytes := stringToBytesWithKey(key)
+
+	if _, err := zs.getZSetMeta(keyBytes); err != nil {
+		return nil, err
+	}
+	prefix := append(zsetPrefix(keyBytes), zsetMemberKind)
+	start, end := prefix, prefixEnd(prefix)
+	switch {
+	case min.Inf > 0:
+		return []ZSetValue{}, nil
+	case min.Inf == 0 && min.Exclusive:
+		start = append(append(append([]byte(nil), prefix...), min.Member...), 0)
+	case min.Inf == 0:
+		start = append(append([]byte(nil), prefix...), min.Member...)
+	}
+	switch {
+	case max.Inf < 0:
+		return []ZSetValue{}, nil
+	case max.Inf == 0 && max.Exclusive:
+		end = append(append([]byte(nil), prefix...), max.Member...)
+	case max.Inf == 0:
+		end = append(append(append([]byte(nil), prefix...), max.Member...), 0)
+	}
+	it := zs.ks.db.NewIterator(config.IteratorOptions{Start: start, End: end})
+	defer it.Close()
+
+	values := make([]ZSetValue, 0)
+	for ; it.Valid() && count != 0; it.Next() {
+		if offset > 0 {
+			offset--
+			continue
+		}
+		data, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+		var v ZSetValue
+		if err := v.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		count--
+	}
+	return values, nil
+}
+
+// ZPopMin removes and returns up to count members of a sorted set with the lowest scores
+func (zs *ZSetStructure) ZPopMin(key string, count int) ([]ZSetValue, error) {
+	return zs.pop(key, count, false)
+}
+
+// ZPopMax removes and returns up to count members of a sorted set with the highest scores,
+// from the highest score
+func (zs *ZSetStructure) ZPopMax(key string, count int) ([]ZSetValue, error) {
+	return zs.pop(key, count, true)
+}
+
+func (zs *ZSetStructure) pop(key string, count int, reverse bool) ([]ZSetValue, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	defer zs.ks.lockKeys(key)()
+	if count < 0 {
+		return nil, ErrInvalidArgs
+	}
+	keyBytes := stringToBytesWithKey(key)
+
+	meta, err := zs.getZSetMeta(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	values, err := zs.rangeByRank(keyBytes, 0, count, reverse)
+	if err != nil {
+		return nil, err
+	}
+	if err := zs.removeMembers(keyBytes, meta, values); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = []ZSetValue{}
+	}
+	return values, nil
+}
+
+// ZRemRangeByScore removes the members of a sorted set whose score is between min and max.
+// It returns the number of members it removed.
+func (zs *ZSetStructure) ZRemRangeByScore(key string, min, max ScoreBound) (int, error) {
+	if err := checkKey(key); err != nil {
+		return 0, err
+	}
+	defer zs.ks.lockKeys(key)()
+	keyBytes := stringToBytesWithKey(key)
+
+	meta, err := zs.getZSetMeta(keyBytes)
+	if err != nil {
+		return 0, err
+	}
+	start, end := scoreRange(keyBytes, min, max)
+	it := zs.scoreIterator(keyBytes, start, end, false)
+	values, err := zs.collect(keyBytes, it, 0, -1)
+	it.Close()
+	if err != nil {
+		return 0, err
+	}
+	if err := zs.removeMembers(keyBytes, meta, values); err != nil {
+		return 0, err
+	}
+	return len(values), nil
+}
+
+// ZRemRangeByRank removes the members of a sorted set from rank start to rank stop, inclusive,
+// in order of score. Ranks are 0-based, negative ranks count from the highest score, -1 being the last member.
+// It returns the number of members it removed.
+func (zs *ZSetStructure) ZRemRangeByRank(key string, start, stop int) (int, error) {
+	if err := checkKey(key); err != nil {
+		return 0, err
+	}
+	defer zs.ks.lockKeys(key)()
+	keyBytes := stringToBytesWithKey(key)
+
+	meta, err := zs.getZSetMeta(keyBytes)
+	if err != nil {
+		return 0, err
+	}
+	if start < 0 {
+		start += meta.card
+	}
+	if stop < 0 {
+		stop += meta.card
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= meta.card {
+		stop = meta.card - 1
+	}
+	if start > stop {
+		return 0, nil
+	}
+	values, err := zs.rangeByRank(keyBytes, start, stop+1, false)
+	if err != nil {
+		return 0, err
+	}
+	if err := zs.removeMembers(keyBytes, meta, values); err != nil {
+		return 0, err
+	}
+	return len(values), nil
+}
+
+// lookupMember returns a member of a sorted set, or nil if it does not exist
+func (zs *ZSetStructure) lookupMember(key []byte, member string) (*ZSetValue, error) {
+	v, err := zs.getMember(key, member)
+	if err == _const.ErrKeyNotFound {
+		return nil, nil
+	}
+	return v, err
+}
+
+// removeMembers deletes members of a sorted set and writes its new metadata
+func (zs *ZSetStructure) removeMembers(key []byte, meta *zsetMeta, values []ZSetValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+	batch := zs.ks.newBatch()
+	for i := range values {
+		zs.deleteMember(batch, key, &values[i])
+	}
+	meta.card -= len(values)
+	return zs.commitZSet(batch, key, meta)
+}
+
+// collect returns the members of the score keys of an iterator, after skipping offset of them.
+// It returns at most count members, all of them if count is negative.
+func (zs *ZSetStructure) collect(key []byte, it *engine.Iterator, offset, count int) ([]ZSetValue, error) {
+	values := make([]ZSetValue, 0)
+	for ; it.Valid() && count != 0; it.Next() {
+		if offset > 0 {
+			offset--
+			continue
+		}
+		_, _, rest, _ := decodeZSetKey(it.Key())
+		v, err := zs.getMember(key, string(rest[8:]))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, *v)
+		count--
+	}
+	return values, nil
+}
+
+// scoreRange returns the bounds of the score keys of a sorted set between min and max,
+// the end is exclusive
+func scoreRange(key []byte, min, max ScoreBound) (start, end []byte) {
+	prefix := append(zsetPrefix(key), zsetScoreKind)
+	start = append(append([]byte(nil), prefix...), encodeScore(min.Score)...)
+	if min.Exclusive {
+		start = prefixEnd(start)
+	}
+	end = append(append([]byte(nil), prefix...), encodeScore(max.Score)...)
+	if !max.Exclusive {
+		end = prefixEnd(end)
+	}
+	if bytes.Compare(start, end) > 0 {
+		end = start
+	}
+	return start, end
+}
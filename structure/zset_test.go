@@ -1,20 +1,26 @@
 package structure
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/ByteStorage/FlyDB/config"
 	_const "github.com/ByteStorage/FlyDB/lib/const"
+	"github.com/ByteStorage/FlyDB/lib/encoding"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"math"
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func initZSetDB() (*ZSetStructure, *config.Options) {
 	opts := config.DefaultOptions
 	dir, _ := os.MkdirTemp("", "TestZSetStructure")
 	opts.DirPath = dir
-	zs, _ := NewZSetStructure(opts)
+	ks, _ := NewKeyspaceWithSweepOptions(opts, config.ExpireSweepOptions{})
+	zs := NewZSetStructureWithKeyspace(ks)
 	return zs, &opts
 }
 
@@ -230,7 +236,7 @@ func TestZAdd(t *testing.T) {
 	zs, _ := initZSetDB()
 	type testCase struct {
 		key    string
-		score  int
+		score  float64
 		member string
 		value  string
 		want   ZSetValue
@@ -349,7 +355,7 @@ func TestZIncrBy(t *testing.T) {
 	assert.NoError(t, err)
 
 	Zset, err := zs.getZSetFromDB(stringToBytesWithKey("key"))
-	assert.Equal(t, 6, Zset.dict["existingMember"].score)
+	assert.Equal(t, 6.0, Zset.dict["existingMember"].score)
 }
 func TestZRank(t *testing.T) {
 	zs, _ := initZSetDB()
@@ -624,7 +630,7 @@ func TestZScore(t *testing.T) {
 	assert.NoError(t, err)
 	tests := []struct {
 		expectError   error
-		expectedScore int
+		expectedScore float64
 		key           string
 		member        string
 	}{
@@ -648,12 +654,12 @@ func TestNewSkipList(t *testing.T) {
 	assertions := assert.New(t)
 	assertions.Equal(1, s.level)
 	assertions.Nil(s.head.prev)
-	assertions.Equal(0, s.head.value.score)
+	assertions.Equal(0.0, s.head.value.score)
 	assertions.Equal("", s.head.value.member)
 }
 
 func TestNewSkipListNode(t *testing.T) {
-	score := 10
+	score := 10.0
 	key := "test_key"
 	value := "test_value"
 	level := 5
@@ -662,7 +668,7 @@ func TestNewSkipListNode(t *testing.T) {
 
 	// Validate node's value
 	if node.value.score != score || node.value.member != key || node.value.value != value {
-		t.Errorf("Unexpected value in node, got: %v, want: {score: %d, key: %s, val: %s}.\n", node.value, score, key, value)
+		t.Errorf("Unexpected value in node, got: %v, want: {score: %g, key: %s, val: %s}.\n", node.value, score, key, value)
 	}
 
 	// Validate node's level slice length
@@ -681,7 +687,7 @@ func TestNewSkipListNode(t *testing.T) {
 func TestSkipList_delete(t *testing.T) {
 	type deleteTest struct {
 		name       string
-		score      int
+		score      float64
 		member     string
 		targetList []testZSetNodeValue
 		inputList  []testZSetNodeValue
@@ -727,7 +733,7 @@ func TestSkipList_delete(t *testing.T) {
 }
 
 type testZSetNodeValue struct {
-	score  int
+	score  float64
 	member string
 	value  interface{}
 }
@@ -785,8 +791,8 @@ func TestZCount(t *testing.T) {
 	tests := []struct {
 		key   string
 		input []testZSetNodeValue
-		min   int
-		max   int
+		min   float64
+		max   float64
 		want  int
 		err   error
 	}{
@@ -891,27 +897,27 @@ func TestFZSetMinMax(t *testing.T) {
 	minScore, maxScore := fzs.getMinMaxScore()
 
 	if minScore != 1 || maxScore != 100 {
-		t.Errorf("getMinMaxScore() = %d, %d, want: 1, 100", minScore, maxScore)
+		t.Errorf("getMinMaxScore() = %g, %g, want: 1, 100", minScore, maxScore)
 	}
 
 	if min := fzs.min(5, 10); min != 5 {
-		t.Errorf("min(5, 10) = %d, want: 5", min)
+		t.Errorf("min(5, 10) = %g, want: 5", min)
 	}
 
 	if max := fzs.max(5, 10); max != 10 {
-		t.Errorf("max(5, 10) = %d, want: 10", max)
+		t.Errorf("max(5, 10) = %g, want: 10", max)
 	}
 
 	// if case part of skip list is missing, we should return 0,0
 	fzs.skipList.tail.value = nil
 	minScore, maxScore = fzs.getMinMaxScore()
-	assert.Equal(t, minScore, 0)
-	assert.Equal(t, maxScore, 0)
+	assert.Equal(t, minScore, 0.0)
+	assert.Equal(t, maxScore, 0.0)
 	// again, an error case
 	fzs.skipList = nil
 	minScore, maxScore = fzs.getMinMaxScore()
-	assert.Equal(t, minScore, 0)
-	assert.Equal(t, maxScore, 0)
+	assert.Equal(t, minScore, 0.0)
+	assert.Equal(t, maxScore, 0.0)
 
 }
 func TestZSetStructure_adjustMinMax(t *testing.T) {
@@ -927,8 +933,8 @@ func TestZSetStructure_adjustMinMax(t *testing.T) {
 	assert.NoError(t, err)
 	// as the min now is 30, our provided min of 10 will be turned into 30
 	// as our param of max is 50 and maximum score is 200, it won't change
-	assert.Equal(t, 30, minScore)
-	assert.Equal(t, 50, maxScore)
+	assert.Equal(t, 30.0, minScore)
+	assert.Equal(t, 50.0, maxScore)
 }
 
 func TestZset_getNodeByRank(t *testing.T) {
@@ -976,7 +982,7 @@ func Test_exists(t *testing.T) {
 
 	tt := []struct {
 		key    string
-		score  int
+		score  float64
 		member string
 		want   bool
 	}{
@@ -1040,7 +1046,7 @@ func TestNewZSetStructure(t *testing.T) {
 
 func TestZSetStructure_Keys(t *testing.T) {
 	zset, _ := initZSetDB()
-	defer zset.db.Clean()
+	defer zset.ks.db.Clean()
 
 	err = zset.ZAdd("key1", 1, "mem1", "123123123")
 	assert.Nil(t, err)
@@ -1058,3 +1064,626 @@ func TestZSetStructure_Keys(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 4, len(keys))
 }
+
+func TestZSetStructure_MemberLayout(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	assert.Nil(t, zs.ZAdds("key", []ZSetValue{
+		{score: 5, member: "five", value: ""},
+		{score: -3, member: "minus-three", value: ""},
+		{score: 0, member: "zero", value: ""},
+		{score: -10, member: "minus-ten", value: ""},
+	}...))
+	// Every member has a member key and a score key
+	assert.Equal(t, 8, len(zs.ks.zsetKeys("key")))
+
+	// A new score moves the score key of the member
+	assert.Nil(t, zs.ZAdd("key", 7, "zero", ""))
+	assert.Equal(t, 8, len(zs.ks.zsetKeys("key")))
+
+	values, err := zs.ZRange("key", 0, 4)
+	assert.Nil(t, err)
+	var members []string
+	for _, v := range values {
+		members = append(members, v.member)
+	}
+	assert.Equal(t, []string{"minus-ten", "minus-three", "five", "zero"}, members)
+
+	count, err := zs.ZCount("key", -10, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	rank, err := zs.ZRank("key", "five")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, rank)
+
+	// The members move with the key and are deleted with it
+	assert.Nil(t, zs.ks.Rename("key", "renamed"))
+	assert.Empty(t, zs.ks.zsetKeys("key"))
+	card, err := zs.ZCard("renamed")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, card)
+	score, err := zs.ZScore("renamed", "minus-three")
+	assert.Nil(t, err)
+	assert.Equal(t, -3.0, score)
+
+	deleted, err := zs.ks.Del("renamed")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Empty(t, zs.ks.zsetKeys("renamed"))
+}
+
+func TestZSetStructure_Migrate(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	// Sorted sets written as one msgpack value, as they were before
+	for _, key := range []string{"lazy", "explicit"} {
+		legacy := newZSetNodes()
+		assert.Nil(t, legacy.InsertNode(2, "b", "value-b"))
+		assert.Nil(t, legacy.InsertNode(1, "a", "value-a"))
+		assert.Nil(t, legacy.InsertNode(3, "c", "value-c"))
+		enc := encoding.NewMessagePackEncoder()
+		assert.Nil(t, enc.Encode(legacy))
+		assert.Nil(t, zs.ks.db.Put([]byte(key), append(encodeHeader(ZSet, 0), enc.Bytes()...)))
+	}
+	typ, err := zs.ks.Type("lazy")
+	assert.Nil(t, err)
+	assert.Equal(t, "zset", typ)
+
+	// A sorted set is migrated when it is first used
+	score, err := zs.ZScore("lazy", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, score)
+	assert.Equal(t, 6, len(zs.ks.zsetKeys("lazy")))
+
+	migrated, err := zs.Migrate()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, migrated)
+	migrated, err = zs.Migrate()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, migrated)
+
+	values, err := zs.ZRevRange("explicit", 0, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(values))
+	assert.Equal(t, "c", values[0].member)
+	assert.Equal(t, "a", values[2].member)
+	assert.Equal(t, []byte("value-c"), values[0].value)
+}
+
+func TestZSetStructure_LargerThanDefaultBatch(t *testing.T) {
+	opts := config.DefaultOptions
+	opts.DirPath, _ = os.MkdirTemp("", "TestZSetStructure")
+	ks, err := NewKeyspaceWithSweepOptions(opts, config.ExpireSweepOptions{})
+	require.Nil(t, err)
+	defer ks.Clean()
+	zs := NewZSetStructureWithKeyspace(ks)
+
+	// Every member has two keys, so these sorted sets need more than config.DefaultWriteBatchOptions allows
+	members := int(config.DefaultWriteBatchOptions.MaxBatchNum)/2 + 1000
+	values := make([]ZSetValue, members)
+	for i := range values {
+		values[i] = NewZSetValue(float64(i), fmt.Sprintf("member-%d", i), "")
+	}
+	for _, key := range []string{"deleted", "renamed", "replaced"} {
+		require.Nil(t, zs.ZAdds(key, values...))
+	}
+
+	deleted, err := ks.Del("deleted")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Empty(t, ks.zsetKeys("deleted"))
+
+	assert.Nil(t, ks.Rename("renamed", "moved"))
+	assert.Empty(t, ks.zsetKeys("renamed"))
+	card, err := zs.ZCard("moved")
+	assert.Nil(t, err)
+	assert.Equal(t, members, card)
+
+	// A string takes the key of a sorted set that expired
+	assert.Nil(t, ks.setExpire("replaced", time.Now().Add(-time.Second).UnixNano()))
+	assert.Nil(t, NewStringStructureWithKeyspace(ks).Set("replaced", "value", 0))
+	assert.Empty(t, ks.zsetKeys("replaced"))
+}
+
+func TestEncodeScore(t *testing.T) {
+	scores := []float64{math.Inf(-1), -1e10, -2.5, -1, 0, 1e-9, 1, 2.5, 1e10, math.Inf(1)}
+	for i, score := range scores {
+		assert.Equal(t, score, decodeScore(encodeScore(score)))
+		if i > 0 {
+			assert.Equal(t, -1, bytes.Compare(encodeScore(scores[i-1]), encodeScore(score)))
+		}
+	}
+	assert.Equal(t, encodeScore(0), encodeScore(math.Copysign(0, -1)))
+}
+
+func zsetMembers(values []ZSetValue) []string {
+	members := make([]string, 0, len(values))
+	for _, v := range values {
+		members = append(members, v.member)
+	}
+	return members
+}
+
+func TestParseBounds(t *testing.T) {
+	tests := []struct {
+		input string
+		want  ScoreBound
+		err   error
+	}{
+		{"1.5", ScoreBound{Score: 1.5}, nil},
+		{"(1.5", ScoreBound{Score: 1.5, Exclusive: true}, nil},
+		{"-inf", ScoreBound{Score: math.Inf(-1)}, nil},
+		{"+inf", ScoreBound{Score: math.Inf(1)}, nil},
+		{"(inf", ScoreBound{Score: math.Inf(1), Exclusive: true}, nil},
+		{"nan", ScoreBound{}, ErrInvalidArgs},
+		{"abc", ScoreBound{}, ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		bound, err := ParseScoreBound(tt.input)
+		assert.Equal(t, tt.err, err, tt.input)
+		assert.Equal(t, tt.want, bound, tt.input)
+	}
+
+	bound, err := ParseLexBound("-")
+	assert.Nil(t, err)
+	assert.Equal(t, LexBound{Inf: -1}, bound)
+	bound, err = ParseLexBound("+")
+	assert.Nil(t, err)
+	assert.Equal(t, LexBound{Inf: 1}, bound)
+	bound, err = ParseLexBound("[a")
+	assert.Nil(t, err)
+	assert.Equal(t, LexBound{Member: "a"}, bound)
+	bound, err = ParseLexBound("(a")
+	assert.Nil(t, err)
+	assert.Equal(t, LexBound{Member: "a", Exclusive: true}, bound)
+	_, err = ParseLexBound("a")
+	assert.Equal(t, ErrInvalidArgs, err)
+}
+
+func TestZSetStructure_ZRangeByScore(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	assert.Nil(t, zs.ZAdds("key", []ZSetValue{
+		{score: 1.5, member: "a", value: ""},
+		{score: 2, member: "b", value: ""},
+		{score: 2, member: "c", value: ""},
+		{score: -0.5, member: "d", value: ""},
+		{score: math.Inf(1), member: "e", value: ""},
+	}...))
+	inf, _ := ParseScoreBound("+inf")
+	negInf, _ := ParseScoreBound("-inf")
+
+	tests := []struct {
+		name          string
+		min, max      string
+		offset, count int
+		want          []string
+	}{
+		{"all", "-inf", "+inf", 0, -1, []string{"d", "a", "b", "c", "e"}},
+		{"inclusive", "1.5", "2", 0, -1, []string{"a", "b", "c"}},
+		{"exclusive min", "(1.5", "2", 0, -1, []string{"b", "c"}},
+		{"exclusive max", "-1", "(2", 0, -1, []string{"d", "a"}},
+		{"limit", "-inf", "+inf", 1, 2, []string{"a", "b"}},
+		{"offset past the end", "-inf", "+inf", 10, -1, []string{}},
+		{"empty range", "3", "1", 0, -1, []string{}},
+		{"same exclusive bounds", "(2", "(2", 0, -1, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, err := ParseScoreBound(tt.min)
+			assert.Nil(t, err)
+			max, err := ParseScoreBound(tt.max)
+			assert.Nil(t, err)
+			values, err := zs.ZRangeByScore("key", min, max, tt.offset, tt.count)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, zsetMembers(values))
+		})
+	}
+
+	values, err := zs.ZRevRangeByScore("key", inf, negInf, 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"e", "c", "b", "a", "d"}, zsetMembers(values))
+	values, err = zs.ZRevRangeByScore("key", ScoreBound{Score: 2, Exclusive: true}, negInf, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"d"}, zsetMembers(values))
+
+	_, err = zs.ZRangeByScore("missing", negInf, inf, 0, -1)
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+}
+
+func TestZSetStructure_ZRangeByLex(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	for _, member := range []string{"a", "b", "c", "d", "e"} {
+		assert.Nil(t, zs.ZAdd("key", 0, member, ""))
+	}
+	tests := []struct {
+		min, max      string
+		offset, count int
+		want          []string
+	}{
+		{"-", "+", 0, -1, []string{"a", "b", "c", "d", "e"}},
+		{"[b", "[d", 0, -1, []string{"b", "c", "d"}},
+		{"(b", "(d", 0, -1, []string{"c"}},
+		{"[aa", "+", 0, -1, []string{"b", "c", "d", "e"}},
+		{"-", "+", 1, 2, []string{"b", "c"}},
+		{"+", "-", 0, -1, []string{}},
+		{"[d", "[b", 0, -1, []string{}},
+	}
+	for _, tt := range tests {
+		min, err := ParseLexBound(tt.min)
+		assert.Nil(t, err)
+		max, err := ParseLexBound(tt.max)
+		assert.Nil(t, err)
+		values, err := zs.ZRangeByLex("key", min, max, tt.offset, tt.count)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.want, zsetMembers(values), tt.min+" "+tt.max)
+	}
+}
+
+func TestZSetStructure_ZPop(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	assert.Nil(t, zs.ZAdds("key", []ZSetValue{
+		{score: 1, member: "a", value: ""},
+		{score: 2, member: "b", value: ""},
+		{score: 3, member: "c", value: ""},
+		{score: 4, member: "d", value: ""},
+	}...))
+
+	values, err := zs.ZPopMin("key", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a"}, zsetMembers(values))
+	assert.Equal(t, 1.0, values[0].score)
+	values, err = zs.ZPopMax("key", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"d", "c"}, zsetMembers(values))
+	card, err := zs.ZCard("key")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, card)
+
+	values, err = zs.ZPopMin("key", 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b"}, zsetMembers(values))
+	values, err = zs.ZPopMin("key", 1)
+	assert.Nil(t, err)
+	assert.Empty(t, values)
+	assert.Empty(t, zs.ks.zsetKeys("key"))
+
+	_, err = zs.ZPopMax("key", -1)
+	assert.Equal(t, ErrInvalidArgs, err)
+}
+
+func TestZSetStructure_ZRemRange(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	add := func() {
+		for i, member := range []string{"a", "b", "c", "d", "e"} {
+			assert.Nil(t, zs.ZAdd("key", float64(i), member, ""))
+		}
+	}
+	add()
+	removed, err := zs.ZRemRangeByScore("key", ScoreBound{Score: 1, Exclusive: true}, ScoreBound{Score: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, removed)
+	values, err := zs.ZRangeByScore("key", ScoreBound{Score: math.Inf(-1)}, ScoreBound{Score: math.Inf(1)}, 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "e"}, zsetMembers(values))
+
+	add()
+	tests := []struct {
+		start, stop int
+		removed     int
+		want        []string
+	}{
+		{1, 2, 2, []string{"a", "d", "e"}},
+		{-2, -1, 2, []string{"a", "b", "c"}},
+		{-10, 0, 1, []string{"b", "c", "d", "e"}},
+		{3, 1, 0, []string{"a", "b", "c", "d", "e"}},
+		{0, 10, 5, []string{}},
+	}
+	for _, tt := range tests {
+		add()
+		removed, err := zs.ZRemRangeByRank("key", tt.start, tt.stop)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.removed, removed)
+		values, err := zs.ZRangeByScore("key", ScoreBound{Score: math.Inf(-1)}, ScoreBound{Score: math.Inf(1)}, 0, -1)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.want, zsetMembers(values))
+		card, err := zs.ZCard("key")
+		assert.Nil(t, err)
+		assert.Equal(t, len(tt.want), card)
+	}
+}
+
+func TestZSetStructure_ZMScore(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	assert.Nil(t, zs.ZAdd("key", 1.5, "a", ""))
+	assert.Nil(t, zs.ZAdd("key", -2, "b", ""))
+	scores, err := zs.ZMScore("key", "a", "missing", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(scores))
+	assert.Equal(t, 1.5, *scores[0])
+	assert.Nil(t, scores[1])
+	assert.Equal(t, -2.0, *scores[2])
+
+	_, err = zs.ZMScore("missing", "a")
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+}
+
+func TestZSetStructure_ZAddWithOptions(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	assert.Nil(t, zs.ZAdd("key", 5, "a", ""))
+	tests := []struct {
+		name  string
+		opts  ZAddOptions
+		score float64
+		added int
+		want  float64
+	}{
+		{"NX keeps an existing member", ZAddOptions{NX: true}, 1, 0, 5},
+		{"XX updates an existing member", ZAddOptions{XX: true}, 6, 0, 6},
+		{"CH counts an update", ZAddOptions{CH: true}, 7, 1, 7},
+		{"GT keeps a lower score", ZAddOptions{GT: true, CH: true}, 3, 0, 7},
+		{"GT takes a greater score", ZAddOptions{GT: true, CH: true}, 8, 1, 8},
+		{"LT keeps a greater score", ZAddOptions{LT: true, CH: true}, 9, 0, 8},
+		{"LT takes a lower score", ZAddOptions{LT: true, CH: true}, 2, 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, err := zs.ZAddWithOptions("key", tt.opts, ZSetValue{score: tt.score, member: "a", value: ""})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.added, added)
+			score, err := zs.ZScore("key", "a")
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, score)
+		})
+	}
+
+	// XX does not add members, NX and GT do
+	added, err := zs.ZAddWithOptions("key", ZAddOptions{XX: true}, ZSetValue{score: 1, member: "b", value: ""})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, added)
+	added, err = zs.ZAddWithOptions("key", ZAddOptions{NX: true}, ZSetValue{score: 1, member: "b", value: ""})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, added)
+	added, err = zs.ZAddWithOptions("key", ZAddOptions{GT: true}, ZSetValue{score: 1, member: "c", value: ""})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, added)
+	card, err := zs.ZCard("key")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, card)
+
+	for _, opts := range []ZAddOptions{{NX: true, XX: true}, {GT: true, LT: true}, {NX: true, GT: true}} {
+		_, err := zs.ZAddWithOptions("key", opts, ZSetValue{score: 1, member: "a", value: ""})
+		assert.Equal(t, ErrInvalidArgs, err)
+	}
+	_, err = zs.ZAddWithOptions("key", ZAddOptions{}, ZSetValue{score: math.NaN(), member: "a", value: ""})
+	assert.Equal(t, ErrInvalidArgs, err)
+}
+
+func TestZSetStructure_ZAddIncr(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	score, ok, err := zs.ZAddIncr("key", ZAddOptions{}, 1.5, "a", "")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, score)
+	score, ok, err = zs.ZAddIncr("key", ZAddOptions{XX: true}, 2, "a", "")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3.5, score)
+
+	_, ok, err = zs.ZAddIncr("key", ZAddOptions{NX: true}, 2, "a", "")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	_, ok, err = zs.ZAddIncr("key", ZAddOptions{GT: true}, -1, "a", "")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	_, ok, err = zs.ZAddIncr("key", ZAddOptions{XX: true}, 1, "b", "")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	score, err = zs.ZScore("key", "a")
+	assert.Nil(t, err)
+	assert.Equal(t, 3.5, score)
+	card, err := zs.ZCard("key")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, card)
+
+	assert.Nil(t, zs.ZAdd("key", math.Inf(1), "inf", ""))
+	_, _, err = zs.ZAddIncr("key", ZAddOptions{}, math.Inf(-1), "inf", "")
+	assert.Equal(t, ErrInvalidArgs, err)
+}
+
+func TestParseAggregate(t *testing.T) {
+	for input, want := range map[string]Aggregate{"": AggregateSum, "sum": AggregateSum, "MIN": AggregateMin, "Max": AggregateMax} {
+		aggregate, err := ParseAggregate(input)
+		assert.Nil(t, err)
+		assert.Equal(t, want, aggregate, input)
+	}
+	_, err := ParseAggregate("avg")
+	assert.Equal(t, ErrInvalidArgs, err)
+}
+
+func TestZSetStructure_Combine(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	assert.Nil(t, zs.ZAdds("a", []ZSetValue{
+		{score: 1, member: "x", value: "a"},
+		{score: 2, member: "y", value: "a"},
+		{score: 3, member: "z", value: "a"},
+	}...))
+	assert.Nil(t, zs.ZAdds("b", []ZSetValue{
+		{score: 10, member: "y", value: "b"},
+		{score: 20, member: "z", value: "b"},
+		{score: 30, member: "w", value: "b"},
+	}...))
+	scores := func(values []ZSetValue) map[string]float64 {
+		m := make(map[string]float64)
+		for _, v := range values {
+			m[v.member] = v.score
+		}
+		return m
+	}
+
+	values, err := zs.ZUnion([]string{"a", "b", "missing"}, ZCombineOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x", "y", "z", "w"}, zsetMembers(values))
+	assert.Equal(t, map[string]float64{"x": 1, "y": 12, "z": 23, "w": 30}, scores(values))
+	assert.Equal(t, []byte("a"), values[1].value)
+
+	values, err = zs.ZUnion([]string{"a", "b"}, ZCombineOptions{Weights: []float64{2, 0.5}, Aggregate: AggregateMax})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]float64{"x": 2, "y": 5, "z": 10, "w": 15}, scores(values))
+
+	values, err = zs.ZInter([]string{"a", "b"}, ZCombineOptions{Aggregate: AggregateMin})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"y", "z"}, zsetMembers(values))
+	assert.Equal(t, map[string]float64{"y": 2, "z": 3}, scores(values))
+
+	values, err = zs.ZInter([]string{"a", "missing"}, ZCombineOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, values)
+
+	values, err = zs.ZDiff("a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x"}, zsetMembers(values))
+
+	_, err = zs.ZUnion([]string{"a", "b"}, ZCombineOptions{Weights: []float64{1}})
+	assert.Equal(t, ErrInvalidArgs, err)
+	_, err = zs.ZInter(nil, ZCombineOptions{})
+	assert.Equal(t, ErrInvalidArgs, err)
+
+	str := NewStringStructureWithKeyspace(zs.ks)
+	assert.Nil(t, str.Set("string", "value", 0))
+	_, err = zs.ZUnion([]string{"a", "string"}, ZCombineOptions{})
+	assert.Equal(t, ErrWrongType, err)
+
+	// Infinities of opposite signs add up to 0
+	assert.Nil(t, zs.ZAdd("inf", math.Inf(1), "x", ""))
+	assert.Nil(t, zs.ZAdd("-inf", math.Inf(-1), "x", ""))
+	values, err = zs.ZUnion([]string{"inf", "-inf"}, ZCombineOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, values[0].score)
+}
+
+func TestZSetStructure_CombineStore(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	assert.Nil(t, zs.ZAdds("a", []ZSetValue{
+		{score: 1, member: "x", value: ""},
+		{score: 2, member: "y", value: ""},
+	}...))
+	assert.Nil(t, zs.ZAdds("b", []ZSetValue{
+		{score: 3, member: "y", value: ""},
+		{score: 4, member: "z", value: ""},
+	}...))
+
+	// The destination replaces a key of any type
+	str := NewStringStructureWithKeyspace(zs.ks)
+	assert.Nil(t, str.Set("dest", "value", 100))
+	count, err := zs.ZUnionStore("dest", []string{"a", "b"}, ZCombineOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	card, err := zs.ZCard("dest")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, card)
+	ttl, err := zs.ks.TTL("dest")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), ttl)
+	values, err := zs.ZRangeByScore("dest", ScoreBound{Score: math.Inf(-1)}, ScoreBound{Score: math.Inf(1)}, 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x", "z", "y"}, zsetMembers(values))
+	score, err := zs.ZScore("dest", "y")
+	assert.Nil(t, err)
+	assert.Equal(t, 5.0, score)
+
+	// The destination may be one of the keys
+	count, err = zs.ZInterStore("a", []string{"a", "b"}, ZCombineOptions{Weights: []float64{1, 2}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	score, err = zs.ZScore("a", "y")
+	assert.Nil(t, err)
+	assert.Equal(t, 8.0, score)
+	assert.Equal(t, 2, len(zs.ks.zsetKeys("a")))
+
+	count, err = zs.ZDiffStore("diff", "dest", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	values, err = zs.ZRangeByScore("diff", ScoreBound{Score: math.Inf(-1)}, ScoreBound{Score: math.Inf(1)}, 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x"}, zsetMembers(values))
+
+	// An empty result deletes the destination
+	count, err = zs.ZInterStore("dest", []string{"b", "missing"}, ZCombineOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+	_, err = zs.ZCard("dest")
+	assert.Equal(t, _const.ErrKeyNotFound, err)
+	assert.Empty(t, zs.ks.zsetKeys("dest"))
+}
+
+func TestZSetStructure_RangeCost(t *testing.T) {
+	zs, _ := initZSetDB()
+	defer zs.ks.Clean()
+
+	values := make([]ZSetValue, 10)
+	for i := range values {
+		values[i] = NewZSetValue(float64(i), fmt.Sprintf("member%02d", i), "")
+	}
+	assert.Nil(t, zs.ZAdds("small", values...))
+
+	// grow adds n members to a large sorted set, whose keys sort next to the ones of the small one
+	grow := func(n int) {
+		values := make([]ZSetValue, n)
+		for i := range values {
+			values[i] = NewZSetValue(float64(i), fmt.Sprintf("member%06d", i), "")
+		}
+		assert.Nil(t, zs.ZAdds("large", values...))
+	}
+	// rangeAllocs returns the allocations of the range reads of the small sorted set
+	rangeAllocs := func() []float64 {
+		reads := []func() error{
+			func() error { _, err := zs.ZRange("small", 2, 5); return err },
+			func() error { _, err := zs.ZRevRange("small", 2, 5); return err },
+			func() error {
+				_, err := zs.ZRangeByScore("small", ScoreBound{Score: 2}, ScoreBound{Score: 5}, 0, -1)
+				return err
+			},
+			func() error {
+				_, err := zs.ZRangeByLex("small", LexBound{Member: "member02"}, LexBound{Member: "member05"}, 0, -1)
+				return err
+			},
+			func() error { _, err := zs.ZRank("small", "member05"); return err },
+		}
+		allocs := make([]float64, len(reads))
+		for i, read := range reads {
+			allocs[i] = testing.AllocsPerRun(10, func() {
+				assert.Nil(t, read())
+			})
+		}
+		return allocs
+	}
+
+	grow(1000)
+	small := rangeAllocs()
+	grow(20000)
+	large := rangeAllocs()
+	for i := range small {
+		assert.Less(t, large[i], small[i]*1.5, "read %d: %v allocations next to 1000 members, %v next to 20000", i, small[i], large[i])
+	}
+}